@@ -0,0 +1,167 @@
+package access
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EventFilter selects the events streamed by an event subscription.
+//
+// An event matches the filter if its type is one of the requested event types, if any are
+// provided, and, when addresses or contracts are provided, the event was emitted by one of
+// the given accounts or contracts. Core events (e.g. flow.AccountCreated) are not emitted by a
+// contract, so they are dropped as soon as an address or contract filter is given.
+type EventFilter struct {
+	eventTypes map[flow.EventType]struct{}
+	addresses  map[string]struct{}
+	contracts  map[string]struct{}
+}
+
+// NewEventFilter creates a new EventFilter from the given event types, account addresses
+// and contract identifiers (formatted as A.<address>.<contract name>).
+// At least one event type, address or contract must be provided. Execution nodes index events
+// by type, so the events of a filter without event types can only be read from a local event index.
+func NewEventFilter(
+	chain flow.Chain,
+	eventTypes []string,
+	addresses []string,
+	contracts []string,
+) (EventFilter, error) {
+	f := EventFilter{
+		eventTypes: make(map[flow.EventType]struct{}, len(eventTypes)),
+		addresses:  make(map[string]struct{}, len(addresses)),
+		contracts:  make(map[string]struct{}, len(contracts)),
+	}
+
+	if len(eventTypes) == 0 && len(addresses) == 0 && len(contracts) == 0 {
+		return f, fmt.Errorf("at least one event type, address or contract must be provided")
+	}
+
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if _, _, err := parseEventType(eventType); err != nil {
+			return f, err
+		}
+		f.eventTypes[flow.EventType(eventType)] = struct{}{}
+	}
+
+	for _, address := range addresses {
+		addr := flow.HexToAddress(strings.TrimSpace(address))
+		if !chain.IsValid(addr) {
+			return f, fmt.Errorf("invalid address %s for chain %s", address, chain)
+		}
+		f.addresses[addr.String()] = struct{}{}
+	}
+
+	for _, contract := range contracts {
+		contract = strings.TrimSpace(contract)
+		parts := strings.Split(contract, ".")
+		if len(parts) != 3 || parts[0] != "A" || parts[2] == "" {
+			return f, fmt.Errorf("invalid contract %s, expected format A.<address>.<name>", contract)
+		}
+		addr := flow.HexToAddress(parts[1])
+		if !chain.IsValid(addr) {
+			return f, fmt.Errorf("invalid contract address %s for chain %s", parts[1], chain)
+		}
+		f.contracts[fmt.Sprintf("A.%s.%s", addr, parts[2])] = struct{}{}
+	}
+
+	return f, nil
+}
+
+// EventTypes returns the event types selected by the filter in a deterministic order.
+func (f *EventFilter) EventTypes() []flow.EventType {
+	types := make([]flow.EventType, 0, len(f.eventTypes))
+	for eventType := range f.eventTypes {
+		types = append(types, eventType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// Addresses returns the addresses selected by the filter in a deterministic order.
+func (f *EventFilter) Addresses() []flow.Address {
+	addresses := make([]flow.Address, 0, len(f.addresses))
+	for address := range f.addresses {
+		addresses = append(addresses, flow.HexToAddress(address))
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	return addresses
+}
+
+// Contracts returns the contract identifiers selected by the filter in a deterministic order.
+func (f *EventFilter) Contracts() []string {
+	contracts := make([]string, 0, len(f.contracts))
+	for contract := range f.contracts {
+		contracts = append(contracts, contract)
+	}
+	sort.Strings(contracts)
+	return contracts
+}
+
+// Filter returns the subset of events matching the filter, preserving their order.
+func (f *EventFilter) Filter(events []flow.Event) []flow.Event {
+	filtered := make([]flow.Event, 0, len(events))
+	for _, event := range events {
+		if f.Match(event) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// Match returns true if the given event matches the filter.
+func (f *EventFilter) Match(event flow.Event) bool {
+	if len(f.eventTypes) > 0 {
+		if _, ok := f.eventTypes[event.Type]; !ok {
+			return false
+		}
+	}
+
+	if len(f.addresses) == 0 && len(f.contracts) == 0 {
+		return true
+	}
+
+	address, contract, err := parseEventType(string(event.Type))
+	if err != nil || address == "" {
+		// core events are not emitted by an account
+		return false
+	}
+
+	if len(f.addresses) > 0 {
+		if _, ok := f.addresses[address]; !ok {
+			return false
+		}
+	}
+
+	if len(f.contracts) > 0 {
+		if _, ok := f.contracts[contract]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseEventType validates the given event type and returns the address and contract
+// identifier of the emitting contract. Both are empty for core events of the form flow.<name>.
+func parseEventType(eventType string) (string, string, error) {
+	parts := strings.Split(eventType, ".")
+
+	switch {
+	case len(parts) == 2 && parts[0] == "flow" && parts[1] != "":
+		return "", "", nil
+	case len(parts) == 4 && parts[0] == "A" && parts[2] != "" && parts[3] != "":
+		address := flow.HexToAddress(parts[1]).String()
+		return address, fmt.Sprintf("A.%s.%s", address, parts[2]), nil
+	default:
+		return "", "", fmt.Errorf("invalid event type %s", eventType)
+	}
+}
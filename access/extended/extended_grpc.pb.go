@@ -0,0 +1,542 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package extended
+
+import (
+	context "context"
+	access "github.com/onflow/flow/protobuf/go/flow/access"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExtendedAccessAPIClient is the client API for ExtendedAccessAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedAccessAPIClient interface {
+	// SubscribeEvents streams the events matching the filter for every sealed block, in height
+	// order. A message is sent for every sealed block, even if no event matched the filter, so
+	// that the height of the last received block can be used to resume the stream.
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (ExtendedAccessAPI_SubscribeEventsClient, error)
+	// SubscribeTransactionStatuses streams the result of the transaction every time a new status
+	// of the transaction is observed, until the transaction is sealed or expired.
+	SubscribeTransactionStatuses(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (ExtendedAccessAPI_SubscribeTransactionStatusesClient, error)
+	// SendAndSubscribeTransactionStatuses sends the transaction to the network, and streams its
+	// status updates as SubscribeTransactionStatuses.
+	SendAndSubscribeTransactionStatuses(ctx context.Context, in *access.SendTransactionRequest, opts ...grpc.CallOption) (ExtendedAccessAPI_SendAndSubscribeTransactionStatusesClient, error)
+	// GetAccountBalanceAtLatestBlock gets the balance of an account at the latest sealed block.
+	GetAccountBalanceAtLatestBlock(ctx context.Context, in *GetAccountBalanceAtLatestBlockRequest, opts ...grpc.CallOption) (*AccountBalanceResponse, error)
+	// GetAccountBalanceAtBlockHeight gets the balance of an account at the given block height.
+	GetAccountBalanceAtBlockHeight(ctx context.Context, in *GetAccountBalanceAtBlockHeightRequest, opts ...grpc.CallOption) (*AccountBalanceResponse, error)
+	// GetAccountKeysAtLatestBlock gets the public keys of an account at the latest sealed block.
+	GetAccountKeysAtLatestBlock(ctx context.Context, in *GetAccountKeysAtLatestBlockRequest, opts ...grpc.CallOption) (*AccountKeysResponse, error)
+	// GetAccountKeysAtBlockHeight gets the public keys of an account at the given block height.
+	GetAccountKeysAtBlockHeight(ctx context.Context, in *GetAccountKeysAtBlockHeightRequest, opts ...grpc.CallOption) (*AccountKeysResponse, error)
+	// GetAccountKeyAtLatestBlock gets the public key of an account with the given index at the latest
+	// sealed block.
+	GetAccountKeyAtLatestBlock(ctx context.Context, in *GetAccountKeyAtLatestBlockRequest, opts ...grpc.CallOption) (*AccountKeyResponse, error)
+	// GetAccountKeyAtBlockHeight gets the public key of an account with the given index at the given
+	// block height.
+	GetAccountKeyAtBlockHeight(ctx context.Context, in *GetAccountKeyAtBlockHeightRequest, opts ...grpc.CallOption) (*AccountKeyResponse, error)
+	// SimulateTransaction executes the transaction against the latest sealed state without
+	// submitting it to the network, and returns its result and estimated fees.
+	SimulateTransaction(ctx context.Context, in *SimulateTransactionRequest, opts ...grpc.CallOption) (*SimulateTransactionResponse, error)
+}
+
+type extendedAccessAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedAccessAPIClient(cc grpc.ClientConnInterface) ExtendedAccessAPIClient {
+	return &extendedAccessAPIClient{cc}
+}
+
+func (c *extendedAccessAPIClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (ExtendedAccessAPI_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedAccessAPI_ServiceDesc.Streams[0], "/flow.access.extended.ExtendedAccessAPI/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedAccessAPISubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedAccessAPI_SubscribeEventsClient interface {
+	Recv() (*access.EventsResponse_Result, error)
+	grpc.ClientStream
+}
+
+type extendedAccessAPISubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedAccessAPISubscribeEventsClient) Recv() (*access.EventsResponse_Result, error) {
+	m := new(access.EventsResponse_Result)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *extendedAccessAPIClient) SubscribeTransactionStatuses(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (ExtendedAccessAPI_SubscribeTransactionStatusesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedAccessAPI_ServiceDesc.Streams[1], "/flow.access.extended.ExtendedAccessAPI/SubscribeTransactionStatuses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedAccessAPISubscribeTransactionStatusesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedAccessAPI_SubscribeTransactionStatusesClient interface {
+	Recv() (*access.TransactionResultResponse, error)
+	grpc.ClientStream
+}
+
+type extendedAccessAPISubscribeTransactionStatusesClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedAccessAPISubscribeTransactionStatusesClient) Recv() (*access.TransactionResultResponse, error) {
+	m := new(access.TransactionResultResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *extendedAccessAPIClient) SendAndSubscribeTransactionStatuses(ctx context.Context, in *access.SendTransactionRequest, opts ...grpc.CallOption) (ExtendedAccessAPI_SendAndSubscribeTransactionStatusesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedAccessAPI_ServiceDesc.Streams[2], "/flow.access.extended.ExtendedAccessAPI/SendAndSubscribeTransactionStatuses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedAccessAPISendAndSubscribeTransactionStatusesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedAccessAPI_SendAndSubscribeTransactionStatusesClient interface {
+	Recv() (*access.TransactionResultResponse, error)
+	grpc.ClientStream
+}
+
+type extendedAccessAPISendAndSubscribeTransactionStatusesClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedAccessAPISendAndSubscribeTransactionStatusesClient) Recv() (*access.TransactionResultResponse, error) {
+	m := new(access.TransactionResultResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *extendedAccessAPIClient) GetAccountBalanceAtLatestBlock(ctx context.Context, in *GetAccountBalanceAtLatestBlockRequest, opts ...grpc.CallOption) (*AccountBalanceResponse, error) {
+	out := new(AccountBalanceResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.extended.ExtendedAccessAPI/GetAccountBalanceAtLatestBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetAccountBalanceAtBlockHeight(ctx context.Context, in *GetAccountBalanceAtBlockHeightRequest, opts ...grpc.CallOption) (*AccountBalanceResponse, error) {
+	out := new(AccountBalanceResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.extended.ExtendedAccessAPI/GetAccountBalanceAtBlockHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetAccountKeysAtLatestBlock(ctx context.Context, in *GetAccountKeysAtLatestBlockRequest, opts ...grpc.CallOption) (*AccountKeysResponse, error) {
+	out := new(AccountKeysResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.extended.ExtendedAccessAPI/GetAccountKeysAtLatestBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetAccountKeysAtBlockHeight(ctx context.Context, in *GetAccountKeysAtBlockHeightRequest, opts ...grpc.CallOption) (*AccountKeysResponse, error) {
+	out := new(AccountKeysResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.extended.ExtendedAccessAPI/GetAccountKeysAtBlockHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetAccountKeyAtLatestBlock(ctx context.Context, in *GetAccountKeyAtLatestBlockRequest, opts ...grpc.CallOption) (*AccountKeyResponse, error) {
+	out := new(AccountKeyResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.extended.ExtendedAccessAPI/GetAccountKeyAtLatestBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetAccountKeyAtBlockHeight(ctx context.Context, in *GetAccountKeyAtBlockHeightRequest, opts ...grpc.CallOption) (*AccountKeyResponse, error) {
+	out := new(AccountKeyResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.extended.ExtendedAccessAPI/GetAccountKeyAtBlockHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) SimulateTransaction(ctx context.Context, in *SimulateTransactionRequest, opts ...grpc.CallOption) (*SimulateTransactionResponse, error) {
+	out := new(SimulateTransactionResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.extended.ExtendedAccessAPI/SimulateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtendedAccessAPIServer is the server API for ExtendedAccessAPI service.
+// All implementations must embed UnimplementedExtendedAccessAPIServer
+// for forward compatibility
+type ExtendedAccessAPIServer interface {
+	// SubscribeEvents streams the events matching the filter for every sealed block, in height
+	// order. A message is sent for every sealed block, even if no event matched the filter, so
+	// that the height of the last received block can be used to resume the stream.
+	SubscribeEvents(*SubscribeEventsRequest, ExtendedAccessAPI_SubscribeEventsServer) error
+	// SubscribeTransactionStatuses streams the result of the transaction every time a new status
+	// of the transaction is observed, until the transaction is sealed or expired.
+	SubscribeTransactionStatuses(*access.GetTransactionRequest, ExtendedAccessAPI_SubscribeTransactionStatusesServer) error
+	// SendAndSubscribeTransactionStatuses sends the transaction to the network, and streams its
+	// status updates as SubscribeTransactionStatuses.
+	SendAndSubscribeTransactionStatuses(*access.SendTransactionRequest, ExtendedAccessAPI_SendAndSubscribeTransactionStatusesServer) error
+	// GetAccountBalanceAtLatestBlock gets the balance of an account at the latest sealed block.
+	GetAccountBalanceAtLatestBlock(context.Context, *GetAccountBalanceAtLatestBlockRequest) (*AccountBalanceResponse, error)
+	// GetAccountBalanceAtBlockHeight gets the balance of an account at the given block height.
+	GetAccountBalanceAtBlockHeight(context.Context, *GetAccountBalanceAtBlockHeightRequest) (*AccountBalanceResponse, error)
+	// GetAccountKeysAtLatestBlock gets the public keys of an account at the latest sealed block.
+	GetAccountKeysAtLatestBlock(context.Context, *GetAccountKeysAtLatestBlockRequest) (*AccountKeysResponse, error)
+	// GetAccountKeysAtBlockHeight gets the public keys of an account at the given block height.
+	GetAccountKeysAtBlockHeight(context.Context, *GetAccountKeysAtBlockHeightRequest) (*AccountKeysResponse, error)
+	// GetAccountKeyAtLatestBlock gets the public key of an account with the given index at the latest
+	// sealed block.
+	GetAccountKeyAtLatestBlock(context.Context, *GetAccountKeyAtLatestBlockRequest) (*AccountKeyResponse, error)
+	// GetAccountKeyAtBlockHeight gets the public key of an account with the given index at the given
+	// block height.
+	GetAccountKeyAtBlockHeight(context.Context, *GetAccountKeyAtBlockHeightRequest) (*AccountKeyResponse, error)
+	// SimulateTransaction executes the transaction against the latest sealed state without
+	// submitting it to the network, and returns its result and estimated fees.
+	SimulateTransaction(context.Context, *SimulateTransactionRequest) (*SimulateTransactionResponse, error)
+	mustEmbedUnimplementedExtendedAccessAPIServer()
+}
+
+// UnimplementedExtendedAccessAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedExtendedAccessAPIServer struct {
+}
+
+func (UnimplementedExtendedAccessAPIServer) SubscribeEvents(*SubscribeEventsRequest, ExtendedAccessAPI_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) SubscribeTransactionStatuses(*access.GetTransactionRequest, ExtendedAccessAPI_SubscribeTransactionStatusesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTransactionStatuses not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) SendAndSubscribeTransactionStatuses(*access.SendTransactionRequest, ExtendedAccessAPI_SendAndSubscribeTransactionStatusesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SendAndSubscribeTransactionStatuses not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetAccountBalanceAtLatestBlock(context.Context, *GetAccountBalanceAtLatestBlockRequest) (*AccountBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountBalanceAtLatestBlock not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetAccountBalanceAtBlockHeight(context.Context, *GetAccountBalanceAtBlockHeightRequest) (*AccountBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountBalanceAtBlockHeight not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetAccountKeysAtLatestBlock(context.Context, *GetAccountKeysAtLatestBlockRequest) (*AccountKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountKeysAtLatestBlock not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetAccountKeysAtBlockHeight(context.Context, *GetAccountKeysAtBlockHeightRequest) (*AccountKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountKeysAtBlockHeight not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetAccountKeyAtLatestBlock(context.Context, *GetAccountKeyAtLatestBlockRequest) (*AccountKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountKeyAtLatestBlock not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) GetAccountKeyAtBlockHeight(context.Context, *GetAccountKeyAtBlockHeightRequest) (*AccountKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountKeyAtBlockHeight not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) SimulateTransaction(context.Context, *SimulateTransactionRequest) (*SimulateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateTransaction not implemented")
+}
+func (UnimplementedExtendedAccessAPIServer) mustEmbedUnimplementedExtendedAccessAPIServer() {}
+
+// UnsafeExtendedAccessAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedAccessAPIServer will
+// result in compilation errors.
+type UnsafeExtendedAccessAPIServer interface {
+	mustEmbedUnimplementedExtendedAccessAPIServer()
+}
+
+func RegisterExtendedAccessAPIServer(s grpc.ServiceRegistrar, srv ExtendedAccessAPIServer) {
+	s.RegisterService(&ExtendedAccessAPI_ServiceDesc, srv)
+}
+
+func _ExtendedAccessAPI_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedAccessAPIServer).SubscribeEvents(m, &extendedAccessAPISubscribeEventsServer{stream})
+}
+
+type ExtendedAccessAPI_SubscribeEventsServer interface {
+	Send(*access.EventsResponse_Result) error
+	grpc.ServerStream
+}
+
+type extendedAccessAPISubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedAccessAPISubscribeEventsServer) Send(m *access.EventsResponse_Result) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ExtendedAccessAPI_SubscribeTransactionStatuses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(access.GetTransactionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedAccessAPIServer).SubscribeTransactionStatuses(m, &extendedAccessAPISubscribeTransactionStatusesServer{stream})
+}
+
+type ExtendedAccessAPI_SubscribeTransactionStatusesServer interface {
+	Send(*access.TransactionResultResponse) error
+	grpc.ServerStream
+}
+
+type extendedAccessAPISubscribeTransactionStatusesServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedAccessAPISubscribeTransactionStatusesServer) Send(m *access.TransactionResultResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ExtendedAccessAPI_SendAndSubscribeTransactionStatuses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(access.SendTransactionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedAccessAPIServer).SendAndSubscribeTransactionStatuses(m, &extendedAccessAPISendAndSubscribeTransactionStatusesServer{stream})
+}
+
+type ExtendedAccessAPI_SendAndSubscribeTransactionStatusesServer interface {
+	Send(*access.TransactionResultResponse) error
+	grpc.ServerStream
+}
+
+type extendedAccessAPISendAndSubscribeTransactionStatusesServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedAccessAPISendAndSubscribeTransactionStatusesServer) Send(m *access.TransactionResultResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ExtendedAccessAPI_GetAccountBalanceAtLatestBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountBalanceAtLatestBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetAccountBalanceAtLatestBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.extended.ExtendedAccessAPI/GetAccountBalanceAtLatestBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetAccountBalanceAtLatestBlock(ctx, req.(*GetAccountBalanceAtLatestBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetAccountBalanceAtBlockHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountBalanceAtBlockHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetAccountBalanceAtBlockHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.extended.ExtendedAccessAPI/GetAccountBalanceAtBlockHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetAccountBalanceAtBlockHeight(ctx, req.(*GetAccountBalanceAtBlockHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetAccountKeysAtLatestBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountKeysAtLatestBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetAccountKeysAtLatestBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.extended.ExtendedAccessAPI/GetAccountKeysAtLatestBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetAccountKeysAtLatestBlock(ctx, req.(*GetAccountKeysAtLatestBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetAccountKeysAtBlockHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountKeysAtBlockHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetAccountKeysAtBlockHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.extended.ExtendedAccessAPI/GetAccountKeysAtBlockHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetAccountKeysAtBlockHeight(ctx, req.(*GetAccountKeysAtBlockHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetAccountKeyAtLatestBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountKeyAtLatestBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetAccountKeyAtLatestBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.extended.ExtendedAccessAPI/GetAccountKeyAtLatestBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetAccountKeyAtLatestBlock(ctx, req.(*GetAccountKeyAtLatestBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_GetAccountKeyAtBlockHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountKeyAtBlockHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetAccountKeyAtBlockHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.extended.ExtendedAccessAPI/GetAccountKeyAtBlockHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetAccountKeyAtBlockHeight(ctx, req.(*GetAccountKeyAtBlockHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedAccessAPI_SimulateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).SimulateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.extended.ExtendedAccessAPI/SimulateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).SimulateTransaction(ctx, req.(*SimulateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtendedAccessAPI_ServiceDesc is the grpc.ServiceDesc for ExtendedAccessAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedAccessAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.access.extended.ExtendedAccessAPI",
+	HandlerType: (*ExtendedAccessAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAccountBalanceAtLatestBlock",
+			Handler:    _ExtendedAccessAPI_GetAccountBalanceAtLatestBlock_Handler,
+		},
+		{
+			MethodName: "GetAccountBalanceAtBlockHeight",
+			Handler:    _ExtendedAccessAPI_GetAccountBalanceAtBlockHeight_Handler,
+		},
+		{
+			MethodName: "GetAccountKeysAtLatestBlock",
+			Handler:    _ExtendedAccessAPI_GetAccountKeysAtLatestBlock_Handler,
+		},
+		{
+			MethodName: "GetAccountKeysAtBlockHeight",
+			Handler:    _ExtendedAccessAPI_GetAccountKeysAtBlockHeight_Handler,
+		},
+		{
+			MethodName: "GetAccountKeyAtLatestBlock",
+			Handler:    _ExtendedAccessAPI_GetAccountKeyAtLatestBlock_Handler,
+		},
+		{
+			MethodName: "GetAccountKeyAtBlockHeight",
+			Handler:    _ExtendedAccessAPI_GetAccountKeyAtBlockHeight_Handler,
+		},
+		{
+			MethodName: "SimulateTransaction",
+			Handler:    _ExtendedAccessAPI_SimulateTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _ExtendedAccessAPI_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTransactionStatuses",
+			Handler:       _ExtendedAccessAPI_SubscribeTransactionStatuses_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SendAndSubscribeTransactionStatuses",
+			Handler:       _ExtendedAccessAPI_SendAndSubscribeTransactionStatuses_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "extended/extended.proto",
+}
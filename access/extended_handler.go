@@ -0,0 +1,303 @@
+package access
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ExtendedHandler serves the methods of the access node which are not part of the Flow Access API.
+type ExtendedHandler struct {
+	extended.UnimplementedExtendedAccessAPIServer
+	api   API
+	chain flow.Chain
+}
+
+func NewExtendedHandler(api API, chain flow.Chain) *ExtendedHandler {
+	return &ExtendedHandler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// SubscribeEvents streams the events matching the filter for every sealed block.
+func (h *ExtendedHandler) SubscribeEvents(
+	req *extended.SubscribeEventsRequest,
+	stream extended.ExtendedAccessAPI_SubscribeEventsServer,
+) error {
+	var startBlockID flow.Identifier
+	if len(req.GetStartBlockId()) > 0 {
+		var err error
+		startBlockID, err = convert.BlockID(req.GetStartBlockId())
+		if err != nil {
+			return err
+		}
+	}
+
+	filter, err := NewEventFilter(
+		h.chain,
+		req.GetFilter().GetEventType(),
+		req.GetFilter().GetAddress(),
+		req.GetFilter().GetContract(),
+	)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid event filter: %v", err)
+	}
+
+	sub := h.api.SubscribeEvents(stream.Context(), startBlockID, req.GetStartHeight(), filter)
+
+	return forward(sub, func(msg interface{}) error {
+		blockEvents, ok := msg.(*flow.BlockEvents)
+		if !ok {
+			return status.Errorf(codes.Internal, "unexpected subscription message type %T", msg)
+		}
+
+		result, err := blockEventsToMessage(*blockEvents)
+		if err != nil {
+			return err
+		}
+		return stream.Send(result)
+	})
+}
+
+// SubscribeTransactionStatuses streams the result of the transaction every time a new status is observed.
+func (h *ExtendedHandler) SubscribeTransactionStatuses(
+	req *access.GetTransactionRequest,
+	stream extended.ExtendedAccessAPI_SubscribeTransactionStatusesServer,
+) error {
+	id, err := convert.TransactionID(req.GetId())
+	if err != nil {
+		return err
+	}
+
+	sub := h.api.SubscribeTransactionStatuses(stream.Context(), id)
+
+	return forward(sub, func(msg interface{}) error {
+		return sendTransactionResult(msg, stream.Send)
+	})
+}
+
+// SendAndSubscribeTransactionStatuses sends the transaction, and streams its result every time a new status
+// is observed.
+func (h *ExtendedHandler) SendAndSubscribeTransactionStatuses(
+	req *access.SendTransactionRequest,
+	stream extended.ExtendedAccessAPI_SendAndSubscribeTransactionStatusesServer,
+) error {
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sub := h.api.SendAndSubscribeTransactionStatuses(stream.Context(), &tx)
+
+	return forward(sub, func(msg interface{}) error {
+		return sendTransactionResult(msg, stream.Send)
+	})
+}
+
+func sendTransactionResult(msg interface{}, send func(*access.TransactionResultResponse) error) error {
+	result, ok := msg.(*TransactionResult)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected subscription message type %T", msg)
+	}
+	return send(TransactionResultToMessage(result))
+}
+
+// GetAccountBalanceAtLatestBlock gets the balance of an account at the latest sealed block.
+func (h *ExtendedHandler) GetAccountBalanceAtLatestBlock(
+	ctx context.Context,
+	req *extended.GetAccountBalanceAtLatestBlockRequest,
+) (*extended.AccountBalanceResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := h.api.GetAccountBalanceAtLatestBlock(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extended.AccountBalanceResponse{
+		Balance: balance,
+	}, nil
+}
+
+// GetAccountBalanceAtBlockHeight gets the balance of an account at the given block height.
+func (h *ExtendedHandler) GetAccountBalanceAtBlockHeight(
+	ctx context.Context,
+	req *extended.GetAccountBalanceAtBlockHeightRequest,
+) (*extended.AccountBalanceResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := h.api.GetAccountBalanceAtBlockHeight(ctx, address, req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	return &extended.AccountBalanceResponse{
+		Balance: balance,
+	}, nil
+}
+
+// GetAccountKeysAtLatestBlock gets the public keys of an account at the latest sealed block.
+func (h *ExtendedHandler) GetAccountKeysAtLatestBlock(
+	ctx context.Context,
+	req *extended.GetAccountKeysAtLatestBlockRequest,
+) (*extended.AccountKeysResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := h.api.GetAccountKeysAtLatestBlock(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return accountKeysResponse(keys)
+}
+
+// GetAccountKeysAtBlockHeight gets the public keys of an account at the given block height.
+func (h *ExtendedHandler) GetAccountKeysAtBlockHeight(
+	ctx context.Context,
+	req *extended.GetAccountKeysAtBlockHeightRequest,
+) (*extended.AccountKeysResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := h.api.GetAccountKeysAtBlockHeight(ctx, address, req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	return accountKeysResponse(keys)
+}
+
+// GetAccountKeyAtLatestBlock gets the public key of an account with the given index at the latest sealed block.
+func (h *ExtendedHandler) GetAccountKeyAtLatestBlock(
+	ctx context.Context,
+	req *extended.GetAccountKeyAtLatestBlockRequest,
+) (*extended.AccountKeyResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := h.api.GetAccountKeyAtLatestBlock(ctx, address, uint64(req.GetIndex()))
+	if err != nil {
+		return nil, err
+	}
+
+	return accountKeyResponse(key)
+}
+
+// GetAccountKeyAtBlockHeight gets the public key of an account with the given index at the given block height.
+func (h *ExtendedHandler) GetAccountKeyAtBlockHeight(
+	ctx context.Context,
+	req *extended.GetAccountKeyAtBlockHeightRequest,
+) (*extended.AccountKeyResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := h.api.GetAccountKeyAtBlockHeight(ctx, address, uint64(req.GetIndex()), req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	return accountKeyResponse(key)
+}
+
+// SimulateTransaction executes the transaction against the latest sealed state without submitting it,
+// and returns its result and estimated fees.
+func (h *ExtendedHandler) SimulateTransaction(
+	ctx context.Context,
+	req *extended.SimulateTransactionRequest,
+) (*extended.SimulateTransactionResponse, error) {
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.api.SimulateTransaction(ctx, &tx, req.GetSkipSignatureVerification())
+	if err != nil {
+		return nil, err
+	}
+
+	computationIntensities := make(map[uint32]uint64, len(result.ComputationIntensities))
+	for kind, intensity := range result.ComputationIntensities {
+		computationIntensities[uint32(kind)] = uint64(intensity)
+	}
+
+	memoryIntensities := make(map[uint32]uint64, len(result.MemoryIntensities))
+	for kind, intensity := range result.MemoryIntensities {
+		memoryIntensities[uint32(kind)] = uint64(intensity)
+	}
+
+	return &extended.SimulateTransactionResponse{
+		BlockId:                result.BlockID[:],
+		BlockHeight:            result.BlockHeight,
+		Events:                 convert.EventsToMessages(result.Events),
+		ErrorCode:              uint32(result.ErrorCode),
+		ErrorMessage:           result.ErrorMessage,
+		ComputationUsed:        result.ComputationUsed,
+		MemoryUsed:             result.MemoryUsed,
+		ComputationIntensities: computationIntensities,
+		MemoryIntensities:      memoryIntensities,
+		Fees:                   result.Fees,
+	}, nil
+}
+
+func accountKeysResponse(keys []flow.AccountPublicKey) (*extended.AccountKeysResponse, error) {
+	keyMsgs := make([]*entities.AccountKey, len(keys))
+	for i, key := range keys {
+		keyMsg, err := convert.AccountKeyToMessage(key)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		keyMsgs[i] = keyMsg
+	}
+
+	return &extended.AccountKeysResponse{
+		AccountKeys: keyMsgs,
+	}, nil
+}
+
+func accountKeyResponse(key *flow.AccountPublicKey) (*extended.AccountKeyResponse, error) {
+	keyMsg, err := convert.AccountKeyToMessage(*key)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &extended.AccountKeyResponse{
+		AccountKey: keyMsg,
+	}, nil
+}
+
+// forward sends all the messages of the subscription to the client, until the subscription ends.
+// It returns the error that ended the subscription, if any.
+func forward(sub Subscription, send func(msg interface{}) error) error {
+	for msg := range sub.Channel() {
+		err := send(msg)
+		if err != nil {
+			// the subscription ends once the client is gone, as the stream context is canceled
+			return fmt.Errorf("could not send message of subscription %s: %w", sub.ID(), err)
+		}
+	}
+	return sub.Err()
+}
@@ -0,0 +1,291 @@
+package access_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/onflow/cadence/runtime/common"
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/extended"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/fvm/meter"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// subscription is a subscription whose messages are all known upfront.
+type subscription struct {
+	ch  chan interface{}
+	err error
+}
+
+func newSubscription(err error, msgs ...interface{}) *subscription {
+	sub := &subscription{ch: make(chan interface{}, len(msgs)), err: err}
+	for _, msg := range msgs {
+		sub.ch <- msg
+	}
+	close(sub.ch)
+	return sub
+}
+
+func (s *subscription) ID() string                  { return "subscription" }
+func (s *subscription) Channel() <-chan interface{} { return s.ch }
+func (s *subscription) Err() error                  { return s.err }
+
+// runExtendedHandler serves the extended API of the given backend, and returns a client connected to it.
+func runExtendedHandler(t *testing.T, api access.API) extended.ExtendedAccessAPIClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	extended.RegisterExtendedAccessAPIServer(server, access.NewExtendedHandler(api, flow.Testnet.Chain()))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return extended.NewExtendedAccessAPIClient(conn)
+}
+
+func TestExtendedHandler_SubscribeEvents(t *testing.T) {
+	eventType := "A.0000000000000001.Contract.Event"
+
+	t.Run("streams the events of the subscription", func(t *testing.T) {
+		startBlockID := unittest.IdentifierFixture()
+		blocksEvents := []*flow.BlockEvents{
+			{BlockID: unittest.IdentifierFixture(), BlockHeight: 10, Events: []flow.Event{unittest.EventFixture(flow.EventType(eventType), 0, 0, unittest.IdentifierFixture(), 0)}},
+			{BlockID: unittest.IdentifierFixture(), BlockHeight: 11},
+		}
+
+		api := new(accessmock.API)
+		api.On("SubscribeEvents", mock.Anything, startBlockID, uint64(0), mock.Anything).
+			Return(newSubscription(nil, blocksEvents[0], blocksEvents[1]))
+
+		client := runExtendedHandler(t, api)
+		stream, err := client.SubscribeEvents(context.Background(), &extended.SubscribeEventsRequest{
+			StartBlockId: startBlockID[:],
+			Filter:       &extended.EventFilter{EventType: []string{eventType}},
+		})
+		require.NoError(t, err)
+
+		for _, expected := range blocksEvents {
+			result, err := stream.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, expected.BlockID[:], result.GetBlockId())
+			assert.Equal(t, expected.BlockHeight, result.GetBlockHeight())
+			require.Len(t, result.GetEvents(), len(expected.Events))
+			for i, event := range expected.Events {
+				assert.Equal(t, string(event.Type), result.GetEvents()[i].GetType())
+			}
+		}
+
+		_, err = stream.Recv()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("returns the error ending the subscription", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("SubscribeEvents", mock.Anything, flow.ZeroID, uint64(5), mock.Anything).
+			Return(newSubscription(status.Error(codes.NotFound, "not found")))
+
+		client := runExtendedHandler(t, api)
+		stream, err := client.SubscribeEvents(context.Background(), &extended.SubscribeEventsRequest{
+			StartHeight: 5,
+			Filter:      &extended.EventFilter{EventType: []string{eventType}},
+		})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("rejects an invalid filter", func(t *testing.T) {
+		client := runExtendedHandler(t, new(accessmock.API))
+		stream, err := client.SubscribeEvents(context.Background(), &extended.SubscribeEventsRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestExtendedHandler_SubscribeTransactionStatuses(t *testing.T) {
+	txID := unittest.IdentifierFixture()
+	blockID := unittest.IdentifierFixture()
+	results := []*access.TransactionResult{
+		{Status: flow.TransactionStatusFinalized, BlockID: blockID, TransactionID: txID},
+		{Status: flow.TransactionStatusSealed, BlockID: blockID, TransactionID: txID},
+	}
+
+	// requireStatuses checks that the statuses of the results are streamed, and that the stream ends after them.
+	requireStatuses := func(t *testing.T, recv func() (*accessproto.TransactionResultResponse, error)) {
+		for _, expected := range results {
+			result, err := recv()
+			require.NoError(t, err)
+			assert.Equal(t, entities.TransactionStatus(expected.Status), result.GetStatus())
+			assert.Equal(t, txID[:], result.GetTransactionId())
+		}
+
+		_, err := recv()
+		require.ErrorIs(t, err, io.EOF)
+	}
+
+	t.Run("streams the statuses of the transaction", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("SubscribeTransactionStatuses", mock.Anything, txID).
+			Return(newSubscription(nil, results[0], results[1]))
+
+		client := runExtendedHandler(t, api)
+		stream, err := client.SubscribeTransactionStatuses(context.Background(), &accessproto.GetTransactionRequest{Id: txID[:]})
+		require.NoError(t, err)
+
+		requireStatuses(t, stream.Recv)
+	})
+
+	t.Run("sends the transaction and streams its statuses", func(t *testing.T) {
+		tx := unittest.TransactionBodyFixture()
+
+		api := new(accessmock.API)
+		api.On("SendAndSubscribeTransactionStatuses", mock.Anything, mock.MatchedBy(func(sent *flow.TransactionBody) bool {
+			return sent.ID() == tx.ID()
+		})).Return(newSubscription(nil, results[0], results[1]))
+
+		client := runExtendedHandler(t, api)
+		stream, err := client.SendAndSubscribeTransactionStatuses(context.Background(), &accessproto.SendTransactionRequest{
+			Transaction: convert.TransactionToMessage(tx),
+		})
+		require.NoError(t, err)
+
+		requireStatuses(t, stream.Recv)
+	})
+
+	t.Run("rejects a missing transaction ID", func(t *testing.T) {
+		client := runExtendedHandler(t, new(accessmock.API))
+		stream, err := client.SubscribeTransactionStatuses(context.Background(), &accessproto.GetTransactionRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestExtendedHandler_Accounts(t *testing.T) {
+	ctx := context.Background()
+	address := unittest.RandomAddressFixture()
+	height := uint64(42)
+
+	privateKey, err := unittest.AccountKeyDefaultFixture()
+	require.NoError(t, err)
+	key := privateKey.PublicKey(1000)
+	key.Index = 1
+
+	t.Run("balance at block height", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetAccountBalanceAtBlockHeight", mock.Anything, address, height).Return(uint64(100), nil)
+
+		client := runExtendedHandler(t, api)
+		resp, err := client.GetAccountBalanceAtBlockHeight(ctx, &extended.GetAccountBalanceAtBlockHeightRequest{
+			Address:     address.Bytes(),
+			BlockHeight: height,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), resp.GetBalance())
+	})
+
+	t.Run("keys at latest block", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetAccountKeysAtLatestBlock", mock.Anything, address).Return([]flow.AccountPublicKey{key}, nil)
+
+		client := runExtendedHandler(t, api)
+		resp, err := client.GetAccountKeysAtLatestBlock(ctx, &extended.GetAccountKeysAtLatestBlockRequest{
+			Address: address.Bytes(),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetAccountKeys(), 1)
+		assert.Equal(t, key.PublicKey.Encode(), resp.GetAccountKeys()[0].GetPublicKey())
+	})
+
+	t.Run("key at block height", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetAccountKeyAtBlockHeight", mock.Anything, address, uint64(1), height).Return(&key, nil)
+
+		client := runExtendedHandler(t, api)
+		resp, err := client.GetAccountKeyAtBlockHeight(ctx, &extended.GetAccountKeyAtBlockHeightRequest{
+			Address:     address.Bytes(),
+			Index:       1,
+			BlockHeight: height,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), resp.GetAccountKey().GetIndex())
+		assert.Equal(t, uint32(1000), resp.GetAccountKey().GetWeight())
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		client := runExtendedHandler(t, new(accessmock.API))
+		_, err := client.GetAccountBalanceAtLatestBlock(ctx, &extended.GetAccountBalanceAtLatestBlockRequest{
+			Address: []byte{1, 2, 3},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestExtendedHandler_SimulateTransaction(t *testing.T) {
+	tx := unittest.TransactionBodyFixture()
+
+	t.Run("simulates the transaction", func(t *testing.T) {
+		expected := &access.SimulatedTransactionResult{
+			BlockID:                unittest.IdentifierFixture(),
+			BlockHeight:            10,
+			ComputationUsed:        20,
+			ComputationIntensities: meter.MeteredComputationIntensities{common.ComputationKindStatement: 5},
+			Fees:                   100,
+		}
+
+		api := new(accessmock.API)
+		api.On("SimulateTransaction", mock.Anything, mock.MatchedBy(func(simulated *flow.TransactionBody) bool {
+			return simulated.ID() == tx.ID()
+		}), true).Return(expected, nil)
+
+		client := runExtendedHandler(t, api)
+		resp, err := client.SimulateTransaction(context.Background(), &extended.SimulateTransactionRequest{
+			Transaction:               convert.TransactionToMessage(tx),
+			SkipSignatureVerification: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected.BlockID[:], resp.GetBlockId())
+		assert.Equal(t, expected.BlockHeight, resp.GetBlockHeight())
+		assert.Equal(t, expected.ComputationUsed, resp.GetComputationUsed())
+		assert.Equal(t, map[uint32]uint64{uint32(common.ComputationKindStatement): 5}, resp.GetComputationIntensities())
+		assert.Equal(t, expected.Fees, resp.GetFees())
+	})
+
+	t.Run("returns the error of the backend", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("SimulateTransaction", mock.Anything, mock.Anything, false).
+			Return(nil, status.Error(codes.Unimplemented, "transaction simulation is not enabled"))
+
+		client := runExtendedHandler(t, api)
+		_, err := client.SimulateTransaction(context.Background(), &extended.SimulateTransactionRequest{
+			Transaction: convert.TransactionToMessage(tx),
+		})
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
@@ -11,6 +11,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	storage "github.com/onflow/flow-go/storage"
+
 	testing "testing"
 )
 
@@ -157,6 +159,140 @@ func (_m *API) GetAccountAtLatestBlock(ctx context.Context, address flow.Address
 	return r0, r1
 }
 
+// GetAccountBalanceAtBlockHeight provides a mock function with given fields: ctx, address, height
+func (_m *API) GetAccountBalanceAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (uint64, error) {
+	ret := _m.Called(ctx, address, height)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) uint64); ok {
+		r0 = rf(ctx, address, height)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(ctx, address, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountBalanceAtLatestBlock provides a mock function with given fields: ctx, address
+func (_m *API) GetAccountBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) uint64); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeyAtBlockHeight provides a mock function with given fields: ctx, address, keyIndex, height
+func (_m *API) GetAccountKeyAtBlockHeight(ctx context.Context, address flow.Address, keyIndex uint64, height uint64) (*flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, keyIndex, height)
+
+	var r0 *flow.AccountPublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64) *flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, keyIndex, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountPublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint64) error); ok {
+		r1 = rf(ctx, address, keyIndex, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeyAtLatestBlock provides a mock function with given fields: ctx, address, keyIndex
+func (_m *API) GetAccountKeyAtLatestBlock(ctx context.Context, address flow.Address, keyIndex uint64) (*flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, keyIndex)
+
+	var r0 *flow.AccountPublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) *flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, keyIndex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountPublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(ctx, address, keyIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeysAtBlockHeight provides a mock function with given fields: ctx, address, height
+func (_m *API) GetAccountKeysAtBlockHeight(ctx context.Context, address flow.Address, height uint64) ([]flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, height)
+
+	var r0 []flow.AccountPublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) []flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.AccountPublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(ctx, address, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeysAtLatestBlock provides a mock function with given fields: ctx, address
+func (_m *API) GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Address) ([]flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 []flow.AccountPublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address) []flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.AccountPublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockByHeight provides a mock function with given fields: ctx, height
 func (_m *API) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
 	ret := _m.Called(ctx, height)
@@ -272,6 +408,29 @@ func (_m *API) GetCollectionByID(ctx context.Context, id flow.Identifier) (*flow
 	return r0, r1
 }
 
+// GetEventsByQuery provides a mock function with given fields: ctx, startHeight, endHeight, query
+func (_m *API) GetEventsByQuery(ctx context.Context, startHeight uint64, endHeight uint64, query storage.EventQuery) ([]flow.BlockEvents, error) {
+	ret := _m.Called(ctx, startHeight, endHeight, query)
+
+	var r0 []flow.BlockEvents
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, storage.EventQuery) []flow.BlockEvents); ok {
+		r0 = rf(ctx, startHeight, endHeight, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.BlockEvents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, storage.EventQuery) error); ok {
+		r1 = rf(ctx, startHeight, endHeight, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEventsForBlockIDs provides a mock function with given fields: ctx, eventType, blockIDs
 func (_m *API) GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier) ([]flow.BlockEvents, error) {
 	ret := _m.Called(ctx, eventType, blockIDs)
@@ -576,6 +735,22 @@ func (_m *API) Ping(ctx context.Context) error {
 	return r0
 }
 
+// SendAndSubscribeTransactionStatuses provides a mock function with given fields: ctx, tx
+func (_m *API) SendAndSubscribeTransactionStatuses(ctx context.Context, tx *flow.TransactionBody) access.Subscription {
+	ret := _m.Called(ctx, tx)
+
+	var r0 access.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody) access.Subscription); ok {
+		r0 = rf(ctx, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(access.Subscription)
+		}
+	}
+
+	return r0
+}
+
 // SendTransaction provides a mock function with given fields: ctx, tx
 func (_m *API) SendTransaction(ctx context.Context, tx *flow.TransactionBody) error {
 	ret := _m.Called(ctx, tx)
@@ -590,6 +765,61 @@ func (_m *API) SendTransaction(ctx context.Context, tx *flow.TransactionBody) er
 	return r0
 }
 
+// SimulateTransaction provides a mock function with given fields: ctx, tx, skipSignatureVerification
+func (_m *API) SimulateTransaction(ctx context.Context, tx *flow.TransactionBody, skipSignatureVerification bool) (*access.SimulatedTransactionResult, error) {
+	ret := _m.Called(ctx, tx, skipSignatureVerification)
+
+	var r0 *access.SimulatedTransactionResult
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, bool) *access.SimulatedTransactionResult); ok {
+		r0 = rf(ctx, tx, skipSignatureVerification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.SimulatedTransactionResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, bool) error); ok {
+		r1 = rf(ctx, tx, skipSignatureVerification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeEvents provides a mock function with given fields: ctx, startBlockID, startHeight, filter
+func (_m *API) SubscribeEvents(ctx context.Context, startBlockID flow.Identifier, startHeight uint64, filter access.EventFilter) access.Subscription {
+	ret := _m.Called(ctx, startBlockID, startHeight, filter)
+
+	var r0 access.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, uint64, access.EventFilter) access.Subscription); ok {
+		r0 = rf(ctx, startBlockID, startHeight, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(access.Subscription)
+		}
+	}
+
+	return r0
+}
+
+// SubscribeTransactionStatuses provides a mock function with given fields: ctx, txID
+func (_m *API) SubscribeTransactionStatuses(ctx context.Context, txID flow.Identifier) access.Subscription {
+	ret := _m.Called(ctx, txID)
+
+	var r0 access.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) access.Subscription); ok {
+		r0 = rf(ctx, txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(access.Subscription)
+		}
+	}
+
+	return r0
+}
+
 // NewAPI creates a new instance of API. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewAPI(t testing.TB) *API {
 	mock := &API{}
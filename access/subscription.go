@@ -0,0 +1,18 @@
+package access
+
+// Subscription represents a long running streaming request, such as an event subscription.
+// The backend pushes data into the subscription, while the API handler (gRPC or REST) reads
+// from it and forwards each message to the client.
+type Subscription interface {
+	// ID returns the unique identifier of the subscription. It is mainly used for logging.
+	ID() string
+
+	// Channel returns the channel from which the streamed data can be read.
+	// The channel is closed once the subscription ends, either because the client
+	// went away or because the backend encountered an error.
+	Channel() <-chan interface{}
+
+	// Err returns the error that caused the subscription to end, if any.
+	// It should only be called after the channel returned by Channel has been closed.
+	Err() error
+}
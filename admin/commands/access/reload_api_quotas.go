@@ -0,0 +1,36 @@
+package access
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/access/quota"
+)
+
+var _ commands.AdminCommand = (*ReloadAPIQuotasCommand)(nil)
+
+// ReloadAPIQuotasCommand reloads the API keys and quotas of the Access API from the key store file.
+type ReloadAPIQuotasCommand struct {
+	limiter *quota.Limiter
+}
+
+func (r *ReloadAPIQuotasCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	keys, err := r.limiter.Reload()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload API quotas: %w", err)
+	}
+
+	return map[string]interface{}{"keys": keys}, nil
+}
+
+func (r *ReloadAPIQuotasCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
+
+func NewReloadAPIQuotasCommand(limiter *quota.Limiter) commands.AdminCommand {
+	return &ReloadAPIQuotasCommand{
+		limiter: limiter,
+	}
+}
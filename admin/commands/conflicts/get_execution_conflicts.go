@@ -0,0 +1,60 @@
+package conflicts
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/conflicts"
+)
+
+var _ commands.AdminCommand = (*GetExecutionConflictsCommand)(nil)
+
+// GetExecutionConflictsCommand returns the blocks for which other execution nodes produced results
+// conflicting with the results of this node, and whether execution is halted because of them.
+type GetExecutionConflictsCommand struct {
+	detector *conflicts.Detector
+}
+
+func (g *GetExecutionConflictsCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	if g.detector == nil {
+		return nil, errors.New("conflict detection is not available")
+	}
+
+	blocks := make([]interface{}, 0)
+	for _, conflict := range g.detector.Conflicts() {
+		conflicting := make([]interface{}, 0, len(conflict.ConflictingResultIDs))
+		for _, id := range conflict.ConflictingResultIDs {
+			conflicting = append(conflicting, id.String())
+		}
+
+		block := map[string]interface{}{
+			"block_id":               conflict.BlockID.String(),
+			"height":                 conflict.Height,
+			"own_result_id":          conflict.OwnResultID.String(),
+			"conflicting_result_ids": conflicting,
+			"sealed":                 conflict.Sealed(),
+		}
+		if conflict.Sealed() {
+			block["sealed_result_id"] = conflict.SealedResultID.String()
+			block["resumed"] = conflict.Resumed
+		}
+		blocks = append(blocks, block)
+	}
+
+	return map[string]interface{}{
+		"conflicts": blocks,
+		"halted":    g.detector.ExecutionHalted(),
+	}, nil
+}
+
+func (g *GetExecutionConflictsCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
+
+func NewGetExecutionConflictsCommand(detector *conflicts.Detector) commands.AdminCommand {
+	return &GetExecutionConflictsCommand{
+		detector: detector,
+	}
+}
@@ -0,0 +1,44 @@
+package conflicts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/conflicts"
+)
+
+var _ commands.AdminCommand = (*ResumeExecutionCommand)(nil)
+
+// ResumeExecutionCommand resumes the execution of blocks after it was halted because of a sealed result
+// conflicting with the result of this node.
+type ResumeExecutionCommand struct {
+	detector *conflicts.Detector
+}
+
+func (r *ResumeExecutionCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	if r.detector == nil {
+		return nil, errors.New("conflict detection is not available")
+	}
+
+	resumed, err := r.detector.Resume()
+	if err != nil {
+		return nil, fmt.Errorf("could not resume execution: %w", err)
+	}
+
+	return map[string]interface{}{
+		"resumed_conflicts": resumed,
+	}, nil
+}
+
+func (r *ResumeExecutionCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
+
+func NewResumeExecutionCommand(detector *conflicts.Detector) commands.AdminCommand {
+	return &ResumeExecutionCommand{
+		detector: detector,
+	}
+}
@@ -0,0 +1,41 @@
+package pruner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/pruner"
+)
+
+var _ commands.AdminCommand = (*GetPrunerProgressCommand)(nil)
+
+// GetPrunerProgressCommand returns the progress of the execution data pruner.
+type GetPrunerProgressCommand struct {
+	pruner *pruner.Pruner
+}
+
+func (g *GetPrunerProgressCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	if g.pruner == nil {
+		return nil, errors.New("pruning is disabled")
+	}
+
+	progress := g.pruner.Progress()
+
+	return map[string]interface{}{
+		"pruned_height":     progress.PrunedHeight,
+		"target_height":     progress.TargetHeight,
+		"retention_heights": g.pruner.RetentionHeights(),
+	}, nil
+}
+
+func (g *GetPrunerProgressCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
+
+func NewGetPrunerProgressCommand(pruner *pruner.Pruner) commands.AdminCommand {
+	return &GetPrunerProgressCommand{
+		pruner: pruner,
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -22,7 +24,9 @@ import (
 	hotsignature "github.com/onflow/flow-go/consensus/hotstuff/signature"
 	"github.com/onflow/flow-go/consensus/hotstuff/verification"
 	recovery "github.com/onflow/flow-go/consensus/recovery/protocol"
+	"github.com/onflow/flow-go/engine/access/graphql"
 	"github.com/onflow/flow-go/engine/access/ingestion"
+	"github.com/onflow/flow-go/engine/access/quota"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	"github.com/onflow/flow-go/engine/common/follower"
@@ -34,10 +38,12 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/buffer"
+	"github.com/onflow/flow-go/module/execution"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
 	"github.com/onflow/flow-go/module/id"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/state_synchronization"
 	"github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/network"
 	netcache "github.com/onflow/flow-go/network/cache"
@@ -90,6 +96,7 @@ type AccessNodeConfig struct {
 	nodeInfoFile                 string
 	apiRatelimits                map[string]int
 	apiBurstlimits               map[string]int
+	apiQuotaFile                 string
 	rpcConf                      rpc.Config
 	ExecutionNodeAddress         string // deprecated
 	HistoricalAccessRPCs         []access.AccessAPIClient
@@ -98,6 +105,11 @@ type AccessNodeConfig struct {
 	logTxTimeToFinalizedExecuted bool
 	retryEnabled                 bool
 	rpcMetricsEnabled            bool
+	executionDataIndexingEnabled bool
+	eventIndexingEnabled         bool
+	executionDataDir             string
+	registerIndexDir             string
+	scriptExecutionTimeLimit     time.Duration
 	baseOptions                  []cmd.Option
 
 	PublicNetworkConfig PublicNetworkConfig
@@ -112,6 +124,7 @@ type PublicNetworkConfig struct {
 
 // DefaultAccessNodeConfig defines all the default values for the AccessNodeConfig
 func DefaultAccessNodeConfig() *AccessNodeConfig {
+	homedir, _ := os.UserHomeDir()
 	return &AccessNodeConfig{
 		collectionGRPCPort: 9000,
 		executionGRPCPort:  9000,
@@ -120,6 +133,10 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 			SecureGRPCListenAddr:      "0.0.0.0:9001",
 			HTTPListenAddr:            "0.0.0.0:8000",
 			RESTListenAddr:            "",
+			RESTValidateResponses:     false,
+			GraphQLListenAddr:         "",
+			GraphQLMaxDepth:           graphql.DefaultMaxDepth,
+			GraphQLMaxCost:            graphql.DefaultMaxCost,
 			CollectionAddr:            "",
 			HistoricalAccessAddrs:     "",
 			CollectionClientTimeout:   3 * time.Second,
@@ -135,9 +152,15 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		pingEnabled:                  false,
 		retryEnabled:                 false,
 		rpcMetricsEnabled:            false,
+		executionDataIndexingEnabled: false,
+		eventIndexingEnabled:         false,
+		executionDataDir:             filepath.Join(homedir, ".flow", "execution_data_blobstore"),
+		registerIndexDir:             filepath.Join(homedir, ".flow", "register_index"),
+		scriptExecutionTimeLimit:     execution.DefaultScriptExecutionTimeLimit,
 		nodeInfoFile:                 "",
 		apiRatelimits:                nil,
 		apiBurstlimits:               nil,
+		apiQuotaFile:                 "",
 		staked:                       true,
 		bootstrapNodeAddresses:       []string{},
 		bootstrapNodePublicKeys:      []string{},
@@ -171,6 +194,12 @@ type FlowAccessNodeBuilder struct {
 	BlocksToMarkExecuted       *stdmap.Times
 	TransactionMetrics         module.TransactionMetrics
 	PingMetrics                module.PingMetrics
+	APIQuotas                  *quota.Limiter
+	RegisterIndex              *storage.RegisterIndex
+	EventIndex                 *storage.EventIndex
+	ExecutionDataService       state_synchronization.ExecutionDataService
+	ScriptExecutor             backend.ScriptExecutor
+	TransactionSimulator       backend.TransactionSimulator
 	Committee                  hotstuff.Committee
 	Finalized                  *flow.Header
 	Pending                    []*flow.Header
@@ -436,6 +465,10 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.StringVar(&builder.rpcConf.SecureGRPCListenAddr, "secure-rpc-addr", defaultConfig.rpcConf.SecureGRPCListenAddr, "the address the secure gRPC server listens on")
 		flags.StringVarP(&builder.rpcConf.HTTPListenAddr, "http-addr", "h", defaultConfig.rpcConf.HTTPListenAddr, "the address the http proxy server listens on")
 		flags.StringVar(&builder.rpcConf.RESTListenAddr, "rest-addr", defaultConfig.rpcConf.RESTListenAddr, "the address the REST server listens on (if empty the REST server will not be started)")
+		flags.BoolVar(&builder.rpcConf.RESTValidateResponses, "rest-validate-responses", defaultConfig.rpcConf.RESTValidateResponses, "whether REST responses are validated against the OpenAPI spec, responses which do not match the spec are replaced with an internal error")
+		flags.StringVar(&builder.rpcConf.GraphQLListenAddr, "graphql-addr", defaultConfig.rpcConf.GraphQLListenAddr, "the address the GraphQL server listens on (if empty the GraphQL server will not be started)")
+		flags.IntVar(&builder.rpcConf.GraphQLMaxDepth, "graphql-max-depth", defaultConfig.rpcConf.GraphQLMaxDepth, "maximum depth of GraphQL queries")
+		flags.IntVar(&builder.rpcConf.GraphQLMaxCost, "graphql-max-cost", defaultConfig.rpcConf.GraphQLMaxCost, "maximum cost of GraphQL queries, where each field costs 1 and the fields selected from lists are multiplied by 10")
 		flags.StringVarP(&builder.rpcConf.CollectionAddr, "static-collection-ingress-addr", "", defaultConfig.rpcConf.CollectionAddr, "the address (of the collection node) to send transactions to")
 		flags.StringVarP(&builder.ExecutionNodeAddress, "script-addr", "s", defaultConfig.ExecutionNodeAddress, "the address (of the execution node) forward the script to")
 		flags.StringVarP(&builder.rpcConf.HistoricalAccessAddrs, "historical-access-addr", "", defaultConfig.rpcConf.HistoricalAccessAddrs, "comma separated rpc addresses for historical access nodes")
@@ -453,16 +486,25 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.StringVarP(&builder.nodeInfoFile, "node-info-file", "", defaultConfig.nodeInfoFile, "full path to a json file which provides more details about nodes when reporting its reachability metrics")
 		flags.StringToIntVar(&builder.apiRatelimits, "api-rate-limits", defaultConfig.apiRatelimits, "per second rate limits for Access API methods e.g. Ping=300,GetTransaction=500 etc.")
 		flags.StringToIntVar(&builder.apiBurstlimits, "api-burst-limits", defaultConfig.apiBurstlimits, "burst limits for Access API methods e.g. Ping=100,GetTransaction=100 etc.")
+		flags.StringVar(&builder.apiQuotaFile, "api-quota-file", defaultConfig.apiQuotaFile, "full path to a json key store file with the API keys and per client quotas for the Access API, reloaded with the reload-api-quotas admin command (if empty no quotas are enforced)")
 		flags.BoolVar(&builder.staked, "staked", defaultConfig.staked, "whether this node is a staked access node or not")
 		flags.StringVar(&builder.observerNetworkingKeyPath, "observer-networking-key-path", defaultConfig.observerNetworkingKeyPath, "path to the networking key for observer")
 		flags.StringSliceVar(&builder.bootstrapNodeAddresses, "bootstrap-node-addresses", defaultConfig.bootstrapNodeAddresses, "the network addresses of the bootstrap access node if this is an unstaked access node e.g. access-001.mainnet.flow.org:9653,access-002.mainnet.flow.org:9653")
 		flags.StringSliceVar(&builder.bootstrapNodePublicKeys, "bootstrap-node-public-keys", defaultConfig.bootstrapNodePublicKeys, "the networking public key of the bootstrap access node if this is an unstaked access node (in the same order as the bootstrap node addresses) e.g. \"d57a5e9c5.....\",\"44ded42d....\"")
 		flags.BoolVar(&builder.supportsUnstakedFollower, "supports-unstaked-node", defaultConfig.supportsUnstakedFollower, "true if this staked access node supports unstaked node")
 		flags.StringVar(&builder.PublicNetworkConfig.BindAddress, "public-network-address", defaultConfig.PublicNetworkConfig.BindAddress, "staked access node's public network bind address")
+		flags.BoolVar(&builder.executionDataIndexingEnabled, "execution-data-indexing-enabled", defaultConfig.executionDataIndexingEnabled, "whether to index the execution data of sealed blocks and execute scripts locally")
+		flags.BoolVar(&builder.eventIndexingEnabled, "event-indexing-enabled", defaultConfig.eventIndexingEnabled, "whether to index the events of sealed blocks and answer event queries locally, requires execution data indexing")
+		flags.StringVar(&builder.executionDataDir, "execution-data-dir", defaultConfig.executionDataDir, "directory to store the downloaded execution data")
+		flags.StringVar(&builder.registerIndexDir, "register-index-dir", defaultConfig.registerIndexDir, "directory to store the register index used for local script execution")
+		flags.DurationVar(&builder.scriptExecutionTimeLimit, "script-execution-time-limit", defaultConfig.scriptExecutionTimeLimit, "maximum duration of a locally executed script")
 	}).ValidateFlags(func() error {
 		if builder.supportsUnstakedFollower && (builder.PublicNetworkConfig.BindAddress == cmd.NotSet || builder.PublicNetworkConfig.BindAddress == "") {
 			return errors.New("public-network-address must be set if supports-unstaked-node is true")
 		}
+		if builder.eventIndexingEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if event-indexing-enabled is true")
+		}
 
 		return nil
 	})
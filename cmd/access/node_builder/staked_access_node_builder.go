@@ -2,9 +2,14 @@ package node_builder
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/dgraph-io/badger/v2"
+	badgerds "github.com/ipfs/go-ds-badger2"
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/routing"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -15,27 +20,39 @@ import (
 
 	"github.com/onflow/flow-go/crypto"
 
+	"github.com/onflow/flow-go/admin/commands"
+	accessCommands "github.com/onflow/flow-go/admin/commands/access"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/ingestion"
 	pingeng "github.com/onflow/flow-go/engine/access/ping"
+	"github.com/onflow/flow-go/engine/access/quota"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	"github.com/onflow/flow-go/engine/common/requester"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/encoding/cbor"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/execution"
 	"github.com/onflow/flow-go/module/id"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/metrics/unstaked"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
 	"github.com/onflow/flow-go/network"
 	netcache "github.com/onflow/flow-go/network/cache"
+	"github.com/onflow/flow-go/network/compressor"
 	"github.com/onflow/flow-go/network/p2p"
 	"github.com/onflow/flow-go/network/p2p/unicast"
 	relaynet "github.com/onflow/flow-go/network/relay"
 	"github.com/onflow/flow-go/network/topology"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/grpcutils"
 )
 
@@ -118,6 +135,12 @@ func (builder *StakedAccessNodeBuilder) enqueueRelayNetwork() {
 }
 
 func (builder *StakedAccessNodeBuilder) Build() (cmd.Node, error) {
+	if builder.apiQuotaFile != "" {
+		builder.AdminCommand("reload-api-quotas", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return accessCommands.NewReloadAPIQuotasCommand(builder.APIQuotas)
+		})
+	}
+
 	builder.
 		BuildConsensusFollower().
 		Module("collection node client", func(node *cmd.NodeConfig) error {
@@ -190,6 +213,80 @@ func (builder *StakedAccessNodeBuilder) Build() (cmd.Node, error) {
 			builder.PingMetrics = metrics.NewPingCollector()
 			return nil
 		}).
+		Module("api quotas", func(node *cmd.NodeConfig) error {
+			if builder.apiQuotaFile == "" {
+				return nil
+			}
+
+			limiter, err := quota.NewLimiter(node.Logger, metrics.NewAccessQuotaCollector(), builder.apiQuotaFile)
+			if err != nil {
+				return fmt.Errorf("could not load API quotas: %w", err)
+			}
+			builder.APIQuotas = limiter
+
+			return nil
+		}).
+		Module("register index", func(node *cmd.NodeConfig) error {
+			if !builder.executionDataIndexingEnabled {
+				return nil
+			}
+
+			err := os.MkdirAll(builder.registerIndexDir, 0700)
+			if err != nil {
+				return err
+			}
+
+			db, err := badger.Open(badger.DefaultOptions(builder.registerIndexDir).WithLogger(nil))
+			if err != nil {
+				return fmt.Errorf("could not open register index db: %w", err)
+			}
+			builder.ShutdownFunc(db.Close)
+
+			registers, err := bstorage.NewRegisterIndex(db)
+			if errors.Is(err, storage.ErrNotFound) {
+				// the register index is initialized with the execution state of the root block
+				checkpointFile := filepath.Join(node.BootstrapDir, bootstrap.PathRootCheckpoint)
+				registers, err = indexer.BootstrapRegisterIndex(node.Logger, db, checkpointFile, node.RootBlock.Header.Height)
+			}
+			if err != nil {
+				return fmt.Errorf("could not initialize register index: %w", err)
+			}
+			builder.RegisterIndex = registers
+
+			vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+			vmCtx := fvm.NewContext(node.Logger, node.FvmOptions...)
+			builder.ScriptExecutor = execution.NewScripts(
+				node.Logger,
+				vm,
+				vmCtx,
+				node.Storage.Headers,
+				registers,
+				builder.scriptExecutionTimeLimit,
+			)
+			builder.TransactionSimulator = execution.NewTransactions(
+				node.Logger,
+				vm,
+				vmCtx,
+				node.Storage.Headers,
+				registers,
+			)
+
+			return nil
+		}).
+		Module("event index", func(node *cmd.NodeConfig) error {
+			if !builder.eventIndexingEnabled {
+				return nil
+			}
+
+			events := bstorage.NewEvents(node.Metrics.Cache, node.DB)
+			eventIndex, err := bstorage.NewEventIndex(node.DB, events)
+			if err != nil {
+				return fmt.Errorf("could not initialize event index: %w", err)
+			}
+			builder.EventIndex = eventIndex
+
+			return nil
+		}).
 		Module("server certificate", func(node *cmd.NodeConfig) error {
 			// generate the server certificate that will be served by the GRPC server
 			x509Certificate, err := grpcutils.X509Certificate(node.NetworkKey)
@@ -221,6 +318,12 @@ func (builder *StakedAccessNodeBuilder) Build() (cmd.Node, error) {
 				builder.rpcMetricsEnabled,
 				builder.apiRatelimits,
 				builder.apiBurstlimits,
+				rpc.WithBackendOptions(
+					backend.WithScriptExecutor(builder.ScriptExecutor),
+					backend.WithTransactionSimulator(builder.TransactionSimulator),
+					backend.WithEventIndex(builder.eventIndex()),
+				),
+				rpc.WithQuotas(builder.APIQuotas),
 			)
 			return builder.RpcEng, nil
 		}).
@@ -258,6 +361,53 @@ func (builder *StakedAccessNodeBuilder) Build() (cmd.Node, error) {
 			return builder.RequestEng, nil
 		})
 
+	if builder.executionDataIndexingEnabled {
+		builder.
+			Component("execution data service", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+				err := os.MkdirAll(builder.executionDataDir, 0700)
+				if err != nil {
+					return nil, err
+				}
+
+				ds, err := badgerds.NewDatastore(builder.executionDataDir, &badgerds.DefaultOptions)
+				if err != nil {
+					return nil, err
+				}
+				builder.ShutdownFunc(ds.Close)
+
+				bs, err := node.Network.RegisterBlobService(engine.ExecutionDataService, ds)
+				if err != nil {
+					return nil, fmt.Errorf("could not register blob service: %w", err)
+				}
+
+				eds := state_synchronization.NewExecutionDataService(
+					&cbor.Codec{},
+					compressor.NewLz4Compressor(),
+					bs,
+					metrics.NewExecutionDataServiceCollector(),
+					node.Logger,
+				)
+				builder.ExecutionDataService = eds
+
+				return eds, nil
+			}).
+			Component("execution data indexer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+				executionDataIndexer := indexer.New(
+					node.Logger,
+					node.State,
+					node.Storage.Headers,
+					node.Storage.Results,
+					builder.ExecutionDataService,
+					builder.RegisterIndex,
+					builder.eventIndex(),
+					indexer.DefaultFetchTimeout,
+				)
+				builder.FinalizationDistributor.AddOnBlockFinalizedConsumer(executionDataIndexer.OnFinalizedBlock)
+
+				return executionDataIndexer, nil
+			})
+	}
+
 	if builder.supportsUnstakedFollower {
 		builder.Component("unstaked sync request handler", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			syncRequestHandler, err := synceng.NewRequestHandlerEngine(
@@ -301,6 +451,14 @@ func (builder *StakedAccessNodeBuilder) Build() (cmd.Node, error) {
 }
 
 // enqueueUnstakedNetworkInit enqueues the unstaked network component initialized for the staked node
+// eventIndex returns the event index, or nil if events are not indexed.
+func (builder *StakedAccessNodeBuilder) eventIndex() storage.EventIndex {
+	if builder.EventIndex == nil {
+		return nil
+	}
+	return builder.EventIndex
+}
+
 func (builder *StakedAccessNodeBuilder) enqueueUnstakedNetworkInit() {
 	builder.Component("unstaked network", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 		builder.PublicNetworkConfig.Metrics = metrics.NewNetworkCollector(metrics.WithNetworkPrefix("unstaked"))
@@ -23,6 +23,8 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/onflow/flow-go/admin/commands"
+	conflictsCommands "github.com/onflow/flow-go/admin/commands/conflicts"
+	prunerCommands "github.com/onflow/flow-go/admin/commands/pruner"
 	stateSyncCommands "github.com/onflow/flow-go/admin/commands/state_synchronization"
 	uploaderCommands "github.com/onflow/flow-go/admin/commands/uploader"
 	"github.com/onflow/flow-go/consensus"
@@ -37,11 +39,16 @@ import (
 	"github.com/onflow/flow-go/engine/common/requester"
 	"github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/execution/checker"
+	"github.com/onflow/flow-go/engine/execution/checkpoint"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	"github.com/onflow/flow-go/engine/execution/computation/computer/uploader"
+	"github.com/onflow/flow-go/engine/execution/conflicts"
+	"github.com/onflow/flow-go/engine/execution/history"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
+	"github.com/onflow/flow-go/engine/execution/pruner"
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
@@ -52,6 +59,9 @@ import (
 	"github.com/onflow/flow-go/fvm/systemcontracts"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	ledger "github.com/onflow/flow-go/ledger/complete"
+	ledgerhistory "github.com/onflow/flow-go/ledger/complete/history"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/nodestore"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/encoding/cbor"
@@ -66,10 +76,12 @@ import (
 	chainsync "github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/network/compressor"
 	"github.com/onflow/flow-go/network/p2p"
+	"github.com/onflow/flow-go/network/p2p/unicast"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/blocktimer"
 	storage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
 )
 
 type ExecutionConfig struct {
@@ -77,9 +89,13 @@ type ExecutionConfig struct {
 	triedir                     string
 	executionDataDir            string
 	mTrieCacheSize              uint32
+	mTrieMemoryBudget           uint64
+	mTrieNodeStoreDir           string
 	transactionResultsCacheSize uint
 	checkpointDistance          uint
 	checkpointsToKeep           uint
+	checkpointMaxDeltas         uint
+	checkpointWorkers           uint
 	stateDeltasLimit            uint
 	cadenceExecutionCache       uint
 	cadenceTracing              bool
@@ -98,7 +114,24 @@ type ExecutionConfig struct {
 	enableBlockDataUpload       bool
 	gcpBucketName               string
 	s3BucketName                string
+	archiveDir                  string
+	archiveCompression          string
+	archiveRetentionHeights     uint64
+	archiveRetentionAge         time.Duration
 	edsDatastoreTTL             time.Duration
+	parallelExecutionWorkers    uint
+	logTopRegisterInteractions  uint
+	pruningRetentionHeights     uint64
+	pruningBatchSize            uint
+	pruningBatchInterval        time.Duration
+	pruningCheckInterval        time.Duration
+	checkpointBootstrapPeerStr  string
+	checkpointBootstrapTimeout  time.Duration
+	serveCheckpoints            bool
+	haltOnExecutionConflict     bool
+	persistentProgramsCacheSize uint
+	registerHistoryDir          string
+	registerHistoryInterval     time.Duration
 }
 
 type ExecutionNodeBuilder struct {
@@ -125,12 +158,26 @@ func (e *ExecutionNodeBuilder) LoadFlags() {
 			flags.StringVar(&e.exeConf.executionDataDir, "execution-data-dir", filepath.Join(homedir, ".flow", "execution_data_blobstore"),
 				"directory to use for Execution Data blobstore")
 			flags.Uint32Var(&e.exeConf.mTrieCacheSize, "mtrie-cache-size", 500, "cache size for MTrie")
+			flags.Uint64Var(&e.exeConf.mTrieMemoryBudget, "mtrie-memory-budget", 0,
+				"approximate memory size in bytes of the MTrie nodes kept in memory, the other nodes are stored on disk (0 to keep all nodes in memory)")
+			flags.StringVar(&e.exeConf.mTrieNodeStoreDir, "mtrie-node-store-dir", "",
+				"directory to store the MTrie nodes exceeding the memory budget (defaults to the nodestore subdirectory of the triedir)")
 			flags.UintVar(&e.exeConf.checkpointDistance, "checkpoint-distance", 20, "number of WAL segments between checkpoints")
 			flags.UintVar(&e.exeConf.checkpointsToKeep, "checkpoints-to-keep", 5, "number of recent checkpoints to keep (0 to keep all)")
+			flags.UintVar(&e.exeConf.checkpointMaxDeltas, "checkpoint-max-deltas", 0,
+				"maximum number of delta checkpoints, which only contain the trie nodes created since the previous checkpoint, created between full checkpoints (0 to only create full checkpoints)")
+			flags.UintVar(&e.exeConf.checkpointWorkers, "checkpoint-workers", 0,
+				"number of workers writing full checkpoints as partitions concurrently (0 to write full checkpoints to a single file)")
 			flags.UintVar(&e.exeConf.stateDeltasLimit, "state-deltas-limit", 100, "maximum number of state deltas in the memory pool")
 			flags.UintVar(&e.exeConf.cadenceExecutionCache, "cadence-execution-cache", computation.DefaultProgramsCacheSize,
 				"cache size for Cadence execution")
+			flags.UintVar(&e.exeConf.persistentProgramsCacheSize, "persistent-programs-cache-size", 0,
+				"maximum number of contract programs to persist and load again when the node restarts (0 to disable)")
 			flags.BoolVar(&e.exeConf.cadenceTracing, "cadence-tracing", false, "enables cadence runtime level tracing")
+			flags.UintVar(&e.exeConf.parallelExecutionWorkers, "parallel-execution-workers", 0,
+				"number of workers executing the transactions of a collection optimistically in parallel (0 or 1 to execute them sequentially)")
+			flags.UintVar(&e.exeConf.logTopRegisterInteractions, "log-top-register-interactions", 0,
+				"number of transactions of each block with the largest register reads and writes to log (0 to disable)")
 			flags.UintVar(&e.exeConf.chdpCacheSize, "chdp-cache", storage.DefaultCacheSize, "cache size for Chunk Data Packs")
 			flags.DurationVar(&e.exeConf.requestInterval, "request-interval", 60*time.Second, "the interval between requests for the requester engine")
 			flags.DurationVar(&e.exeConf.scriptLogThreshold, "script-log-threshold", computation.DefaultScriptLogThreshold,
@@ -154,13 +201,51 @@ func (e *ExecutionNodeBuilder) LoadFlags() {
 			flags.BoolVar(&e.exeConf.enableBlockDataUpload, "enable-blockdata-upload", false, "enable uploading block data to Cloud Bucket")
 			flags.StringVar(&e.exeConf.gcpBucketName, "gcp-bucket-name", "", "GCP Bucket name for block data uploader")
 			flags.StringVar(&e.exeConf.s3BucketName, "s3-bucket-name", "", "S3 Bucket name for block data uploader")
+			flags.StringVar(&e.exeConf.archiveDir, "blockdata-archive-dir", "", "directory of the local block data archive")
+			flags.StringVar(&e.exeConf.archiveCompression, "blockdata-archive-compression", string(uploader.ArchiveCompressionLz4),
+				"compression of the local block data archive (none, gzip or lz4)")
+			flags.Uint64Var(&e.exeConf.archiveRetentionHeights, "blockdata-archive-retention-heights", 0,
+				"number of heights kept in the local block data archive, 0 keeps all heights")
+			flags.DurationVar(&e.exeConf.archiveRetentionAge, "blockdata-archive-retention-age", 0,
+				"duration for which blocks are kept in the local block data archive, 0 keeps blocks forever")
 			flags.DurationVar(&e.exeConf.edsDatastoreTTL, "execution-data-service-datastore-ttl", 0,
 				"TTL for new blobs added to the execution data service blobstore")
+			flags.Uint64Var(&e.exeConf.pruningRetentionHeights, "pruning-retention-heights", 0,
+				"number of sealed heights whose execution data is kept in the database, 0 disables pruning")
+			flags.UintVar(&e.exeConf.pruningBatchSize, "pruning-batch-size", pruner.DefaultBatchSize,
+				"number of heights pruned before the pruner pauses")
+			flags.DurationVar(&e.exeConf.pruningBatchInterval, "pruning-batch-interval", pruner.DefaultBatchInterval,
+				"pause between two batches of pruned heights")
+			flags.DurationVar(&e.exeConf.pruningCheckInterval, "pruning-check-interval", pruner.DefaultCheckInterval,
+				"interval in which the pruner checks for heights to prune")
+			flags.StringVar(&e.exeConf.checkpointBootstrapPeerStr, "checkpoint-bootstrap-peer", "",
+				"node ID of a trusted execution node to download the root checkpoint from when the execution database is not bootstrapped")
+			flags.DurationVar(&e.exeConf.checkpointBootstrapTimeout, "checkpoint-bootstrap-timeout", 30*time.Minute,
+				"timeout for downloading the root checkpoint from the checkpoint bootstrap peer")
+			flags.BoolVar(&e.exeConf.serveCheckpoints, "serve-checkpoints", false,
+				"serve checkpoints of the execution states held in memory to other execution nodes")
+			flags.BoolVar(&e.exeConf.haltOnExecutionConflict, "halt-on-execution-conflict", false,
+				"stop executing blocks once a sealed result conflicting with the own result is detected, until execution is resumed with the resume-execution admin command")
+			flags.StringVar(&e.exeConf.registerHistoryDir, "register-history-dir", "",
+				"directory of the register history index, which allows reading registers at past sealed heights (empty to disable)")
+			flags.DurationVar(&e.exeConf.registerHistoryInterval, "register-history-check-interval", history.DefaultCheckInterval,
+				"interval in which the register history indexer checks for sealed heights to index")
 		}).
 		ValidateFlags(func() error {
 			if e.exeConf.enableBlockDataUpload {
-				if e.exeConf.gcpBucketName == "" && e.exeConf.s3BucketName == "" {
-					return fmt.Errorf("invalid flag. gcp-bucket-name or s3-bucket-name required when blockdata-uploader is enabled")
+				if e.exeConf.gcpBucketName == "" && e.exeConf.s3BucketName == "" && e.exeConf.archiveDir == "" {
+					return fmt.Errorf("invalid flag. gcp-bucket-name, s3-bucket-name or blockdata-archive-dir required when blockdata-uploader is enabled")
+				}
+			}
+			if _, err := uploader.ParseArchiveCompression(e.exeConf.archiveCompression); err != nil {
+				return fmt.Errorf("invalid flag. blockdata-archive-compression: %w", err)
+			}
+			if e.exeConf.pruningRetentionHeights > 0 && e.exeConf.pruningBatchSize == 0 {
+				return fmt.Errorf("invalid flag. pruning-batch-size must be positive when pruning is enabled")
+			}
+			if e.exeConf.checkpointBootstrapPeerStr != "" {
+				if _, err := flow.HexStringToIdentifier(e.exeConf.checkpointBootstrapPeerStr); err != nil {
+					return fmt.Errorf("invalid flag. checkpoint-bootstrap-peer: %w", err)
 				}
 			}
 			return nil
@@ -174,13 +259,17 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		executionState                state.ExecutionState
 		followerState                 protocol.MutableState
 		ledgerStorage                 *ledger.Ledger
+		registerHistory               *ledgerhistory.Index
 		events                        *storage.Events
 		serviceEvents                 *storage.ServiceEvents
 		txResults                     *storage.TransactionResults
 		results                       *storage.ExecutionResults
 		myReceipts                    *storage.MyExecutionReceipts
+		chunkDataPacks                *storage.ChunkDataPacks
+		executionPruner               *pruner.Pruner
 		providerEngine                *exeprovider.Engine
 		checkerEng                    *checker.Engine
+		conflictDetector              *conflicts.Detector
 		syncCore                      *chainsync.Core
 		pendingBlocks                 *buffer.PendingBlocks // used in follower engine
 		deltas                        *ingestion.Deltas
@@ -208,6 +297,15 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		AdminCommand("set-uploader-enabled", func(config *NodeConfig) commands.AdminCommand {
 			return uploaderCommands.NewToggleUploaderCommand()
 		}).
+		AdminCommand("get-pruner-progress", func(config *NodeConfig) commands.AdminCommand {
+			return prunerCommands.NewGetPrunerProgressCommand(executionPruner)
+		}).
+		AdminCommand("get-execution-conflicts", func(config *NodeConfig) commands.AdminCommand {
+			return conflictsCommands.NewGetExecutionConflictsCommand(conflictDetector)
+		}).
+		AdminCommand("resume-execution", func(config *NodeConfig) commands.AdminCommand {
+			return conflictsCommands.NewResumeExecutionCommand(conflictDetector)
+		}).
 		Module("mutable follower state", func(node *NodeConfig) error {
 			// For now, we only support state implementations from package badger.
 			// If we ever support different implementations, the following can be replaced by a type-aware factory
@@ -320,6 +418,45 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 			// blockDataUploader will stay nil and disable calling uploader at all
 			return &module.NoopReadyDoneAware{}, nil
 		}).
+		Component("local block data archiver", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+			if e.exeConf.enableBlockDataUpload && e.exeConf.archiveDir != "" {
+				logger := node.Logger.With().Str("component_name", "local_block_data_archiver").Logger()
+
+				compression, err := uploader.ParseArchiveCompression(e.exeConf.archiveCompression)
+				if err != nil {
+					return nil, err
+				}
+
+				archiver, err := uploader.NewLocalArchiver(
+					e.exeConf.archiveDir,
+					compression,
+					uploader.ArchiveRetention{
+						Heights: e.exeConf.archiveRetentionHeights,
+						MaxAge:  e.exeConf.archiveRetentionAge,
+					},
+					logger,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("cannot create local block data archiver: %w", err)
+				}
+
+				asyncUploader := uploader.NewAsyncUploader(
+					archiver,
+					blockdataUploaderRetryTimeout,
+					blockDataUploaderMaxRetry,
+					logger,
+					collector,
+				)
+				blockDataUploaders = append(blockDataUploaders, asyncUploader)
+
+				return asyncUploader, nil
+			}
+
+			// Since we don't have conditional component creation, we just use Noop one.
+			// It's functions will be once per startup/shutdown - non-measurable performance penalty
+			// blockDataUploader will stay nil and disable calling uploader at all
+			return &module.NoopReadyDoneAware{}, nil
+		}).
 		Module("state deltas mempool", func(node *NodeConfig) error {
 			var err error
 			deltas, err = ingestion.NewDeltas(e.exeConf.stateDeltasLimit)
@@ -349,39 +486,106 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 
 			// if the execution database does not exist, then we need to bootstrap the execution database.
 			if !bootstrapped {
-				// when bootstrapping, the bootstrap folder must have a checkpoint file
-				// we need to cover this file to the trie folder to restore the trie to restore the execution state.
-				err = copyBootstrapState(node.BootstrapDir, e.exeConf.triedir)
-				if err != nil {
-					return nil, fmt.Errorf("could not load bootstrap state from checkpoint file: %w", err)
+				// the execution database is bootstrapped with the sealed root state, unless a recent sealed
+				// state is fetched from a trusted execution node
+				rootCommit, rootHeader := node.RootSeal.FinalState, node.RootBlock.Header
+
+				if e.exeConf.checkpointBootstrapPeerStr != "" {
+					// download the checkpoint of the latest sealed state the peer holds, the checkpoint is
+					// only stored if its trie matches the sealed state commitment. The blocks after the
+					// sealed block are then executed as any unexecuted block.
+					seal, err := e.fetchBootstrapState(node)
+					if err != nil {
+						return nil, fmt.Errorf("could not fetch bootstrap state from peer: %w", err)
+					}
+
+					rootCommit = seal.FinalState
+					rootHeader, err = node.State.AtBlockID(seal.BlockID).Head()
+					if err != nil {
+						return nil, fmt.Errorf("could not get header of sealed block %v: %w", seal.BlockID, err)
+					}
+
+					// the result of the next executed block references the sealed result as its previous result
+					err = node.Storage.Results.ForceIndex(seal.BlockID, seal.ResultID)
+					if err != nil {
+						return nil, fmt.Errorf("could not index sealed result of block %v: %w", seal.BlockID, err)
+					}
+				} else {
+					// when bootstrapping, the bootstrap folder must have a checkpoint file
+					// we need to cover this file to the trie folder to restore the trie to restore the execution state.
+					err = copyBootstrapState(node.BootstrapDir, e.exeConf.triedir)
+					if err != nil {
+						return nil, fmt.Errorf("could not load bootstrap state from checkpoint file: %w", err)
+					}
 				}
 
 				// TODO: check that the checkpoint file contains the root block's statecommit hash
 
-				err = bootstrapper.BootstrapExecutionDatabase(node.DB, node.RootSeal.FinalState, node.RootBlock.Header)
+				err = bootstrapper.BootstrapExecutionDatabase(node.DB, rootCommit, rootHeader)
 				if err != nil {
 					return nil, fmt.Errorf("could not bootstrap execution database: %w", err)
 				}
 			} else {
 				// if execution database has been bootstrapped, then the root statecommit must equal to the one
-				// in the bootstrap folder
-				if commit != node.RootSeal.FinalState {
+				// in the bootstrap folder, unless it was bootstrapped with a sealed state fetched from a peer
+				if commit != node.RootSeal.FinalState && !e.bootstrappedFromPeer(node) {
 					return nil, fmt.Errorf("mismatching root statecommitment. database has state commitment: %x, "+
 						"bootstap has statecommitment: %x",
 						commit, node.RootSeal.FinalState)
 				}
 			}
 
-			ledgerStorage, err = ledger.NewLedger(diskWAL, int(e.exeConf.mTrieCacheSize), collector, node.Logger.With().Str("subcomponent",
-				"ledger").Logger(), ledger.DefaultPathFinderVersion)
+			var forestOpts []mtrie.ForestOption
+			if e.exeConf.mTrieMemoryBudget > 0 {
+				nodeStoreDir := e.exeConf.mTrieNodeStoreDir
+				if nodeStoreDir == "" {
+					nodeStoreDir = filepath.Join(e.exeConf.triedir, "nodestore")
+				}
+
+				nodeStore, err := nodestore.New(nodeStoreDir, e.exeConf.mTrieMemoryBudget, collector, node.Logger)
+				if err != nil {
+					return nil, fmt.Errorf("could not create mtrie node store: %w", err)
+				}
+				e.FlowNodeBuilder.ShutdownFunc(nodeStore.Close)
+
+				forestOpts = append(forestOpts, mtrie.WithNodeStore(nodeStore))
+			}
+
+			// the register history index records the updates replayed from the WAL, so it is opened before the ledger
+			if e.exeConf.registerHistoryDir != "" {
+				registerHistory, err = ledgerhistory.New(e.exeConf.registerHistoryDir, node.Logger)
+				if err != nil {
+					return nil, err
+				}
+				e.FlowNodeBuilder.ShutdownFunc(registerHistory.Close)
+			}
+
+			ledgerStorage, err = ledger.NewLedgerWithHistory(diskWAL, int(e.exeConf.mTrieCacheSize), collector, node.Logger.With().Str("subcomponent",
+				"ledger").Logger(), ledger.DefaultPathFinderVersion, registerHistory, forestOpts...)
 			return ledgerStorage, err
 		}).
+		Component("checkpoint server", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+			if !e.exeConf.serveCheckpoints {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+
+			server := checkpoint.NewServer(node.Logger, ledgerStorage, node.State)
+			_, err := node.Network.RegisterStreamProtocol(unicast.CheckpointProtocolId(node.SporkID), server.HandleStream)
+			if err != nil {
+				return nil, fmt.Errorf("could not register checkpoint protocol: %w", err)
+			}
+
+			return &module.NoopReadyDoneAware{}, nil
+		}).
 		Component("execution state ledger WAL compactor", func(node *NodeConfig) (module.ReadyDoneAware, error) {
 
 			checkpointer, err := ledgerStorage.Checkpointer()
 			if err != nil {
 				return nil, fmt.Errorf("cannot create checkpointer: %w", err)
 			}
+			checkpointer.SetMaxDeltaCheckpoints(e.exeConf.checkpointMaxDeltas)
+			checkpointer.SetPartitionWorkers(e.exeConf.checkpointWorkers)
+
 			compactor := wal.NewCompactor(checkpointer,
 				10*time.Second,
 				e.exeConf.checkpointDistance,
@@ -478,6 +682,19 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 			vm := fvm.NewVirtualMachine(rt)
 			vmCtx := fvm.NewContext(node.Logger, node.FvmOptions...)
 
+			var persistentPrograms *computation.PersistentProgramsCache
+			if e.exeConf.persistentProgramsCacheSize > 0 {
+				persistentPrograms, err = computation.NewPersistentProgramsCache(
+					node.Logger,
+					node.DB,
+					metrics.NewExecutionProgramsCacheCollector(),
+					e.exeConf.persistentProgramsCacheSize,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("could not create persistent programs cache: %w", err)
+				}
+			}
+
 			ledgerViewCommitter := committer.NewLedgerViewCommitter(ledgerStorage, node.Tracer)
 			manager, err := computation.New(
 				node.Logger,
@@ -487,20 +704,25 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 				node.State,
 				vm,
 				vmCtx,
-				e.exeConf.cadenceExecutionCache,
+				computation.ComputationConfig{
+					ProgramsCacheSize:        e.exeConf.cadenceExecutionCache,
+					PersistentPrograms:       persistentPrograms,
+					ScriptLogThreshold:       e.exeConf.scriptLogThreshold,
+					ScriptExecutionTimeLimit: e.exeConf.scriptExecutionTimeLimit,
+				},
 				ledgerViewCommitter,
-				e.exeConf.scriptLogThreshold,
-				e.exeConf.scriptExecutionTimeLimit,
 				blockDataUploaders,
 				executionDataService,
 				executionDataCIDCache,
+				computer.WithParallelExecution(int(e.exeConf.parallelExecutionWorkers)),
+				computer.WithRegisterInteractionsLog(int(e.exeConf.logTopRegisterInteractions)),
 			)
 			if err != nil {
 				return nil, err
 			}
 			computationManager = manager
 
-			chunkDataPacks := storage.NewChunkDataPacks(node.Metrics.Cache, node.DB, node.Storage.Collections, e.exeConf.chdpCacheSize)
+			chunkDataPacks = storage.NewChunkDataPacks(node.Metrics.Cache, node.DB, node.Storage.Collections, e.exeConf.chdpCacheSize)
 			stateCommitments := storage.NewCommits(node.Metrics.Cache, node.DB)
 
 			// Needed for gRPC server, make sure to assign to main scoped vars
@@ -552,6 +774,13 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 			}
 			blockView := executionState.NewView(stateCommit)
 
+			// Load the persisted programs at the latest executed block, before its children are executed
+			blockHeader, err := node.Storage.Headers.ByBlockID(blockID)
+			if err != nil {
+				return nil, fmt.Errorf("cannot get the header of the latest executed block %s: %w", blockID.String(), err)
+			}
+			computationManager.WarmUpPrograms(blockHeader, executionState.NewView(stateCommit))
+
 			// Get the epoch counter from the smart contract at the last executed block.
 			contractEpochCounter, err := getContractEpochCounter(vm, vmCtx, blockView)
 			// Failing to fetch the epoch counter from the smart contract is a fatal error.
@@ -598,6 +827,74 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 			)
 			return checkerEng, nil
 		}).
+		Component("execution data pruner", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+			if e.exeConf.pruningRetentionHeights == 0 {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+
+			var err error
+			executionPruner, err = pruner.New(
+				node.Logger,
+				pruner.Config{
+					RetentionHeights: e.exeConf.pruningRetentionHeights,
+					BatchSize:        e.exeConf.pruningBatchSize,
+					BatchInterval:    e.exeConf.pruningBatchInterval,
+					CheckInterval:    e.exeConf.pruningCheckInterval,
+				},
+				node.DB,
+				node.State,
+				executionState,
+				node.Storage.Headers.(*storage.Headers),
+				results,
+				myReceipts,
+				chunkDataPacks,
+				events,
+				serviceEvents,
+				txResults,
+				metrics.NewExecutionPrunerCollector(),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("could not create execution data pruner: %w", err)
+			}
+
+			return executionPruner, nil
+		}).
+		Component("register history indexer", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+			if registerHistory == nil {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+
+			return history.New(
+				node.Logger,
+				history.Config{
+					CheckInterval: e.exeConf.registerHistoryInterval,
+				},
+				registerHistory,
+				ledgerStorage,
+				node.State,
+				node.Storage.Headers,
+				executionState,
+			), nil
+		}).
+		Component("execution result conflict detector", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+			var err error
+			conflictDetector, err = conflicts.New(
+				node.Logger,
+				node.DB,
+				node.Me,
+				node.Storage.Headers,
+				node.Storage.Payloads,
+				node.Storage.Receipts,
+				results,
+				metrics.NewExecutionConflictCollector(),
+				e.exeConf.haltOnExecutionConflict,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("could not create execution result conflict detector: %w", err)
+			}
+
+			return conflictDetector, nil
+		}).
 		Component("ingestion engine", func(node *NodeConfig) (module.ReadyDoneAware, error) {
 			var err error
 			collectionRequester, err = requester.New(node.Logger, node.Metrics.Engine, node.Network, node.Me, node.State,
@@ -647,11 +944,13 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 				e.exeConf.syncFast,
 				checkAuthorizedAtBlock,
 				e.exeConf.pauseExecution,
+				conflictDetector,
 			)
 
 			// TODO: we should solve these mutual dependencies better
 			// => https://github.com/dapperlabs/flow-go/issues/4360
 			collectionRequester = collectionRequester.WithHandle(ingestionEng.OnCollection)
+			conflictDetector.AddResumeConsumer(ingestionEng.ResumeExecution)
 
 			node.ProtocolEvents.AddConsumer(ingestionEng)
 
@@ -685,6 +984,7 @@ func (e *ExecutionNodeBuilder) LoadComponentsAndModules() {
 
 			finalizationDistributor = pubsub.NewFinalizationDistributor()
 			finalizationDistributor.AddConsumer(checkerEng)
+			finalizationDistributor.AddConsumer(conflictDetector)
 
 			// creates a consensus follower with ingestEngine as the notifier
 			// so that it gets notified upon each new finalized block
@@ -804,6 +1104,48 @@ func getContractEpochCounter(vm *fvm.VirtualMachine, vmCtx fvm.Context, view *de
 	return epochCounter, nil
 }
 
+// fetchBootstrapState downloads the checkpoint of the sealed root state commitment from the configured
+// checkpoint bootstrap peer into the execution state folder.
+func (e *ExecutionNodeBuilder) fetchBootstrapState(node *NodeConfig) (*flow.Seal, error) {
+	peerID, err := flow.HexStringToIdentifier(e.exeConf.checkpointBootstrapPeerStr)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse checkpoint bootstrap peer: %w", err)
+	}
+
+	seals, err := checkpoint.SealedStates(node.State)
+	if err != nil {
+		return nil, fmt.Errorf("could not get sealed states: %w", err)
+	}
+
+	streams, err := node.Network.RegisterStreamProtocol(unicast.CheckpointProtocolId(node.SporkID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not register checkpoint protocol: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.exeConf.checkpointBootstrapTimeout)
+	defer cancel()
+
+	return checkpoint.Fetch(ctx, node.Logger, streams, peerID, seals, e.exeConf.triedir)
+}
+
+// bootstrappedFromPeer returns whether the execution database was bootstrapped with a sealed state fetched
+// from a peer, rather than the root state: the highest executed block is then a block of the protocol state
+// above the root block.
+func (e *ExecutionNodeBuilder) bootstrappedFromPeer(node *NodeConfig) bool {
+	var blockID flow.Identifier
+	err := node.DB.View(operation.RetrieveExecutedBlock(&blockID))
+	if err != nil {
+		return false
+	}
+
+	header, err := node.State.AtBlockID(blockID).Head()
+	if err != nil {
+		return false
+	}
+
+	return header.Height > node.RootBlock.Header.Height
+}
+
 // copy the checkpoint files from the bootstrap folder to the execution state folder
 // Checkpoint file is required to restore the trie, and has to be placed in the execution
 // state folder.
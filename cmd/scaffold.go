@@ -842,23 +842,28 @@ func (fnb *FlowNodeBuilder) initLocal() {
 }
 
 func (fnb *FlowNodeBuilder) initFvmOptions() {
-	blockFinder := fvm.NewBlockFinder(fnb.Storage.Headers)
+	fnb.FvmOptions = FvmOptions(fnb.RootChainID, fnb.Storage.Headers)
+}
+
+// FvmOptions returns the options of the FVM which are used by the nodes of the given chain.
+func FvmOptions(chainID flow.ChainID, headers storage.Headers) []fvm.Option {
+	blockFinder := fvm.NewBlockFinder(headers)
 	vmOpts := []fvm.Option{
-		fvm.WithChain(fnb.RootChainID.Chain()),
+		fvm.WithChain(chainID.Chain()),
 		fvm.WithBlocks(blockFinder),
 		fvm.WithAccountStorageLimit(true),
 	}
-	if fnb.RootChainID == flow.Testnet || fnb.RootChainID == flow.Canary || fnb.RootChainID == flow.Mainnet {
+	if chainID == flow.Testnet || chainID == flow.Canary || chainID == flow.Mainnet {
 		vmOpts = append(vmOpts,
 			fvm.WithTransactionFeesEnabled(true),
 		)
 	}
-	if fnb.RootChainID == flow.Testnet || fnb.RootChainID == flow.Canary || fnb.RootChainID == flow.Localnet || fnb.RootChainID == flow.Benchnet {
+	if chainID == flow.Testnet || chainID == flow.Canary || chainID == flow.Localnet || chainID == flow.Benchnet {
 		vmOpts = append(vmOpts,
 			fvm.WithRestrictedDeployment(false),
 		)
 	}
-	fnb.FvmOptions = vmOpts
+	return vmOpts
 }
 
 func (fnb *FlowNodeBuilder) handleModule(v namedModuleFunc) error {
@@ -0,0 +1,220 @@
+package index_events
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
+	badgerds "github.com/ipfs/go-ds-badger2"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/network/compressor"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+var (
+	flagDatadir          string
+	flagExecutionDataDir string
+	flagStartHeight      uint64
+	flagEndHeight        uint64
+)
+
+var Cmd = &cobra.Command{
+	Use:   "index-events",
+	Short: "Backfill the event index of an access node from downloaded execution data",
+	Long: `Backfill the event index of an access node from downloaded execution data.
+
+Heights below the indexed range are indexed from the highest to the lowest height, heights above
+the indexed range from the lowest to the highest height, so that the indexed range stays
+continuous. Heights which are already indexed are skipped. The access node must be stopped while
+the index is backfilled.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagExecutionDataDir, "execution-data-dir", "",
+		"directory of the execution data blobstore")
+	_ = Cmd.MarkFlagRequired("execution-data-dir")
+
+	Cmd.Flags().Uint64Var(&flagStartHeight, "start-height", 0,
+		"lowest height to index")
+	_ = Cmd.MarkFlagRequired("start-height")
+
+	Cmd.Flags().Uint64Var(&flagEndHeight, "end-height", 0,
+		"highest height to index, defaults to the latest sealed height")
+}
+
+func run(*cobra.Command, []string) {
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+	state, err := common.InitProtocolState(db, storages)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not init protocol state")
+	}
+
+	endHeight := flagEndHeight
+	if endHeight == 0 {
+		sealed, err := state.Sealed().Head()
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not get sealed header")
+		}
+		endHeight = sealed.Height
+	}
+
+	if flagStartHeight > endHeight {
+		log.Fatal().Uint64("start_height", flagStartHeight).Uint64("end_height", endHeight).
+			Msg("start height must be less than or equal to end height")
+	}
+
+	eventIndex, err := bstorage.NewEventIndex(db, bstorage.NewEvents(metrics.NewNoopCollector(), db))
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not init event index")
+	}
+
+	ds, err := badgerds.NewDatastore(flagExecutionDataDir, &badgerds.DefaultOptions)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not init execution data datastore")
+	}
+	defer ds.Close()
+
+	logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+	eds := state_synchronization.NewExecutionDataService(
+		&cbor.Codec{},
+		compressor.NewLz4Compressor(),
+		blobService(blockservice.New(blockstore.NewBlockstore(ds), nil)),
+		metrics.NewNoopCollector(),
+		logger,
+	)
+
+	indexer := &backfiller{
+		headers:    storages.Headers,
+		results:    storages.Results,
+		eds:        eds,
+		eventIndex: eventIndex,
+	}
+
+	firstHeight, latestHeight, err := eventIndex.IndexedRange()
+	if errors.Is(err, storage.ErrNotFound) {
+		// the index is empty, so it can be filled from the lowest height
+		indexer.indexUp(flagStartHeight, endHeight)
+		log.Info().Uint64("start_height", flagStartHeight).Uint64("end_height", endHeight).Msg("indexed events")
+		return
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not get indexed range")
+	}
+
+	log.Info().Uint64("first_height", firstHeight).Uint64("latest_height", latestHeight).Msg("found indexed range")
+
+	if flagStartHeight < firstHeight {
+		indexer.indexDown(flagStartHeight, min(endHeight, firstHeight-1))
+	}
+
+	if endHeight > latestHeight {
+		indexer.indexUp(max(flagStartHeight, latestHeight+1), endHeight)
+	}
+
+	firstHeight, latestHeight, err = eventIndex.IndexedRange()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not get indexed range")
+	}
+
+	log.Info().Uint64("first_height", firstHeight).Uint64("latest_height", latestHeight).Msg("indexed events")
+}
+
+// backfiller indexes the events of sealed blocks from the downloaded execution data.
+type backfiller struct {
+	headers    storage.Headers
+	results    storage.ExecutionResults
+	eds        state_synchronization.ExecutionDataService
+	eventIndex storage.EventIndex
+}
+
+// indexDown indexes the events from the end height down to the start height.
+func (b *backfiller) indexDown(start uint64, end uint64) {
+	for height := end; height >= start; height-- {
+		b.index(height)
+
+		if height == 0 {
+			break
+		}
+	}
+}
+
+// indexUp indexes the events from the start height up to the end height.
+func (b *backfiller) indexUp(start uint64, end uint64) {
+	for height := start; height <= end; height++ {
+		b.index(height)
+	}
+}
+
+func (b *backfiller) index(height uint64) {
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		log.Fatal().Err(err).Uint64("height", height).Msg("could not get header")
+	}
+
+	blockID := header.ID()
+	result, err := b.results.ByBlockID(blockID)
+	if err != nil {
+		log.Fatal().Err(err).Uint64("height", height).Msg("could not get execution result")
+	}
+
+	executionData, err := b.eds.Get(context.Background(), result.ExecutionDataID)
+	if err != nil {
+		log.Fatal().Err(err).Uint64("height", height).Msg("could not get execution data")
+	}
+
+	err = b.eventIndex.Store(height, blockID, executionData.Events)
+	if err != nil {
+		log.Fatal().Err(err).Uint64("height", height).Msg("could not index events")
+	}
+
+	if height%1000 == 0 {
+		log.Info().Uint64("height", height).Msg("indexed events")
+	}
+}
+
+// blobService serves the blobs of the local blobstore only.
+func blobService(blockService blockservice.BlockService) *mocknetwork.BlobService {
+	bs := new(mocknetwork.BlobService)
+	bs.On("GetBlobs", mock.Anything, mock.AnythingOfType("[]cid.Cid")).
+		Return(func(ctx context.Context, ks []cid.Cid) <-chan blobs.Blob {
+			return blockService.GetBlocks(ctx, ks)
+		})
+	return bs
+}
+
+func min(a uint64, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a uint64, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,449 @@
+package reexecute_block
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd"
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/engine/execution/computation"
+	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	fvmState "github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/compressor"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger"
+)
+
+var (
+	flagDatadir           string
+	flagExecutionStateDir string
+	flagChain             string
+	flagBlockID           string
+	flagHeight            uint64
+	flagParallelWorkers   uint
+)
+
+var Cmd = &cobra.Command{
+	Use:   "reexecute-block",
+	Short: "Re-execute a block from the local data of an execution node and diff the result against the stored result",
+	Long: `Re-execute a block from the local data of an execution node and diff the result against the stored result.
+
+The block is executed from the state commitment of its parent, which is loaded from the checkpoints and
+write-ahead logs of the execution state, with the collections stored in the protocol state. The state
+commitments, events and transaction results of each chunk are compared with the stored execution result,
+and the registers read or written by each chunk are compared with the stored end state of the chunk.
+Registers which were only touched by the stored execution are not compared. For every register which
+differs, the values written by each re-executed transaction of the chunk are printed.
+
+The execution node must be stopped while the block is re-executed. The updates of the re-execution are not
+recorded in the write-ahead log, and nothing is written to the protocol state.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagExecutionStateDir, "execution-state-dir", "",
+		"Execution Node state dir (where WAL logs are written")
+	_ = Cmd.MarkFlagRequired("execution-state-dir")
+
+	Cmd.Flags().StringVar(&flagChain, "chain", "", "Chain name")
+	_ = Cmd.MarkFlagRequired("chain")
+
+	Cmd.Flags().StringVar(&flagBlockID, "block-id", "",
+		"ID of the block to re-execute (hex-encoded, 64 characters)")
+
+	Cmd.Flags().Uint64Var(&flagHeight, "height", 0,
+		"height of the block to re-execute")
+
+	Cmd.Flags().UintVar(&flagParallelWorkers, "parallel-execution-workers", 0,
+		"number of workers executing the transactions of a collection in parallel, 0 or 1 to execute them sequentially")
+}
+
+func getChain(chainName string) (chain flow.Chain, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid chain: %s", r)
+		}
+	}()
+	chain = flow.ChainID(chainName).Chain()
+	return
+}
+
+func run(*cobra.Command, []string) {
+	if (len(flagBlockID) > 0) == (flagHeight > 0) {
+		log.Fatal().Msg("exactly one of --block-id and --height must be provided")
+	}
+
+	chain, err := getChain(flagChain)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid chain name")
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+
+	var header *flow.Header
+	if len(flagBlockID) > 0 {
+		blockID, err := flow.HexStringToIdentifier(flagBlockID)
+		if err != nil {
+			log.Fatal().Err(err).Msg("malformed block ID")
+		}
+		header, err = storages.Headers.ByBlockID(blockID)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not get block header")
+		}
+	} else {
+		header, err = storages.Headers.ByHeight(flagHeight)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not get block header")
+		}
+	}
+	blockID := header.ID()
+
+	log.Info().Hex("block_id", blockID[:]).Uint64("height", header.Height).Msg("re-executing block")
+
+	cache := &metrics.NoopCollector{}
+	commits := badger.NewCommits(cache, db)
+
+	parentCommit, err := commits.ByBlockID(header.ParentID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not get state commitment of parent block")
+	}
+
+	executableBlock, err := readExecutableBlock(storages, blockID, parentCommit)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read block")
+	}
+
+	stored, err := readStoredExecution(storages, commits, badger.NewServiceEvents(cache, db), blockID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read stored execution result")
+	}
+
+	diskWal, err := wal.NewDiskWAL(
+		zerolog.Nop(),
+		nil,
+		metrics.NewNoopCollector(),
+		flagExecutionStateDir,
+		complete.DefaultCacheSize,
+		pathfinder.PathByteSize,
+		wal.SegmentSize,
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create disk WAL")
+	}
+	defer func() {
+		<-diskWal.Done()
+	}()
+
+	ldg, err := complete.NewLedger(
+		&readOnlyWAL{diskWal},
+		complete.DefaultCacheSize,
+		&metrics.NoopCollector{},
+		log.Logger,
+		complete.DefaultPathFinderVersion)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create ledger from write-a-head logs and checkpoints")
+	}
+	// the ledger is stopped before the write-ahead log, deferred functions run in reverse order
+	defer func() {
+		<-ldg.Done()
+	}()
+
+	deltas := make(transactionDeltas)
+	manager, err := newComputationManager(chain, storages.Headers, ldg, deltas)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create computation manager")
+	}
+
+	view := delta.NewView(state.LedgerGetRegister(ldg, parentCommit))
+	computed, err := manager.ComputeBlock(context.Background(), executableBlock, view)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not re-execute block")
+	}
+
+	differences, err := printDiff(os.Stdout, stored, computed, ledgerRegisters(ldg), deltas)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not diff execution results")
+	}
+
+	if differences > 0 {
+		log.Warn().Int("differences", differences).Msg("re-executed result differs from stored result")
+		return
+	}
+
+	log.Info().Msg("re-executed result matches stored result")
+}
+
+// readExecutableBlock reads the block with the given ID and its collections.
+func readExecutableBlock(
+	storages *storage.All,
+	blockID flow.Identifier,
+	startState flow.StateCommitment,
+) (*entity.ExecutableBlock, error) {
+	block, err := storages.Blocks.ByID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get block: %w", err)
+	}
+
+	collections := make(map[flow.Identifier]*entity.CompleteCollection, len(block.Payload.Guarantees))
+	for _, guarantee := range block.Payload.Guarantees {
+		collection, err := storages.Collections.ByID(guarantee.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get collection %v: %w", guarantee.CollectionID, err)
+		}
+		collections[guarantee.CollectionID] = &entity.CompleteCollection{
+			Guarantee:    guarantee,
+			Transactions: collection.Transactions,
+		}
+	}
+
+	return &entity.ExecutableBlock{
+		Block:               block,
+		CompleteCollections: collections,
+		StartState:          &startState,
+	}, nil
+}
+
+// readStoredExecution reads the result of the block with the given ID as stored by the execution node.
+func readStoredExecution(
+	storages *storage.All,
+	commits *badger.Commits,
+	serviceEvents *badger.ServiceEvents,
+	blockID flow.Identifier,
+) (*storedExecution, error) {
+	result, err := storages.Results.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution result: %w", err)
+	}
+
+	commit, err := commits.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get state commitment: %w", err)
+	}
+
+	events, err := storages.Events.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get events: %w", err)
+	}
+
+	services, err := serviceEvents.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get service events: %w", err)
+	}
+
+	transactionResults, err := storages.TransactionResults.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get transaction results: %w", err)
+	}
+
+	return &storedExecution{
+		result:             result,
+		commit:             commit,
+		events:             events,
+		serviceEvents:      services,
+		transactionResults: transactionResults,
+	}, nil
+}
+
+// newComputationManager creates a computation manager which executes blocks like the execution nodes of
+// the given chain, and commits the execution state to the given ledger. The register updates of each
+// executed transaction are recorded in the given deltas.
+func newComputationManager(
+	chain flow.Chain,
+	headers storage.Headers,
+	ldg ledger.Ledger,
+	deltas transactionDeltas,
+) (*computation.Manager, error) {
+	vm := &recordingVM{
+		VirtualMachine: fvm.NewVirtualMachine(fvm.NewInterpreterRuntime()),
+		deltas:         deltas,
+	}
+	vmCtx := fvm.NewContext(log.Logger, cmd.FvmOptions(chain.ChainID(), headers)...)
+
+	// the execution data is only computed to execute the block like the execution node, it is not stored
+	eds := state_synchronization.NewExecutionDataService(
+		&cbor.Codec{},
+		compressor.NewLz4Compressor(),
+		&discardingBlobService{},
+		metrics.NewNoopCollector(),
+		log.Logger,
+	)
+
+	tracer := trace.NewNoopTracer()
+
+	return computation.New(
+		log.Logger,
+		metrics.NewNoopCollector(),
+		tracer,
+		nil,
+		nil,
+		vm,
+		vmCtx,
+		computation.ComputationConfig{
+			ProgramsCacheSize:        computation.DefaultProgramsCacheSize,
+			ScriptLogThreshold:       computation.DefaultScriptLogThreshold,
+			ScriptExecutionTimeLimit: computation.DefaultScriptExecutionTimeLimit,
+		},
+		committer.NewLedgerViewCommitter(ldg, tracer),
+		nil,
+		eds,
+		state_synchronization.NewExecutionDataCIDCache(1),
+		computer.WithParallelExecution(int(flagParallelWorkers)),
+	)
+}
+
+// recordingVM records the register updates of every transaction it executes. Transactions executed
+// more than once, such as transactions re-executed after a conflict in parallel execution, are recorded
+// with the updates of their last execution, which are the ones merged into the block.
+type recordingVM struct {
+	computation.VirtualMachine
+	mu     sync.Mutex
+	deltas transactionDeltas
+}
+
+func (vm *recordingVM) Run(ctx fvm.Context, proc fvm.Procedure, v fvmState.View, programs *programs.Programs) error {
+	err := vm.VirtualMachine.Run(ctx, proc, v, programs)
+	if err != nil {
+		return err
+	}
+
+	tx, ok := proc.(*fvm.TransactionProcedure)
+	if !ok {
+		return nil
+	}
+	view, ok := v.(*delta.View)
+	if !ok {
+		return nil
+	}
+
+	updates := delta.NewDelta()
+	updates.MergeWith(view.Delta())
+
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vm.deltas[tx.TxIndex] = updates
+
+	return nil
+}
+
+// discardingBlobService accepts and discards all blobs, the execution data of the re-executed block
+// is only computed and never stored.
+type discardingBlobService struct{}
+
+var _ network.BlobService = (*discardingBlobService)(nil)
+
+func (bs *discardingBlobService) Start(irrecoverable.SignalerContext) {}
+
+func (bs *discardingBlobService) Ready() <-chan struct{} {
+	ready := make(chan struct{})
+	close(ready)
+	return ready
+}
+
+func (bs *discardingBlobService) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+func (bs *discardingBlobService) GetBlob(context.Context, cid.Cid) (blobs.Blob, error) {
+	return nil, blockservice.ErrNotFound
+}
+
+func (bs *discardingBlobService) GetBlobs(context.Context, []cid.Cid) <-chan blobs.Blob {
+	ch := make(chan blobs.Blob)
+	close(ch)
+	return ch
+}
+
+func (bs *discardingBlobService) AddBlob(context.Context, blobs.Blob) error {
+	return nil
+}
+
+func (bs *discardingBlobService) AddBlobs(context.Context, []blobs.Blob) error {
+	return nil
+}
+
+func (bs *discardingBlobService) DeleteBlob(context.Context, cid.Cid) error {
+	return nil
+}
+
+func (bs *discardingBlobService) GetSession(context.Context) network.BlobGetter {
+	return bs
+}
+
+func (bs *discardingBlobService) TriggerReprovide(context.Context) error {
+	return nil
+}
+
+// readOnlyWAL replays the checkpoints and write-ahead logs of the execution state,
+// but does not record the updates of the re-execution.
+type readOnlyWAL struct {
+	*wal.DiskWAL
+}
+
+func (w *readOnlyWAL) RecordUpdate(*ledger.TrieUpdate) error {
+	return nil
+}
+
+func (w *readOnlyWAL) RecordDelete(ledger.RootHash) error {
+	return nil
+}
+
+// ledgerRegisters reads register values from the given ledger.
+func ledgerRegisters(ldg ledger.Ledger) registerReader {
+	return func(commit flow.StateCommitment, ids []flow.RegisterID) ([]flow.RegisterValue, error) {
+		keys := make([]ledger.Key, 0, len(ids))
+		for _, id := range ids {
+			keys = append(keys, state.RegisterIDToKey(id))
+		}
+
+		query, err := ledger.NewQuery(ledger.State(commit), keys)
+		if err != nil {
+			return nil, fmt.Errorf("could not create ledger query: %w", err)
+		}
+
+		values, err := ldg.Get(query)
+		if err != nil {
+			return nil, err
+		}
+
+		registers := make([]flow.RegisterValue, 0, len(values))
+		for _, value := range values {
+			registers = append(registers, flow.RegisterValue(value))
+		}
+		return registers, nil
+	}
+}
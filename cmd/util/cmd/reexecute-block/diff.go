@@ -0,0 +1,290 @@
+package reexecute_block
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// storedExecution is the result of a block as stored by the execution node.
+type storedExecution struct {
+	result             *flow.ExecutionResult
+	commit             flow.StateCommitment
+	events             []flow.Event
+	serviceEvents      []flow.Event
+	transactionResults []flow.TransactionResult
+}
+
+// transactionDeltas are the register updates of each re-executed transaction by transaction index.
+type transactionDeltas map[uint32]delta.Delta
+
+// registerReader reads the values of the given registers at the given state commitment.
+type registerReader func(commit flow.StateCommitment, ids []flow.RegisterID) ([]flow.RegisterValue, error)
+
+// differ prints the differences between the stored and the re-executed result of a block.
+type differ struct {
+	w           io.Writer
+	registers   registerReader
+	deltas      transactionDeltas
+	differences int
+	err         error
+}
+
+// printDiff prints the differences between the stored and the re-executed result of a block, and returns
+// the number of differences. The registers are compared chunk by chunk, since the execution result only
+// commits to the end state of each chunk, and the registers which differ are then traced to the re-executed
+// transactions which wrote them. Transaction results and events are compared transaction by transaction.
+func printDiff(
+	w io.Writer,
+	stored *storedExecution,
+	computed *execution.ComputationResult,
+	registers registerReader,
+	deltas transactionDeltas,
+) (int, error) {
+	d := &differ{w: w, registers: registers, deltas: deltas}
+	block := computed.ExecutableBlock
+
+	d.printf("block %v at height %d", block.ID(), block.Height())
+
+	commit := computed.StateCommitments[len(computed.StateCommitments)-1]
+	if commit != stored.commit {
+		d.difference("state commitment: stored %x, re-executed %x", stored.commit[:], commit[:])
+	}
+
+	if len(stored.result.Chunks) != len(computed.StateCommitments) {
+		d.difference("chunks: stored %d, re-executed %d", len(stored.result.Chunks), len(computed.StateCommitments))
+	}
+
+	storedResults := make(map[flow.Identifier]flow.TransactionResult, len(stored.transactionResults))
+	for _, result := range stored.transactionResults {
+		storedResults[result.TransactionID] = result
+	}
+	storedEvents := eventsByTransaction(stored.events)
+
+	var computedEvents []flow.Event
+	for _, events := range computed.Events {
+		computedEvents = append(computedEvents, events...)
+	}
+	reexecutedEvents := eventsByTransaction(computedEvents)
+
+	collections := block.Collections()
+	startState := *block.StartState
+	txIndex := 0
+	for i, endState := range computed.StateCommitments {
+		// the system chunk executes the remaining transactions
+		count := len(computed.TransactionResults) - txIndex
+		if i < len(collections) {
+			count = len(collections[i].Transactions)
+		}
+
+		d.printf("chunk %d: %d transactions, start state %x, end state %x", i, count, startState[:], endState[:])
+
+		var chunk *flow.Chunk
+		if i < len(stored.result.Chunks) {
+			chunk = stored.result.Chunks[i]
+			d.diffChunk(chunk, count, endState, computed.EventsHashes[i])
+		}
+
+		results := computed.TransactionResults[txIndex : txIndex+count]
+		for j, result := range results {
+			d.diffTransaction(uint32(txIndex+j), result, storedResults, storedEvents, reexecutedEvents)
+		}
+
+		if chunk != nil {
+			d.diffRegisters(startState, chunk.EndState, computed.StateSnapshots[i], uint32(txIndex), results)
+		}
+
+		txIndex += count
+
+		startState = endState
+	}
+
+	d.diffEvents("service", stored.serviceEvents, computed.ServiceEvents)
+
+	return d.differences, d.err
+}
+
+// diffChunk prints the differences between the stored chunk and the re-executed chunk.
+func (d *differ) diffChunk(chunk *flow.Chunk, count int, endState flow.StateCommitment, eventsHash flow.Identifier) {
+	if chunk.NumberOfTransactions != uint64(count) {
+		d.difference("  transactions: stored %d, re-executed %d", chunk.NumberOfTransactions, count)
+	}
+	if chunk.EndState != endState {
+		d.difference("  end state: stored %x, re-executed %x", chunk.EndState[:], endState[:])
+	}
+	if chunk.EventCollection != eventsHash {
+		d.difference("  events hash: stored %v, re-executed %v", chunk.EventCollection, eventsHash)
+	}
+}
+
+// diffTransaction prints the differences between the stored and the re-executed result and events of
+// the transaction with the given index.
+func (d *differ) diffTransaction(
+	txIndex uint32,
+	result flow.TransactionResult,
+	storedResults map[flow.Identifier]flow.TransactionResult,
+	storedEvents map[uint32][]flow.Event,
+	computedEvents map[uint32][]flow.Event,
+) {
+	prefix := fmt.Sprintf("  transaction %d (%v)", txIndex, result.TransactionID)
+
+	stored, ok := storedResults[result.TransactionID]
+	if !ok {
+		d.difference("%s: result missing in stored results", prefix)
+	} else {
+		if stored.ErrorMessage != result.ErrorMessage {
+			d.difference("%s: error message: stored %q, re-executed %q", prefix, stored.ErrorMessage, result.ErrorMessage)
+		}
+		if stored.ComputationUsed != result.ComputationUsed {
+			d.difference("%s: computation used: stored %d, re-executed %d", prefix, stored.ComputationUsed, result.ComputationUsed)
+		}
+	}
+
+	d.diffEvents(prefix, storedEvents[txIndex], computedEvents[txIndex])
+}
+
+// diffEvents prints the differences between the given stored and re-executed events.
+func (d *differ) diffEvents(prefix string, stored []flow.Event, computed []flow.Event) {
+	for i := 0; i < len(stored) || i < len(computed); i++ {
+		switch {
+		case i >= len(stored):
+			d.difference("%s: event %d: missing in stored result, re-executed %s %s",
+				prefix, i, computed[i].Type, computed[i].Payload)
+		case i >= len(computed):
+			d.difference("%s: event %d: stored %s %s, missing in re-executed result",
+				prefix, i, stored[i].Type, stored[i].Payload)
+		case stored[i].Type != computed[i].Type || !bytes.Equal(stored[i].Payload, computed[i].Payload):
+			d.difference("%s: event %d: stored %s %s, re-executed %s %s",
+				prefix, i, stored[i].Type, stored[i].Payload, computed[i].Type, computed[i].Payload)
+		}
+	}
+}
+
+// diffRegisters prints the registers read or written by the re-executed chunk whose values differ
+// between the stored end state of the chunk and the re-executed end state. For each register which
+// differs, the values written by the transactions of the chunk are printed transaction by transaction.
+func (d *differ) diffRegisters(
+	startState flow.StateCommitment,
+	storedEndState flow.StateCommitment,
+	snapshot *delta.SpockSnapshot,
+	firstTxIndex uint32,
+	results []flow.TransactionResult,
+) {
+	ids := touchedRegisters(snapshot)
+	if len(ids) == 0 {
+		return
+	}
+
+	storedValues, err := d.registers(storedEndState, ids)
+	if err != nil {
+		d.printf("  registers not compared, could not read stored end state %x: %v", storedEndState[:], err)
+		return
+	}
+
+	startValues, err := d.registers(startState, ids)
+	if err != nil {
+		d.fail(fmt.Errorf("could not read start state %x: %w", startState[:], err))
+		return
+	}
+
+	for i, id := range ids {
+		value, written := snapshot.Delta.Get(id.Owner, id.Controller, id.Key)
+		if !written {
+			value = startValues[i]
+		}
+
+		if bytes.Equal(storedValues[i], value) {
+			continue
+		}
+
+		d.difference("  register %x/%x/%q: start %x, stored %x, re-executed %x",
+			id.Owner, id.Controller, id.Key, startValues[i], storedValues[i], value)
+		d.diffRegisterWrites(id, startValues[i], firstTxIndex, results)
+	}
+}
+
+// diffRegisterWrites prints the values of the given register written by each transaction of a chunk.
+func (d *differ) diffRegisterWrites(
+	id flow.RegisterID,
+	startValue flow.RegisterValue,
+	firstTxIndex uint32,
+	results []flow.TransactionResult,
+) {
+	previous := startValue
+	for i, result := range results {
+		txIndex := firstTxIndex + uint32(i)
+		value, written := d.deltas[txIndex].Get(id.Owner, id.Controller, id.Key)
+		if !written {
+			continue
+		}
+
+		d.printf("    transaction %d (%v): wrote %x, previous value %x", txIndex, result.TransactionID, value, previous)
+		previous = value
+	}
+}
+
+func (d *differ) printf(format string, args ...interface{}) {
+	if d.err != nil {
+		return
+	}
+	_, err := fmt.Fprintf(d.w, format+"\n", args...)
+	if err != nil {
+		d.fail(fmt.Errorf("could not print difference: %w", err))
+	}
+}
+
+func (d *differ) difference(format string, args ...interface{}) {
+	d.differences++
+	d.printf(format, args...)
+}
+
+func (d *differ) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// eventsByTransaction groups the given events by transaction index, ordered by event index.
+func eventsByTransaction(events []flow.Event) map[uint32][]flow.Event {
+	grouped := make(map[uint32][]flow.Event)
+	for _, event := range events {
+		grouped[event.TransactionIndex] = append(grouped[event.TransactionIndex], event)
+	}
+	for _, events := range grouped {
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].EventIndex < events[j].EventIndex
+		})
+	}
+	return grouped
+}
+
+// touchedRegisters returns the registers read or written in the given snapshot, in a deterministic order.
+func touchedRegisters(snapshot *delta.SpockSnapshot) []flow.RegisterID {
+	touched := make(map[flow.RegisterID]struct{})
+	for _, id := range snapshot.Reads {
+		touched[id] = struct{}{}
+	}
+	for _, id := range snapshot.Delta.RegisterIDs() {
+		touched[id] = struct{}{}
+	}
+
+	ids := make([]flow.RegisterID, 0, len(touched))
+	for id := range touched {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].Owner != ids[j].Owner {
+			return ids[i].Owner < ids[j].Owner
+		}
+		if ids[i].Controller != ids[j].Controller {
+			return ids[i].Controller < ids[j].Controller
+		}
+		return ids[i].Key < ids[j].Key
+	})
+	return ids
+}
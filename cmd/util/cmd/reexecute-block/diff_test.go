@@ -0,0 +1,147 @@
+package reexecute_block
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPrintDiff(t *testing.T) {
+
+	owner := string(flow.HexToAddress("01").Bytes())
+	counter := flow.NewRegisterID(owner, "", "counter")
+	balance := flow.NewRegisterID(owner, "", "balance")
+
+	startState := unittest.StateCommitmentFixture()
+	chunkState := unittest.StateCommitmentFixture()
+	endState := unittest.StateCommitmentFixture()
+
+	// fixture builds a re-executed result of a block with one collection and the system chunk, the collection
+	// transaction reads the balance and increments the counter, and a stored execution identical to it
+	fixture := func() (*storedExecution, *execution.ComputationResult, map[flow.StateCommitment]map[flow.RegisterID]flow.RegisterValue, transactionDeltas) {
+		block := unittest.ExecutableBlockFixture([][]flow.Identifier{unittest.IdentifierListFixture(1)})
+		block.StartState = &startState
+
+		txID := block.Collections()[0].Transactions[0].ID()
+		systemTxID := unittest.IdentifierFixture()
+
+		states := map[flow.StateCommitment]map[flow.RegisterID]flow.RegisterValue{
+			startState: {counter: {1}, balance: {10}},
+			chunkState: {counter: {2}, balance: {10}},
+			endState:   {counter: {2}, balance: {10}},
+		}
+
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return states[startState][flow.NewRegisterID(owner, controller, key)], nil
+		})
+		_, err := view.Get(owner, "", "balance")
+		require.NoError(t, err)
+		err = view.Set(owner, "", "counter", flow.RegisterValue{2})
+		require.NoError(t, err)
+
+		events := []flow.Event{
+			unittest.EventFixture("A.01.Counter.Incremented", 0, 0, txID, 0),
+			unittest.EventFixture("A.01.Counter.Incremented", 0, 1, txID, 0),
+		}
+		eventsHashes := []flow.Identifier{unittest.IdentifierFixture(), unittest.IdentifierFixture()}
+
+		computed := &execution.ComputationResult{
+			ExecutableBlock:  block,
+			StateCommitments: []flow.StateCommitment{chunkState, endState},
+			StateSnapshots:   []*delta.SpockSnapshot{view.Interactions(), delta.NewView(nil).Interactions()},
+			Events:           []flow.EventsList{events, nil},
+			EventsHashes:     eventsHashes,
+			TransactionResults: []flow.TransactionResult{
+				{TransactionID: txID, ComputationUsed: 10},
+				{TransactionID: systemTxID, ComputationUsed: 5},
+			},
+		}
+
+		result := unittest.ExecutionResultFixture()
+		result.Chunks = flow.ChunkList{
+			{ChunkBody: flow.ChunkBody{NumberOfTransactions: 1, EventCollection: eventsHashes[0]}, EndState: chunkState},
+			{ChunkBody: flow.ChunkBody{NumberOfTransactions: 1, EventCollection: eventsHashes[1]}, EndState: endState},
+		}
+
+		stored := &storedExecution{
+			result: result,
+			commit: endState,
+			// the events are stored ordered by transaction ID
+			events: []flow.Event{events[1], events[0]},
+			transactionResults: []flow.TransactionResult{
+				{TransactionID: systemTxID, ComputationUsed: 5},
+				{TransactionID: txID, ComputationUsed: 10},
+			},
+		}
+
+		txDelta := delta.NewDelta()
+		txDelta.Set(owner, "", "counter", flow.RegisterValue{2})
+		deltas := transactionDeltas{0: txDelta, 1: delta.NewDelta()}
+
+		return stored, computed, states, deltas
+	}
+
+	reader := func(states map[flow.StateCommitment]map[flow.RegisterID]flow.RegisterValue) registerReader {
+		return func(commit flow.StateCommitment, ids []flow.RegisterID) ([]flow.RegisterValue, error) {
+			values, ok := states[commit]
+			if !ok {
+				return nil, fmt.Errorf("state %x not found", commit[:])
+			}
+			result := make([]flow.RegisterValue, 0, len(ids))
+			for _, id := range ids {
+				result = append(result, values[id])
+			}
+			return result, nil
+		}
+	}
+
+	t.Run("identical results", func(t *testing.T) {
+		stored, computed, states, deltas := fixture()
+
+		var out bytes.Buffer
+		differences, err := printDiff(&out, stored, computed, reader(states), deltas)
+		require.NoError(t, err)
+		require.Equal(t, 0, differences, out.String())
+	})
+
+	t.Run("different results", func(t *testing.T) {
+		stored, computed, states, deltas := fixture()
+
+		storedChunkState := unittest.StateCommitmentFixture()
+		states[storedChunkState] = map[flow.RegisterID]flow.RegisterValue{counter: {3}, balance: {10}}
+		stored.result.Chunks[0].EndState = storedChunkState
+		stored.transactionResults[1].ErrorMessage = "failed"
+		stored.events[0].Payload = []byte("stored")
+
+		var out bytes.Buffer
+		differences, err := printDiff(&out, stored, computed, reader(states), deltas)
+		require.NoError(t, err)
+		require.Equal(t, 4, differences, out.String())
+
+		txID := computed.TransactionResults[0].TransactionID
+		require.Contains(t, out.String(), fmt.Sprintf("  end state: stored %x, re-executed %x\n", storedChunkState[:], chunkState[:]))
+		require.Contains(t, out.String(), fmt.Sprintf("  transaction 0 (%v): error message: stored \"failed\", re-executed \"\"\n", txID))
+		require.Contains(t, out.String(), fmt.Sprintf("  transaction 0 (%v): event 1: stored A.01.Counter.Incremented stored, re-executed A.01.Counter.Incremented \n", txID))
+		require.Contains(t, out.String(), fmt.Sprintf("  register %x//\"counter\": start 01, stored 03, re-executed 02\n", owner)+
+			fmt.Sprintf("    transaction 0 (%v): wrote 02, previous value 01\n", txID))
+		require.NotContains(t, out.String(), "balance")
+	})
+
+	t.Run("stored end state not available", func(t *testing.T) {
+		stored, computed, states, deltas := fixture()
+		delete(states, chunkState)
+
+		var out bytes.Buffer
+		differences, err := printDiff(&out, stored, computed, reader(states), deltas)
+		require.NoError(t, err)
+		require.Equal(t, 0, differences, out.String())
+		require.Contains(t, out.String(), "registers not compared")
+	})
+}
@@ -0,0 +1,233 @@
+package register_history
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/history"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+)
+
+var (
+	flagDatadir           string
+	flagExecutionStateDir string
+	flagOutputDir         string
+	flagStartHeight       uint64
+	flagEndHeight         uint64
+)
+
+var Cmd = &cobra.Command{
+	Use:   "register-history",
+	Short: "Build the register history index of an execution node from the checkpoints and the write-ahead log",
+	Long: `Build the register history index of an execution node from the checkpoints and the write-ahead log.
+
+If the index is empty, it is bootstrapped at the start height with the trie of the start height, which
+must be in the latest checkpoint or result from an update in the write-ahead log. The ledger updates
+replayed from the write-ahead log are recorded, and the heights after the latest indexed height are
+indexed up to the end height. The execution node must be stopped while the index is built.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagExecutionStateDir, "execution-state-dir", "",
+		"directory of the checkpoints and the write-ahead log")
+	_ = Cmd.MarkFlagRequired("execution-state-dir")
+
+	Cmd.Flags().StringVar(&flagOutputDir, "output-dir", "",
+		"directory of the register history index")
+	_ = Cmd.MarkFlagRequired("output-dir")
+
+	Cmd.Flags().Uint64Var(&flagStartHeight, "start-height", 0,
+		"height at which an empty index is bootstrapped")
+
+	Cmd.Flags().Uint64Var(&flagEndHeight, "end-height", 0,
+		"highest height to index, defaults to the latest sealed height")
+}
+
+func run(*cobra.Command, []string) {
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+	state, err := common.InitProtocolState(db, storages)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not init protocol state")
+	}
+
+	endHeight := flagEndHeight
+	if endHeight == 0 {
+		sealed, err := state.Sealed().Head()
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not get sealed header")
+		}
+		endHeight = sealed.Height
+	}
+
+	index, err := history.New(flagOutputDir, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open register history index")
+	}
+	defer index.Close()
+
+	commits := &commitsByHeight{headers: storages.Headers, commits: storages.Commits}
+
+	// an empty index is bootstrapped with the trie of the start height, once it is found in the replayed
+	// checkpoint or write-ahead log
+	var bootstrapCommit *ledger.State
+	_, latestHeight, err := index.IndexedRange()
+	if errors.Is(err, history.ErrNotIndexed) {
+		if flagStartHeight > endHeight {
+			log.Fatal().Uint64("start_height", flagStartHeight).Uint64("end_height", endHeight).
+				Msg("start height must be less than or equal to end height")
+		}
+
+		commit, err := commits.commit(flagStartHeight)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not get state commitment of start height")
+		}
+		bootstrapCommit = &commit
+		latestHeight = flagStartHeight
+	} else if err != nil {
+		log.Fatal().Err(err).Msg("could not get indexed range")
+	} else {
+		log.Info().Uint64("latest_height", latestHeight).Msg("found indexed range")
+	}
+
+	bootstrap := func(t *trie.MTrie) error {
+		if bootstrapCommit == nil || ledger.State(t.RootHash()) != *bootstrapCommit {
+			return nil
+		}
+		bootstrapCommit = nil
+		return index.Bootstrap(flagStartHeight, ledger.State(t.RootHash()), t)
+	}
+
+	diskWAL, err := wal.NewDiskWAL(
+		zerolog.Nop(),
+		nil,
+		metrics.NewNoopCollector(),
+		flagExecutionStateDir,
+		complete.DefaultCacheSize,
+		pathfinder.PathByteSize,
+		wal.SegmentSize,
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create disk WAL")
+	}
+	defer func() {
+		<-diskWAL.Done()
+	}()
+
+	forest, err := mtrie.NewForest(complete.DefaultCacheSize, &metrics.NoopCollector{}, nil)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create forest")
+	}
+
+	log.Info().Msg("replaying checkpoint and write-ahead log")
+
+	err = diskWAL.Replay(
+		func(tries []*trie.MTrie) error {
+			err := forest.AddTries(tries)
+			if err != nil {
+				return err
+			}
+			for _, t := range tries {
+				err = bootstrap(t)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(update *ledger.TrieUpdate) error {
+			rootHash, err := forest.Update(update)
+			if err != nil {
+				return err
+			}
+
+			if bootstrapCommit != nil {
+				// the updates leading to the start height are not needed
+				t, err := forest.GetTrie(rootHash)
+				if err != nil {
+					return err
+				}
+				return bootstrap(t)
+			}
+
+			return index.StoreUpdate(ledger.State(rootHash), update)
+		},
+		func(rootHash ledger.RootHash) error {
+			forest.RemoveTrie(rootHash)
+			return nil
+		},
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not replay checkpoint and write-ahead log")
+	}
+
+	if bootstrapCommit != nil {
+		log.Fatal().Uint64("start_height", flagStartHeight).Hex("commit", bootstrapCommit[:]).
+			Msg("trie of start height was not found in the checkpoint or the write-ahead log")
+	}
+
+	for height := latestHeight + 1; height <= endHeight; height++ {
+		commit, err := commits.commit(height)
+		if err != nil {
+			log.Fatal().Err(err).Uint64("height", height).Msg("could not get state commitment")
+		}
+
+		err = index.IndexHeight(height, commit)
+		if errors.Is(err, history.ErrMissingUpdate) {
+			log.Fatal().Err(err).Uint64("height", height).
+				Msg("updates of the height are not in the write-ahead log, the index must be bootstrapped at a later height")
+		}
+		if err != nil {
+			log.Fatal().Err(err).Uint64("height", height).Msg("could not index height")
+		}
+
+		if height%1000 == 0 {
+			log.Info().Uint64("height", height).Msg("indexed register history")
+		}
+	}
+
+	first, latest, err := index.IndexedRange()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not get indexed range")
+	}
+
+	log.Info().Uint64("first_height", first).Uint64("latest_height", latest).Msg("indexed register history")
+}
+
+// commitsByHeight provides the state commitments of finalized blocks.
+type commitsByHeight struct {
+	headers storage.Headers
+	commits storage.Commits
+}
+
+func (c *commitsByHeight) commit(height uint64) (ledger.State, error) {
+	header, err := c.headers.ByHeight(height)
+	if err != nil {
+		return ledger.State{}, err
+	}
+
+	commit, err := c.commits.ByBlockID(header.ID())
+	if err != nil {
+		return ledger.State{}, err
+	}
+
+	return ledger.State(commit), nil
+}
@@ -0,0 +1,129 @@
+package replay_block_data
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/engine/execution/computation/computer/uploader"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+var (
+	flagArchiveDir        string
+	flagDatadir           string
+	flagExecutionStateDir string
+	flagStartHeight       uint64
+	flagEndHeight         uint64
+)
+
+var Cmd = &cobra.Command{
+	Use:   "replay-block-data",
+	Short: "Replay the blocks of a local block data archive into a fresh store",
+	Long: `Replay the blocks of a local block data archive into a fresh store.
+
+The blocks, collections, transaction results, events and final state commitments of the archived
+blocks are stored in the protocol database in --datadir, in the order of their heights. If
+--execution-state-dir is given, the trie updates of the archived blocks are applied to the execution
+state in this directory, which must contain the state the first replayed block was executed from,
+and the resulting state commitments are verified against the archived final state commitments.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagArchiveDir, "archive-dir", "",
+		"directory of the block data archive")
+	_ = Cmd.MarkFlagRequired("archive-dir")
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagExecutionStateDir, "execution-state-dir", "",
+		"Execution Node state dir (where WAL logs are written), the execution state is not replayed if empty")
+
+	Cmd.Flags().Uint64Var(&flagStartHeight, "start-height", 0,
+		"lowest height to replay")
+
+	Cmd.Flags().Uint64Var(&flagEndHeight, "end-height", 0,
+		"highest height to replay, defaults to the highest archived height")
+}
+
+func run(*cobra.Command, []string) {
+	reader := uploader.NewArchiveReader(flagArchiveDir)
+
+	manifests, err := reader.Manifests()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not list archived blocks")
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	r := &replayer{
+		db:       db,
+		storages: common.InitStorages(db),
+	}
+
+	if len(flagExecutionStateDir) > 0 {
+		diskWal, err := wal.NewDiskWAL(
+			zerolog.Nop(),
+			nil,
+			metrics.NewNoopCollector(),
+			flagExecutionStateDir,
+			complete.DefaultCacheSize,
+			pathfinder.PathByteSize,
+			wal.SegmentSize,
+		)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot create disk WAL")
+		}
+		defer func() {
+			<-diskWal.Done()
+		}()
+
+		ldg, err := complete.NewLedger(
+			diskWal,
+			complete.DefaultCacheSize,
+			&metrics.NoopCollector{},
+			log.Logger,
+			complete.DefaultPathFinderVersion)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot create ledger from write-a-head logs and checkpoints")
+		}
+		defer func() {
+			<-ldg.Done()
+		}()
+
+		r.ledger = ldg
+	}
+
+	replayed := 0
+	for _, manifest := range manifests {
+		if manifest.Height < flagStartHeight || flagEndHeight > 0 && manifest.Height > flagEndHeight {
+			continue
+		}
+
+		blockData, err := reader.Read(manifest)
+		if err != nil {
+			log.Fatal().Err(err).Uint64("height", manifest.Height).Msg("could not read archived block")
+		}
+
+		err = r.replay(blockData)
+		if err != nil {
+			log.Fatal().Err(err).
+				Hex("block_id", manifest.BlockID[:]).
+				Uint64("height", manifest.Height).
+				Msg("could not replay archived block")
+		}
+
+		replayed++
+		log.Debug().Hex("block_id", manifest.BlockID[:]).Uint64("height", manifest.Height).Msg("replayed block")
+	}
+
+	log.Info().Int("blocks", replayed).Msg("replayed archived blocks")
+}
@@ -0,0 +1,119 @@
+package replay_block_data
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/engine/execution/computation/computer/uploader"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+// replayer stores archived blocks, and optionally applies their trie updates to an execution state.
+type replayer struct {
+	db       *badger.DB
+	storages *storage.All
+	ledger   ledger.Ledger
+}
+
+// replay stores the given archived block. Blocks which are already stored are replayed again.
+func (r *replayer) replay(blockData *uploader.BlockData) error {
+	blockID := blockData.Block.ID()
+
+	err := r.storages.Blocks.Store(blockData.Block)
+	if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+		return fmt.Errorf("could not store block: %w", err)
+	}
+
+	for _, collection := range blockData.Collections {
+		err = r.storages.Collections.Store(&flow.Collection{Transactions: collection.Transactions})
+		if err != nil {
+			return fmt.Errorf("could not store collection %v: %w", collection.Guarantee.CollectionID, err)
+		}
+	}
+
+	if r.ledger != nil {
+		err = r.applyTrieUpdates(blockData)
+		if err != nil {
+			return err
+		}
+	}
+
+	results := make([]flow.TransactionResult, 0, len(blockData.TxResults))
+	for _, result := range blockData.TxResults {
+		results = append(results, *result)
+	}
+
+	events := make(flow.EventsList, 0, len(blockData.Events))
+	for _, event := range blockData.Events {
+		events = append(events, *event)
+	}
+
+	batch := bstorage.NewBatch(r.db)
+
+	err = r.storages.TransactionResults.BatchStore(blockID, results, batch)
+	if err != nil {
+		return fmt.Errorf("could not store transaction results: %w", err)
+	}
+
+	err = r.storages.Events.BatchStore(blockID, []flow.EventsList{events}, batch)
+	if err != nil {
+		return fmt.Errorf("could not store events: %w", err)
+	}
+
+	err = r.storages.Commits.BatchStore(blockID, blockData.FinalStateCommitment, batch)
+	if err != nil {
+		return fmt.Errorf("could not store state commitment: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush batch: %w", err)
+	}
+
+	return nil
+}
+
+// applyTrieUpdates applies the trie updates of the given block to the execution state, and verifies
+// that they are applied in sequence and result in the final state commitment of the block.
+func (r *replayer) applyTrieUpdates(blockData *uploader.BlockData) error {
+	if len(blockData.TrieUpdates) == 0 {
+		return nil
+	}
+
+	state := ledger.State(blockData.TrieUpdates[0].RootHash)
+	for i, trieUpdate := range blockData.TrieUpdates {
+		if ledger.State(trieUpdate.RootHash) != state {
+			return fmt.Errorf("trie update %d does not start from the state of trie update %d: expected %v, got %v",
+				i, i-1, state, trieUpdate.RootHash)
+		}
+
+		keys := make([]ledger.Key, 0, len(trieUpdate.Payloads))
+		values := make([]ledger.Value, 0, len(trieUpdate.Payloads))
+		for _, payload := range trieUpdate.Payloads {
+			keys = append(keys, payload.Key)
+			values = append(values, payload.Value)
+		}
+
+		update, err := ledger.NewUpdate(state, keys, values)
+		if err != nil {
+			return fmt.Errorf("could not create update from trie update %d: %w", i, err)
+		}
+
+		state, _, err = r.ledger.Set(update)
+		if err != nil {
+			return fmt.Errorf("could not apply trie update %d: %w", i, err)
+		}
+	}
+
+	if flow.StateCommitment(state) != blockData.FinalStateCommitment {
+		return fmt.Errorf("replayed state commitment %v does not match archived final state commitment %x",
+			state, blockData.FinalStateCommitment[:])
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package replay_block_data
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/computation/computer/uploader"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/module/metrics"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReplay(t *testing.T) {
+
+	newLedger := func(t *testing.T) *complete.Ledger {
+		ldg, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+		return ldg
+	}
+
+	// blockData returns the block data of a block with one collection, whose two chunks update the given ledger
+	blockData := func(t *testing.T, ldg *complete.Ledger) *uploader.BlockData {
+		collection := unittest.CompleteCollectionFixture()
+		block := unittest.BlockWithGuaranteesFixture([]*flow.CollectionGuarantee{collection.Guarantee})
+		txID := collection.Transactions[0].ID()
+
+		state := ldg.InitialState()
+		var trieUpdates []*ledger.TrieUpdate
+		for i := byte(0); i < 2; i++ {
+			update, err := ledger.NewUpdate(
+				state,
+				[]ledger.Key{ledger.NewKey([]ledger.KeyPart{ledger.NewKeyPart(0, []byte{i})})},
+				[]ledger.Value{{i, i}},
+			)
+			require.NoError(t, err)
+
+			trieUpdate, err := pathfinder.UpdateToTrieUpdate(update, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+			trieUpdates = append(trieUpdates, trieUpdate)
+
+			state, _, err = ldg.Set(update)
+			require.NoError(t, err)
+		}
+
+		event := unittest.EventFixture("A.01.Test.Event", 0, 0, txID, 0)
+
+		return &uploader.BlockData{
+			Block:                block,
+			Collections:          []*entity.CompleteCollection{collection},
+			TxResults:            []*flow.TransactionResult{{TransactionID: txID, ComputationUsed: 10}},
+			Events:               []*flow.Event{&event},
+			TrieUpdates:          trieUpdates,
+			FinalStateCommitment: flow.StateCommitment(state),
+		}
+	}
+
+	t.Run("archived blocks are stored and their execution state is replayed", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			data := blockData(t, newLedger(t))
+			blockID := data.Block.ID()
+
+			ldg := newLedger(t)
+			r := &replayer{
+				db:       db,
+				storages: bstorage.InitAll(metrics.NewNoopCollector(), db),
+				ledger:   ldg,
+			}
+
+			require.NoError(t, r.replay(data))
+
+			// replaying a block again is a no-op
+			require.NoError(t, r.replay(data))
+
+			block, err := r.storages.Blocks.ByID(blockID)
+			require.NoError(t, err)
+			require.Equal(t, blockID, block.ID())
+
+			collection, err := r.storages.Collections.ByID(data.Collections[0].Guarantee.CollectionID)
+			require.NoError(t, err)
+			require.Equal(t, data.Collections[0].Transactions, collection.Transactions)
+
+			results, err := r.storages.TransactionResults.ByBlockID(blockID)
+			require.NoError(t, err)
+			require.Equal(t, []flow.TransactionResult{*data.TxResults[0]}, results)
+
+			events, err := r.storages.Events.ByBlockID(blockID)
+			require.NoError(t, err)
+			require.Equal(t, []flow.Event{*data.Events[0]}, events)
+
+			commit, err := r.storages.Commits.ByBlockID(blockID)
+			require.NoError(t, err)
+			require.Equal(t, data.FinalStateCommitment, commit)
+
+			query, err := ledger.NewQuery(
+				ledger.State(commit),
+				[]ledger.Key{ledger.NewKey([]ledger.KeyPart{ledger.NewKeyPart(0, []byte{1})})},
+			)
+			require.NoError(t, err)
+			values, err := ldg.Get(query)
+			require.NoError(t, err)
+			require.Equal(t, []ledger.Value{{1, 1}}, values)
+		})
+	})
+
+	t.Run("mismatching final state commitment is detected", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			data := blockData(t, newLedger(t))
+			data.FinalStateCommitment = unittest.StateCommitmentFixture()
+
+			r := &replayer{
+				db:       db,
+				storages: bstorage.InitAll(metrics.NewNoopCollector(), db),
+				ledger:   newLedger(t),
+			}
+
+			err := r.replay(data)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "does not match archived final state commitment")
+		})
+	})
+
+	t.Run("missing start state is detected", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			data := blockData(t, newLedger(t))
+
+			// the block is replayed from the state after its first chunk
+			data.TrieUpdates = data.TrieUpdates[1:]
+
+			r := &replayer{
+				db:       db,
+				storages: bstorage.InitAll(metrics.NewNoopCollector(), db),
+				ledger:   newLedger(t),
+			}
+
+			err := r.replay(data)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "could not apply trie update 0")
+		})
+	})
+}
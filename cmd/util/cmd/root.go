@@ -15,10 +15,14 @@ import (
 	edbs "github.com/onflow/flow-go/cmd/util/cmd/execution-data-blobstore/cmd"
 	extract "github.com/onflow/flow-go/cmd/util/cmd/execution-state-extract"
 	ledger_json_exporter "github.com/onflow/flow-go/cmd/util/cmd/export-json-execution-state"
+	index_events "github.com/onflow/flow-go/cmd/util/cmd/index-events"
 	read_badger "github.com/onflow/flow-go/cmd/util/cmd/read-badger/cmd"
 	read_execution_state "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state"
 	read_protocol_state "github.com/onflow/flow-go/cmd/util/cmd/read-protocol-state/cmd"
+	reexecute_block "github.com/onflow/flow-go/cmd/util/cmd/reexecute-block"
+	register_history "github.com/onflow/flow-go/cmd/util/cmd/register-history"
 	index_er "github.com/onflow/flow-go/cmd/util/cmd/reindex/cmd"
+	replay_block_data "github.com/onflow/flow-go/cmd/util/cmd/replay-block-data"
 	rollback_executed_height "github.com/onflow/flow-go/cmd/util/cmd/rollback-executed-height/cmd"
 	"github.com/onflow/flow-go/cmd/util/cmd/snapshot"
 	truncate_database "github.com/onflow/flow-go/cmd/util/cmd/truncate-database"
@@ -70,6 +74,10 @@ func addCommands() {
 	rootCmd.AddCommand(rollback_executed_height.Cmd)
 	rootCmd.AddCommand(read_execution_state.Cmd)
 	rootCmd.AddCommand(snapshot.Cmd)
+	rootCmd.AddCommand(index_events.Cmd)
+	rootCmd.AddCommand(reexecute_block.Cmd)
+	rootCmd.AddCommand(replay_block_data.Cmd)
+	rootCmd.AddCommand(register_history.Cmd)
 }
 
 func initConfig() {
@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/rest/request"
+)
+
+// Error codes returned in the extensions of errors, so that clients can handle errors without parsing
+// their message.
+const (
+	codeBadRequest     = "BAD_REQUEST"
+	codeNotFound       = "NOT_FOUND"
+	codeNotImplemented = "NOT_IMPLEMENTED"
+	codeInternal       = "INTERNAL"
+)
+
+// requestError is an error returned to the client, with its code in the extensions of the error.
+type requestError struct {
+	code    string
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+func (e *requestError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &requestError{code: codeBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+// convertError converts an error of the backend into the error returned to the client. The messages are
+// consistent with the errors of the REST API.
+func convertError(err error, log zerolog.Logger) error {
+	if se, ok := status.FromError(err); ok {
+		switch se.Code() {
+		case codes.NotFound:
+			return &requestError{code: codeNotFound, message: fmt.Sprintf("Flow resource not found: %s", se.Message())}
+		case codes.InvalidArgument:
+			return &requestError{code: codeBadRequest, message: fmt.Sprintf("Invalid Flow argument: %s", se.Message())}
+		case codes.Internal:
+			return &requestError{code: codeBadRequest, message: fmt.Sprintf("Invalid Flow request: %s", se.Message())}
+		case codes.Unimplemented:
+			return &requestError{code: codeNotImplemented, message: fmt.Sprintf("Not supported by this node: %s", se.Message())}
+		}
+	}
+
+	log.Error().Err(err).Msg("failed to resolve graphql field")
+	return &requestError{code: codeInternal, message: "internal server error"}
+}
+
+// parseID parses an identifier argument.
+func parseID(args map[string]interface{}, name string) (request.ID, error) {
+	var id request.ID
+	raw, _ := args[name].(string)
+	if raw == "" {
+		return id, badRequest("invalid %s argument: ID must not be empty", name)
+	}
+	err := id.Parse(raw)
+	if err != nil {
+		return id, badRequest("invalid %s argument: %v", name, err)
+	}
+	return id, nil
+}
+
+// parseAddress parses an address argument.
+func parseAddress(args map[string]interface{}, name string) (request.Address, error) {
+	var address request.Address
+	raw, _ := args[name].(string)
+	err := address.Parse(raw)
+	if err != nil {
+		return address, badRequest("invalid %s argument: %v", name, err)
+	}
+	return address, nil
+}
@@ -0,0 +1,142 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/rs/zerolog"
+)
+
+// MaxRequestSize is the maximum size of a request body.
+const MaxRequestSize = 1 << 20 // 1MB
+
+// queryRequest is a GraphQL request, which is sent as JSON body of POST requests, or as query params of
+// GET requests.
+type queryRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves GraphQL queries. Queries are parsed, validated against the schema and checked against
+// the limits before they are executed.
+type Handler struct {
+	log    zerolog.Logger
+	schema graphql.Schema
+	limits Limits
+}
+
+// NewHandler returns a new GraphQL handler.
+func NewHandler(log zerolog.Logger, schema graphql.Schema, limits Limits) *Handler {
+	return &Handler{
+		log:    log,
+		schema: schema,
+		limits: limits,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	errLog := h.log.With().Str("request_url", req.URL.String()).Logger()
+
+	query, err := parseRequest(w, req)
+	if err != nil {
+		h.writeResult(w, http.StatusBadRequest, errorResult(err), errLog)
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(query.Query),
+			Name: "GraphQL request",
+		}),
+	})
+	if err != nil {
+		h.writeResult(w, http.StatusBadRequest, &graphql.Result{
+			Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(err)},
+		}, errLog)
+		return
+	}
+
+	validation := graphql.ValidateDocument(&h.schema, doc, nil)
+	if !validation.IsValid {
+		h.writeResult(w, http.StatusBadRequest, &graphql.Result{Errors: validation.Errors}, errLog)
+		return
+	}
+
+	err = checkLimits(h.schema, doc, query.OperationName, h.limits)
+	if err != nil {
+		h.writeResult(w, http.StatusBadRequest, errorResult(err), errLog)
+		return
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: query.OperationName,
+		Args:          query.Variables,
+		Context:       req.Context(),
+	})
+
+	// errors of individual fields are returned with the data resolved for the other fields
+	h.writeResult(w, http.StatusOK, result, errLog)
+}
+
+// parseRequest parses the GraphQL request from the body of POST requests, or the query params of GET requests.
+func parseRequest(w http.ResponseWriter, req *http.Request) (*queryRequest, error) {
+	var query queryRequest
+
+	switch req.Method {
+	case http.MethodGet:
+		params := req.URL.Query()
+		query.Query = params.Get("query")
+		query.OperationName = params.Get("operationName")
+		if variables := params.Get("variables"); variables != "" {
+			err := json.Unmarshal([]byte(variables), &query.Variables)
+			if err != nil {
+				return nil, fmt.Errorf("invalid variables: %w", err)
+			}
+		}
+	case http.MethodPost:
+		body := http.MaxBytesReader(w, req.Body, MaxRequestSize)
+		err := json.NewDecoder(body).Decode(&query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request body: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported method %s", req.Method)
+	}
+
+	if query.Query == "" {
+		return nil, fmt.Errorf("query must be provided")
+	}
+
+	return &query, nil
+}
+
+func errorResult(err error) *graphql.Result {
+	return &graphql.Result{
+		Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())},
+	}
+}
+
+// writeResult writes the result as JSON response with the given status code to the client.
+func (h *Handler) writeResult(w http.ResponseWriter, code int, result *graphql.Result, logger zerolog.Logger) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to encode graphql response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	_, err = w.Write(body)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to write graphql response")
+	}
+}
@@ -0,0 +1,259 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/rs/zerolog"
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// fullBlockQuery queries a full view of a block, as used by explorers.
+const fullBlockQuery = `
+query FullBlock($height: UInt64!) {
+  block(height: $height) {
+    id
+    parentId
+    height
+    timestamp
+    collections {
+      id
+      transactions {
+        ...transaction
+      }
+    }
+  }
+}
+
+fragment transaction on Transaction {
+  id
+  script
+  arguments
+  referenceBlockId
+  gasLimit
+  payer
+  authorizers
+  proposalKey { address keyIndex sequenceNumber }
+  result {
+    status
+    statusCode
+    errorMessage
+    events { type transactionId transactionIndex eventIndex payload }
+  }
+}`
+
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func execute(t *testing.T, backend access.API, limits Limits, query string, variables map[string]interface{}) (int, response) {
+	router, err := newRouter(backend, zerolog.Nop(), limits, nil)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	return rr.Code, resp
+}
+
+func TestFullBlockQuery(t *testing.T) {
+	backend := &mock.API{}
+
+	collection := unittest.CollectionFixture(2)
+	light := collection.Light()
+	guarantee := unittest.CollectionGuaranteeFixture(func(g *flow.CollectionGuarantee) {
+		g.CollectionID = light.ID()
+	})
+	block := unittest.BlockWithGuaranteesFixture([]*flow.CollectionGuarantee{guarantee})
+
+	backend.On("GetBlockByHeight", mocks.Anything, block.Header.Height).Return(block, nil)
+	backend.On("GetCollectionByID", mocks.Anything, light.ID()).Return(&light, nil)
+	for i, tx := range collection.Transactions {
+		event := unittest.EventFixture(flow.EventAccountCreated, uint32(i), 0, tx.ID(), 0)
+		backend.On("GetTransaction", mocks.Anything, tx.ID()).Return(tx, nil)
+		backend.On("GetTransactionResult", mocks.Anything, tx.ID()).Return(&access.TransactionResult{
+			Status:        flow.TransactionStatusSealed,
+			Events:        []flow.Event{event},
+			BlockID:       block.ID(),
+			TransactionID: tx.ID(),
+			CollectionID:  light.ID(),
+		}, nil)
+	}
+
+	code, resp := execute(t, backend, DefaultLimits(), fullBlockQuery, map[string]interface{}{
+		"height": fmt.Sprint(block.Header.Height),
+	})
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, resp.Errors)
+
+	b := resp.Data["block"].(map[string]interface{})
+	require.Equal(t, block.ID().String(), b["id"])
+	require.Equal(t, fmt.Sprint(block.Header.Height), b["height"])
+
+	collections := b["collections"].([]interface{})
+	require.Len(t, collections, 1)
+	transactions := collections[0].(map[string]interface{})["transactions"].([]interface{})
+	require.Len(t, transactions, 2)
+
+	for i, tx := range collection.Transactions {
+		transaction := transactions[i].(map[string]interface{})
+		require.Equal(t, tx.ID().String(), transaction["id"])
+		require.Equal(t, string(tx.Script), transaction["script"])
+		require.Equal(t, fmt.Sprint(tx.GasLimit), transaction["gasLimit"])
+
+		result := transaction["result"].(map[string]interface{})
+		require.Equal(t, "SEALED", result["status"])
+		events := result["events"].([]interface{})
+		require.Len(t, events, 1)
+		require.Equal(t, string(flow.EventAccountCreated), events[0].(map[string]interface{})["type"])
+	}
+
+	backend.AssertExpectations(t)
+}
+
+func TestAccountQuery(t *testing.T) {
+	backend := &mock.API{}
+
+	account, err := unittest.AccountFixture()
+	require.NoError(t, err)
+	backend.On("GetAccountAtBlockHeight", mocks.Anything, account.Address, uint64(10)).Return(account, nil)
+
+	query := fmt.Sprintf(`{
+	  account(address: "0x%s", height: "10") {
+	    address
+	    balance
+	    keys { index publicKey signingAlgorithm hashingAlgorithm weight sequenceNumber revoked }
+	    contracts { name code }
+	  }
+	}`, account.Address.Hex())
+
+	code, resp := execute(t, backend, DefaultLimits(), query, nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, resp.Errors)
+
+	a := resp.Data["account"].(map[string]interface{})
+	require.Equal(t, account.Address.String(), a["address"])
+	require.Equal(t, "100", a["balance"])
+
+	keys := a["keys"].([]interface{})
+	require.Len(t, keys, 1)
+	require.Equal(t, account.Keys[0].PublicKey.String(), keys[0].(map[string]interface{})["publicKey"])
+	require.Equal(t, "ECDSA_P256", keys[0].(map[string]interface{})["signingAlgorithm"])
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": "contract1", "code": "contract1"},
+		map[string]interface{}{"name": "contract2", "code": "contract2"},
+	}, a["contracts"])
+}
+
+func TestQueryErrors(t *testing.T) {
+	backend := &mock.API{}
+
+	t.Run("not found", func(t *testing.T) {
+		id := unittest.IdentifierFixture()
+		backend.On("GetTransaction", mocks.Anything, id).Return(nil, status.Error(codes.NotFound, "transaction not found"))
+
+		code, resp := execute(t, backend, DefaultLimits(), fmt.Sprintf(`{ transaction(id: "%s") { id } }`, id), nil)
+		require.Equal(t, http.StatusOK, code)
+		require.Nil(t, resp.Data["transaction"])
+		require.Len(t, resp.Errors, 1)
+		require.Equal(t, "Flow resource not found: transaction not found", resp.Errors[0].Message)
+		require.Equal(t, codeNotFound, resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		code, resp := execute(t, backend, DefaultLimits(), `{ block(id: "foo") { id } }`, nil)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, resp.Errors, 1)
+		require.Equal(t, "invalid id argument: invalid ID format", resp.Errors[0].Message)
+		require.Equal(t, codeBadRequest, resp.Errors[0].Extensions["code"])
+
+		_, resp = execute(t, backend, DefaultLimits(), `{ block { id } }`, nil)
+		require.Equal(t, "exactly one of id or height must be provided", resp.Errors[0].Message)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		code, resp := execute(t, backend, DefaultLimits(), `{ block(height: "1") { unknown } }`, nil)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Len(t, resp.Errors, 1)
+		require.Contains(t, resp.Errors[0].Message, `Cannot query field "unknown" on type "Block"`)
+
+		code, resp = execute(t, backend, DefaultLimits(), `{ block(`, nil)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Len(t, resp.Errors, 1)
+	})
+}
+
+func TestQueryLimits(t *testing.T) {
+	backend := &mock.API{}
+	variables := map[string]interface{}{"height": "1"}
+
+	t.Run("depth", func(t *testing.T) {
+		code, resp := execute(t, backend, Limits{MaxDepth: 5, MaxCost: DefaultMaxCost}, fullBlockQuery, variables)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Len(t, resp.Errors, 1)
+		require.Equal(t, "query depth 6 exceeds the maximum depth of 5", resp.Errors[0].Message)
+	})
+
+	t.Run("cost", func(t *testing.T) {
+		code, resp := execute(t, backend, Limits{MaxDepth: DefaultMaxDepth, MaxCost: 1000}, fullBlockQuery, variables)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Len(t, resp.Errors, 1)
+		require.Equal(t, "query cost 6626 exceeds the maximum cost of 1000", resp.Errors[0].Message)
+	})
+
+	t.Run("introspection", func(t *testing.T) {
+		query := `{ __schema { types { name fields { name type { name ofType { name ofType { name ofType { name } } } } } } } }`
+		code, resp := execute(t, backend, DefaultLimits(), query, nil)
+		require.Equal(t, http.StatusOK, code)
+		require.Empty(t, resp.Errors)
+	})
+
+	// no query exceeding the limits reaches the backend
+	backend.AssertExpectations(t)
+}
+
+func TestGetRequest(t *testing.T) {
+	backend := &mock.API{}
+
+	block := unittest.BlockFixture()
+	backend.On("GetLatestBlock", mocks.Anything, false).Return(&block, nil)
+
+	router, err := newRouter(backend, zerolog.Nop(), DefaultLimits(), nil)
+	require.NoError(t, err)
+
+	params := url.Values{}
+	params.Set("query", `{ latestBlock(sealed: false) { id } }`)
+	req := httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, fmt.Sprintf(`{"data":{"latestBlock":{"id":"%s"}}}`, block.ID()), rr.Body.String())
+}
@@ -0,0 +1,167 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	// DefaultMaxDepth is the default maximum depth of queries, which allows to query the events of the
+	// transaction results of the collections of a block.
+	DefaultMaxDepth = 10
+
+	// DefaultMaxCost is the default maximum cost of queries, which allows to query a full view of a block,
+	// including its collections, transactions, results and events.
+	DefaultMaxCost = 20_000
+
+	// listCostFactor is the assumed number of items of a list field. The cost of the selection of a list
+	// field is multiplied by this factor, since it is resolved for each item.
+	listCostFactor = 10
+)
+
+// Limits bounds the queries accepted by the server. Queries are analysed before they are executed, so
+// that no query exceeding the limits ever reaches the backend.
+type Limits struct {
+	// MaxDepth is the maximum nesting depth of the fields of a query.
+	MaxDepth int
+	// MaxCost is the maximum cost of a query, where each field costs 1, and the cost of the fields
+	// selected from a list is multiplied by the assumed number of items of the list.
+	MaxCost int
+}
+
+// DefaultLimits returns the default query limits.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxDepth: DefaultMaxDepth,
+		MaxCost:  DefaultMaxCost,
+	}
+}
+
+// complexity is the depth and cost of a selection set.
+type complexity struct {
+	depth int
+	cost  int
+}
+
+// analyzer computes the complexity of the operations of a validated query document.
+type analyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+}
+
+// checkLimits returns an error if the operation of the document with the given name exceeds the limits.
+// The document must have been validated against the schema.
+func checkLimits(schema graphql.Schema, doc *ast.Document, operationName string, limits Limits) error {
+	a := &analyzer{
+		fragments: make(map[string]*ast.FragmentDefinition),
+	}
+
+	var operations []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operations = append(operations, def)
+			}
+		case *ast.FragmentDefinition:
+			a.fragments[def.Name.Value] = def
+		}
+	}
+
+	for _, op := range operations {
+		var root *graphql.Object
+		switch op.Operation {
+		case ast.OperationTypeQuery:
+			root = schema.QueryType()
+		default:
+			return fmt.Errorf("unsupported operation type %s", op.Operation)
+		}
+
+		c := a.selectionSet(root, op.SelectionSet)
+		if c.depth > limits.MaxDepth {
+			return fmt.Errorf("query depth %d exceeds the maximum depth of %d", c.depth, limits.MaxDepth)
+		}
+		if c.cost > limits.MaxCost {
+			return fmt.Errorf("query cost %d exceeds the maximum cost of %d", c.cost, limits.MaxCost)
+		}
+	}
+
+	return nil
+}
+
+// selectionSet returns the complexity of the given selection set of the given type. Introspection fields
+// are not counted, since they are answered from the schema without accessing the backend.
+func (a *analyzer) selectionSet(parent *graphql.Object, set *ast.SelectionSet) complexity {
+	var total complexity
+	if set == nil {
+		return total
+	}
+
+	for _, selection := range set.Selections {
+		var c complexity
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c = a.field(parent, selection)
+		case *ast.InlineFragment:
+			c = a.selectionSet(parent, selection.SelectionSet)
+		case *ast.FragmentSpread:
+			// fragments are validated to be acyclic
+			fragment, ok := a.fragments[selection.Name.Value]
+			if ok {
+				c = a.selectionSet(parent, fragment.SelectionSet)
+			}
+		}
+
+		total.cost += c.cost
+		if c.depth > total.depth {
+			total.depth = c.depth
+		}
+	}
+
+	return total
+}
+
+// field returns the complexity of the given field of the given type, including its selection.
+func (a *analyzer) field(parent *graphql.Object, field *ast.Field) complexity {
+	name := field.Name.Value
+	if strings.HasPrefix(name, "__") {
+		return complexity{}
+	}
+
+	def, ok := parent.Fields()[name]
+	if !ok {
+		return complexity{}
+	}
+
+	fieldType, list := unwrapType(def.Type)
+	object, ok := fieldType.(*graphql.Object)
+	if !ok {
+		// leaf field
+		return complexity{depth: 1, cost: 1}
+	}
+
+	c := a.selectionSet(object, field.SelectionSet)
+	if list {
+		c.cost *= listCostFactor
+	}
+
+	return complexity{depth: c.depth + 1, cost: c.cost + 1}
+}
+
+// unwrapType returns the named type of a field type, and whether the field type is a list.
+func unwrapType(t graphql.Type) (graphql.Type, bool) {
+	list := false
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			list = true
+			t = wrapped.OfType
+		default:
+			return t, list
+		}
+	}
+}
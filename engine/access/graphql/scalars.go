@@ -0,0 +1,82 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// uint64Scalar represents unsigned 64 bit integers, which are serialized as strings since they exceed the
+// range of the GraphQL Int type, consistent with the REST API.
+var uint64Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "UInt64",
+	Description: "An unsigned 64 bit integer, serialized as a decimal string.",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case uint64:
+			return strconv.FormatUint(v, 10)
+		case uint32:
+			return strconv.FormatUint(uint64(v), 10)
+		case uint:
+			return strconv.FormatUint(uint64(v), 10)
+		case int:
+			return strconv.Itoa(v)
+		default:
+			return nil
+		}
+	},
+	ParseValue: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case string:
+			return parseUint64(v)
+		case int:
+			if v < 0 {
+				return nil
+			}
+			return uint64(v)
+		case float64:
+			if v < 0 || v != float64(uint64(v)) {
+				return nil
+			}
+			return uint64(v)
+		default:
+			return nil
+		}
+	},
+	ParseLiteral: func(value ast.Value) interface{} {
+		switch v := value.(type) {
+		case *ast.StringValue:
+			return parseUint64(v.Value)
+		case *ast.IntValue:
+			return parseUint64(v.Value)
+		default:
+			return nil
+		}
+	},
+})
+
+// parseUint64 parses a decimal string, and returns nil if it is not a valid unsigned integer, which is
+// reported by the GraphQL validation as an invalid value.
+func parseUint64(value string) interface{} {
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// uint64Arg returns the value of an optional UInt64 argument.
+func uint64Arg(args map[string]interface{}, name string) (uint64, bool, error) {
+	value, ok := args[name]
+	if !ok || value == nil {
+		return 0, false, nil
+	}
+
+	v, ok := value.(uint64)
+	if !ok {
+		return 0, false, fmt.Errorf("invalid %s argument", name)
+	}
+	return v, true, nil
+}
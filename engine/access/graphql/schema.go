@@ -0,0 +1,671 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// resolver resolves the fields of the schema with the access API backend.
+type resolver struct {
+	backend access.API
+	log     zerolog.Logger
+}
+
+// contract is a contract deployed to an account.
+type contract struct {
+	name string
+	code []byte
+}
+
+// newSchema creates the GraphQL schema of the access API, which exposes blocks, collections, transactions,
+// their results and events, and accounts as a graph resolved with the given backend.
+func newSchema(backend access.API, log zerolog.Logger) (graphql.Schema, error) {
+	r := &resolver{
+		backend: backend,
+		log:     log,
+	}
+
+	nonNullString := graphql.NewNonNull(graphql.String)
+	nonNullUInt64 := graphql.NewNonNull(uint64Scalar)
+	nonNullInt := graphql.NewNonNull(graphql.Int)
+
+	statusEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "TransactionStatus",
+		Description: "The status of a transaction.",
+		Values: graphql.EnumValueConfigMap{
+			"UNKNOWN":   {Value: flow.TransactionStatusUnknown},
+			"PENDING":   {Value: flow.TransactionStatusPending},
+			"FINALIZED": {Value: flow.TransactionStatusFinalized},
+			"EXECUTED":  {Value: flow.TransactionStatusExecuted},
+			"SEALED":    {Value: flow.TransactionStatusSealed},
+			"EXPIRED":   {Value: flow.TransactionStatusExpired},
+		},
+	})
+
+	eventType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Event",
+		Description: "An event emitted by a transaction.",
+		Fields: graphql.Fields{
+			"type": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(flow.Event).Type), nil
+				},
+			},
+			"transactionId": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.Event).TransactionID.String(), nil
+				},
+			},
+			"transactionIndex": {
+				Type: nonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(flow.Event).TransactionIndex), nil
+				},
+			},
+			"eventIndex": {
+				Type: nonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(flow.Event).EventIndex), nil
+				},
+			},
+			"payload": {
+				Type:        nonNullString,
+				Description: "The JSON-CDC encoded payload of the event.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(flow.Event).Payload), nil
+				},
+			},
+		},
+	})
+
+	blockEventsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "BlockEvents",
+		Description: "The events of a block.",
+		Fields: graphql.Fields{
+			"blockId": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.BlockEvents).BlockID.String(), nil
+				},
+			},
+			"blockHeight": {
+				Type: nonNullUInt64,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.BlockEvents).BlockHeight, nil
+				},
+			},
+			"blockTimestamp": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.BlockEvents).BlockTimestamp.Format(time.RFC3339Nano), nil
+				},
+			},
+			"events": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(eventType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.BlockEvents).Events, nil
+				},
+			},
+		},
+	})
+
+	transactionResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TransactionResult",
+		Description: "The result of a transaction.",
+		Fields: graphql.Fields{
+			"transactionId": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).TransactionID.String(), nil
+				},
+			},
+			"blockId": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).BlockID.String(), nil
+				},
+			},
+			"collectionId": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).CollectionID.String(), nil
+				},
+			},
+			"status": {
+				Type: graphql.NewNonNull(statusEnum),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).Status, nil
+				},
+			},
+			"statusCode": {
+				Type: nonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*access.TransactionResult).StatusCode), nil
+				},
+			},
+			"errorMessage": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).ErrorMessage, nil
+				},
+			},
+			"events": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(eventType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*access.TransactionResult).Events, nil
+				},
+			},
+		},
+	})
+
+	proposalKeyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ProposalKey",
+		Description: "The key used to propose a transaction.",
+		Fields: graphql.Fields{
+			"address": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.ProposalKey).Address.String(), nil
+				},
+			},
+			"keyIndex": {
+				Type: nonNullUInt64,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.ProposalKey).KeyIndex, nil
+				},
+			},
+			"sequenceNumber": {
+				Type: nonNullUInt64,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.ProposalKey).SequenceNumber, nil
+				},
+			},
+		},
+	})
+
+	transactionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Transaction",
+		Description: "A transaction.",
+		Fields: graphql.Fields{
+			"id": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.TransactionBody).ID().String(), nil
+				},
+			},
+			"script": {
+				Type:        nonNullString,
+				Description: "The Cadence script of the transaction.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*flow.TransactionBody).Script), nil
+				},
+			},
+			"arguments": {
+				Type:        graphql.NewNonNull(graphql.NewList(nonNullString)),
+				Description: "The JSON-CDC encoded arguments of the transaction.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					args := p.Source.(*flow.TransactionBody).Arguments
+					encoded := make([]string, len(args))
+					for i, arg := range args {
+						encoded[i] = string(arg)
+					}
+					return encoded, nil
+				},
+			},
+			"referenceBlockId": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.TransactionBody).ReferenceBlockID.String(), nil
+				},
+			},
+			"gasLimit": {
+				Type: nonNullUInt64,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.TransactionBody).GasLimit, nil
+				},
+			},
+			"payer": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.TransactionBody).Payer.String(), nil
+				},
+			},
+			"proposalKey": {
+				Type: graphql.NewNonNull(proposalKeyType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.TransactionBody).ProposalKey, nil
+				},
+			},
+			"authorizers": {
+				Type: graphql.NewNonNull(graphql.NewList(nonNullString)),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					authorizers := p.Source.(*flow.TransactionBody).Authorizers
+					addresses := make([]string, len(authorizers))
+					for i, authorizer := range authorizers {
+						addresses[i] = authorizer.String()
+					}
+					return addresses, nil
+				},
+			},
+			"result": {
+				Type: graphql.NewNonNull(transactionResultType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return r.transactionResult(p.Context, p.Source.(*flow.TransactionBody).ID())
+				},
+			},
+		},
+	})
+
+	collectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Collection",
+		Description: "A collection of transactions included in a block.",
+		Fields: graphql.Fields{
+			"id": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.LightCollection).ID().String(), nil
+				},
+			},
+			"transactions": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(transactionType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return r.collectionTransactions(p.Context, p.Source.(*flow.LightCollection))
+				},
+			},
+		},
+	})
+
+	blockType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Block",
+		Description: "A finalized block.",
+		Fields: graphql.Fields{
+			"id": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Block).ID().String(), nil
+				},
+			},
+			"parentId": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Block).Header.ParentID.String(), nil
+				},
+			},
+			"height": {
+				Type: nonNullUInt64,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Block).Header.Height, nil
+				},
+			},
+			"timestamp": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Block).Header.Timestamp.Format(time.RFC3339Nano), nil
+				},
+			},
+			"collections": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(collectionType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return r.blockCollections(p.Context, p.Source.(*flow.Block))
+				},
+			},
+			"transactions": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(transactionType))),
+				Description: "The transactions of the block, including the system transaction.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return r.blockTransactions(p.Context, p.Source.(*flow.Block))
+				},
+			},
+			"transactionResults": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(transactionResultType))),
+				Description: "The results of the transactions of the block, in the order of the transactions.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return r.blockTransactionResults(p.Context, p.Source.(*flow.Block))
+				},
+			},
+		},
+	})
+
+	accountKeyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "AccountKey",
+		Description: "A public key of an account.",
+		Fields: graphql.Fields{
+			"index": {
+				Type: nonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).Index, nil
+				},
+			},
+			"publicKey": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).PublicKey.String(), nil
+				},
+			},
+			"signingAlgorithm": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).SignAlgo.String(), nil
+				},
+			},
+			"hashingAlgorithm": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).HashAlgo.String(), nil
+				},
+			},
+			"sequenceNumber": {
+				Type: nonNullUInt64,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).SeqNumber, nil
+				},
+			},
+			"weight": {
+				Type: nonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).Weight, nil
+				},
+			},
+			"revoked": {
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(flow.AccountPublicKey).Revoked, nil
+				},
+			},
+		},
+	})
+
+	contractType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Contract",
+		Description: "A contract deployed to an account.",
+		Fields: graphql.Fields{
+			"name": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(contract).name, nil
+				},
+			},
+			"code": {
+				Type:        nonNullString,
+				Description: "The Cadence code of the contract.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(contract).code), nil
+				},
+			},
+		},
+	})
+
+	accountType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Account",
+		Description: "An account.",
+		Fields: graphql.Fields{
+			"address": {
+				Type: nonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Account).Address.String(), nil
+				},
+			},
+			"balance": {
+				Type: nonNullUInt64,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Account).Balance, nil
+				},
+			},
+			"keys": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(accountKeyType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*flow.Account).Keys, nil
+				},
+			},
+			"contracts": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(contractType))),
+				Description: "The contracts of the account, ordered by name.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return accountContracts(p.Source.(*flow.Account)), nil
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"block": {
+				Type:        blockType,
+				Description: "Gets a block by its ID or height, exactly one of which must be provided.",
+				Args: graphql.FieldConfigArgument{
+					"id":     {Type: graphql.String},
+					"height": {Type: uint64Scalar},
+				},
+				Resolve: r.block,
+			},
+			"latestBlock": {
+				Type:        graphql.NewNonNull(blockType),
+				Description: "Gets the latest sealed block, or the latest finalized block if sealed is false.",
+				Args: graphql.FieldConfigArgument{
+					"sealed": {Type: graphql.Boolean, DefaultValue: true},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					sealed, _ := p.Args["sealed"].(bool)
+					block, err := r.backend.GetLatestBlock(p.Context, sealed)
+					if err != nil {
+						return nil, convertError(err, r.log)
+					}
+					return block, nil
+				},
+			},
+			"collection": {
+				Type: collectionType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: nonNullString},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := parseID(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					collection, err := r.backend.GetCollectionByID(p.Context, id.Flow())
+					if err != nil {
+						return nil, convertError(err, r.log)
+					}
+					return collection, nil
+				},
+			},
+			"transaction": {
+				Type: transactionType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: nonNullString},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := parseID(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					tx, err := r.backend.GetTransaction(p.Context, id.Flow())
+					if err != nil {
+						return nil, convertError(err, r.log)
+					}
+					return tx, nil
+				},
+			},
+			"transactionResult": {
+				Type: transactionResultType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: nonNullString},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := parseID(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return r.transactionResult(p.Context, id.Flow())
+				},
+			},
+			"account": {
+				Type:        accountType,
+				Description: "Gets an account at the given block height, or at the latest sealed block if no height is provided.",
+				Args: graphql.FieldConfigArgument{
+					"address": {Type: nonNullString},
+					"height":  {Type: uint64Scalar},
+				},
+				Resolve: r.account,
+			},
+			"events": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(blockEventsType))),
+				Description: "Gets the events of the given type emitted in the blocks of the height range.",
+				Args: graphql.FieldConfigArgument{
+					"type":        {Type: nonNullString},
+					"startHeight": {Type: nonNullUInt64},
+					"endHeight":   {Type: nonNullUInt64},
+				},
+				Resolve: r.events,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+	})
+}
+
+func (r *resolver) block(p graphql.ResolveParams) (interface{}, error) {
+	height, hasHeight, err := uint64Arg(p.Args, "height")
+	if err != nil {
+		return nil, badRequest(err.Error())
+	}
+	rawID, _ := p.Args["id"].(string)
+	hasID := rawID != ""
+	if hasID == hasHeight {
+		return nil, badRequest("exactly one of id or height must be provided")
+	}
+
+	var block *flow.Block
+	if hasID {
+		id, err := parseID(p.Args, "id")
+		if err != nil {
+			return nil, err
+		}
+		block, err = r.backend.GetBlockByID(p.Context, id.Flow())
+		if err != nil {
+			return nil, convertError(err, r.log)
+		}
+	} else {
+		block, err = r.backend.GetBlockByHeight(p.Context, height)
+		if err != nil {
+			return nil, convertError(err, r.log)
+		}
+	}
+
+	return block, nil
+}
+
+func (r *resolver) account(p graphql.ResolveParams) (interface{}, error) {
+	address, err := parseAddress(p.Args, "address")
+	if err != nil {
+		return nil, err
+	}
+	height, hasHeight, err := uint64Arg(p.Args, "height")
+	if err != nil {
+		return nil, badRequest(err.Error())
+	}
+
+	var account *flow.Account
+	if hasHeight {
+		account, err = r.backend.GetAccountAtBlockHeight(p.Context, address.Flow(), height)
+	} else {
+		account, err = r.backend.GetAccountAtLatestBlock(p.Context, address.Flow())
+	}
+	if err != nil {
+		return nil, convertError(err, r.log)
+	}
+
+	return account, nil
+}
+
+func (r *resolver) events(p graphql.ResolveParams) (interface{}, error) {
+	eventType, _ := p.Args["type"].(string)
+	startHeight, _, err := uint64Arg(p.Args, "startHeight")
+	if err != nil {
+		return nil, badRequest(err.Error())
+	}
+	endHeight, _, err := uint64Arg(p.Args, "endHeight")
+	if err != nil {
+		return nil, badRequest(err.Error())
+	}
+
+	events, err := r.backend.GetEventsForHeightRange(p.Context, eventType, startHeight, endHeight)
+	if err != nil {
+		return nil, convertError(err, r.log)
+	}
+
+	return events, nil
+}
+
+func (r *resolver) transactionResult(ctx context.Context, txID flow.Identifier) (*access.TransactionResult, error) {
+	result, err := r.backend.GetTransactionResult(ctx, txID)
+	if err != nil {
+		return nil, convertError(err, r.log)
+	}
+	return result, nil
+}
+
+func (r *resolver) blockCollections(ctx context.Context, block *flow.Block) ([]*flow.LightCollection, error) {
+	collections := make([]*flow.LightCollection, 0, len(block.Payload.Guarantees))
+	for _, guarantee := range block.Payload.Guarantees {
+		collection, err := r.backend.GetCollectionByID(ctx, guarantee.CollectionID)
+		if err != nil {
+			return nil, convertError(err, r.log)
+		}
+		collections = append(collections, collection)
+	}
+	return collections, nil
+}
+
+func (r *resolver) blockTransactions(ctx context.Context, block *flow.Block) ([]*flow.TransactionBody, error) {
+	transactions, err := r.backend.GetTransactionsByBlockID(ctx, block.ID())
+	if err != nil {
+		return nil, convertError(err, r.log)
+	}
+	return transactions, nil
+}
+
+func (r *resolver) blockTransactionResults(ctx context.Context, block *flow.Block) ([]*access.TransactionResult, error) {
+	results, err := r.backend.GetTransactionResultsByBlockID(ctx, block.ID())
+	if err != nil {
+		return nil, convertError(err, r.log)
+	}
+	return results, nil
+}
+
+func (r *resolver) collectionTransactions(ctx context.Context, collection *flow.LightCollection) ([]*flow.TransactionBody, error) {
+	transactions := make([]*flow.TransactionBody, 0, len(collection.Transactions))
+	for _, txID := range collection.Transactions {
+		tx, err := r.backend.GetTransaction(ctx, txID)
+		if err != nil {
+			return nil, convertError(err, r.log)
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, nil
+}
+
+// accountContracts returns the contracts of the account ordered by name, so that responses are deterministic.
+func accountContracts(account *flow.Account) []contract {
+	contracts := make([]contract, 0, len(account.Contracts))
+	for name, code := range account.Contracts {
+		contracts = append(contracts, contract{name: name, code: code})
+	}
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].name < contracts[j].name
+	})
+	return contracts
+}
@@ -0,0 +1,72 @@
+// Package graphql implements the GraphQL API of the access node.
+//
+// The API exposes the same backend as the REST API as a typed graph, so that clients can query a block
+// together with its collections, transactions, results and events, or an account with its keys and
+// contracts, in a single request. Queries are bounded in depth and cost.
+package graphql
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/cors"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/quota"
+	"github.com/onflow/flow-go/engine/access/rest/middleware"
+)
+
+// routeName is the name of the GraphQL route, under which requests are counted against the quota of
+// their client.
+const routeName = "graphql"
+
+// NewServer returns an HTTP server serving the GraphQL API at /graphql. Queries exceeding the given limits
+// are rejected. If quotas is not nil, requests are checked against the quota of their client.
+func NewServer(backend access.API, listenAddress string, logger zerolog.Logger, limits Limits, quotas *quota.Limiter) (*http.Server, error) {
+	router, err := newRouter(backend, logger, limits, quotas)
+	if err != nil {
+		return nil, err
+	}
+
+	c := cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedHeaders: []string{"*"},
+		AllowedMethods: []string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodOptions,
+			http.MethodHead},
+	})
+
+	return &http.Server{
+		Addr:         listenAddress,
+		Handler:      c.Handler(router),
+		WriteTimeout: time.Second * 15,
+		ReadTimeout:  time.Second * 15,
+		IdleTimeout:  time.Second * 60,
+	}, nil
+}
+
+func newRouter(backend access.API, logger zerolog.Logger, limits Limits, quotas *quota.Limiter) (*mux.Router, error) {
+	schema, err := newSchema(backend, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create graphql schema: %w", err)
+	}
+
+	router := mux.NewRouter()
+	router.Use(middleware.LoggingMiddleware(logger))
+	if quotas != nil {
+		router.Use(middleware.Quota(logger, quotas))
+	}
+
+	router.
+		Methods(http.MethodGet, http.MethodPost).
+		Path("/graphql").
+		Name(routeName).
+		Handler(NewHandler(logger, schema, limits))
+
+	return router, nil
+}
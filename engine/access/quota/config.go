@@ -0,0 +1,138 @@
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// AnonymousClient is the name under which clients without an API key are reported.
+const AnonymousClient = "anonymous"
+
+// Limit is a token bucket limit. The bucket holds up to Burst tokens and is refilled with Rate tokens per
+// second, each request takes one token. A zero rate disables the limit.
+type Limit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// Quota defines the limits of a client. Methods defines the limits of individual API methods, a request
+// to any other method is counted against the default limit, which is shared by all these methods.
+//
+// Methods are named as the gRPC methods of the Access API for gRPC requests (e.g. GetTransaction), and
+// as the routes of the REST API for REST requests (e.g. getTransactionByID).
+type Quota struct {
+	Limit
+	Methods map[string]Limit `json:"methods,omitempty"`
+}
+
+// limit returns the limit of the given method, and whether the method has its own limit.
+func (q *Quota) limit(method string) (Limit, bool) {
+	if l, ok := q.Methods[method]; ok {
+		return l, true
+	}
+	return q.Limit, false
+}
+
+// Key is an API key and the quota of the clients using it. Clients are reported by the name of their key,
+// so that keys never appear in logs or metrics.
+type Key struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	Quota
+}
+
+// Config is the content of the key store file.
+type Config struct {
+	// RequireKey rejects all requests without a valid API key.
+	RequireKey bool `json:"require_key"`
+	// Anonymous is the quota of clients without API key, which is applied per IP address.
+	Anonymous Quota `json:"anonymous"`
+	// Keys are the API keys accepted by the node.
+	Keys []Key `json:"keys"`
+}
+
+// ReadConfig reads and validates the key store file at the given path.
+func ReadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key store: %w", err)
+	}
+
+	var config Config
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode key store: %w", err)
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid key store: %w", err)
+	}
+
+	return &config, nil
+}
+
+// Validate checks that keys and their names are unique, and that all limits are valid.
+func (c *Config) Validate() error {
+	err := c.Anonymous.validate()
+	if err != nil {
+		return fmt.Errorf("invalid anonymous quota: %w", err)
+	}
+
+	names := make(map[string]struct{}, len(c.Keys))
+	keys := make(map[string]struct{}, len(c.Keys))
+	for i, key := range c.Keys {
+		if key.Name == "" {
+			return fmt.Errorf("key %d has no name", i)
+		}
+		if key.Name == AnonymousClient {
+			return fmt.Errorf("key %d uses reserved name %s", i, AnonymousClient)
+		}
+		if _, ok := names[key.Name]; ok {
+			return fmt.Errorf("duplicate key name %s", key.Name)
+		}
+		names[key.Name] = struct{}{}
+
+		if key.Key == "" {
+			return fmt.Errorf("key %s is empty", key.Name)
+		}
+		if _, ok := keys[key.Key]; ok {
+			return fmt.Errorf("key %s is used more than once", key.Name)
+		}
+		keys[key.Key] = struct{}{}
+
+		err := key.Quota.validate()
+		if err != nil {
+			return fmt.Errorf("invalid quota of key %s: %w", key.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (q *Quota) validate() error {
+	err := q.Limit.validate()
+	if err != nil {
+		return err
+	}
+
+	for method, l := range q.Methods {
+		err := l.validate()
+		if err != nil {
+			return fmt.Errorf("invalid limit of method %s: %w", method, err)
+		}
+	}
+
+	return nil
+}
+
+func (l Limit) validate() error {
+	if l.Rate < 0 {
+		return fmt.Errorf("rate must not be negative")
+	}
+	if l.Rate > 0 && l.Burst < 1 {
+		return fmt.Errorf("burst must be at least 1")
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Package quota enforces per client quotas on the Access API.
+//
+// Clients identify themselves with an API key, which is sent in the x-api-key header (gRPC metadata or
+// HTTP header). Clients without key are identified by their IP address and share the anonymous quota.
+// The quotas are token buckets, which are defined per client and API method in a key store file that
+// can be reloaded while the node is running.
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/module"
+)
+
+// APIKeyHeader is the header in which clients send their API key.
+const APIKeyHeader = "x-api-key"
+
+// maxBuckets is the maximum number of token buckets kept in memory. The least recently used buckets are
+// evicted first, and start full if their client returns.
+const maxBuckets = 100_000
+
+var (
+	// ErrQuotaExceeded is returned when the quota of the client for the requested method is exhausted.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrUnknownKey is returned when the API key of the client is not in the key store.
+	ErrUnknownKey = errors.New("unknown API key")
+	// ErrKeyRequired is returned when the client has no API key, but the key store requires one.
+	ErrKeyRequired = errors.New("API key required")
+)
+
+// unauthenticatedClient is the name under which requests with invalid API keys are reported.
+const unauthenticatedClient = "unauthenticated"
+
+// bucketKey identifies a token bucket. The limit is part of the key, so that a reload of the key store
+// only resets the buckets of the limits which changed.
+type bucketKey struct {
+	client string // the key name, or the IP address of anonymous clients, with a prefix to keep them apart
+	method string // empty for the default limit
+	limit  Limit
+}
+
+// Limiter checks the requests of clients against their quota.
+type Limiter struct {
+	log     zerolog.Logger
+	metrics module.AccessQuotaMetrics
+	path    string
+
+	mu     sync.RWMutex
+	config *Config
+	keys   map[string]*Key // API key -> key
+
+	buckets *lru.Cache // bucketKey -> *rate.Limiter
+}
+
+// NewLimiter creates a limiter with the quotas of the key store file at the given path.
+func NewLimiter(log zerolog.Logger, metrics module.AccessQuotaMetrics, path string) (*Limiter, error) {
+	buckets, err := lru.New(maxBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("could not create bucket cache: %w", err)
+	}
+
+	l := &Limiter{
+		log:     log.With().Str("component", "quota_limiter").Logger(),
+		metrics: metrics,
+		path:    path,
+		buckets: buckets,
+	}
+
+	_, err = l.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Reload reads the key store file again and applies its quotas. The buckets of limits which did not change
+// keep their state. If the file cannot be read or is invalid, the current quotas are kept.
+// It returns the number of API keys loaded.
+func (l *Limiter) Reload() (int, error) {
+	config, err := ReadConfig(l.path)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make(map[string]*Key, len(config.Keys))
+	for i := range config.Keys {
+		key := &config.Keys[i]
+		keys[key.Key] = key
+	}
+
+	l.mu.Lock()
+	l.config = config
+	l.keys = keys
+	l.mu.Unlock()
+
+	l.metrics.QuotaKeysLoaded(len(keys))
+	l.log.Info().
+		Int("keys", len(keys)).
+		Bool("require_key", config.RequireKey).
+		Msg("loaded API quotas")
+
+	return len(keys), nil
+}
+
+// Allow checks a request of a client to the given method against the quota of the client, and takes a
+// token from its bucket if the request is allowed. The client is identified by its API key, or by its IP
+// address if the key is empty.
+//
+// Expected errors during normal operations:
+//   - ErrQuotaExceeded if the quota of the client is exhausted
+//   - ErrUnknownKey if the API key is not in the key store
+//   - ErrKeyRequired if the API key is empty, but the key store requires one
+func (l *Limiter) Allow(apiKey string, ip string, method string) error {
+	l.mu.RLock()
+	config := l.config
+	key := l.keys[apiKey]
+	l.mu.RUnlock()
+
+	var client, bucketClient string
+	var quota *Quota
+	switch {
+	case key != nil:
+		client = key.Name
+		bucketClient = "key:" + key.Name
+		quota = &key.Quota
+	case apiKey != "":
+		l.metrics.QuotaRequestRejected(unauthenticatedClient, method)
+		return ErrUnknownKey
+	case config.RequireKey:
+		l.metrics.QuotaRequestRejected(unauthenticatedClient, method)
+		return ErrKeyRequired
+	default:
+		client = AnonymousClient
+		bucketClient = "ip:" + ip
+		quota = &config.Anonymous
+	}
+
+	limit, own := quota.limit(method)
+	if limit.Rate == 0 {
+		l.metrics.QuotaRequestAllowed(client, method)
+		return nil
+	}
+
+	bk := bucketKey{client: bucketClient, limit: limit}
+	if own {
+		bk.method = method
+	}
+
+	if !l.bucket(bk).Allow() {
+		l.metrics.QuotaRequestRejected(client, method)
+		l.log.Trace().
+			Str("client", client).
+			Str("method", method).
+			Float64("rate", limit.Rate).
+			Msg("quota exceeded")
+		return fmt.Errorf("%w for %s, please retry later", ErrQuotaExceeded, method)
+	}
+
+	l.metrics.QuotaRequestAllowed(client, method)
+	return nil
+}
+
+// bucket returns the token bucket with the given key, and creates it if it does not exist yet.
+func (l *Limiter) bucket(key bucketKey) *rate.Limiter {
+	if b, ok := l.buckets.Get(key); ok {
+		return b.(*rate.Limiter)
+	}
+
+	// concurrent requests of the same client may race to create the bucket, in which case the
+	// first bucket added is used
+	b := rate.NewLimiter(rate.Limit(key.limit.Rate), key.limit.Burst)
+	existing, ok, _ := l.buckets.PeekOrAdd(key, b)
+	if ok {
+		return existing.(*rate.Limiter)
+	}
+	return b
+}
@@ -0,0 +1,145 @@
+package quota
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func writeConfig(t *testing.T, path string, config Config) {
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+func TestLimiter(t *testing.T) {
+	config := Config{
+		Anonymous: Quota{
+			Limit: Limit{Rate: 0.001, Burst: 2},
+		},
+		Keys: []Key{{
+			Name: "partner",
+			Key:  "secret",
+			Quota: Quota{
+				Limit: Limit{Rate: 0.001, Burst: 3},
+				Methods: map[string]Limit{
+					"SendTransaction": {Rate: 0.001, Burst: 1},
+					"Ping":            {},
+				},
+			},
+		}},
+	}
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		path := filepath.Join(dir, "keys.json")
+		writeConfig(t, path, config)
+
+		limiter, err := NewLimiter(zerolog.Nop(), metrics.NewNoopCollector(), path)
+		require.NoError(t, err)
+
+		t.Run("anonymous clients are limited per IP", func(t *testing.T) {
+			require.NoError(t, limiter.Allow("", "10.0.0.1", "GetTransaction"))
+			require.NoError(t, limiter.Allow("", "10.0.0.1", "GetBlock"))
+			require.ErrorIs(t, limiter.Allow("", "10.0.0.1", "GetTransaction"), ErrQuotaExceeded)
+
+			require.NoError(t, limiter.Allow("", "10.0.0.2", "GetTransaction"))
+		})
+
+		t.Run("keys are limited per method", func(t *testing.T) {
+			require.NoError(t, limiter.Allow("secret", "10.0.0.1", "SendTransaction"))
+			require.ErrorIs(t, limiter.Allow("secret", "10.0.0.1", "SendTransaction"), ErrQuotaExceeded)
+
+			// other methods share the default limit of the key
+			for i := 0; i < 3; i++ {
+				require.NoError(t, limiter.Allow("secret", "10.0.0.1", "GetBlock"))
+			}
+			require.ErrorIs(t, limiter.Allow("secret", "10.0.0.1", "GetTransaction"), ErrQuotaExceeded)
+
+			// methods with a zero rate are not limited
+			for i := 0; i < 10; i++ {
+				require.NoError(t, limiter.Allow("secret", "10.0.0.1", "Ping"))
+			}
+		})
+
+		t.Run("unknown keys are rejected", func(t *testing.T) {
+			require.ErrorIs(t, limiter.Allow("unknown", "10.0.0.3", "Ping"), ErrUnknownKey)
+		})
+
+		t.Run("reload", func(t *testing.T) {
+			changed := config
+			changed.RequireKey = true
+			changed.Keys = []Key{config.Keys[0], {
+				Name:  "other",
+				Key:   "other-secret",
+				Quota: Quota{Limit: Limit{Rate: 0.001, Burst: 1}},
+			}}
+			changed.Keys[0].Methods = map[string]Limit{
+				"SendTransaction": {Rate: 0.001, Burst: 2},
+			}
+			writeConfig(t, path, changed)
+
+			keys, err := limiter.Reload()
+			require.NoError(t, err)
+			require.Equal(t, 2, keys)
+
+			require.ErrorIs(t, limiter.Allow("", "10.0.0.2", "Ping"), ErrKeyRequired)
+			require.NoError(t, limiter.Allow("other-secret", "10.0.0.2", "Ping"))
+
+			// buckets of unchanged limits keep their state, changed limits start with a new bucket
+			require.ErrorIs(t, limiter.Allow("secret", "10.0.0.1", "GetBlock"), ErrQuotaExceeded)
+			require.NoError(t, limiter.Allow("secret", "10.0.0.1", "SendTransaction"))
+			require.NoError(t, limiter.Allow("secret", "10.0.0.1", "SendTransaction"))
+			require.ErrorIs(t, limiter.Allow("secret", "10.0.0.1", "SendTransaction"), ErrQuotaExceeded)
+		})
+
+		t.Run("invalid key store keeps the current quotas", func(t *testing.T) {
+			require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+			_, err := limiter.Reload()
+			require.Error(t, err)
+			require.ErrorIs(t, limiter.Allow("", "10.0.0.2", "Ping"), ErrKeyRequired)
+		})
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := func() Config {
+		return Config{
+			Anonymous: Quota{Limit: Limit{Rate: 10, Burst: 10}},
+			Keys: []Key{
+				{Name: "a", Key: "key-a"},
+				{Name: "b", Key: "key-b", Quota: Quota{Methods: map[string]Limit{"Ping": {Rate: 1, Burst: 1}}}},
+			},
+		}
+	}
+
+	config := valid()
+	require.NoError(t, config.Validate())
+
+	tests := map[string]func(c *Config){
+		"missing name":      func(c *Config) { c.Keys[0].Name = "" },
+		"reserved name":     func(c *Config) { c.Keys[0].Name = AnonymousClient },
+		"duplicate name":    func(c *Config) { c.Keys[1].Name = "a" },
+		"missing key":       func(c *Config) { c.Keys[0].Key = "" },
+		"duplicate key":     func(c *Config) { c.Keys[1].Key = "key-a" },
+		"negative rate":     func(c *Config) { c.Anonymous.Rate = -1 },
+		"missing burst":     func(c *Config) { c.Anonymous.Burst = 0 },
+		"invalid method":    func(c *Config) { c.Keys[1].Methods["Ping"] = Limit{Rate: 1} },
+		"invalid key limit": func(c *Config) { c.Keys[0].Rate = 1 },
+	}
+
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := valid()
+			modify(&config)
+			require.Error(t, config.Validate())
+		})
+	}
+}
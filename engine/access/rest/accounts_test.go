@@ -127,6 +127,113 @@ func TestGetAccount(t *testing.T) {
 	})
 }
 
+func TestGetAccountKeys(t *testing.T) {
+	backend := &mock.API{}
+
+	t.Run("get keys at latest sealed block", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 100
+		block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+
+		req := getAccountSubResourceRequest(t, account.Address.String(), "keys", sealedHeightQueryParam)
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(&block, nil).
+			Once()
+		backend.Mock.
+			On("GetAccountKeysAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(account.Keys, nil)
+
+		expected := fmt.Sprintf("[%s]", expectedKeyResponse(account))
+
+		assertOKResponse(t, req, expected, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get key by index at height", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 1337
+
+		req := getAccountSubResourceRequest(t, account.Address.String(), "keys/0", fmt.Sprintf("%d", height))
+
+		backend.Mock.
+			On("GetAccountKeyAtBlockHeight", mocktestify.Anything, account.Address, uint64(0), height).
+			Return(&account.Keys[0], nil)
+
+		assertOKResponse(t, req, expectedKeyResponse(account), backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get invalid", func(t *testing.T) {
+		address := unittest.AddressFixture().String()
+		tests := []struct {
+			req *http.Request
+			out string
+		}{
+			{getAccountSubResourceRequest(t, "123", "keys", ""), `{"code":400, "message":"invalid address"}`},
+			{getAccountSubResourceRequest(t, address, "keys", "foo"), `{"code":400, "message":"invalid height format"}`},
+			{getAccountSubResourceRequest(t, address, "keys/foo", ""), `{"code":400, "message":"invalid key index format"}`},
+		}
+
+		for i, test := range tests {
+			rr, err := executeRequest(test.req, backend)
+			assert.NoError(t, err)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.JSONEq(t, test.out, rr.Body.String(), fmt.Sprintf("test #%d failed: %v", i, test))
+		}
+	})
+}
+
+func TestGetAccountBalance(t *testing.T) {
+	backend := &mock.API{}
+
+	t.Run("get balance at latest finalized block", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 100
+		block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+
+		req := getAccountSubResourceRequest(t, account.Address.String(), "balance", finalHeightQueryParam)
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, false).
+			Return(&block, nil)
+		backend.Mock.
+			On("GetAccountBalanceAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(account.Balance, nil)
+
+		assertOKResponse(t, req, `{"balance":"100"}`, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get balance at height", func(t *testing.T) {
+		account := accountFixture(t)
+		var height uint64 = 1337
+
+		req := getAccountSubResourceRequest(t, account.Address.String(), "balance", fmt.Sprintf("%d", height))
+
+		backend.Mock.
+			On("GetAccountBalanceAtBlockHeight", mocktestify.Anything, account.Address, height).
+			Return(account.Balance, nil)
+
+		assertOKResponse(t, req, `{"balance":"100"}`, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+}
+
+func expectedKeyResponse(account *flow.Account) string {
+	return fmt.Sprintf(`{
+			  "index":"0",
+			  "public_key":"%s",
+			  "signing_algorithm":"ECDSA_P256",
+			  "hashing_algorithm":"SHA3_256",
+			  "sequence_number":"0",
+			  "weight":"1000",
+			  "revoked":false
+			}`, account.Keys[0].PublicKey.String())
+}
+
 func expectedExpandedResponse(account *flow.Account) string {
 	return fmt.Sprintf(`{
 			  "address":"%s",
@@ -169,6 +276,21 @@ func getAccountRequest(t *testing.T, account *flow.Account, height string, expan
 	return req
 }
 
+func getAccountSubResourceRequest(t *testing.T, address string, resource string, height string) *http.Request {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/%s", address, resource))
+	require.NoError(t, err)
+
+	if height != "" {
+		q := u.Query()
+		q.Add("block_height", height)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+	return req
+}
+
 func accountFixture(t *testing.T) *flow.Account {
 	account, err := unittest.AccountFixture()
 	require.NoError(t, err)
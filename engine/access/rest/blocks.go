@@ -35,6 +35,8 @@ func GetBlocksByIDs(r *request.Request, backend access.API, link models.LinkGene
 	return blocks, nil
 }
 
+// GetBlocksByHeight gets blocks by provided heights or height range. Height ranges are paginated,
+// and only exceed the maximum height range if the client requests pagination.
 func GetBlocksByHeight(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
 	req, err := r.GetBlockRequest()
 	if err != nil {
@@ -78,9 +80,12 @@ func GetBlocksByHeight(r *request.Request, backend access.API, link models.LinkG
 		}
 	}
 
+	// only return a page of the height range, the rest is linked in the response
+	pageStart, pageEnd, next := heightPage(req.Pagination, req.StartHeight, req.EndHeight)
+
 	blocks := make([]*models.Block, 0)
 	// start and end height inclusive
-	for i := req.StartHeight; i <= req.EndHeight; i++ {
+	for i := pageStart; i <= pageEnd; i++ {
 		block, err := getBlock(forHeight(i), r, backend, link)
 		if err != nil {
 			return nil, err
@@ -88,7 +93,12 @@ func GetBlocksByHeight(r *request.Request, backend access.API, link models.LinkG
 		blocks = append(blocks, block)
 	}
 
-	return blocks, nil
+	nextLink, err := nextPageLink(r, link.BlocksPageLink, next, req.EndHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.Page{Items: blocks, NextLink: nextLink}, nil
 }
 
 // GetBlockPayloadByID gets block payload by ID
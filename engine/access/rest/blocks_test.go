@@ -145,6 +145,38 @@ func TestGetBlocks(t *testing.T) {
 	}
 }
 
+// TestGetBlocksPagination tests that height ranges are returned in pages linked by the Link header
+func TestGetBlocksPagination(t *testing.T) {
+	backend := &mock.API{}
+	_, heights, blocks, executionResults := generateMocks(backend, 5)
+
+	req := getByStartEndHeightExpandedURL(t, heights[0], heights[4])
+	q := req.URL.Query()
+	q.Set("limit", "3")
+	req.URL.RawQuery = q.Encode()
+
+	rr, err := executeRequest(req, backend)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, expectedBlockResponsesExpanded(blocks[:3], executionResults[:3], true), rr.Body.String())
+
+	q.Set("cursor", request.Cursor{Height: 3}.String())
+	expectedLink := fmt.Sprintf(`</v1/blocks?%s>; rel="next"`, q.Encode())
+	require.Equal(t, expectedLink, rr.Header().Get("Link"))
+
+	req.URL.RawQuery = q.Encode()
+	rr, err = executeRequest(req, backend)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, expectedBlockResponsesExpanded(blocks[3:], executionResults[3:], true), rr.Body.String())
+	require.Empty(t, rr.Header().Get("Link"))
+
+	// cursors must be within the requested range
+	q.Set("cursor", request.Cursor{Height: 5}.String())
+	req.URL.RawQuery = q.Encode()
+	assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message": "cursor is outside of the requested height range"}`, backend)
+}
+
 func requestURL(t *testing.T, ids []string, start string, end string, expandResponse bool, heights ...string) *http.Request {
 	u, _ := url.Parse("/v1/blocks")
 	q := u.Query()
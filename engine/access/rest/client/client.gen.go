@@ -0,0 +1,5044 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.11.0 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+const (
+	ApiKeyScopes = "apiKey.Scopes"
+)
+
+// Defines values for HashingAlgorithm.
+const (
+	KMAC128   HashingAlgorithm = "KMAC128"
+	Keccak256 HashingAlgorithm = "Keccak_256"
+	SHA2256   HashingAlgorithm = "SHA2_256"
+	SHA2384   HashingAlgorithm = "SHA2_384"
+	SHA3256   HashingAlgorithm = "SHA3_256"
+	SHA3384   HashingAlgorithm = "SHA3_384"
+)
+
+// Defines values for SigningAlgorithm.
+const (
+	BLSBLS12381    SigningAlgorithm = "BLS_BLS12381"
+	ECDSAP256      SigningAlgorithm = "ECDSA_P256"
+	ECDSASecp256k1 SigningAlgorithm = "ECDSA_secp256k1"
+)
+
+// Defines values for TransactionExecution.
+const (
+	TransactionExecutionFailure TransactionExecution = "Failure"
+	TransactionExecutionPending TransactionExecution = "Pending"
+	TransactionExecutionSuccess TransactionExecution = "Success"
+)
+
+// Defines values for TransactionStatus.
+const (
+	TransactionStatusExecuted  TransactionStatus = "Executed"
+	TransactionStatusExpired   TransactionStatus = "Expired"
+	TransactionStatusFinalized TransactionStatus = "Finalized"
+	TransactionStatusPending   TransactionStatus = "Pending"
+	TransactionStatusSealed    TransactionStatus = "Sealed"
+)
+
+// Account defines model for Account.
+type Account struct {
+	Expandable AccountExpandable `json:"_expandable"`
+	Links      *Links            `json:"_links,omitempty"`
+
+	// An 8-byte hex encoded account address.
+	Address Address `json:"address"`
+
+	// Flow balance of the account.
+	Balance interface{} `json:"balance"`
+
+	// The Base64 encoded code of the contracts of the account by name.
+	Contracts *Account_Contracts  `json:"contracts,omitempty"`
+	Keys      *[]AccountPublicKey `json:"keys,omitempty"`
+}
+
+// The Base64 encoded code of the contracts of the account by name.
+type Account_Contracts struct {
+	AdditionalProperties map[string][]byte `json:"-"`
+}
+
+// AccountBalance defines model for AccountBalance.
+type AccountBalance struct {
+	// Flow balance of the account.
+	Balance interface{} `json:"balance"`
+}
+
+// AccountExpandable defines model for AccountExpandable.
+type AccountExpandable struct {
+	Contracts *string `json:"contracts,omitempty"`
+	Keys      *string `json:"keys,omitempty"`
+}
+
+// AccountPublicKey defines model for AccountPublicKey.
+type AccountPublicKey struct {
+	HashingAlgorithm HashingAlgorithm `json:"hashing_algorithm"`
+
+	// Index of the public key.
+	Index interface{} `json:"index"`
+
+	// Hex encoded public key.
+	PublicKey string `json:"public_key"`
+
+	// Flag indicating whether the key is active or not.
+	Revoked bool `json:"revoked"`
+
+	// Current account sequence number.
+	SequenceNumber   interface{}      `json:"sequence_number"`
+	SigningAlgorithm SigningAlgorithm `json:"signing_algorithm"`
+
+	// Weight of the key.
+	Weight interface{} `json:"weight"`
+}
+
+// An 8-byte hex encoded account address.
+type Address = string
+
+// AggregatedSignature defines model for AggregatedSignature.
+type AggregatedSignature struct {
+	SignerIds          []Identifier `json:"signer_ids"`
+	VerifierSignatures []Signature  `json:"verifier_signatures"`
+}
+
+// Block defines model for Block.
+type Block struct {
+	Expandable      BlockExpandable  `json:"_expandable"`
+	Links           *Links           `json:"_links,omitempty"`
+	ExecutionResult *ExecutionResult `json:"execution_result,omitempty"`
+	Header          BlockHeader      `json:"header"`
+	Payload         *BlockPayload    `json:"payload,omitempty"`
+}
+
+// BlockEvents defines model for BlockEvents.
+type BlockEvents struct {
+	Links *Links `json:"_links,omitempty"`
+
+	// A 64-bit unsigned integer.
+	BlockHeight *Uint64 `json:"block_height,omitempty"`
+
+	// A 32-byte hex encoded identifier.
+	BlockId        *Identifier `json:"block_id,omitempty"`
+	BlockTimestamp *time.Time  `json:"block_timestamp,omitempty"`
+	Events         *[]Event    `json:"events,omitempty"`
+}
+
+// BlockExpandable defines model for BlockExpandable.
+type BlockExpandable struct {
+	ExecutionResult *string `json:"execution_result,omitempty"`
+	Payload         *string `json:"payload,omitempty"`
+}
+
+// BlockHeader defines model for BlockHeader.
+type BlockHeader struct {
+	// A 64-bit unsigned integer.
+	Height Uint64 `json:"height"`
+
+	// A 32-byte hex encoded identifier.
+	Id Identifier `json:"id"`
+
+	// A 32-byte hex encoded identifier.
+	ParentId Identifier `json:"parent_id"`
+
+	// A Base64 encoded signature.
+	ParentVoterSignature Signature `json:"parent_voter_signature"`
+	Timestamp            time.Time `json:"timestamp"`
+}
+
+// A block height, or one of the special values 'final' or 'sealed'.
+type BlockHeight = string
+
+// BlockPayload defines model for BlockPayload.
+type BlockPayload struct {
+	BlockSeals           []BlockSeal           `json:"block_seals"`
+	CollectionGuarantees []CollectionGuarantee `json:"collection_guarantees"`
+}
+
+// BlockSeal defines model for BlockSeal.
+type BlockSeal struct {
+	AggregatedApprovalSignatures []AggregatedSignature `json:"aggregated_approval_signatures"`
+
+	// A 32-byte hex encoded identifier.
+	BlockId    Identifier `json:"block_id"`
+	FinalState string     `json:"final_state"`
+
+	// A 32-byte hex encoded identifier.
+	ResultId Identifier `json:"result_id"`
+}
+
+// Chunk defines model for Chunk.
+type Chunk struct {
+	// A 32-byte hex encoded identifier.
+	BlockId Identifier `json:"block_id"`
+
+	// A 64-bit unsigned integer.
+	CollectionIndex Uint64 `json:"collection_index"`
+	EndState        string `json:"end_state"`
+	EventCollection string `json:"event_collection"`
+
+	// A 64-bit unsigned integer.
+	Index Uint64 `json:"index"`
+
+	// A 64-bit unsigned integer.
+	NumberOfTransactions Uint64 `json:"number_of_transactions"`
+	StartState           string `json:"start_state"`
+
+	// A 64-bit unsigned integer.
+	TotalComputationUsed Uint64 `json:"total_computation_used"`
+}
+
+// Collection defines model for Collection.
+type Collection struct {
+	Expandable CollectionExpandable `json:"_expandable"`
+	Links      *Links               `json:"_links,omitempty"`
+
+	// A 32-byte hex encoded identifier.
+	Id           Identifier     `json:"id"`
+	Transactions *[]Transaction `json:"transactions,omitempty"`
+}
+
+// CollectionExpandable defines model for CollectionExpandable.
+type CollectionExpandable struct {
+	Transactions *[]string `json:"transactions,omitempty"`
+}
+
+// CollectionGuarantee defines model for CollectionGuarantee.
+type CollectionGuarantee struct {
+	// A 32-byte hex encoded identifier.
+	CollectionId Identifier `json:"collection_id"`
+
+	// A Base64 encoded signature.
+	Signature Signature    `json:"signature"`
+	SignerIds []Identifier `json:"signer_ids"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Code    *int32  `json:"code,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
+// Event defines model for Event.
+type Event struct {
+	// A 64-bit unsigned integer.
+	EventIndex Uint64 `json:"event_index"`
+
+	// The Base64 encoded JSON-Cadence payload of the event.
+	Payload []byte `json:"payload"`
+
+	// A 32-byte hex encoded identifier.
+	TransactionId Identifier `json:"transaction_id"`
+
+	// A 64-bit unsigned integer.
+	TransactionIndex Uint64 `json:"transaction_index"`
+
+	// An event type, either a core event type flow.<name> or a contract event type A.<address>.<contract>.<name>.
+	Type EventType `json:"type"`
+}
+
+// An event type, either a core event type flow.<name> or a contract event type A.<address>.<contract>.<name>.
+type EventType = string
+
+// ExecutionResult defines model for ExecutionResult.
+type ExecutionResult struct {
+	Links *Links `json:"_links,omitempty"`
+
+	// A 32-byte hex encoded identifier.
+	BlockId Identifier `json:"block_id"`
+	Chunks  *[]Chunk   `json:"chunks,omitempty"`
+	Events  []Event    `json:"events"`
+
+	// A 32-byte hex encoded identifier.
+	Id Identifier `json:"id"`
+
+	// A 32-byte hex encoded identifier.
+	PreviousResultId Identifier `json:"previous_result_id"`
+}
+
+// HashingAlgorithm defines model for HashingAlgorithm.
+type HashingAlgorithm string
+
+// A 32-byte hex encoded identifier.
+type Identifier = string
+
+// Links defines model for Links.
+type Links struct {
+	Self *string `json:"_self,omitempty"`
+}
+
+// ProposalKey defines model for ProposalKey.
+type ProposalKey struct {
+	// An 8-byte hex encoded account address.
+	Address Address `json:"address"`
+
+	// A 64-bit unsigned integer.
+	KeyIndex Uint64 `json:"key_index"`
+
+	// A 64-bit unsigned integer.
+	SequenceNumber Uint64 `json:"sequence_number"`
+}
+
+// ScriptsBody defines model for ScriptsBody.
+type ScriptsBody struct {
+	// An array containing arguments each encoded as Base64 passed in the JSON-Cadence interchange format.
+	Arguments *[]string `json:"arguments,omitempty"`
+
+	// Base64 encoded content of the Cadence script.
+	Script *string `json:"script,omitempty"`
+}
+
+// A Base64 encoded signature.
+type Signature = []byte
+
+// SigningAlgorithm defines model for SigningAlgorithm.
+type SigningAlgorithm string
+
+// SimulatedTransactionResult defines model for SimulatedTransactionResult.
+type SimulatedTransactionResult struct {
+	// A 64-bit unsigned integer.
+	BlockHeight Uint64 `json:"block_height"`
+
+	// A 32-byte hex encoded identifier.
+	BlockId Identifier `json:"block_id"`
+
+	// Computation intensities by computation kind.
+	ComputationIntensities SimulatedTransactionResult_ComputationIntensities `json:"computation_intensities"`
+
+	// A 64-bit unsigned integer.
+	ComputationUsed Uint64 `json:"computation_used"`
+
+	// Error code of the FVM error in case the transaction wasn't successful, 0 otherwise.
+	ErrorCode interface{} `json:"error_code"`
+
+	// Provided transaction error in case the transaction wasn't successful.
+	ErrorMessage string  `json:"error_message"`
+	Events       []Event `json:"events"`
+
+	// Fees the transaction would be charged, in the smallest unit of FLOW.
+	Fees interface{} `json:"fees"`
+
+	// Memory intensities by memory kind.
+	MemoryIntensities SimulatedTransactionResult_MemoryIntensities `json:"memory_intensities"`
+
+	// A 64-bit unsigned integer.
+	MemoryUsed Uint64 `json:"memory_used"`
+}
+
+// Computation intensities by computation kind.
+type SimulatedTransactionResult_ComputationIntensities struct {
+	AdditionalProperties map[string]Uint64 `json:"-"`
+}
+
+// Memory intensities by memory kind.
+type SimulatedTransactionResult_MemoryIntensities struct {
+	AdditionalProperties map[string]Uint64 `json:"-"`
+}
+
+// Transaction defines model for Transaction.
+type Transaction struct {
+	Expandable TransactionExpandable `json:"_expandable"`
+	Links      *Links                `json:"_links,omitempty"`
+
+	// Array of Base64 encoded arguments in the JSON-Cadence interchange format.
+	Arguments          [][]byte               `json:"arguments"`
+	Authorizers        []Address              `json:"authorizers"`
+	EnvelopeSignatures []TransactionSignature `json:"envelope_signatures"`
+
+	// The limit on the amount of computation a transaction is allowed to preform.
+	GasLimit interface{} `json:"gas_limit"`
+
+	// A 32-byte hex encoded identifier.
+	Id Identifier `json:"id"`
+
+	// An 8-byte hex encoded account address.
+	Payer             Address                `json:"payer"`
+	PayloadSignatures []TransactionSignature `json:"payload_signatures"`
+	ProposalKey       ProposalKey            `json:"proposal_key"`
+
+	// A 32-byte hex encoded identifier.
+	ReferenceBlockId Identifier         `json:"reference_block_id"`
+	Result           *TransactionResult `json:"result,omitempty"`
+
+	// Base64 encoded Cadence script.
+	Script []byte `json:"script"`
+}
+
+// This value indicates whether the transaction execution succeeded or not, this value should be checked when determining transaction success.
+type TransactionExecution string
+
+// TransactionExpandable defines model for TransactionExpandable.
+type TransactionExpandable struct {
+	Result *string `json:"result,omitempty"`
+}
+
+// TransactionResult defines model for TransactionResult.
+type TransactionResult struct {
+	Links *Links `json:"_links,omitempty"`
+
+	// A 32-byte hex encoded identifier.
+	BlockId Identifier `json:"block_id"`
+
+	// A 64-bit unsigned integer.
+	ComputationUsed Uint64 `json:"computation_used"`
+
+	// Provided transaction error in case the transaction wasn't successful.
+	ErrorMessage string  `json:"error_message"`
+	Events       []Event `json:"events"`
+
+	// This value indicates whether the transaction execution succeeded or not, this value should be checked when determining transaction success.
+	Execution *TransactionExecution `json:"execution,omitempty"`
+
+	// This value indicates the state of the transaction execution. Only sealed and expired are final and immutable states.
+	Status     TransactionStatus `json:"status"`
+	StatusCode int32             `json:"status_code"`
+}
+
+// TransactionSignature defines model for TransactionSignature.
+type TransactionSignature struct {
+	// An 8-byte hex encoded account address.
+	Address Address `json:"address"`
+
+	// A 64-bit unsigned integer.
+	KeyIndex Uint64 `json:"key_index"`
+
+	// A Base64 encoded signature.
+	Signature Signature `json:"signature"`
+}
+
+// This value indicates the state of the transaction execution. Only sealed and expired are final and immutable states.
+type TransactionStatus string
+
+// TransactionsBody defines model for TransactionsBody.
+type TransactionsBody struct {
+	// An array containing arguments each encoded as Base64 passed in the JSON-Cadence interchange format.
+	Arguments          *[]string               `json:"arguments,omitempty"`
+	Authorizers        *[]Address              `json:"authorizers,omitempty"`
+	EnvelopeSignatures *[]TransactionSignature `json:"envelope_signatures,omitempty"`
+
+	// The limit on the amount of computation a transaction is allowed to preform.
+	GasLimit *string `json:"gas_limit,omitempty"`
+
+	// An 8-byte hex encoded account address.
+	Payer             Address                 `json:"payer"`
+	PayloadSignatures *[]TransactionSignature `json:"payload_signatures,omitempty"`
+	ProposalKey       ProposalKey             `json:"proposal_key"`
+
+	// A 32-byte hex encoded identifier.
+	ReferenceBlockId Identifier `json:"reference_block_id"`
+
+	// Base64 encoded content of the Cadence script.
+	Script string `json:"script"`
+}
+
+// A 64-bit unsigned integer.
+type Uint64 = string
+
+// Cursor defines model for cursor.
+type Cursor = string
+
+// A block height, or one of the special values 'final' or 'sealed'.
+type EndHeight = BlockHeight
+
+// Expand defines model for expand.
+type Expand = []string
+
+// A 32-byte hex encoded identifier.
+type Id = Identifier
+
+// Ids defines model for ids.
+type Ids = []Identifier
+
+// Limit defines model for limit.
+type Limit = int
+
+// Select defines model for select.
+type Select = []string
+
+// A block height, or one of the special values 'final' or 'sealed'.
+type StartHeight = BlockHeight
+
+// N400BadRequest defines model for 400BadRequest.
+type N400BadRequest = Error
+
+// N401Unauthorized defines model for 401Unauthorized.
+type N401Unauthorized = Error
+
+// N404NotFound defines model for 404NotFound.
+type N404NotFound = Error
+
+// N429TooManyRequests defines model for 429TooManyRequests.
+type N429TooManyRequests = Error
+
+// N500InternalServerError defines model for 500InternalServerError.
+type N500InternalServerError = Error
+
+// N501NotImplemented defines model for 501NotImplemented.
+type N501NotImplemented = Error
+
+// GetAccountParams defines parameters for GetAccount.
+type GetAccountParams struct {
+	// The height of the block, or one of the special values 'final' or 'sealed', defaults to 'sealed'.
+	BlockHeight *BlockHeight `form:"block_height,omitempty" json:"block_height,omitempty"`
+
+	// A comma-separated list of fields to expand in the response.
+	Expand *Expand `form:"expand,omitempty" json:"expand,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetAccountBalanceParams defines parameters for GetAccountBalance.
+type GetAccountBalanceParams struct {
+	// The height of the block, or one of the special values 'final' or 'sealed', defaults to 'sealed'.
+	BlockHeight *BlockHeight `form:"block_height,omitempty" json:"block_height,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetAccountKeysParams defines parameters for GetAccountKeys.
+type GetAccountKeysParams struct {
+	// The height of the block, or one of the special values 'final' or 'sealed', defaults to 'sealed'.
+	BlockHeight *BlockHeight `form:"block_height,omitempty" json:"block_height,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetAccountKeyByIndexParams defines parameters for GetAccountKeyByIndex.
+type GetAccountKeyByIndexParams struct {
+	// The height of the block, or one of the special values 'final' or 'sealed', defaults to 'sealed'.
+	BlockHeight *BlockHeight `form:"block_height,omitempty" json:"block_height,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetBlocksByHeightParams defines parameters for GetBlocksByHeight.
+type GetBlocksByHeightParams struct {
+	// A comma-separated list of block heights, or one of the special values 'final' or 'sealed'.
+	Height *[]BlockHeight `form:"height,omitempty" json:"height,omitempty"`
+
+	// The first height of the height range.
+	StartHeight *StartHeight `form:"start_height,omitempty" json:"start_height,omitempty"`
+
+	// The last height of the height range, or one of the special values 'final' or 'sealed'.
+	EndHeight *EndHeight `form:"end_height,omitempty" json:"end_height,omitempty"`
+
+	// The opaque cursor of the page, as returned in the link to the next page.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// The maximum number of items of the page, defaults to and is bounded by the maximum page size of the endpoint. Block and event height ranges exceeding their maximum are only accepted with a limit or cursor.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// A comma-separated list of fields to expand in the response.
+	Expand *Expand `form:"expand,omitempty" json:"expand,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetBlocksByIDsParams defines parameters for GetBlocksByIDs.
+type GetBlocksByIDsParams struct {
+	// A comma-separated list of fields to expand in the response.
+	Expand *Expand `form:"expand,omitempty" json:"expand,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetBlockPayloadByIDParams defines parameters for GetBlockPayloadByID.
+type GetBlockPayloadByIDParams struct {
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetCollectionByIDParams defines parameters for GetCollectionByID.
+type GetCollectionByIDParams struct {
+	// A comma-separated list of fields to expand in the response.
+	Expand *Expand `form:"expand,omitempty" json:"expand,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetEventsParams defines parameters for GetEvents.
+type GetEventsParams struct {
+	// The event type, optional if any of the address, contract or transaction ID filters are provided.
+	Type *EventType `form:"type,omitempty" json:"type,omitempty"`
+
+	// The first height of the height range.
+	StartHeight *StartHeight `form:"start_height,omitempty" json:"start_height,omitempty"`
+
+	// The last height of the height range, or one of the special values 'final' or 'sealed'.
+	EndHeight *EndHeight `form:"end_height,omitempty" json:"end_height,omitempty"`
+
+	// A comma-separated list of block IDs.
+	BlockIds *[]Identifier `form:"block_ids,omitempty" json:"block_ids,omitempty"`
+
+	// Only returns events emitted by contracts deployed to the account.
+	Address *Address `form:"address,omitempty" json:"address,omitempty"`
+
+	// Only returns events emitted by the contract, in the format A.<address>.<name>.
+	Contract *string `form:"contract,omitempty" json:"contract,omitempty"`
+
+	// Only returns events emitted by the transaction.
+	TransactionId *Identifier `form:"transaction_id,omitempty" json:"transaction_id,omitempty"`
+
+	// The opaque cursor of the page, as returned in the link to the next page.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// The maximum number of items of the page, defaults to and is bounded by the maximum page size of the endpoint. Block and event height ranges exceeding their maximum are only accepted with a limit or cursor.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetExecutionResultByBlockIDParams defines parameters for GetExecutionResultByBlockID.
+type GetExecutionResultByBlockIDParams struct {
+	// A comma-separated list of block IDs.
+	BlockId *[]Identifier `form:"block_id,omitempty" json:"block_id,omitempty"`
+
+	// The first height of the height range.
+	StartHeight *StartHeight `form:"start_height,omitempty" json:"start_height,omitempty"`
+
+	// The last height of the height range, or one of the special values 'final' or 'sealed'.
+	EndHeight *EndHeight `form:"end_height,omitempty" json:"end_height,omitempty"`
+
+	// The opaque cursor of the page, as returned in the link to the next page.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// The maximum number of items of the page, defaults to and is bounded by the maximum page size of the endpoint. Block and event height ranges exceeding their maximum are only accepted with a limit or cursor.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetExecutionResultByIDParams defines parameters for GetExecutionResultByID.
+type GetExecutionResultByIDParams struct {
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// ExecuteScriptJSONBody defines parameters for ExecuteScript.
+type ExecuteScriptJSONBody = ScriptsBody
+
+// ExecuteScriptParams defines parameters for ExecuteScript.
+type ExecuteScriptParams struct {
+	// The ID of the block to execute the script at, can not be provided together with a block height.
+	BlockId *Identifier `form:"block_id,omitempty" json:"block_id,omitempty"`
+
+	// The height of the block, or one of the special values 'final' or 'sealed', defaults to 'sealed'.
+	BlockHeight *BlockHeight `form:"block_height,omitempty" json:"block_height,omitempty"`
+}
+
+// SubscribeEventsParams defines parameters for SubscribeEvents.
+type SubscribeEventsParams struct {
+	// The ID of the block to start streaming from.
+	StartBlockId *Identifier `form:"start_block_id,omitempty" json:"start_block_id,omitempty"`
+
+	// The height of the block to start streaming from.
+	StartHeight *string `form:"start_height,omitempty" json:"start_height,omitempty"`
+
+	// A comma-separated list of event types to stream.
+	EventTypes *[]EventType `form:"event_types,omitempty" json:"event_types,omitempty"`
+
+	// A comma-separated list of accounts to stream the events of.
+	Addresses *[]Address `form:"addresses,omitempty" json:"addresses,omitempty"`
+
+	// A comma-separated list of contracts to stream the events of, in the format A.<address>.<name>.
+	Contracts *[]string `form:"contracts,omitempty" json:"contracts,omitempty"`
+}
+
+// GetTransactionResultByIDParams defines parameters for GetTransactionResultByID.
+type GetTransactionResultByIDParams struct {
+	// A comma-separated list of fields to expand in the response.
+	Expand *Expand `form:"expand,omitempty" json:"expand,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// GetTransactionsParams defines parameters for GetTransactions.
+type GetTransactionsParams struct {
+	// The first height of the height range.
+	StartHeight *StartHeight `form:"start_height,omitempty" json:"start_height,omitempty"`
+
+	// The last height of the height range, or one of the special values 'final' or 'sealed'.
+	EndHeight *EndHeight `form:"end_height,omitempty" json:"end_height,omitempty"`
+
+	// The opaque cursor of the page, as returned in the link to the next page.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// The maximum number of items of the page, defaults to and is bounded by the maximum page size of the endpoint. Block and event height ranges exceeding their maximum are only accepted with a limit or cursor.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// A comma-separated list of fields to expand in the response.
+	Expand *Expand `form:"expand,omitempty" json:"expand,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// CreateTransactionJSONBody defines parameters for CreateTransaction.
+type CreateTransactionJSONBody = TransactionsBody
+
+// SimulateTransactionJSONBody defines parameters for SimulateTransaction.
+type SimulateTransactionJSONBody = TransactionsBody
+
+// SimulateTransactionParams defines parameters for SimulateTransaction.
+type SimulateTransactionParams struct {
+	// Whether the transaction signatures are verified, unsigned transactions can only be simulated if set.
+	SkipSignatureVerification *bool `form:"skip_signature_verification,omitempty" json:"skip_signature_verification,omitempty"`
+}
+
+// GetTransactionByIDParams defines parameters for GetTransactionByID.
+type GetTransactionByIDParams struct {
+	// A comma-separated list of fields to expand in the response.
+	Expand *Expand `form:"expand,omitempty" json:"expand,omitempty"`
+
+	// A comma-separated list of fields to include in the response, all fields are included by default.
+	Select *Select `form:"select,omitempty" json:"select,omitempty"`
+}
+
+// ExecuteScriptJSONRequestBody defines body for ExecuteScript for application/json ContentType.
+type ExecuteScriptJSONRequestBody = ExecuteScriptJSONBody
+
+// CreateTransactionJSONRequestBody defines body for CreateTransaction for application/json ContentType.
+type CreateTransactionJSONRequestBody = CreateTransactionJSONBody
+
+// SimulateTransactionJSONRequestBody defines body for SimulateTransaction for application/json ContentType.
+type SimulateTransactionJSONRequestBody = SimulateTransactionJSONBody
+
+// Getter for additional properties for Account_Contracts. Returns the specified
+// element and whether it was found
+func (a Account_Contracts) Get(fieldName string) (value []byte, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Account_Contracts
+func (a *Account_Contracts) Set(fieldName string, value []byte) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string][]byte)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Account_Contracts to handle AdditionalProperties
+func (a *Account_Contracts) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string][]byte)
+		for fieldName, fieldBuf := range object {
+			var fieldVal []byte
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Account_Contracts to handle AdditionalProperties
+func (a Account_Contracts) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for SimulatedTransactionResult_ComputationIntensities. Returns the specified
+// element and whether it was found
+func (a SimulatedTransactionResult_ComputationIntensities) Get(fieldName string) (value Uint64, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for SimulatedTransactionResult_ComputationIntensities
+func (a *SimulatedTransactionResult_ComputationIntensities) Set(fieldName string, value Uint64) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]Uint64)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for SimulatedTransactionResult_ComputationIntensities to handle AdditionalProperties
+func (a *SimulatedTransactionResult_ComputationIntensities) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]Uint64)
+		for fieldName, fieldBuf := range object {
+			var fieldVal Uint64
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for SimulatedTransactionResult_ComputationIntensities to handle AdditionalProperties
+func (a SimulatedTransactionResult_ComputationIntensities) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for SimulatedTransactionResult_MemoryIntensities. Returns the specified
+// element and whether it was found
+func (a SimulatedTransactionResult_MemoryIntensities) Get(fieldName string) (value Uint64, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for SimulatedTransactionResult_MemoryIntensities
+func (a *SimulatedTransactionResult_MemoryIntensities) Set(fieldName string, value Uint64) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]Uint64)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for SimulatedTransactionResult_MemoryIntensities to handle AdditionalProperties
+func (a *SimulatedTransactionResult_MemoryIntensities) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]Uint64)
+		for fieldName, fieldBuf := range object {
+			var fieldVal Uint64
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for SimulatedTransactionResult_MemoryIntensities to handle AdditionalProperties
+func (a SimulatedTransactionResult_MemoryIntensities) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAccount request
+	GetAccount(ctx context.Context, address Address, params *GetAccountParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountBalance request
+	GetAccountBalance(ctx context.Context, address Address, params *GetAccountBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountKeys request
+	GetAccountKeys(ctx context.Context, address Address, params *GetAccountKeysParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountKeyByIndex request
+	GetAccountKeyByIndex(ctx context.Context, address Address, index string, params *GetAccountKeyByIndexParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBlocksByHeight request
+	GetBlocksByHeight(ctx context.Context, params *GetBlocksByHeightParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBlocksByIDs request
+	GetBlocksByIDs(ctx context.Context, id Ids, params *GetBlocksByIDsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBlockPayloadByID request
+	GetBlockPayloadByID(ctx context.Context, id Id, params *GetBlockPayloadByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCollectionByID request
+	GetCollectionByID(ctx context.Context, id Id, params *GetCollectionByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEvents request
+	GetEvents(ctx context.Context, params *GetEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetExecutionResultByBlockID request
+	GetExecutionResultByBlockID(ctx context.Context, params *GetExecutionResultByBlockIDParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetExecutionResultByID request
+	GetExecutionResultByID(ctx context.Context, id Id, params *GetExecutionResultByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOpenAPISpec request
+	GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExecuteScript request with any body
+	ExecuteScriptWithBody(ctx context.Context, params *ExecuteScriptParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ExecuteScript(ctx context.Context, params *ExecuteScriptParams, body ExecuteScriptJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SendAndSubscribeTransactionStatuses request
+	SendAndSubscribeTransactionStatuses(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeEvents request
+	SubscribeEvents(ctx context.Context, params *SubscribeEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeTransactionStatuses request
+	SubscribeTransactionStatuses(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTransactionResultByID request
+	GetTransactionResultByID(ctx context.Context, id Id, params *GetTransactionResultByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTransactions request
+	GetTransactions(ctx context.Context, params *GetTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateTransaction request with any body
+	CreateTransactionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateTransaction(ctx context.Context, body CreateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SimulateTransaction request with any body
+	SimulateTransactionWithBody(ctx context.Context, params *SimulateTransactionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SimulateTransaction(ctx context.Context, params *SimulateTransactionParams, body SimulateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTransactionByID request
+	GetTransactionByID(ctx context.Context, id Id, params *GetTransactionByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAccount(ctx context.Context, address Address, params *GetAccountParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountBalance(ctx context.Context, address Address, params *GetAccountBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountBalanceRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountKeys(ctx context.Context, address Address, params *GetAccountKeysParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountKeysRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountKeyByIndex(ctx context.Context, address Address, index string, params *GetAccountKeyByIndexParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountKeyByIndexRequest(c.Server, address, index, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBlocksByHeight(ctx context.Context, params *GetBlocksByHeightParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBlocksByHeightRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBlocksByIDs(ctx context.Context, id Ids, params *GetBlocksByIDsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBlocksByIDsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBlockPayloadByID(ctx context.Context, id Id, params *GetBlockPayloadByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBlockPayloadByIDRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCollectionByID(ctx context.Context, id Id, params *GetCollectionByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCollectionByIDRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEvents(ctx context.Context, params *GetEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetExecutionResultByBlockID(ctx context.Context, params *GetExecutionResultByBlockIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetExecutionResultByBlockIDRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetExecutionResultByID(ctx context.Context, id Id, params *GetExecutionResultByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetExecutionResultByIDRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOpenAPISpecRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExecuteScriptWithBody(ctx context.Context, params *ExecuteScriptParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExecuteScriptRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExecuteScript(ctx context.Context, params *ExecuteScriptParams, body ExecuteScriptJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExecuteScriptRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SendAndSubscribeTransactionStatuses(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSendAndSubscribeTransactionStatusesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeEvents(ctx context.Context, params *SubscribeEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeTransactionStatuses(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeTransactionStatusesRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTransactionResultByID(ctx context.Context, id Id, params *GetTransactionResultByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTransactionResultByIDRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTransactions(ctx context.Context, params *GetTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTransactionsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTransactionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTransactionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTransaction(ctx context.Context, body CreateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTransactionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SimulateTransactionWithBody(ctx context.Context, params *SimulateTransactionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSimulateTransactionRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SimulateTransaction(ctx context.Context, params *SimulateTransactionParams, body SimulateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSimulateTransactionRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTransactionByID(ctx context.Context, id Id, params *GetTransactionByIDParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTransactionByIDRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAccountRequest generates requests for GetAccount
+func NewGetAccountRequest(server string, address Address, params *GetAccountParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/accounts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.BlockHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "block_height", runtime.ParamLocationQuery, *params.BlockHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Expand != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "expand", runtime.ParamLocationQuery, *params.Expand); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAccountBalanceRequest generates requests for GetAccountBalance
+func NewGetAccountBalanceRequest(server string, address Address, params *GetAccountBalanceParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/accounts/%s/balance", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.BlockHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "block_height", runtime.ParamLocationQuery, *params.BlockHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAccountKeysRequest generates requests for GetAccountKeys
+func NewGetAccountKeysRequest(server string, address Address, params *GetAccountKeysParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/accounts/%s/keys", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.BlockHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "block_height", runtime.ParamLocationQuery, *params.BlockHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAccountKeyByIndexRequest generates requests for GetAccountKeyByIndex
+func NewGetAccountKeyByIndexRequest(server string, address Address, index string, params *GetAccountKeyByIndexParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "index", runtime.ParamLocationPath, index)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/accounts/%s/keys/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.BlockHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "block_height", runtime.ParamLocationQuery, *params.BlockHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBlocksByHeightRequest generates requests for GetBlocksByHeight
+func NewGetBlocksByHeightRequest(server string, params *GetBlocksByHeightParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/blocks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Height != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "height", runtime.ParamLocationQuery, *params.Height); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.StartHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_height", runtime.ParamLocationQuery, *params.StartHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.EndHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end_height", runtime.ParamLocationQuery, *params.EndHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Cursor != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Expand != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "expand", runtime.ParamLocationQuery, *params.Expand); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBlocksByIDsRequest generates requests for GetBlocksByIDs
+func NewGetBlocksByIDsRequest(server string, id Ids, params *GetBlocksByIDsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/blocks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Expand != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "expand", runtime.ParamLocationQuery, *params.Expand); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBlockPayloadByIDRequest generates requests for GetBlockPayloadByID
+func NewGetBlockPayloadByIDRequest(server string, id Id, params *GetBlockPayloadByIDParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/blocks/%s/payload", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCollectionByIDRequest generates requests for GetCollectionByID
+func NewGetCollectionByIDRequest(server string, id Id, params *GetCollectionByIDParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Expand != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "expand", runtime.ParamLocationQuery, *params.Expand); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEventsRequest generates requests for GetEvents
+func NewGetEventsRequest(server string, params *GetEventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Type != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.StartHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_height", runtime.ParamLocationQuery, *params.StartHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.EndHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end_height", runtime.ParamLocationQuery, *params.EndHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.BlockIds != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "block_ids", runtime.ParamLocationQuery, *params.BlockIds); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Address != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "address", runtime.ParamLocationQuery, *params.Address); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Contract != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "contract", runtime.ParamLocationQuery, *params.Contract); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.TransactionId != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "transaction_id", runtime.ParamLocationQuery, *params.TransactionId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Cursor != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetExecutionResultByBlockIDRequest generates requests for GetExecutionResultByBlockID
+func NewGetExecutionResultByBlockIDRequest(server string, params *GetExecutionResultByBlockIDParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/execution_results")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.BlockId != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "block_id", runtime.ParamLocationQuery, *params.BlockId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.StartHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_height", runtime.ParamLocationQuery, *params.StartHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.EndHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end_height", runtime.ParamLocationQuery, *params.EndHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Cursor != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetExecutionResultByIDRequest generates requests for GetExecutionResultByID
+func NewGetExecutionResultByIDRequest(server string, id Id, params *GetExecutionResultByIDParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/execution_results/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOpenAPISpecRequest generates requests for GetOpenAPISpec
+func NewGetOpenAPISpecRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/openapi.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExecuteScriptRequest calls the generic ExecuteScript builder with application/json body
+func NewExecuteScriptRequest(server string, params *ExecuteScriptParams, body ExecuteScriptJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewExecuteScriptRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewExecuteScriptRequestWithBody generates requests for ExecuteScript with any type of body
+func NewExecuteScriptRequestWithBody(server string, params *ExecuteScriptParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/scripts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.BlockId != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "block_id", runtime.ParamLocationQuery, *params.BlockId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.BlockHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "block_height", runtime.ParamLocationQuery, *params.BlockHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSendAndSubscribeTransactionStatusesRequest generates requests for SendAndSubscribeTransactionStatuses
+func NewSendAndSubscribeTransactionStatusesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/send_and_subscribe_transaction_statuses")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeEventsRequest generates requests for SubscribeEvents
+func NewSubscribeEventsRequest(server string, params *SubscribeEventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subscribe_events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.StartBlockId != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_block_id", runtime.ParamLocationQuery, *params.StartBlockId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.StartHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_height", runtime.ParamLocationQuery, *params.StartHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.EventTypes != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "event_types", runtime.ParamLocationQuery, *params.EventTypes); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Addresses != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "addresses", runtime.ParamLocationQuery, *params.Addresses); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Contracts != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "contracts", runtime.ParamLocationQuery, *params.Contracts); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeTransactionStatusesRequest generates requests for SubscribeTransactionStatuses
+func NewSubscribeTransactionStatusesRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subscribe_transaction_statuses/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTransactionResultByIDRequest generates requests for GetTransactionResultByID
+func NewGetTransactionResultByIDRequest(server string, id Id, params *GetTransactionResultByIDParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transaction_results/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Expand != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "expand", runtime.ParamLocationQuery, *params.Expand); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTransactionsRequest generates requests for GetTransactions
+func NewGetTransactionsRequest(server string, params *GetTransactionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transactions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.StartHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_height", runtime.ParamLocationQuery, *params.StartHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.EndHeight != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end_height", runtime.ParamLocationQuery, *params.EndHeight); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Cursor != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Expand != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "expand", runtime.ParamLocationQuery, *params.Expand); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateTransactionRequest calls the generic CreateTransaction builder with application/json body
+func NewCreateTransactionRequest(server string, body CreateTransactionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateTransactionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateTransactionRequestWithBody generates requests for CreateTransaction with any type of body
+func NewCreateTransactionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transactions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSimulateTransactionRequest calls the generic SimulateTransaction builder with application/json body
+func NewSimulateTransactionRequest(server string, params *SimulateTransactionParams, body SimulateTransactionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSimulateTransactionRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewSimulateTransactionRequestWithBody generates requests for SimulateTransaction with any type of body
+func NewSimulateTransactionRequestWithBody(server string, params *SimulateTransactionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transactions/simulate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.SkipSignatureVerification != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "skip_signature_verification", runtime.ParamLocationQuery, *params.SkipSignatureVerification); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTransactionByIDRequest generates requests for GetTransactionByID
+func NewGetTransactionByIDRequest(server string, id Id, params *GetTransactionByIDParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/transactions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Expand != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "expand", runtime.ParamLocationQuery, *params.Expand); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Select != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "select", runtime.ParamLocationQuery, *params.Select); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAccount request
+	GetAccountWithResponse(ctx context.Context, address Address, params *GetAccountParams, reqEditors ...RequestEditorFn) (*GetAccountResponse, error)
+
+	// GetAccountBalance request
+	GetAccountBalanceWithResponse(ctx context.Context, address Address, params *GetAccountBalanceParams, reqEditors ...RequestEditorFn) (*GetAccountBalanceResponse, error)
+
+	// GetAccountKeys request
+	GetAccountKeysWithResponse(ctx context.Context, address Address, params *GetAccountKeysParams, reqEditors ...RequestEditorFn) (*GetAccountKeysResponse, error)
+
+	// GetAccountKeyByIndex request
+	GetAccountKeyByIndexWithResponse(ctx context.Context, address Address, index string, params *GetAccountKeyByIndexParams, reqEditors ...RequestEditorFn) (*GetAccountKeyByIndexResponse, error)
+
+	// GetBlocksByHeight request
+	GetBlocksByHeightWithResponse(ctx context.Context, params *GetBlocksByHeightParams, reqEditors ...RequestEditorFn) (*GetBlocksByHeightResponse, error)
+
+	// GetBlocksByIDs request
+	GetBlocksByIDsWithResponse(ctx context.Context, id Ids, params *GetBlocksByIDsParams, reqEditors ...RequestEditorFn) (*GetBlocksByIDsResponse, error)
+
+	// GetBlockPayloadByID request
+	GetBlockPayloadByIDWithResponse(ctx context.Context, id Id, params *GetBlockPayloadByIDParams, reqEditors ...RequestEditorFn) (*GetBlockPayloadByIDResponse, error)
+
+	// GetCollectionByID request
+	GetCollectionByIDWithResponse(ctx context.Context, id Id, params *GetCollectionByIDParams, reqEditors ...RequestEditorFn) (*GetCollectionByIDResponse, error)
+
+	// GetEvents request
+	GetEventsWithResponse(ctx context.Context, params *GetEventsParams, reqEditors ...RequestEditorFn) (*GetEventsResponse, error)
+
+	// GetExecutionResultByBlockID request
+	GetExecutionResultByBlockIDWithResponse(ctx context.Context, params *GetExecutionResultByBlockIDParams, reqEditors ...RequestEditorFn) (*GetExecutionResultByBlockIDResponse, error)
+
+	// GetExecutionResultByID request
+	GetExecutionResultByIDWithResponse(ctx context.Context, id Id, params *GetExecutionResultByIDParams, reqEditors ...RequestEditorFn) (*GetExecutionResultByIDResponse, error)
+
+	// GetOpenAPISpec request
+	GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error)
+
+	// ExecuteScript request with any body
+	ExecuteScriptWithBodyWithResponse(ctx context.Context, params *ExecuteScriptParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExecuteScriptResponse, error)
+
+	ExecuteScriptWithResponse(ctx context.Context, params *ExecuteScriptParams, body ExecuteScriptJSONRequestBody, reqEditors ...RequestEditorFn) (*ExecuteScriptResponse, error)
+
+	// SendAndSubscribeTransactionStatuses request
+	SendAndSubscribeTransactionStatusesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SendAndSubscribeTransactionStatusesResponse, error)
+
+	// SubscribeEvents request
+	SubscribeEventsWithResponse(ctx context.Context, params *SubscribeEventsParams, reqEditors ...RequestEditorFn) (*SubscribeEventsResponse, error)
+
+	// SubscribeTransactionStatuses request
+	SubscribeTransactionStatusesWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*SubscribeTransactionStatusesResponse, error)
+
+	// GetTransactionResultByID request
+	GetTransactionResultByIDWithResponse(ctx context.Context, id Id, params *GetTransactionResultByIDParams, reqEditors ...RequestEditorFn) (*GetTransactionResultByIDResponse, error)
+
+	// GetTransactions request
+	GetTransactionsWithResponse(ctx context.Context, params *GetTransactionsParams, reqEditors ...RequestEditorFn) (*GetTransactionsResponse, error)
+
+	// CreateTransaction request with any body
+	CreateTransactionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTransactionResponse, error)
+
+	CreateTransactionWithResponse(ctx context.Context, body CreateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTransactionResponse, error)
+
+	// SimulateTransaction request with any body
+	SimulateTransactionWithBodyWithResponse(ctx context.Context, params *SimulateTransactionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SimulateTransactionResponse, error)
+
+	SimulateTransactionWithResponse(ctx context.Context, params *SimulateTransactionParams, body SimulateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*SimulateTransactionResponse, error)
+
+	// GetTransactionByID request
+	GetTransactionByIDWithResponse(ctx context.Context, id Id, params *GetTransactionByIDParams, reqEditors ...RequestEditorFn) (*GetTransactionByIDResponse, error)
+}
+
+type GetAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Account
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountBalanceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountBalance
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountBalanceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountBalanceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]AccountPublicKey
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountKeyByIndexResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountPublicKey
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountKeyByIndexResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountKeyByIndexResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBlocksByHeightResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Block
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBlocksByHeightResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBlocksByHeightResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBlocksByIDsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Block
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBlocksByIDsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBlocksByIDsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBlockPayloadByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BlockPayload
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBlockPayloadByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBlockPayloadByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCollectionByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Collection
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCollectionByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCollectionByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]BlockEvents
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+	JSON501      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetExecutionResultByBlockIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ExecutionResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetExecutionResultByBlockIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetExecutionResultByBlockIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetExecutionResultByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ExecutionResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetExecutionResultByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetExecutionResultByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOpenAPISpecResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSON401      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOpenAPISpecResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOpenAPISpecResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExecuteScriptResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]byte
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ExecuteScriptResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExecuteScriptResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SendAndSubscribeTransactionStatusesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SendAndSubscribeTransactionStatusesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SendAndSubscribeTransactionStatusesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeTransactionStatusesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeTransactionStatusesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeTransactionStatusesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionResultByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionResultByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionResultByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Transaction
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Transaction
+	JSON400      *Error
+	JSON401      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SimulateTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SimulatedTransactionResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON429      *Error
+	JSON500      *Error
+	JSON501      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SimulateTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SimulateTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Transaction
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON429      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAccountWithResponse request returning *GetAccountResponse
+func (c *ClientWithResponses) GetAccountWithResponse(ctx context.Context, address Address, params *GetAccountParams, reqEditors ...RequestEditorFn) (*GetAccountResponse, error) {
+	rsp, err := c.GetAccount(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountResponse(rsp)
+}
+
+// GetAccountBalanceWithResponse request returning *GetAccountBalanceResponse
+func (c *ClientWithResponses) GetAccountBalanceWithResponse(ctx context.Context, address Address, params *GetAccountBalanceParams, reqEditors ...RequestEditorFn) (*GetAccountBalanceResponse, error) {
+	rsp, err := c.GetAccountBalance(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountBalanceResponse(rsp)
+}
+
+// GetAccountKeysWithResponse request returning *GetAccountKeysResponse
+func (c *ClientWithResponses) GetAccountKeysWithResponse(ctx context.Context, address Address, params *GetAccountKeysParams, reqEditors ...RequestEditorFn) (*GetAccountKeysResponse, error) {
+	rsp, err := c.GetAccountKeys(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountKeysResponse(rsp)
+}
+
+// GetAccountKeyByIndexWithResponse request returning *GetAccountKeyByIndexResponse
+func (c *ClientWithResponses) GetAccountKeyByIndexWithResponse(ctx context.Context, address Address, index string, params *GetAccountKeyByIndexParams, reqEditors ...RequestEditorFn) (*GetAccountKeyByIndexResponse, error) {
+	rsp, err := c.GetAccountKeyByIndex(ctx, address, index, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountKeyByIndexResponse(rsp)
+}
+
+// GetBlocksByHeightWithResponse request returning *GetBlocksByHeightResponse
+func (c *ClientWithResponses) GetBlocksByHeightWithResponse(ctx context.Context, params *GetBlocksByHeightParams, reqEditors ...RequestEditorFn) (*GetBlocksByHeightResponse, error) {
+	rsp, err := c.GetBlocksByHeight(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBlocksByHeightResponse(rsp)
+}
+
+// GetBlocksByIDsWithResponse request returning *GetBlocksByIDsResponse
+func (c *ClientWithResponses) GetBlocksByIDsWithResponse(ctx context.Context, id Ids, params *GetBlocksByIDsParams, reqEditors ...RequestEditorFn) (*GetBlocksByIDsResponse, error) {
+	rsp, err := c.GetBlocksByIDs(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBlocksByIDsResponse(rsp)
+}
+
+// GetBlockPayloadByIDWithResponse request returning *GetBlockPayloadByIDResponse
+func (c *ClientWithResponses) GetBlockPayloadByIDWithResponse(ctx context.Context, id Id, params *GetBlockPayloadByIDParams, reqEditors ...RequestEditorFn) (*GetBlockPayloadByIDResponse, error) {
+	rsp, err := c.GetBlockPayloadByID(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBlockPayloadByIDResponse(rsp)
+}
+
+// GetCollectionByIDWithResponse request returning *GetCollectionByIDResponse
+func (c *ClientWithResponses) GetCollectionByIDWithResponse(ctx context.Context, id Id, params *GetCollectionByIDParams, reqEditors ...RequestEditorFn) (*GetCollectionByIDResponse, error) {
+	rsp, err := c.GetCollectionByID(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCollectionByIDResponse(rsp)
+}
+
+// GetEventsWithResponse request returning *GetEventsResponse
+func (c *ClientWithResponses) GetEventsWithResponse(ctx context.Context, params *GetEventsParams, reqEditors ...RequestEditorFn) (*GetEventsResponse, error) {
+	rsp, err := c.GetEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEventsResponse(rsp)
+}
+
+// GetExecutionResultByBlockIDWithResponse request returning *GetExecutionResultByBlockIDResponse
+func (c *ClientWithResponses) GetExecutionResultByBlockIDWithResponse(ctx context.Context, params *GetExecutionResultByBlockIDParams, reqEditors ...RequestEditorFn) (*GetExecutionResultByBlockIDResponse, error) {
+	rsp, err := c.GetExecutionResultByBlockID(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetExecutionResultByBlockIDResponse(rsp)
+}
+
+// GetExecutionResultByIDWithResponse request returning *GetExecutionResultByIDResponse
+func (c *ClientWithResponses) GetExecutionResultByIDWithResponse(ctx context.Context, id Id, params *GetExecutionResultByIDParams, reqEditors ...RequestEditorFn) (*GetExecutionResultByIDResponse, error) {
+	rsp, err := c.GetExecutionResultByID(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetExecutionResultByIDResponse(rsp)
+}
+
+// GetOpenAPISpecWithResponse request returning *GetOpenAPISpecResponse
+func (c *ClientWithResponses) GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error) {
+	rsp, err := c.GetOpenAPISpec(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOpenAPISpecResponse(rsp)
+}
+
+// ExecuteScriptWithBodyWithResponse request with arbitrary body returning *ExecuteScriptResponse
+func (c *ClientWithResponses) ExecuteScriptWithBodyWithResponse(ctx context.Context, params *ExecuteScriptParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExecuteScriptResponse, error) {
+	rsp, err := c.ExecuteScriptWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExecuteScriptResponse(rsp)
+}
+
+func (c *ClientWithResponses) ExecuteScriptWithResponse(ctx context.Context, params *ExecuteScriptParams, body ExecuteScriptJSONRequestBody, reqEditors ...RequestEditorFn) (*ExecuteScriptResponse, error) {
+	rsp, err := c.ExecuteScript(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExecuteScriptResponse(rsp)
+}
+
+// SendAndSubscribeTransactionStatusesWithResponse request returning *SendAndSubscribeTransactionStatusesResponse
+func (c *ClientWithResponses) SendAndSubscribeTransactionStatusesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SendAndSubscribeTransactionStatusesResponse, error) {
+	rsp, err := c.SendAndSubscribeTransactionStatuses(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSendAndSubscribeTransactionStatusesResponse(rsp)
+}
+
+// SubscribeEventsWithResponse request returning *SubscribeEventsResponse
+func (c *ClientWithResponses) SubscribeEventsWithResponse(ctx context.Context, params *SubscribeEventsParams, reqEditors ...RequestEditorFn) (*SubscribeEventsResponse, error) {
+	rsp, err := c.SubscribeEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeEventsResponse(rsp)
+}
+
+// SubscribeTransactionStatusesWithResponse request returning *SubscribeTransactionStatusesResponse
+func (c *ClientWithResponses) SubscribeTransactionStatusesWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*SubscribeTransactionStatusesResponse, error) {
+	rsp, err := c.SubscribeTransactionStatuses(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeTransactionStatusesResponse(rsp)
+}
+
+// GetTransactionResultByIDWithResponse request returning *GetTransactionResultByIDResponse
+func (c *ClientWithResponses) GetTransactionResultByIDWithResponse(ctx context.Context, id Id, params *GetTransactionResultByIDParams, reqEditors ...RequestEditorFn) (*GetTransactionResultByIDResponse, error) {
+	rsp, err := c.GetTransactionResultByID(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionResultByIDResponse(rsp)
+}
+
+// GetTransactionsWithResponse request returning *GetTransactionsResponse
+func (c *ClientWithResponses) GetTransactionsWithResponse(ctx context.Context, params *GetTransactionsParams, reqEditors ...RequestEditorFn) (*GetTransactionsResponse, error) {
+	rsp, err := c.GetTransactions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionsResponse(rsp)
+}
+
+// CreateTransactionWithBodyWithResponse request with arbitrary body returning *CreateTransactionResponse
+func (c *ClientWithResponses) CreateTransactionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTransactionResponse, error) {
+	rsp, err := c.CreateTransactionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTransactionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateTransactionWithResponse(ctx context.Context, body CreateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTransactionResponse, error) {
+	rsp, err := c.CreateTransaction(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTransactionResponse(rsp)
+}
+
+// SimulateTransactionWithBodyWithResponse request with arbitrary body returning *SimulateTransactionResponse
+func (c *ClientWithResponses) SimulateTransactionWithBodyWithResponse(ctx context.Context, params *SimulateTransactionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SimulateTransactionResponse, error) {
+	rsp, err := c.SimulateTransactionWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSimulateTransactionResponse(rsp)
+}
+
+func (c *ClientWithResponses) SimulateTransactionWithResponse(ctx context.Context, params *SimulateTransactionParams, body SimulateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*SimulateTransactionResponse, error) {
+	rsp, err := c.SimulateTransaction(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSimulateTransactionResponse(rsp)
+}
+
+// GetTransactionByIDWithResponse request returning *GetTransactionByIDResponse
+func (c *ClientWithResponses) GetTransactionByIDWithResponse(ctx context.Context, id Id, params *GetTransactionByIDParams, reqEditors ...RequestEditorFn) (*GetTransactionByIDResponse, error) {
+	rsp, err := c.GetTransactionByID(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionByIDResponse(rsp)
+}
+
+// ParseGetAccountResponse parses an HTTP response from a GetAccountWithResponse call
+func ParseGetAccountResponse(rsp *http.Response) (*GetAccountResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Account
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountBalanceResponse parses an HTTP response from a GetAccountBalanceWithResponse call
+func ParseGetAccountBalanceResponse(rsp *http.Response) (*GetAccountBalanceResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountBalanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountBalance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountKeysResponse parses an HTTP response from a GetAccountKeysWithResponse call
+func ParseGetAccountKeysResponse(rsp *http.Response) (*GetAccountKeysResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AccountPublicKey
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountKeyByIndexResponse parses an HTTP response from a GetAccountKeyByIndexWithResponse call
+func ParseGetAccountKeyByIndexResponse(rsp *http.Response) (*GetAccountKeyByIndexResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountKeyByIndexResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountPublicKey
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBlocksByHeightResponse parses an HTTP response from a GetBlocksByHeightWithResponse call
+func ParseGetBlocksByHeightResponse(rsp *http.Response) (*GetBlocksByHeightResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBlocksByHeightResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Block
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBlocksByIDsResponse parses an HTTP response from a GetBlocksByIDsWithResponse call
+func ParseGetBlocksByIDsResponse(rsp *http.Response) (*GetBlocksByIDsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBlocksByIDsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Block
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBlockPayloadByIDResponse parses an HTTP response from a GetBlockPayloadByIDWithResponse call
+func ParseGetBlockPayloadByIDResponse(rsp *http.Response) (*GetBlockPayloadByIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBlockPayloadByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BlockPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCollectionByIDResponse parses an HTTP response from a GetCollectionByIDWithResponse call
+func ParseGetCollectionByIDResponse(rsp *http.Response) (*GetCollectionByIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCollectionByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Collection
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEventsResponse parses an HTTP response from a GetEventsWithResponse call
+func ParseGetEventsResponse(rsp *http.Response) (*GetEventsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []BlockEvents
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetExecutionResultByBlockIDResponse parses an HTTP response from a GetExecutionResultByBlockIDWithResponse call
+func ParseGetExecutionResultByBlockIDResponse(rsp *http.Response) (*GetExecutionResultByBlockIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetExecutionResultByBlockIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ExecutionResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetExecutionResultByIDResponse parses an HTTP response from a GetExecutionResultByIDWithResponse call
+func ParseGetExecutionResultByIDResponse(rsp *http.Response) (*GetExecutionResultByIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetExecutionResultByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ExecutionResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOpenAPISpecResponse parses an HTTP response from a GetOpenAPISpecWithResponse call
+func ParseGetOpenAPISpecResponse(rsp *http.Response) (*GetOpenAPISpecResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOpenAPISpecResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExecuteScriptResponse parses an HTTP response from a ExecuteScriptWithResponse call
+func ParseExecuteScriptResponse(rsp *http.Response) (*ExecuteScriptResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExecuteScriptResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []byte
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSendAndSubscribeTransactionStatusesResponse parses an HTTP response from a SendAndSubscribeTransactionStatusesWithResponse call
+func ParseSendAndSubscribeTransactionStatusesResponse(rsp *http.Response) (*SendAndSubscribeTransactionStatusesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SendAndSubscribeTransactionStatusesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeEventsResponse parses an HTTP response from a SubscribeEventsWithResponse call
+func ParseSubscribeEventsResponse(rsp *http.Response) (*SubscribeEventsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeTransactionStatusesResponse parses an HTTP response from a SubscribeTransactionStatusesWithResponse call
+func ParseSubscribeTransactionStatusesResponse(rsp *http.Response) (*SubscribeTransactionStatusesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeTransactionStatusesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTransactionResultByIDResponse parses an HTTP response from a GetTransactionResultByIDWithResponse call
+func ParseGetTransactionResultByIDResponse(rsp *http.Response) (*GetTransactionResultByIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTransactionResultByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransactionResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTransactionsResponse parses an HTTP response from a GetTransactionsWithResponse call
+func ParseGetTransactionsResponse(rsp *http.Response) (*GetTransactionsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateTransactionResponse parses an HTTP response from a CreateTransactionWithResponse call
+func ParseCreateTransactionResponse(rsp *http.Response) (*CreateTransactionResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateTransactionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSimulateTransactionResponse parses an HTTP response from a SimulateTransactionWithResponse call
+func ParseSimulateTransactionResponse(rsp *http.Response) (*SimulateTransactionResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SimulateTransactionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SimulatedTransactionResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 501:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON501 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTransactionByIDResponse parses an HTTP response from a GetTransactionByIDWithResponse call
+func ParseGetTransactionByIDResponse(rsp *http.Response) (*GetTransactionByIDResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTransactionByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
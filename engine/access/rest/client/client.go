@@ -0,0 +1,5 @@
+package client
+
+// The client is generated from the OpenAPI spec of the REST API in the openapi package, and is
+// regenerated with `make generate-openapi` whenever the spec changes.
+//go:generate oapi-codegen --config=oapi-codegen.yaml ../openapi/access.yaml
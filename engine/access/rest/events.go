@@ -7,13 +7,18 @@ import (
 	"github.com/onflow/flow-go/engine/access/rest/request"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 )
 
 const blockQueryParam = "block_ids"
 const eventTypeQuery = "type"
 
-// GetEvents for the provided block range or list of block IDs filtered by type.
-func GetEvents(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+// GetEvents for the provided block range or list of block IDs filtered by type. Block ranges
+// are paginated, and only exceed the maximum height range if the client requests pagination.
+// Block ranges can be further filtered by emitting account, contract or transaction, in which
+// case only blocks with matching events are returned.
+func GetEvents(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
 	req, err := r.GetEventsRequest()
 	if err != nil {
 		return nil, NewBadRequestError(err)
@@ -45,12 +50,28 @@ func GetEvents(r *request.Request, backend access.API, _ models.LinkGenerator) (
 		}
 	}
 
-	// if request provided block height range then return events for that range
-	events, err := backend.GetEventsForHeightRange(r.Context(), req.Type, req.StartHeight, req.EndHeight)
+	// if request provided block height range then return events for a page of that range
+	pageStart, pageEnd, next := heightPage(req.Pagination, req.StartHeight, req.EndHeight)
+	var events []flow.BlockEvents
+	if req.HasFilters() {
+		events, err = backend.GetEventsByQuery(r.Context(), pageStart, pageEnd, storage.EventQuery{
+			Type:          flow.EventType(req.Type),
+			Address:       req.Address,
+			Contract:      req.Contract,
+			TransactionID: req.TransactionID,
+		})
+	} else {
+		events, err = backend.GetEventsForHeightRange(r.Context(), req.Type, pageStart, pageEnd)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nextLink, err := nextPageLink(r, link.EventsPageLink, next, req.EndHeight)
 	if err != nil {
 		return nil, err
 	}
 
 	blocksEvents.Build(events)
-	return blocksEvents, nil
+	return models.Page{Items: blocksEvents, NextLink: nextLink}, nil
 }
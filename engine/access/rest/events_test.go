@@ -8,10 +8,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/onflow/flow-go/engine/access/rest/request"
 	"github.com/onflow/flow-go/engine/access/rest/util"
 
 	"github.com/onflow/flow-go/access/mock"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
 
 	mocks "github.com/stretchr/testify/mock"
@@ -88,6 +90,18 @@ func TestGetEvents(t *testing.T) {
 			expectedStatus:   http.StatusBadRequest,
 			expectedResponse: `{"code":400,"message":"height range 5000 exceeds maximum allowed of 250"}`,
 		},
+		{
+			description:      "Get invalid - too big page size",
+			request:          getEventPageReq(t, "A.179b6b1cb6755e31.Foo.Bar", "0", "5000", "", "251"),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"code":400,"message":"limit must be between 1 and 250"}`,
+		},
+		{
+			description:      "Get invalid - cursor with block IDs",
+			request:          getEventPageReq(t, "A.179b6b1cb6755e31.Foo.Bar", "", "", request.Cursor{Height: 1}.String(), "", allBlockIDs...),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"code":400,"message":"cursor can only be provided with start and end height range"}`,
+		},
 		{
 			description:      "Get invalid - can not provide all params",
 			request:          getEventReq(t, "A.179b6b1cb6755e31.Foo.Bar", "100", "120", []string{"10e782612a014b5c9c7d17994d7e67157064f3dd42fa92cd080bfb0fe22c3f71"}),
@@ -116,10 +130,101 @@ func TestGetEvents(t *testing.T) {
 
 }
 
+func TestGetEventsPagination(t *testing.T) {
+	backend := &mock.API{}
+	events := generateEventsMocks(backend, 5)
+	eventType := "A.179b6b1cb6755e31.Foo.Bar"
+
+	backend.Mock.
+		On("GetEventsForHeightRange", mocks.Anything, eventType, uint64(0), uint64(1)).
+		Return(events[:2], nil)
+	backend.Mock.
+		On("GetEventsForHeightRange", mocks.Anything, eventType, uint64(2), uint64(3)).
+		Return(events[2:4], nil)
+	backend.Mock.
+		On("GetEventsForHeightRange", mocks.Anything, eventType, uint64(4), uint64(4)).
+		Return(events[4:], nil)
+
+	// the end height is resolved in the next links, so that all pages cover the same range
+	req := getEventPageReq(t, eventType, "0", "sealed", "", "2")
+
+	for _, page := range [][]flow.BlockEvents{events[:2], events[2:4], events[4:]} {
+		rr, err := executeRequest(req, backend)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, testBlockEventResponse(page), rr.Body.String())
+
+		next := rr.Header().Get("Link")
+		if page[len(page)-1].BlockHeight == 4 {
+			require.Empty(t, next)
+			break
+		}
+
+		cursor := request.Cursor{Height: page[len(page)-1].BlockHeight + 1}
+		expected := getEventPageReq(t, eventType, "0", "4", cursor.String(), "2")
+		require.Equal(t, fmt.Sprintf(`<%s>; rel="next"`, expected.URL.String()), next)
+
+		req = expected
+	}
+}
+
+func TestGetEventsWithFilters(t *testing.T) {
+	backend := &mock.API{}
+	events := generateEventsMocks(backend, 5)
+	address := unittest.RandomAddressFixture()
+
+	query := storage.EventQuery{
+		Type:     "A.179b6b1cb6755e31.Foo.Bar",
+		Address:  address,
+		Contract: "A.179b6b1cb6755e31.Foo",
+	}
+	backend.Mock.
+		On("GetEventsByQuery", mocks.Anything, uint64(0), uint64(4), query).
+		Return(events[1:3], nil)
+
+	req := getEventReq(t, string(query.Type), "0", "4", nil)
+	q := req.URL.Query()
+	q.Add("address", address.String())
+	q.Add("contract", query.Contract)
+	req.URL.RawQuery = q.Encode()
+
+	rr, err := executeRequest(req, backend)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, testBlockEventResponse(events[1:3]), rr.Body.String())
+
+	// nodes without an event index can not answer filtered queries
+	backend.Mock.
+		On("GetEventsByQuery", mocks.Anything, uint64(0), uint64(2), mocks.Anything).
+		Return(nil, status.Error(codes.Unimplemented, "events are not indexed"))
+
+	req = getEventReq(t, "", "0", "2", nil)
+	q = req.URL.Query()
+	q.Del(eventTypeQuery)
+	q.Add("address", address.String())
+	req.URL.RawQuery = q.Encode()
+
+	rr, err = executeRequest(req, backend)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotImplemented, rr.Code)
+}
+
 func getEventReq(t *testing.T, eventType string, start string, end string, blockIDs []string) *http.Request {
+	return getEventPageReq(t, eventType, start, end, "", "", blockIDs...)
+}
+
+func getEventPageReq(t *testing.T, eventType string, start string, end string, cursor string, limit string, blockIDs ...string) *http.Request {
 	u, _ := url.Parse("/v1/events")
 	q := u.Query()
 
+	if cursor != "" {
+		q.Add("cursor", cursor)
+	}
+
+	if limit != "" {
+		q.Add("limit", limit)
+	}
+
 	if len(blockIDs) > 0 {
 		q.Add(blockQueryParam, strings.Join(blockIDs, ","))
 	}
@@ -6,32 +6,78 @@ import (
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/models"
 	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/model/flow"
 )
 
-// GetExecutionResultsByBlockIDs gets Execution Result payload by block IDs.
+// GetExecutionResultsByBlockIDs gets Execution Result payload by block IDs or block height range.
+// Height ranges are paginated.
 func GetExecutionResultsByBlockIDs(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
 	req, err := r.GetExecutionResultByBlockIDsRequest()
 	if err != nil {
 		return nil, NewBadRequestError(err)
 	}
 
-	// for each block ID we retrieve execution result
-	results := make([]models.ExecutionResult, len(req.BlockIDs))
-	for i, id := range req.BlockIDs {
-		res, err := backend.GetExecutionResultForBlockID(r.Context(), id)
+	if req.HasBlockIDs() {
+		// for each block ID we retrieve execution result
+		results := make([]models.ExecutionResult, len(req.BlockIDs))
+		for i, id := range req.BlockIDs {
+			response, err := getExecutionResult(r, backend, link, id)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = response
+		}
+
+		return results, nil
+	}
+
+	// support providing end height as "sealed" or "final"
+	if req.EndHeight == request.FinalHeight || req.EndHeight == request.SealedHeight {
+		latest, err := backend.GetLatestBlockHeader(r.Context(), req.EndHeight == request.SealedHeight)
 		if err != nil {
 			return nil, err
 		}
 
-		var response models.ExecutionResult
-		err = response.Build(res, link)
+		req.EndHeight = latest.Height
+		if req.StartHeight > req.EndHeight {
+			return nil, NewBadRequestError(fmt.Errorf("start height must be less than or equal to end height"))
+		}
+	}
+
+	// for each height of the page we retrieve the execution result of the block
+	pageStart, pageEnd, next := heightPage(req.Pagination, req.StartHeight, req.EndHeight)
+	results := make([]models.ExecutionResult, 0, pageEnd-pageStart+1)
+	for height := pageStart; height <= pageEnd; height++ {
+		header, err := backend.GetBlockHeaderByHeight(r.Context(), height)
+		if err != nil {
+			return nil, NewNotFoundError(fmt.Sprintf("error looking up block at height %d", height), err)
+		}
+
+		response, err := getExecutionResult(r, backend, link, header.ID())
 		if err != nil {
 			return nil, err
 		}
-		results[i] = response
+		results = append(results, response)
+	}
+
+	nextLink, err := nextPageLink(r, link.ExecutionResultsPageLink, next, req.EndHeight)
+	if err != nil {
+		return nil, err
 	}
 
-	return results, nil
+	return models.Page{Items: results, NextLink: nextLink}, nil
+}
+
+func getExecutionResult(r *request.Request, backend access.API, link models.LinkGenerator, blockID flow.Identifier) (models.ExecutionResult, error) {
+	var response models.ExecutionResult
+
+	res, err := backend.GetExecutionResultForBlockID(r.Context(), blockID)
+	if err != nil {
+		return response, err
+	}
+
+	err = response.Build(res, link)
+	return response, err
 }
 
 // GetExecutionResultByID gets execution result by the ID.
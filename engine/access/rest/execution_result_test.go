@@ -11,8 +11,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/request"
 	"github.com/onflow/flow-go/engine/access/rest/util"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -99,6 +101,51 @@ func TestGetResultBlockID(t *testing.T) {
 	})
 }
 
+func TestGetResultHeightRange(t *testing.T) {
+	backend := &mock.API{}
+
+	headers := make([]*flow.Header, 3)
+	results := make([]string, 3)
+	for i := range headers {
+		header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(uint64(10 + i)))
+		headers[i] = &header
+		result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(headers[i].ID()))
+		results[i] = executionResultExpectedStr(result)
+
+		backend.Mock.
+			On("GetBlockHeaderByHeight", mocks.Anything, headers[i].Height).
+			Return(headers[i], nil)
+		backend.Mock.
+			On("GetExecutionResultForBlockID", mocks.Anything, headers[i].ID()).
+			Return(result, nil)
+	}
+	backend.Mock.
+		On("GetLatestBlockHeader", mocks.Anything, true).
+		Return(headers[2], nil)
+
+	query := url.Values{}
+	query.Set("start_height", "10")
+	query.Set("end_height", "sealed")
+	query.Set("limit", "2")
+	req, _ := http.NewRequest("GET", "/v1/execution_results?"+query.Encode(), nil)
+
+	rr, err := executeRequest(req, backend)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, fmt.Sprintf(`[%s]`, strings.Join(results[:2], ",")), rr.Body.String())
+
+	query.Set("cursor", request.Cursor{Height: 12}.String())
+	query.Set("end_height", "12")
+	require.Equal(t, fmt.Sprintf(`</v1/execution_results?%s>; rel="next"`, query.Encode()), rr.Header().Get("Link"))
+
+	req, _ = http.NewRequest("GET", "/v1/execution_results?"+query.Encode(), nil)
+	rr, err = executeRequest(req, backend)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, fmt.Sprintf(`[%s]`, results[2]), rr.Body.String())
+	require.Empty(t, rr.Header().Get("Link"))
+}
+
 func executionResultExpectedStr(result *flow.ExecutionResult) string {
 	chunks := make([]string, len(result.Chunks))
 	for i, chunk := range result.Chunks {
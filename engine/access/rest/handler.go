@@ -80,6 +80,14 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// paginated lists return the link to the next page in the Link header
+	if page, ok := response.(models.Page); ok {
+		if page.NextLink != "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, page.NextLink))
+		}
+		response = page.Items
+	}
+
 	// apply the select filter if any select fields have been specified
 	response, err = util.SelectFilter(response, decoratedRequest.Selects())
 	if err != nil {
@@ -124,6 +132,11 @@ func (h *Handler) errorHandler(w http.ResponseWriter, err error, errorLogger zer
 			h.errorResponse(w, http.StatusBadRequest, msg, errorLogger)
 			return
 		}
+		if se.Code() == codes.Unimplemented {
+			msg := fmt.Sprintf("Not supported by this node: %s", se.Message())
+			h.errorResponse(w, http.StatusNotImplemented, msg, errorLogger)
+			return
+		}
 	}
 
 	// stop going further - catch all error
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/access/quota"
+)
+
+// Quota creates a middleware which checks each request against the quota of its client, and rejects requests
+// exceeding the quota with a too many requests error. Clients are identified by the API key in the request
+// header, or by their IP address. The methods of the quotas are the names of the routes.
+func Quota(logger zerolog.Logger, limiter *quota.Limiter) mux.MiddlewareFunc {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var method string
+			if route := mux.CurrentRoute(req); route != nil {
+				method = route.GetName()
+			}
+
+			err := limiter.Allow(req.Header.Get(quota.APIKeyHeader), clientIP(req), method)
+			if err != nil {
+				errLog := logger.With().Str("request_url", req.URL.String()).Logger()
+				switch {
+				case errors.Is(err, quota.ErrQuotaExceeded):
+					writeError(w, http.StatusTooManyRequests, err.Error(), errLog)
+				case errors.Is(err, quota.ErrUnknownKey), errors.Is(err, quota.ErrKeyRequired):
+					writeError(w, http.StatusUnauthorized, err.Error(), errLog)
+				default:
+					errLog.Error().Err(err).Msg("could not check quota")
+					writeError(w, http.StatusInternalServerError, "internal server error", errLog)
+				}
+				return
+			}
+
+			inner.ServeHTTP(w, req)
+		})
+	}
+}
+
+// clientIP returns the IP address of the client of the request.
+func clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/quota"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const testKeyStore = `{
+	"anonymous": {"rate": 0.001, "burst": 1},
+	"keys": [{"name": "partner", "key": "secret", "rate": 0.001, "burst": 1, "methods": {"getItem": {"rate": 0.001, "burst": 2}}}]
+}`
+
+// TestQuota tests that requests exceeding the quota of their client are rejected.
+func TestQuota(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		path := filepath.Join(dir, "keys.json")
+		require.NoError(t, os.WriteFile(path, []byte(testKeyStore), 0600))
+
+		limiter, err := quota.NewLimiter(zerolog.Nop(), metrics.NewNoopCollector(), path)
+		require.NoError(t, err)
+
+		r := mux.NewRouter()
+		r.Handle("/items/{id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).Name("getItem")
+		r.Use(Quota(zerolog.Nop(), limiter))
+
+		execute := func(apiKey string, remoteAddr string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			req.RemoteAddr = remoteAddr
+			if apiKey != "" {
+				req.Header.Set("X-API-Key", apiKey)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			return rr
+		}
+
+		t.Run("anonymous", func(t *testing.T) {
+			require.Equal(t, http.StatusOK, execute("", "10.0.0.1:1000").Code)
+
+			rr := execute("", "10.0.0.1:1001")
+			require.Equal(t, http.StatusTooManyRequests, rr.Code)
+			require.JSONEq(t, `{"code":429,"message":"quota exceeded for getItem, please retry later"}`, rr.Body.String())
+
+			require.Equal(t, http.StatusOK, execute("", "10.0.0.2:1000").Code)
+		})
+
+		t.Run("api key", func(t *testing.T) {
+			require.Equal(t, http.StatusOK, execute("secret", "10.0.0.1:1000").Code)
+			require.Equal(t, http.StatusOK, execute("secret", "10.0.0.3:1000").Code)
+			require.Equal(t, http.StatusTooManyRequests, execute("secret", "10.0.0.4:1000").Code)
+		})
+
+		t.Run("unknown api key", func(t *testing.T) {
+			rr := execute("unknown", "10.0.0.5:1000")
+			require.Equal(t, http.StatusUnauthorized, rr.Code)
+			require.JSONEq(t, `{"code":401,"message":"unknown API key"}`, rr.Body.String())
+		})
+	})
+}
@@ -0,0 +1,334 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/access/rest/models"
+)
+
+// SpecValidation creates a middleware which validates each request against the OpenAPI spec of the API,
+// and rejects requests which do not match the spec with a bad request error before they are handled.
+//
+// If validateResponses is set, the responses are also validated against the spec and responses which do
+// not match it are replaced with an internal server error, so that any drift of the handlers from the
+// spec is detected. Otherwise, the responses are sent as is. Responses filtered with the select query
+// param only contain part of the fields and are never validated, neither are websocket subscriptions.
+func SpecValidation(logger zerolog.Logger, spec *openapi3.T, maxBodySize int64, validateResponses bool) (mux.MiddlewareFunc, error) {
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("could not create router for OpenAPI spec: %w", err)
+	}
+
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			errLog := logger.With().Str("request_url", req.URL.String()).Logger()
+
+			route, pathParams, err := router.FindRoute(req)
+			if err != nil {
+				// every route of the server must be specified
+				errLog.Error().Err(err).Msg("route is missing in the OpenAPI spec")
+				if validateResponses {
+					writeError(w, http.StatusInternalServerError, "internal server error", errLog)
+					return
+				}
+				inner.ServeHTTP(w, req)
+				return
+			}
+
+			// limit the body size, as it is read entirely for validation. The body is kept to build the
+			// error messages, and restored for the handler.
+			var body []byte
+			if req.Body != nil && req.Body != http.NoBody {
+				body, err = io.ReadAll(http.MaxBytesReader(w, req.Body, maxBodySize))
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err.Error(), errLog)
+					return
+				}
+				req.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			// the API only accepts JSON bodies, which clients are not required to declare
+			if req.ContentLength != 0 && req.Header.Get("Content-Type") == "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					// API keys are checked by the quota middleware
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+			err = openapi3filter.ValidateRequest(req.Context(), input)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, requestErrorMessage(err, body), errLog)
+				return
+			}
+
+			_, selected := req.URL.Query()[selectQueryParam]
+			if !validateResponses || selected || websocket.IsWebSocketUpgrade(req) {
+				inner.ServeHTTP(w, req)
+				return
+			}
+
+			buffered := newBufferedResponseWriter()
+			inner.ServeHTTP(buffered, req)
+			if buffered.statusCode == 0 {
+				buffered.statusCode = http.StatusOK
+			}
+
+			err = validateResponse(req, input, buffered)
+			if err != nil {
+				errLog.Error().Err(err).Int("response_code", buffered.statusCode).Msg("response does not match the OpenAPI spec")
+				writeError(w, http.StatusInternalServerError, "internal server error", errLog)
+				return
+			}
+
+			buffered.flush(w, errLog)
+		})
+	}, nil
+}
+
+func validateResponse(req *http.Request, input *openapi3filter.RequestValidationInput, response *bufferedResponseWriter) error {
+	return openapi3filter.ValidateResponse(req.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 response.statusCode,
+		Header:                 response.header,
+		Body:                   io.NopCloser(bytes.NewReader(response.body.Bytes())),
+		Options: &openapi3filter.Options{
+			// every status code returned by the handlers must be specified
+			IncludeResponseStatus: true,
+		},
+	})
+}
+
+// requestErrorMessage returns the message returned to the client for a request which does not match
+// the spec. Errors the handlers report as well are mapped to the messages of the handlers, so that
+// clients get the same errors with and without validation. Other schema errors are reduced to the
+// reason and location of the mismatch, since they otherwise contain the whole schema and value.
+func requestErrorMessage(err error, body []byte) string {
+	reason := err.Error()
+
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) && requestErr.Err != nil {
+		reason = requestErr.Err.Error()
+	}
+
+	var parseErr *openapi3filter.ParseError
+	if errors.As(err, &parseErr) {
+		if requestErr != nil && requestErr.Parameter != nil {
+			return fmt.Sprintf("invalid value for %s", requestErr.Parameter.Name)
+		}
+		reason = fmt.Sprintf("invalid value %v", parseErr.Value)
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		pointer := schemaErr.JSONPointer()
+		if requestErr != nil && requestErr.RequestBody != nil {
+			if message, ok := bodyErrorMessage(schemaErr, pointer, body); ok {
+				return message
+			}
+		}
+
+		reason = schemaErr.Reason
+		if len(pointer) > 0 {
+			reason = fmt.Sprintf("%s: %s", strings.Join(pointer, "."), reason)
+		}
+	}
+
+	if requestErr != nil {
+		if requestErr.Parameter != nil {
+			return fmt.Sprintf("invalid %s parameter %s: %s", requestErr.Parameter.In, requestErr.Parameter.Name, reason)
+		}
+		if requestErr.RequestBody != nil {
+			if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+				return "request body must not be empty"
+			}
+			if schemaErr == nil {
+				if message, ok := decodeErrorMessage(body); ok {
+					return message
+				}
+			}
+			return fmt.Sprintf("invalid request body: %s", reason)
+		}
+	}
+
+	var routeErr *routers.RouteError
+	if errors.As(err, &routeErr) {
+		return routeErr.Reason
+	}
+
+	return reason
+}
+
+// bodyErrorMessage returns the message the handlers return when decoding a body with the schema error,
+// if the error is also detected when decoding the body.
+func bodyErrorMessage(schemaErr *openapi3.SchemaError, pointer []string, body []byte) (string, bool) {
+	if len(pointer) == 0 && schemaErr.Value == nil {
+		return "request body must not be empty", true
+	}
+
+	if schemaErr.SchemaField != "type" {
+		return "", false
+	}
+
+	offset, ok := valueOffset(json.NewDecoder(bytes.NewReader(body)), pointer)
+	if !ok {
+		return "", false
+	}
+
+	// decoding errors name the path of the field, without the indexes of arrays
+	var fields []string
+	for _, name := range pointer {
+		if _, err := strconv.Atoi(name); err != nil {
+			fields = append(fields, name)
+		}
+	}
+
+	return fmt.Sprintf("request body contains an invalid value for the %q field (at position %d)", strings.Join(fields, "."), offset), true
+}
+
+// decodeErrorMessage returns the message the handlers return for a body which is not valid JSON.
+func decodeErrorMessage(body []byte) (string, bool) {
+	var value interface{}
+	err := json.NewDecoder(bytes.NewReader(body)).Decode(&value)
+
+	var syntaxError *json.SyntaxError
+	switch {
+	case errors.As(err, &syntaxError):
+		return fmt.Sprintf("request body contains badly-formed JSON (at position %d)", syntaxError.Offset), true
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "request body contains badly-formed JSON", true
+	default:
+		return "", false
+	}
+}
+
+// valueOffset returns the offset at which decoding the value at the given JSON pointer fails if it has
+// an unexpected type: the end of the value for literals, or the start of the value for objects and arrays.
+func valueOffset(dec *json.Decoder, pointer []string) (int64, bool) {
+	token, err := dec.Token()
+	if err != nil {
+		return 0, false
+	}
+	if len(pointer) == 0 {
+		return dec.InputOffset(), true
+	}
+
+	switch token {
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return 0, false
+			}
+			if key == pointer[0] {
+				return valueOffset(dec, pointer[1:])
+			}
+			if !skipValue(dec) {
+				return 0, false
+			}
+		}
+	case json.Delim('['):
+		index, err := strconv.Atoi(pointer[0])
+		if err != nil {
+			return 0, false
+		}
+		for i := 0; dec.More(); i++ {
+			if i == index {
+				return valueOffset(dec, pointer[1:])
+			}
+			if !skipValue(dec) {
+				return 0, false
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// skipValue reads the next value of the decoder.
+func skipValue(dec *json.Decoder) bool {
+	var value json.RawMessage
+	return dec.Decode(&value) == nil
+}
+
+// writeError writes an error response with the given status code and message to the client.
+func writeError(w http.ResponseWriter, code int, message string, logger zerolog.Logger) {
+	body, err := json.Marshal(models.ModelError{
+		Code:    int32(code),
+		Message: message,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to encode error response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	_, err = w.Write(body)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to write error response")
+	}
+}
+
+// bufferedResponseWriter buffers the response of a handler, so that it can be validated before it
+// is sent to the client.
+type bufferedResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		header: make(http.Header),
+	}
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) WriteHeader(code int) {
+	if b.statusCode == 0 {
+		b.statusCode = code
+	}
+}
+
+func (b *bufferedResponseWriter) Write(data []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	return b.body.Write(data)
+}
+
+// flush writes the buffered response to the client.
+func (b *bufferedResponseWriter) flush(w http.ResponseWriter, logger zerolog.Logger) {
+	for key, values := range b.header {
+		w.Header()[key] = values
+	}
+
+	w.WriteHeader(b.statusCode)
+	_, err := w.Write(b.body.Bytes())
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to write http response")
+	}
+}
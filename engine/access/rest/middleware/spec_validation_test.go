@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: /v1
+paths:
+  /items/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+        - name: select
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id:
+                    type: string
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        '200':
+          description: OK
+`
+
+// TestSpecValidation tests that requests and responses which do not match the spec are rejected.
+func TestSpecValidation(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(testSpec))
+	require.NoError(t, err)
+
+	execute := func(validateResponses bool, req *http.Request, response string) *httptest.ResponseRecorder {
+		validation, err := SpecValidation(zerolog.Nop(), spec, 1<<10, validateResponses)
+		require.NoError(t, err)
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(response))
+		})
+
+		r := mux.NewRouter()
+		r.Handle("/v1/items/{id}", handler).Methods(http.MethodGet)
+		r.Handle("/v1/items", handler).Methods(http.MethodPost)
+		r.Handle("/v1/unspecified", handler).Methods(http.MethodGet)
+		r.Use(validation)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("valid request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/items/1?limit=2", nil)
+		rr := execute(true, req, `{"id":"1"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"id":"1"}`, rr.Body.String())
+	})
+
+	t.Run("invalid query param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/items/1?limit=0", nil)
+		rr := execute(true, req, `{"id":"1"}`)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.JSONEq(t, `{"code":400,"message":"invalid query parameter limit: number must be at least 1"}`, rr.Body.String())
+
+		req = httptest.NewRequest(http.MethodGet, "/v1/items/1?limit=foo", nil)
+		rr = execute(true, req, `{"id":"1"}`)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.JSONEq(t, `{"code":400,"message":"invalid value for limit"}`, rr.Body.String())
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		// errors the handlers detect when decoding the body are reported with the messages of the handlers
+		tests := []struct {
+			body    string
+			message string
+		}{
+			{``, "request body must not be empty"},
+			{`null`, "request body must not be empty"},
+			{`{"name":1}`, `request body contains an invalid value for the \"name\" field (at position 9)`},
+			{`{"other":[1,2], "name":{"first":"a"}}`, `request body contains an invalid value for the \"name\" field (at position 24)`},
+			{`{"name":"a"`, "request body contains badly-formed JSON"},
+			{`{"name":}`, "request body contains badly-formed JSON (at position 9)"},
+			{`{}`, `invalid request body: name: property \"name\" is missing`},
+		}
+
+		for _, test := range tests {
+			req := httptest.NewRequest(http.MethodPost, "/v1/items", strings.NewReader(test.body))
+			rr := execute(true, req, ``)
+			require.Equal(t, http.StatusBadRequest, rr.Code, test.body)
+			require.JSONEq(t, fmt.Sprintf(`{"code":400,"message":"%s"}`, test.message), rr.Body.String(), test.body)
+		}
+	})
+
+	t.Run("body exceeding the maximum size", func(t *testing.T) {
+		body := `{"name":"` + strings.Repeat("a", 1<<10) + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/v1/items", strings.NewReader(body))
+		rr := execute(true, req, ``)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/items/1", nil)
+		rr := execute(true, req, `{"name":"1"}`)
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+		require.JSONEq(t, `{"code":500,"message":"internal server error"}`, rr.Body.String())
+
+		// invalid responses are sent as is if responses are not validated
+		rr = execute(false, req, `{"name":"1"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"name":"1"}`, rr.Body.String())
+	})
+
+	t.Run("selected response fields", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/items/1?select=name", nil)
+		rr := execute(true, req, `{"name":"1"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("unspecified route", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/unspecified", nil)
+		rr := execute(true, req, `{}`)
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+
+		rr = execute(false, req, `{}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -66,3 +66,7 @@ func (a *AccountPublicKeys) Build(accountKeys []flow.AccountPublicKey) {
 
 	*a = keys
 }
+
+func (a *AccountBalance) Build(balance uint64) {
+	a.Balance = util.FromUint64(balance)
+}
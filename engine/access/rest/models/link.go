@@ -1,6 +1,8 @@
 package models
 
 import (
+	"net/url"
+
 	"github.com/gorilla/mux"
 
 	"github.com/onflow/flow-go/model/flow"
@@ -25,6 +27,10 @@ type LinkGenerator interface {
 	ExecutionResultLink(id flow.Identifier) (string, error)
 	AccountLink(address string) (string, error)
 	CollectionLink(id flow.Identifier) (string, error)
+	BlocksPageLink(query url.Values) (string, error)
+	EventsPageLink(query url.Values) (string, error)
+	ExecutionResultsPageLink(query url.Values) (string, error)
+	TransactionsPageLink(query url.Values) (string, error)
 }
 
 type LinkFunc func(id flow.Identifier) (string, error)
@@ -65,6 +71,22 @@ func (generator *LinkGeneratorImpl) AccountLink(address string) (string, error)
 	return generator.link("getAccount", "address", address)
 }
 
+func (generator *LinkGeneratorImpl) BlocksPageLink(query url.Values) (string, error) {
+	return generator.pageLink("getBlocksByHeight", query)
+}
+
+func (generator *LinkGeneratorImpl) EventsPageLink(query url.Values) (string, error) {
+	return generator.pageLink("getEvents", query)
+}
+
+func (generator *LinkGeneratorImpl) ExecutionResultsPageLink(query url.Values) (string, error) {
+	return generator.pageLink("getExecutionResultByBlockID", query)
+}
+
+func (generator *LinkGeneratorImpl) TransactionsPageLink(query url.Values) (string, error) {
+	return generator.pageLink("getTransactions", query)
+}
+
 // SelfLink generates the _link key value pair for the response
 // e.g.
 // "_links": { "_self": "/v1/blocks/c5e935bc75163db82e4a6cf9dc3b54656709d3e21c87385138300abd479c33b7" sx}
@@ -92,3 +114,14 @@ func (generator *LinkGeneratorImpl) link(route string, key string, value string)
 	}
 	return url.String(), nil
 }
+
+// pageLink generates the link to a page of a paginated list
+// e.g. "/v1/blocks?cursor=AQAAAAAAAAAyAAAAAAAAAAA&end_height=100&start_height=1"
+func (generator *LinkGeneratorImpl) pageLink(route string, query url.Values) (string, error) {
+	url, err := generator.router.Get(route).URLPath()
+	if err != nil {
+		return "", err
+	}
+	url.RawQuery = query.Encode()
+	return url.String(), nil
+}
@@ -0,0 +1,27 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type SimulatedTransactionResult struct {
+	BlockId     string `json:"block_id"`
+	BlockHeight string `json:"block_height"`
+	// Error code of the FVM error in case the transaction wasn't successful, 0 otherwise.
+	ErrorCode string `json:"error_code"`
+	// Provided transaction error in case the transaction wasn't successful.
+	ErrorMessage    string `json:"error_message"`
+	ComputationUsed string `json:"computation_used"`
+	MemoryUsed      string `json:"memory_used"`
+	// Computation intensities by computation kind.
+	ComputationIntensities map[string]string `json:"computation_intensities"`
+	// Memory intensities by memory kind.
+	MemoryIntensities map[string]string `json:"memory_intensities"`
+	// Fees the transaction would be charged, in the smallest unit of FLOW.
+	Fees   string  `json:"fees"`
+	Events []Event `json:"events"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Page is a single page of a paginated list. The items are written as the response body, and
+// the link to the next page, if any, is returned in the Link header of the response.
+type Page struct {
+	Items    interface{}
+	NextLink string
+}
@@ -145,3 +145,27 @@ func (p *ProposalKey) Build(key flow.ProposalKey) {
 	p.KeyIndex = util.FromUint64(key.KeyIndex)
 	p.SequenceNumber = util.FromUint64(key.SequenceNumber)
 }
+
+func (s *SimulatedTransactionResult) Build(result *access.SimulatedTransactionResult) {
+	s.BlockId = result.BlockID.String()
+	s.BlockHeight = util.FromUint64(result.BlockHeight)
+	s.ErrorCode = util.FromUint64(uint64(result.ErrorCode))
+	s.ErrorMessage = result.ErrorMessage
+	s.ComputationUsed = util.FromUint64(result.ComputationUsed)
+	s.MemoryUsed = util.FromUint64(result.MemoryUsed)
+	s.Fees = util.FromUint64(result.Fees)
+
+	s.ComputationIntensities = make(map[string]string, len(result.ComputationIntensities))
+	for kind, intensity := range result.ComputationIntensities {
+		s.ComputationIntensities[util.FromUint64(uint64(kind))] = util.FromUint64(uint64(intensity))
+	}
+
+	s.MemoryIntensities = make(map[string]string, len(result.MemoryIntensities))
+	for kind, intensity := range result.MemoryIntensities {
+		s.MemoryIntensities[util.FromUint64(uint64(kind))] = util.FromUint64(uint64(intensity))
+	}
+
+	var events Events
+	events.Build(result.Events)
+	s.Events = events
+}
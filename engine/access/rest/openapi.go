@@ -0,0 +1,13 @@
+package rest
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/openapi"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+)
+
+// GetOpenAPISpec returns the OpenAPI spec of the API.
+func GetOpenAPISpec(_ *request.Request, _ access.API, _ models.LinkGenerator) (interface{}, error) {
+	return openapi.Load()
+}
@@ -0,0 +1,47 @@
+// Package openapi provides the OpenAPI specification of the REST API of the access node.
+//
+// The specification is the source of truth for the REST API: it is served by the REST server,
+// every request and response of the server is validated against it, and the models and the
+// REST client are generated from it.
+package openapi
+
+import (
+	_ "embed"
+	"fmt"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed access.yaml
+var spec []byte
+
+var (
+	loadOnce sync.Once
+	doc      *openapi3.T
+	loadErr  error
+)
+
+// Load returns the parsed and validated specification. The specification is only parsed once,
+// the returned document is shared and must not be modified.
+func Load() (*openapi3.T, error) {
+	loadOnce.Do(func() {
+		doc, loadErr = load()
+	})
+	return doc, loadErr
+}
+
+func load() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	d, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse OpenAPI spec: %w", err)
+	}
+
+	err = d.Validate(loader.Context)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+
+	return d, nil
+}
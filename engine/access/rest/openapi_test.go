@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/client"
+	"github.com/onflow/flow-go/engine/access/rest/openapi"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetOpenAPISpec(t *testing.T) {
+	backend := &mock.API{}
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/openapi.json", nil)
+	require.NoError(t, err)
+
+	rr, err := executeRequest(req, backend)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var spec map[string]interface{}
+	err = json.Unmarshal(rr.Body.Bytes(), &spec)
+	require.NoError(t, err)
+	require.Equal(t, "3.0.3", spec["openapi"])
+	require.Contains(t, spec["paths"], "/blocks/{id}")
+}
+
+// TestOpenAPISpecRoutes ensures that every route of the server is specified.
+func TestOpenAPISpecRoutes(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	for _, r := range Routes {
+		path := spec.Paths.Find(r.Pattern)
+		require.NotNil(t, path, "route %s is not specified", r.Name)
+
+		operation := path.GetOperation(r.Method)
+		require.NotNil(t, operation, "route %s is not specified", r.Name)
+		require.Equal(t, r.Name, operation.OperationID)
+	}
+
+	for _, r := range WSRoutes {
+		path := spec.Paths.Find(r.Pattern)
+		require.NotNil(t, path, "route %s is not specified", r.Name)
+		require.NotNil(t, path.Get, "route %s is not specified", r.Name)
+		require.Equal(t, r.Name, path.Get.OperationID)
+	}
+}
+
+// TestGeneratedClient ensures that the client generated from the spec can be used with the server.
+func TestGeneratedClient(t *testing.T) {
+	backend := &mock.API{}
+
+	var b bytes.Buffer
+	router, err := newRouter(backend, zerolog.New(&b), flow.Testnet.Chain(), WithResponseValidation())
+	require.NoError(t, err)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	c, err := client.NewClientWithResponses(server.URL + "/v1")
+	require.NoError(t, err)
+
+	t.Run("get transaction", func(t *testing.T) {
+		tx := unittest.TransactionFixture()
+		backend.Mock.
+			On("GetTransaction", mocks.Anything, tx.ID()).
+			Return(&tx.TransactionBody, nil)
+
+		resp, err := c.GetTransactionByIDWithResponse(context.Background(), tx.ID().String(), &client.GetTransactionByIDParams{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.NotNil(t, resp.JSON200)
+		require.Equal(t, tx.ID().String(), resp.JSON200.Id)
+		require.Equal(t, tx.Payer.String(), resp.JSON200.Payer)
+		require.Len(t, resp.JSON200.EnvelopeSignatures, len(tx.EnvelopeSignatures))
+	})
+
+	t.Run("get blocks with invalid request", func(t *testing.T) {
+		limit := 0
+		resp, err := c.GetBlocksByHeightWithResponse(context.Background(), &client.GetBlocksByHeightParams{
+			Limit: &limit,
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+		require.NotNil(t, resp.JSON400)
+		require.Equal(t, "invalid query parameter limit: number must be at least 1", *resp.JSON400.Message)
+	})
+}
@@ -0,0 +1,43 @@
+package rest
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/onflow/flow-go/engine/access/rest/request"
+)
+
+const cursorQuery = "cursor"
+const endHeightQuery = "end_height"
+
+// pageLinkFunc generates the link to a page of a paginated list from its query.
+type pageLinkFunc func(query url.Values) (string, error)
+
+// heightPage returns the inclusive height range of the requested page of a height range, and the
+// cursor of the next page if the page does not reach the end of the range.
+func heightPage(pagination request.Pagination, startHeight uint64, endHeight uint64) (uint64, uint64, *request.Cursor) {
+	pageStart := pagination.StartHeight(startHeight)
+	pageEnd := endHeight
+	if endHeight-pageStart >= pagination.Limit {
+		pageEnd = pageStart + pagination.Limit - 1
+	}
+
+	if pageEnd == endHeight {
+		return pageStart, pageEnd, nil
+	}
+	return pageStart, pageEnd, &request.Cursor{Height: pageEnd + 1}
+}
+
+// nextPageLink generates the link to the page starting at the cursor. The original query is kept,
+// with the end height resolved so that all pages cover the same height range, even if the end
+// height was requested as "final" or "sealed".
+func nextPageLink(r *request.Request, link pageLinkFunc, cursor *request.Cursor, endHeight uint64) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	query := r.URL.Query()
+	query.Set(cursorQuery, cursor.String())
+	query.Set(endHeightQuery, strconv.FormatUint(endHeight, 10))
+	return link(query)
+}
@@ -1,7 +1,9 @@
 package request
 
 import (
+	"fmt"
 	"io"
+	"strconv"
 
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -24,3 +26,39 @@ func (c *CreateTransaction) Parse(rawTransaction io.Reader, chain flow.Chain) er
 	c.Transaction = tx.Flow()
 	return nil
 }
+
+const skipSignatureVerificationQuery = "skip_signature_verification"
+
+type SimulateTransaction struct {
+	Transaction               flow.TransactionBody
+	SkipSignatureVerification bool
+}
+
+func (s *SimulateTransaction) Build(r *Request) error {
+	return s.Parse(r.Body, r.GetQueryParam(skipSignatureVerificationQuery), r.Chain)
+}
+
+func (s *SimulateTransaction) Parse(rawTransaction io.Reader, rawSkipSignatureVerification string, chain flow.Chain) error {
+	if rawSkipSignatureVerification != "" {
+		skip, err := strconv.ParseBool(rawSkipSignatureVerification)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s", skipSignatureVerificationQuery)
+		}
+		s.SkipSignatureVerification = skip
+	}
+
+	// signatures are only required if they are verified
+	var tx Transaction
+	var err error
+	if s.SkipSignatureVerification {
+		err = tx.ParseUnsigned(rawTransaction, chain)
+	} else {
+		err = tx.Parse(rawTransaction, chain)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.Transaction = tx.Flow()
+	return nil
+}
@@ -0,0 +1,5 @@
+package request
+
+type GetAccountBalance struct {
+	GetAccount
+}
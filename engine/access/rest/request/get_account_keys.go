@@ -0,0 +1,40 @@
+package request
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const keyIndexVar = "index"
+
+type GetAccountKeys struct {
+	GetAccount
+}
+
+type GetAccountKey struct {
+	GetAccount
+	Index uint64
+}
+
+func (g *GetAccountKey) Build(r *Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetVar(keyIndexVar),
+		r.GetQueryParam(blockHeightQuery),
+	)
+}
+
+func (g *GetAccountKey) Parse(rawAddress string, rawIndex string, rawHeight string) error {
+	err := g.GetAccount.Parse(rawAddress, rawHeight)
+	if err != nil {
+		return err
+	}
+
+	index, err := strconv.ParseUint(rawIndex, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid key index format")
+	}
+	g.Index = index
+
+	return nil
+}
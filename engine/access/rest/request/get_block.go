@@ -9,7 +9,7 @@ import (
 const heightQuery = "height"
 const startHeightQuery = "start_height"
 const endHeightQuery = "end_height"
-const MaxBlockRequestHeightRange = 50
+const MaxBlockRequestHeightRange = 50 // also the maximum page size of paginated height ranges
 const idParam = "id"
 
 type GetBlock struct {
@@ -18,14 +18,36 @@ type GetBlock struct {
 	EndHeight    uint64
 	FinalHeight  bool
 	SealedHeight bool
+	Pagination   Pagination
 }
 
 func (g *GetBlock) Build(r *Request) error {
-	return g.Parse(
+	// the pagination is parsed first, as it allows height ranges exceeding the maximum
+	err := g.Pagination.Parse(r.GetQueryParam(cursorQuery), r.GetQueryParam(limitQuery), MaxBlockRequestHeightRange)
+	if err != nil {
+		return err
+	}
+
+	err = g.Parse(
 		r.GetQueryParams(heightQuery),
 		r.GetQueryParam(startHeightQuery),
 		r.GetQueryParam(endHeightQuery),
 	)
+	if err != nil {
+		return err
+	}
+
+	return g.validatePagination()
+}
+
+// validatePagination checks the cursor against the parsed heights. Blocks requested by heights
+// are not paginated.
+func (g *GetBlock) validatePagination() error {
+	if g.HasHeights() && g.Pagination.Cursor != nil {
+		return fmt.Errorf("cursor can only be provided with start and end height range")
+	}
+
+	return g.Pagination.validateRange(g.StartHeight, g.EndHeight)
 }
 
 func (g *GetBlock) HasHeights() bool {
@@ -65,8 +87,10 @@ func (g *GetBlock) Parse(rawHeights []string, rawStart string, rawEnd string) er
 	if g.StartHeight > g.EndHeight {
 		return fmt.Errorf("start height must be less than or equal to end height")
 	}
-	// check if range exceeds maximum but only if end is not equal to special value which is not known yet
-	if g.EndHeight-g.StartHeight >= MaxBlockRequestHeightRange && g.EndHeight != FinalHeight && g.EndHeight != SealedHeight {
+	// check if range exceeds maximum but only if end is not equal to special value which is not known yet,
+	// larger ranges are returned one page at a time to clients requesting pagination
+	if g.EndHeight-g.StartHeight >= MaxBlockRequestHeightRange && g.EndHeight != FinalHeight && g.EndHeight != SealedHeight &&
+		!g.Pagination.Requested() {
 		return fmt.Errorf("height range %d exceeds maximum allowed of %d", g.EndHeight-g.StartHeight, MaxBlockRequestHeightRange)
 	}
 
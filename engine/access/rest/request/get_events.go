@@ -3,28 +3,122 @@ package request
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/onflow/flow-go/model/flow"
 )
 
 const eventTypeQuery = "type"
 const blockQuery = "block_ids"
-const MaxEventRequestHeightRange = 250
+const addressQuery = "address"
+const contractQuery = "contract"
+const transactionIDQuery = "transaction_id"
+const MaxEventRequestHeightRange = 250 // also the maximum page size of paginated height ranges
+
+// MaxIndexedEventRequestHeightRange is the maximum height range, and page size, of requests with
+// filters, which are answered from the local event index of the node.
+const MaxIndexedEventRequestHeightRange = 10_000
 
 type GetEvents struct {
-	StartHeight uint64
-	EndHeight   uint64
-	Type        string
-	BlockIDs    []flow.Identifier
+	StartHeight   uint64
+	EndHeight     uint64
+	Type          string
+	BlockIDs      []flow.Identifier
+	Address       flow.Address
+	Contract      string
+	TransactionID flow.Identifier
+	Pagination    Pagination
 }
 
 func (g *GetEvents) Build(r *Request) error {
-	return g.Parse(
+	err := g.ParseFilters(
+		r.GetQueryParam(addressQuery),
+		r.GetQueryParam(contractQuery),
+		r.GetQueryParam(transactionIDQuery),
+	)
+	if err != nil {
+		return err
+	}
+
+	// the pagination is parsed first, as it allows height ranges exceeding the maximum
+	err = g.Pagination.Parse(r.GetQueryParam(cursorQuery), r.GetQueryParam(limitQuery), g.maxHeightRange())
+	if err != nil {
+		return err
+	}
+
+	err = g.Parse(
 		r.GetQueryParam(eventTypeQuery),
 		r.GetQueryParam(startHeightQuery),
 		r.GetQueryParam(endHeightQuery),
 		r.GetQueryParams(blockQuery),
 	)
+	if err != nil {
+		return err
+	}
+
+	return g.validatePagination()
+}
+
+// validatePagination checks the cursor against the parsed heights. Events requested by block IDs
+// are not paginated.
+func (g *GetEvents) validatePagination() error {
+	if len(g.BlockIDs) > 0 && g.Pagination.Cursor != nil {
+		return fmt.Errorf("cursor can only be provided with start and end height range")
+	}
+
+	return g.Pagination.validateRange(g.StartHeight, g.EndHeight)
+}
+
+// maxHeightRange returns the maximum height range of a request, which is also the maximum page
+// size of paginated requests. Filtered events are looked up in the index, allowing larger ranges.
+func (g *GetEvents) maxHeightRange() uint64 {
+	if g.HasFilters() {
+		return MaxIndexedEventRequestHeightRange
+	}
+	return MaxEventRequestHeightRange
+}
+
+// ParseFilters parses the optional filters by emitting account, contract and transaction. Events
+// requested with filters are looked up in the local event index of the node, and only the blocks
+// with matching events are returned.
+func (g *GetEvents) ParseFilters(rawAddress string, rawContract string, rawTransactionID string) error {
+	g.Address = flow.EmptyAddress
+	if rawAddress != "" {
+		var address Address
+		err := address.Parse(rawAddress)
+		if err != nil {
+			return err
+		}
+		g.Address = address.Flow()
+	}
+
+	g.Contract = ""
+	if rawContract != "" {
+		parts := strings.Split(rawContract, ".")
+		if len(parts) != 3 || parts[0] != "A" || parts[2] == "" {
+			return fmt.Errorf("invalid contract format, expected A.<address>.<name>")
+		}
+		var address Address
+		err := address.Parse(parts[1])
+		if err != nil {
+			return fmt.Errorf("invalid contract address")
+		}
+		g.Contract = fmt.Sprintf("A.%s.%s", address.Flow(), parts[2])
+	}
+
+	var transactionID ID
+	err := transactionID.Parse(rawTransactionID)
+	if err != nil {
+		return fmt.Errorf("invalid transaction ID: %w", err)
+	}
+	g.TransactionID = transactionID.Flow()
+
+	return nil
+}
+
+// HasFilters returns true if events are filtered by emitting account, contract or transaction.
+func (g *GetEvents) HasFilters() bool {
+	return g.Address != flow.EmptyAddress || g.Contract != "" || g.TransactionID != flow.ZeroID
 }
 
 func (g *GetEvents) Parse(rawType string, rawStart string, rawEnd string, rawBlockIDs []string) error {
@@ -57,18 +151,24 @@ func (g *GetEvents) Parse(rawType string, rawStart string, rawEnd string, rawBlo
 		return fmt.Errorf("must provide either block IDs or start and end height range")
 	}
 
+	if g.HasFilters() && len(blockIDs) > 0 {
+		return fmt.Errorf("filters can only be provided with start and end height range")
+	}
+
 	g.Type = rawType
-	if g.Type == "" {
+	if g.Type == "" && !g.HasFilters() {
 		return fmt.Errorf("event type must be provided")
 	}
 
-	// match basic format A.address.contract.event (ignore err since regex will always compile)
-	basic, _ := regexp.MatchString(`[A-Z]\.[a-f0-9]{16}\.[\w+]*\.[\w+]*`, g.Type)
-	// match core events flow.event
-	core, _ := regexp.MatchString(`flow\.[\w]*`, g.Type)
+	if g.Type != "" {
+		// match basic format A.address.contract.event (ignore err since regex will always compile)
+		basic, _ := regexp.MatchString(`[A-Z]\.[a-f0-9]{16}\.[\w+]*\.[\w+]*`, g.Type)
+		// match core events flow.event
+		core, _ := regexp.MatchString(`flow\.[\w]*`, g.Type)
 
-	if !core && !basic {
-		return fmt.Errorf("invalid event type format")
+		if !core && !basic {
+			return fmt.Errorf("invalid event type format")
+		}
 	}
 
 	// validate start end height option
@@ -76,9 +176,12 @@ func (g *GetEvents) Parse(rawType string, rawStart string, rawEnd string, rawBlo
 		if g.StartHeight > g.EndHeight {
 			return fmt.Errorf("start height must be less than or equal to end height")
 		}
-		// check if range exceeds maximum but only if end is not equal to special value which is not known yet
-		if g.EndHeight-g.StartHeight >= MaxEventRequestHeightRange && g.EndHeight != FinalHeight && g.EndHeight != SealedHeight {
-			return fmt.Errorf("height range %d exceeds maximum allowed of %d", g.EndHeight-g.StartHeight, MaxEventRequestHeightRange)
+		// check if range exceeds maximum but only if end is not equal to special value which is not known yet,
+		// larger ranges are returned one page at a time to clients requesting pagination
+		maxRange := g.maxHeightRange()
+		if g.EndHeight-g.StartHeight >= maxRange && g.EndHeight != FinalHeight && g.EndHeight != SealedHeight &&
+			!g.Pagination.Requested() {
+			return fmt.Errorf("height range %d exceeds maximum allowed of %d", g.EndHeight-g.StartHeight, maxRange)
 		}
 	}
 
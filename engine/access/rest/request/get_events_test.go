@@ -60,3 +60,43 @@ func TestGetEvents_ValidParse(t *testing.T) {
 	assert.Equal(t, getEvents.BlockIDs[1].String(), "2ab81061b12d95fb81f2923001e340bc808e67e1eaae3c62479057cc14eb57fd")
 
 }
+
+func TestGetEvents_ParseFilters(t *testing.T) {
+	var getEvents GetEvents
+
+	err := getEvents.ParseFilters("0xf8d6e0586b0a20c7", "A.f8d6e0586b0a20c7.Foo", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "f8d6e0586b0a20c7", getEvents.Address.String())
+	assert.Equal(t, "A.f8d6e0586b0a20c7.Foo", getEvents.Contract)
+	assert.True(t, getEvents.HasFilters())
+
+	// the event type is optional when filters are provided
+	err = getEvents.Parse("", "5", "10", nil)
+	assert.NoError(t, err)
+
+	// filters can not be combined with block IDs
+	err = getEvents.Parse("", "", "", []string{"7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7"})
+	assert.EqualError(t, err, "filters can only be provided with start and end height range")
+
+	err = getEvents.ParseFilters("", "", "")
+	assert.NoError(t, err)
+	assert.False(t, getEvents.HasFilters())
+
+	invalid := []struct {
+		address       string
+		contract      string
+		transactionID string
+		err           string
+	}{
+		{"foo", "", "", "invalid address"},
+		{"", "Foo", "", "invalid contract format, expected A.<address>.<name>"},
+		{"", "A.f8d6e0586b0a20c7", "", "invalid contract format, expected A.<address>.<name>"},
+		{"", "A.123.Foo", "", "invalid contract address"},
+		{"", "", "foo", "invalid transaction ID: invalid ID format"},
+	}
+
+	for i, test := range invalid {
+		err := getEvents.ParseFilters(test.address, test.contract, test.transactionID)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
@@ -9,16 +9,45 @@ import (
 const idQuery = "id"
 
 type GetExecutionResultByBlockIDs struct {
-	BlockIDs []flow.Identifier
+	BlockIDs    []flow.Identifier
+	StartHeight uint64
+	EndHeight   uint64
+	Pagination  Pagination
 }
 
 func (g *GetExecutionResultByBlockIDs) Build(r *Request) error {
-	return g.Parse(
+	err := g.Parse(
 		r.GetQueryParams(blockIDQuery),
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(endHeightQuery),
 	)
+	if err != nil {
+		return err
+	}
+
+	return g.ParsePagination(r.GetQueryParam(cursorQuery), r.GetQueryParam(limitQuery))
 }
 
-func (g *GetExecutionResultByBlockIDs) Parse(rawIDs []string) error {
+// ParsePagination parses the pagination of a height range request. Execution results requested
+// by block IDs are not paginated.
+func (g *GetExecutionResultByBlockIDs) ParsePagination(rawCursor string, rawLimit string) error {
+	err := g.Pagination.Parse(rawCursor, rawLimit, MaxBlockRequestHeightRange)
+	if err != nil {
+		return err
+	}
+
+	if len(g.BlockIDs) > 0 && g.Pagination.Cursor != nil {
+		return fmt.Errorf("cursor can only be provided with start and end height range")
+	}
+
+	return g.Pagination.validateRange(g.StartHeight, g.EndHeight)
+}
+
+func (g *GetExecutionResultByBlockIDs) HasBlockIDs() bool {
+	return len(g.BlockIDs) > 0
+}
+
+func (g *GetExecutionResultByBlockIDs) Parse(rawIDs []string, rawStart string, rawEnd string) error {
 	var ids IDs
 	err := ids.Parse(rawIDs)
 	if err != nil {
@@ -26,8 +55,33 @@ func (g *GetExecutionResultByBlockIDs) Parse(rawIDs []string) error {
 	}
 	g.BlockIDs = ids.Flow()
 
+	var height Height
+	err = height.Parse(rawStart)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	g.StartHeight = height.Flow()
+	err = height.Parse(rawEnd)
+	if err != nil {
+		return fmt.Errorf("invalid end height: %w", err)
+	}
+	g.EndHeight = height.Flow()
+
+	// if both block IDs and one or both of start and end height are provided
+	if len(g.BlockIDs) > 0 && (g.StartHeight != EmptyHeight || g.EndHeight != EmptyHeight) {
+		return fmt.Errorf("can only provide either block IDs or start and end height range")
+	}
+
 	if len(g.BlockIDs) == 0 {
-		return fmt.Errorf("no block IDs provided")
+		if g.StartHeight == EmptyHeight && g.EndHeight == EmptyHeight {
+			return fmt.Errorf("no block IDs provided")
+		}
+		if g.StartHeight == EmptyHeight || g.EndHeight == EmptyHeight {
+			return fmt.Errorf("must provide either block IDs or start and end height range")
+		}
+		if g.StartHeight > g.EndHeight {
+			return fmt.Errorf("start height must be less than or equal to end height")
+		}
 	}
 
 	return nil
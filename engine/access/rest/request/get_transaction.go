@@ -1,6 +1,9 @@
 package request
 
+import "fmt"
+
 const resultExpandable = "result"
+const MaxTransactionsPageSize = 100
 
 type GetTransaction struct {
 	GetByIDRequest
@@ -17,3 +20,60 @@ func (g *GetTransaction) Build(r *Request) error {
 type GetTransactionResult struct {
 	GetByIDRequest
 }
+
+type SubscribeTransactionStatuses struct {
+	GetByIDRequest
+}
+
+// GetTransactions is a request for the transactions of a block height range, ordered by height and
+// by index within the block. The page size is the number of transactions.
+type GetTransactions struct {
+	StartHeight uint64
+	EndHeight   uint64
+	Pagination  Pagination
+}
+
+func (g *GetTransactions) Build(r *Request) error {
+	err := g.Parse(
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(endHeightQuery),
+	)
+	if err != nil {
+		return err
+	}
+
+	return g.ParsePagination(r.GetQueryParam(cursorQuery), r.GetQueryParam(limitQuery))
+}
+
+func (g *GetTransactions) ParsePagination(rawCursor string, rawLimit string) error {
+	err := g.Pagination.Parse(rawCursor, rawLimit, MaxTransactionsPageSize)
+	if err != nil {
+		return err
+	}
+
+	return g.Pagination.validateRange(g.StartHeight, g.EndHeight)
+}
+
+func (g *GetTransactions) Parse(rawStart string, rawEnd string) error {
+	var height Height
+	err := height.Parse(rawStart)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	g.StartHeight = height.Flow()
+	err = height.Parse(rawEnd)
+	if err != nil {
+		return fmt.Errorf("invalid end height: %w", err)
+	}
+	g.EndHeight = height.Flow()
+
+	if g.StartHeight == EmptyHeight || g.EndHeight == EmptyHeight {
+		return fmt.Errorf("must provide start and end height range")
+	}
+
+	if g.StartHeight > g.EndHeight {
+		return fmt.Errorf("start height must be less than or equal to end height")
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package request
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+const cursorQuery = "cursor"
+const limitQuery = "limit"
+
+const cursorVersion byte = 1
+const cursorLength = 1 + 8 + 8
+
+// Cursor is the position at which the next page of a paginated list starts: the height of the
+// block, and the index of the first item within the block for lists of block contents.
+// Cursors are encoded as opaque strings, clients must not rely on their format.
+type Cursor struct {
+	Height uint64
+	Index  uint64
+}
+
+func (c *Cursor) Parse(raw string) error {
+	encoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil || len(encoded) != cursorLength || encoded[0] != cursorVersion {
+		return fmt.Errorf("invalid cursor")
+	}
+
+	c.Height = binary.BigEndian.Uint64(encoded[1:9])
+	c.Index = binary.BigEndian.Uint64(encoded[9:])
+	return nil
+}
+
+func (c Cursor) String() string {
+	encoded := make([]byte, cursorLength)
+	encoded[0] = cursorVersion
+	binary.BigEndian.PutUint64(encoded[1:9], c.Height)
+	binary.BigEndian.PutUint64(encoded[9:], c.Index)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// Pagination is the page size and the optional cursor of a request for a paginated list.
+type Pagination struct {
+	Cursor    *Cursor
+	Limit     uint64
+	requested bool
+}
+
+// Parse parses the cursor and page size. The page size defaults to the maximum page size if
+// not provided.
+func (p *Pagination) Parse(rawCursor string, rawLimit string, maxLimit uint64) error {
+	p.requested = rawCursor != "" || rawLimit != ""
+
+	p.Limit = maxLimit
+	if rawLimit != "" {
+		limit, err := util.ToUint64(rawLimit)
+		if err != nil {
+			return fmt.Errorf("invalid limit: %w", err)
+		}
+		if limit == 0 || limit > maxLimit {
+			return fmt.Errorf("limit must be between 1 and %d", maxLimit)
+		}
+		p.Limit = limit
+	}
+
+	p.Cursor = nil
+	if rawCursor != "" {
+		var cursor Cursor
+		err := cursor.Parse(rawCursor)
+		if err != nil {
+			return err
+		}
+		p.Cursor = &cursor
+	}
+
+	return nil
+}
+
+// Requested returns true if the client provided a cursor or a page size. Height ranges exceeding the
+// maximum page size are only accepted from clients which follow the pagination.
+func (p *Pagination) Requested() bool {
+	return p.requested
+}
+
+// StartHeight returns the height at which the page starts for a height range starting at the
+// given height.
+func (p *Pagination) StartHeight(startHeight uint64) uint64 {
+	if p.Cursor == nil {
+		return startHeight
+	}
+	return p.Cursor.Height
+}
+
+// validateRange checks that the cursor is within the requested height range. The end height is
+// not checked if it is a special value not known yet.
+func (p *Pagination) validateRange(startHeight uint64, endHeight uint64) error {
+	if p.Cursor == nil {
+		return nil
+	}
+
+	if p.Cursor.Height < startHeight {
+		return fmt.Errorf("cursor is outside of the requested height range")
+	}
+	if endHeight != FinalHeight && endHeight != SealedHeight && p.Cursor.Height > endHeight {
+		return fmt.Errorf("cursor is outside of the requested height range")
+	}
+
+	return nil
+}
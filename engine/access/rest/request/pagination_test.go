@@ -0,0 +1,107 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_Parse(t *testing.T) {
+	cursor := Cursor{Height: 1234, Index: 5}
+
+	var parsed Cursor
+	err := parsed.Parse(cursor.String())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, parsed)
+
+	for _, raw := range []string{"foo", "AQID", cursor.String() + "AA", "AgAAAAAAAATSAAAAAAAAAAU"} {
+		err := parsed.Parse(raw)
+		assert.EqualError(t, err, "invalid cursor", raw)
+	}
+}
+
+func TestPagination_Parse(t *testing.T) {
+	cursor := Cursor{Height: 20}
+
+	var p Pagination
+	err := p.Parse("", "", 50)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), p.Limit)
+	assert.Nil(t, p.Cursor)
+
+	err = p.Parse(cursor.String(), "10", 50)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), p.Limit)
+	assert.Equal(t, &cursor, p.Cursor)
+	assert.Equal(t, uint64(20), p.StartHeight(5))
+
+	tests := []struct {
+		cursor string
+		limit  string
+		err    string
+	}{
+		{"", "0", "limit must be between 1 and 50"},
+		{"", "51", "limit must be between 1 and 50"},
+		{"", "-1", "invalid limit: value must be an unsigned 64 bit integer"},
+		{"invalid", "", "invalid cursor"},
+	}
+	for i, test := range tests {
+		err := p.Parse(test.cursor, test.limit, 50)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func TestGetBlock_ValidatePagination(t *testing.T) {
+	var getBlock GetBlock
+
+	err := getBlock.Parse(nil, "10", "100")
+	assert.EqualError(t, err, "height range 90 exceeds maximum allowed of 50")
+
+	// ranges exceeding the maximum are accepted from clients requesting pagination
+	err = getBlock.Pagination.Parse(Cursor{Height: 60}.String(), "", MaxBlockRequestHeightRange)
+	require.NoError(t, err)
+	err = getBlock.Parse(nil, "10", "100")
+	require.NoError(t, err)
+	err = getBlock.validatePagination()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(60), getBlock.Pagination.StartHeight(getBlock.StartHeight))
+
+	err = getBlock.Pagination.Parse("", "10", MaxBlockRequestHeightRange)
+	require.NoError(t, err)
+	err = getBlock.Parse(nil, "10", "100")
+	require.NoError(t, err)
+
+	err = getBlock.Pagination.Parse(Cursor{Height: 5}.String(), "", MaxBlockRequestHeightRange)
+	require.NoError(t, err)
+	err = getBlock.validatePagination()
+	assert.EqualError(t, err, "cursor is outside of the requested height range")
+
+	err = getBlock.Pagination.Parse(Cursor{Height: 101}.String(), "", MaxBlockRequestHeightRange)
+	require.NoError(t, err)
+	err = getBlock.validatePagination()
+	assert.EqualError(t, err, "cursor is outside of the requested height range")
+
+	err = getBlock.Parse([]string{"1", "2"}, "", "")
+	require.NoError(t, err)
+	err = getBlock.Pagination.Parse(Cursor{Height: 2}.String(), "", MaxBlockRequestHeightRange)
+	require.NoError(t, err)
+	err = getBlock.validatePagination()
+	assert.EqualError(t, err, "cursor can only be provided with start and end height range")
+}
+
+func TestGetEvents_ValidatePagination(t *testing.T) {
+	var getEvents GetEvents
+	event := "A.f8d6e0586b0a20c7.Foo.Bar"
+
+	err := getEvents.Parse(event, "0", "500", nil)
+	assert.EqualError(t, err, "height range 500 exceeds maximum allowed of 250")
+
+	// ranges exceeding the maximum are accepted from clients requesting pagination
+	err = getEvents.Pagination.Parse("", "100", MaxEventRequestHeightRange)
+	require.NoError(t, err)
+	err = getEvents.Parse(event, "0", "500", nil)
+	require.NoError(t, err)
+	require.NoError(t, getEvents.validatePagination())
+}
@@ -54,6 +54,24 @@ func (rd *Request) GetAccountRequest() (GetAccount, error) {
 	return req, err
 }
 
+func (rd *Request) GetAccountKeysRequest() (GetAccountKeys, error) {
+	var req GetAccountKeys
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) GetAccountKeyRequest() (GetAccountKey, error) {
+	var req GetAccountKey
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) GetAccountBalanceRequest() (GetAccountBalance, error) {
+	var req GetAccountBalance
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) GetExecutionResultByBlockIDsRequest() (GetExecutionResultByBlockIDs, error) {
 	var req GetExecutionResultByBlockIDs
 	err := req.Build(rd)
@@ -72,6 +90,12 @@ func (rd *Request) GetTransactionRequest() (GetTransaction, error) {
 	return req, err
 }
 
+func (rd *Request) GetTransactionsRequest() (GetTransactions, error) {
+	var req GetTransactions
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) GetTransactionResultRequest() (GetTransactionResult, error) {
 	var req GetTransactionResult
 	err := req.Build(rd)
@@ -84,12 +108,30 @@ func (rd *Request) GetEventsRequest() (GetEvents, error) {
 	return req, err
 }
 
+func (rd *Request) SubscribeEventsRequest() (SubscribeEvents, error) {
+	var req SubscribeEvents
+	err := req.Build(rd)
+	return req, err
+}
+
+func (rd *Request) SubscribeTransactionStatusesRequest() (SubscribeTransactionStatuses, error) {
+	var req SubscribeTransactionStatuses
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) CreateTransactionRequest() (CreateTransaction, error) {
 	var req CreateTransaction
 	err := req.Build(rd)
 	return req, err
 }
 
+func (rd *Request) SimulateTransactionRequest() (SimulateTransaction, error) {
+	var req SimulateTransaction
+	err := req.Build(rd)
+	return req, err
+}
+
 func (rd *Request) Expands(field string) bool {
 	return rd.ExpandFields[field]
 }
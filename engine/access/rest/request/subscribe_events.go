@@ -0,0 +1,80 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const startBlockIDQuery = "start_block_id"
+const eventTypesQuery = "event_types"
+const addressesQuery = "addresses"
+const contractsQuery = "contracts"
+
+type SubscribeEvents struct {
+	StartBlockID flow.Identifier
+	StartHeight  uint64
+	Filter       access.EventFilter
+}
+
+func (s *SubscribeEvents) Build(r *Request) error {
+	return s.Parse(
+		r.GetQueryParam(startBlockIDQuery),
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParams(eventTypesQuery),
+		r.GetQueryParams(addressesQuery),
+		r.GetQueryParams(contractsQuery),
+		r.Chain,
+	)
+}
+
+func (s *SubscribeEvents) Parse(
+	rawStartBlockID string,
+	rawStartHeight string,
+	rawTypes []string,
+	rawAddresses []string,
+	rawContracts []string,
+	chain flow.Chain,
+) error {
+	var startBlockID ID
+	err := startBlockID.Parse(rawStartBlockID)
+	if err != nil {
+		return fmt.Errorf("invalid start block ID: %w", err)
+	}
+	s.StartBlockID = startBlockID.Flow()
+
+	var height Height
+	err = height.Parse(rawStartHeight)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	s.StartHeight = height.Flow()
+
+	// special height values are not supported since the stream always follows sealed blocks
+	if s.StartHeight == FinalHeight || s.StartHeight == SealedHeight {
+		return fmt.Errorf("invalid start height: only numeric heights are supported")
+	}
+	if s.StartHeight == EmptyHeight {
+		s.StartHeight = 0
+	}
+
+	if s.StartBlockID != flow.ZeroID && s.StartHeight > 0 {
+		return fmt.Errorf("can only provide either start block ID or start height")
+	}
+
+	for _, address := range rawAddresses {
+		var addr Address
+		err = addr.Parse(address)
+		if err != nil {
+			return fmt.Errorf("invalid address %s: %w", address, err)
+		}
+	}
+
+	s.Filter, err = access.NewEventFilter(chain, rawTypes, rawAddresses, rawContracts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestSubscribeEvents_InvalidParse(t *testing.T) {
+	var subscribeEvents SubscribeEvents
+	chain := flow.Testnet.Chain()
+	blockID := "7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7"
+
+	tests := []struct {
+		blockID   string
+		height    string
+		types     []string
+		addresses []string
+		contracts []string
+		err       string
+	}{
+		{"", "", nil, nil, nil, "at least one event type, address or contract must be provided"},
+		{"foo", "", []string{"flow.AccountCreated"}, nil, nil, "invalid start block ID: invalid ID format"},
+		{"", "foo", []string{"flow.AccountCreated"}, nil, nil, "invalid start height: invalid height format"},
+		{"", "sealed", []string{"flow.AccountCreated"}, nil, nil, "invalid start height: only numeric heights are supported"},
+		{blockID, "10", []string{"flow.AccountCreated"}, nil, nil, "can only provide either start block ID or start height"},
+		{"", "10", []string{"foo"}, nil, nil, "invalid event type foo"},
+		{"", "10", []string{"flow.AccountCreated"}, []string{"123"}, nil, "invalid address 123: invalid address"},
+		{"", "10", []string{"flow.AccountCreated"}, nil, []string{"A.Foo"}, "invalid contract A.Foo, expected format A.<address>.<name>"},
+	}
+
+	for i, test := range tests {
+		err := subscribeEvents.Parse(test.blockID, test.height, test.types, test.addresses, test.contracts, chain)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func TestSubscribeEvents_ValidParse(t *testing.T) {
+	var subscribeEvents SubscribeEvents
+	chain := flow.Testnet.Chain()
+	address := chain.ServiceAddress()
+	contractEvent := fmt.Sprintf("A.%s.Foo.Bar", address)
+
+	err := subscribeEvents.Parse("", "10", []string{contractEvent, "flow.AccountCreated"}, nil, nil, chain)
+	require.NoError(t, err)
+	assert.Equal(t, flow.ZeroID, subscribeEvents.StartBlockID)
+	assert.Equal(t, uint64(10), subscribeEvents.StartHeight)
+	assert.Equal(t, []flow.EventType{flow.EventType(contractEvent), flow.EventAccountCreated}, subscribeEvents.Filter.EventTypes())
+	assert.True(t, subscribeEvents.Filter.Match(flow.Event{Type: flow.EventAccountCreated}))
+
+	// core events are dropped when filtering by contract
+	err = subscribeEvents.Parse("", "", []string{contractEvent, "flow.AccountCreated"}, nil, []string{fmt.Sprintf("A.%s.Foo", address)}, chain)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), subscribeEvents.StartHeight)
+	assert.True(t, subscribeEvents.Filter.Match(flow.Event{Type: flow.EventType(contractEvent)}))
+	assert.False(t, subscribeEvents.Filter.Match(flow.Event{Type: flow.EventAccountCreated}))
+
+	// events of any type are selected when filtering by address or contract only
+	err = subscribeEvents.Parse("", "", nil, []string{address.String()}, nil, chain)
+	require.NoError(t, err)
+	assert.Empty(t, subscribeEvents.Filter.EventTypes())
+	assert.Equal(t, []flow.Address{address}, subscribeEvents.Filter.Addresses())
+	assert.True(t, subscribeEvents.Filter.Match(flow.Event{Type: flow.EventType(contractEvent)}))
+	assert.True(t, subscribeEvents.Filter.Match(flow.Event{Type: flow.EventType(fmt.Sprintf("A.%s.Foo.Baz", address))}))
+	assert.False(t, subscribeEvents.Filter.Match(flow.Event{Type: flow.EventAccountCreated}))
+}
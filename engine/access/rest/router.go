@@ -9,15 +9,35 @@ import (
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/middleware"
 	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/openapi"
 	"github.com/onflow/flow-go/model/flow"
 )
 
-func newRouter(backend access.API, logger zerolog.Logger, chain flow.Chain) (*mux.Router, error) {
+func newRouter(backend access.API, logger zerolog.Logger, chain flow.Chain, opts ...Option) (*mux.Router, error) {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	router := mux.NewRouter().StrictSlash(true)
 	v1SubRouter := router.PathPrefix("/v1").Subrouter()
 
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	specValidation, err := middleware.SpecValidation(logger, spec, MaxRequestSize, options.validateResponses)
+	if err != nil {
+		return nil, err
+	}
+
 	// common middleware for all request
 	v1SubRouter.Use(middleware.LoggingMiddleware(logger))
+	if options.quotas != nil {
+		v1SubRouter.Use(middleware.Quota(logger, options.quotas))
+	}
+	v1SubRouter.Use(specValidation)
 	v1SubRouter.Use(middleware.QueryExpandable())
 	v1SubRouter.Use(middleware.QuerySelect())
 
@@ -31,6 +51,16 @@ func newRouter(backend access.API, logger zerolog.Logger, chain flow.Chain) (*mu
 			Name(r.Name).
 			Handler(h)
 	}
+
+	for _, r := range WSRoutes {
+		h := NewWSHandler(logger, backend, r.Handler, r.BodyMessage, linkGenerator, chain)
+		v1SubRouter.
+			Methods(http.MethodGet).
+			Path(r.Pattern).
+			Name(r.Name).
+			Handler(h)
+	}
+
 	return router, nil
 }
 
@@ -41,16 +71,34 @@ type route struct {
 	Handler ApiHandlerFunc
 }
 
+type wsRoute struct {
+	Name    string
+	Pattern string
+	Handler SubscribeHandlerFunc
+	// BodyMessage is set if the client sends the body of the request as the first websocket message
+	BodyMessage bool
+}
+
 var Routes = []route{{
 	Method:  http.MethodGet,
 	Pattern: "/transactions/{id}",
 	Name:    "getTransactionByID",
 	Handler: GetTransactionByID,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/transactions",
+	Name:    "getTransactions",
+	Handler: GetTransactions,
 }, {
 	Method:  http.MethodPost,
 	Pattern: "/transactions",
 	Name:    "createTransaction",
 	Handler: CreateTransaction,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/transactions/simulate",
+	Name:    "simulateTransaction",
+	Handler: SimulateTransaction,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/transaction_results/{id}",
@@ -96,9 +144,44 @@ var Routes = []route{{
 	Pattern: "/accounts/{address}",
 	Name:    "getAccount",
 	Handler: GetAccount,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/keys",
+	Name:    "getAccountKeys",
+	Handler: GetAccountKeys,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/keys/{index}",
+	Name:    "getAccountKeyByIndex",
+	Handler: GetAccountKeyByIndex,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/balance",
+	Name:    "getAccountBalance",
+	Handler: GetAccountBalance,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/events",
 	Name:    "getEvents",
 	Handler: GetEvents,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/openapi.json",
+	Name:    "getOpenAPISpec",
+	Handler: GetOpenAPISpec,
+}}
+
+var WSRoutes = []wsRoute{{
+	Pattern: "/subscribe_events",
+	Name:    "subscribeEvents",
+	Handler: SubscribeEvents,
+}, {
+	Pattern: "/subscribe_transaction_statuses/{id}",
+	Name:    "subscribeTransactionStatuses",
+	Handler: SubscribeTransactionStatuses,
+}, {
+	Pattern:     "/send_and_subscribe_transaction_statuses",
+	Name:        "sendAndSubscribeTransactionStatuses",
+	Handler:     SendAndSubscribeTransactionStatuses,
+	BodyMessage: true,
 }}
@@ -8,13 +8,37 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/quota"
 	"github.com/onflow/flow-go/model/flow"
 )
 
+// Option configures the REST API handler.
+type Option func(*options)
+
+type options struct {
+	validateResponses bool
+	quotas            *quota.Limiter
+}
+
+// WithResponseValidation validates the responses against the OpenAPI spec of the API, invalid responses
+// are replaced with an internal error. Requests are always validated.
+func WithResponseValidation() Option {
+	return func(o *options) {
+		o.validateResponses = true
+	}
+}
+
+// WithQuotas checks the requests against the quota of their client.
+func WithQuotas(quotas *quota.Limiter) Option {
+	return func(o *options) {
+		o.quotas = quotas
+	}
+}
+
 // NewServer returns an HTTP server initialized with the REST API handler
-func NewServer(backend access.API, listenAddress string, logger zerolog.Logger, chain flow.Chain) (*http.Server, error) {
+func NewServer(backend access.API, listenAddress string, logger zerolog.Logger, chain flow.Chain, opts ...Option) (*http.Server, error) {
 
-	router, err := newRouter(backend, logger, chain)
+	router, err := newRouter(backend, logger, chain, opts...)
 	if err != nil {
 		return nil, err
 	}
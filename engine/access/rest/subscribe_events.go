@@ -0,0 +1,17 @@
+package rest
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+)
+
+// SubscribeEvents starts a subscription streaming the events of all sealed blocks, filtered
+// by event type, address and contract, starting at the requested block ID or height.
+func SubscribeEvents(r *request.Request, backend access.API) (access.Subscription, error) {
+	req, err := r.SubscribeEventsRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return backend.SubscribeEvents(r.Context(), req.StartBlockID, req.StartHeight, req.Filter), nil
+}
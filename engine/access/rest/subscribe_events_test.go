@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	eventType := "A.179b6b1cb6755e31.Foo.Bar"
+
+	t.Run("stream block events", func(t *testing.T) {
+		api := &mock.API{}
+		events := make([]flow.BlockEvents, 3)
+		sub := backend.NewSubscription(len(events))
+		for i := range events {
+			header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(uint64(i)))
+			events[i] = unittest.BlockEventsFixture(header, 2)
+			require.NoError(t, sub.Send(context.Background(), &events[i], time.Second))
+		}
+		sub.Fail(status.Error(codes.NotFound, "block not found"))
+
+		api.Mock.
+			On("SubscribeEvents", mocks.Anything, flow.ZeroID, uint64(10), mocks.Anything).
+			Return(sub)
+
+		conn := dialSubscription(t, api, subscribeEventsURL(t, eventType, "10"))
+		defer conn.Close()
+
+		for _, expected := range events {
+			_, msg, err := conn.ReadMessage()
+			require.NoError(t, err)
+
+			// the same response model is used for streaming and for the events endpoint
+			expectedResponse := testBlockEventResponse([]flow.BlockEvents{expected})
+			require.JSONEq(t, expectedResponse, "["+string(msg)+"]")
+		}
+
+		// the subscription error is returned in the close message
+		_, _, err := conn.ReadMessage()
+		var closeErr *websocket.CloseError
+		require.ErrorAs(t, err, &closeErr)
+		require.Equal(t, websocket.ClosePolicyViolation, closeErr.Code)
+		require.Equal(t, "block not found", closeErr.Text)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		api := &mock.API{}
+		req, err := http.NewRequest(http.MethodGet, subscribeEventsURL(t, eventType, "foo"), nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400,"message":"invalid start height: invalid height format"}`, api)
+	})
+}
+
+func subscribeEventsURL(t *testing.T, eventType string, startHeight string) string {
+	u, err := url.Parse("/v1/subscribe_events")
+	require.NoError(t, err)
+
+	q := u.Query()
+	q.Add("event_types", eventType)
+	q.Add(startHeightQueryParam, startHeight)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// dialSubscription starts a test server with the REST router and opens a websocket connection.
+func dialSubscription(t *testing.T, api *mock.API, path string) *websocket.Conn {
+	var b bytes.Buffer
+	router, err := newRouter(api, zerolog.New(&b), flow.Testnet.Chain(), WithResponseValidation())
+	require.NoError(t, err)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + path
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	return conn
+}
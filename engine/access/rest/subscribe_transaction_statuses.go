@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+)
+
+// SubscribeTransactionStatuses starts a subscription streaming the result of a transaction
+// every time its status changes, until the transaction is sealed or expired.
+func SubscribeTransactionStatuses(r *request.Request, backend access.API) (access.Subscription, error) {
+	req, err := r.SubscribeTransactionStatusesRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return backend.SubscribeTransactionStatuses(r.Context(), req.ID), nil
+}
+
+// SendAndSubscribeTransactionStatuses sends the transaction received as the first message of the client,
+// and starts a subscription streaming its result every time its status changes, until the transaction
+// is sealed or expired.
+func SendAndSubscribeTransactionStatuses(r *request.Request, backend access.API) (access.Subscription, error) {
+	req, err := r.CreateTransactionRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return backend.SendAndSubscribeTransactionStatuses(r.Context(), &req.Transaction), nil
+}
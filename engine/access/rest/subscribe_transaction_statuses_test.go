@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func subscribeTransactionStatusesURL(id string) string {
+	return fmt.Sprintf("/v1/subscribe_transaction_statuses/%s", id)
+}
+
+func TestSubscribeTransactionStatuses(t *testing.T) {
+
+	t.Run("stream statuses until sealed", func(t *testing.T) {
+		api := &mock.API{}
+		id := unittest.IdentifierFixture()
+		bid := unittest.IdentifierFixture()
+
+		statuses := []flow.TransactionStatus{
+			flow.TransactionStatusPending,
+			flow.TransactionStatusFinalized,
+			flow.TransactionStatusExecuted,
+			flow.TransactionStatusSealed,
+		}
+		sub := backend.NewSubscription(len(statuses))
+		for _, txStatus := range statuses {
+			txr := &access.TransactionResult{
+				Status:        txStatus,
+				BlockID:       bid,
+				TransactionID: id,
+			}
+			require.NoError(t, sub.Send(context.Background(), txr, time.Second))
+		}
+		sub.Close()
+
+		api.Mock.
+			On("SubscribeTransactionStatuses", mocks.Anything, id).
+			Return(sub)
+
+		conn := dialSubscription(t, api, subscribeTransactionStatusesURL(id.String()))
+		defer conn.Close()
+
+		for _, txStatus := range statuses {
+			_, msg, err := conn.ReadMessage()
+			require.NoError(t, err)
+
+			execution := models.PENDING_RESULT
+			if txStatus == flow.TransactionStatusSealed {
+				execution = models.SUCCESS_RESULT
+			}
+
+			expected := fmt.Sprintf(`{
+				"block_id": "%s",
+				"execution": "%s",
+				"status": "%s",
+				"status_code": 0,
+				"error_message": "",
+				"computation_used": "0",
+				"events": [],
+				"_links": {
+					"_self": "/v1/transaction_results/%s"
+				}
+			}`, bid.String(), execution, cases.Title(language.English).String(strings.ToLower(txStatus.String())), id.String())
+			require.JSONEq(t, expected, string(msg))
+		}
+
+		// the connection is closed normally once the subscription ends
+		_, _, err := conn.ReadMessage()
+		require.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		api := &mock.API{}
+		req, err := http.NewRequest(http.MethodGet, subscribeTransactionStatusesURL("invalid"), nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"invalid ID format"}`, api)
+	})
+}
+
+func TestSendAndSubscribeTransactionStatuses(t *testing.T) {
+	path := "/v1/send_and_subscribe_transaction_statuses"
+
+	t.Run("send transaction and stream statuses", func(t *testing.T) {
+		api := &mock.API{}
+		tx := unittest.TransactionBodyFixture()
+		tx.PayloadSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+		tx.Arguments = [][]uint8{}
+		bid := unittest.IdentifierFixture()
+
+		sub := backend.NewSubscription(1)
+		require.NoError(t, sub.Send(context.Background(), &access.TransactionResult{
+			Status:        flow.TransactionStatusPending,
+			BlockID:       bid,
+			TransactionID: tx.ID(),
+		}, time.Second))
+		sub.Close()
+
+		api.Mock.
+			On("SendAndSubscribeTransactionStatuses", mocks.Anything, &tx).
+			Return(sub)
+
+		conn := dialSubscription(t, api, path)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteJSON(validCreateBody(tx)))
+
+		_, msg, err := conn.ReadMessage()
+		require.NoError(t, err)
+		require.Contains(t, string(msg), `"status":"Pending"`)
+
+		// the connection is closed normally once the subscription ends
+		_, _, err = conn.ReadMessage()
+		require.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+	})
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		api := &mock.API{}
+
+		conn := dialSubscription(t, api, path)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"script": "invalid"}`)))
+
+		_, _, err := conn.ReadMessage()
+		require.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation))
+		api.AssertNotCalled(t, "SendAndSubscribeTransactionStatuses", mocks.Anything, mocks.Anything)
+	})
+}
@@ -1,9 +1,12 @@
 package rest
 
 import (
+	"fmt"
+
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/models"
 	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/model/flow"
 )
 
 // GetTransactionByID gets a transaction by requested ID.
@@ -32,6 +35,84 @@ func GetTransactionByID(r *request.Request, backend access.API, link models.Link
 	return response, nil
 }
 
+// GetTransactions gets the transactions of a block height range, ordered by height and by index
+// within the block. The transactions are paginated, and each page scans at most
+// request.MaxBlockRequestHeightRange heights so that pages of sparse ranges are bounded too.
+func GetTransactions(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetTransactionsRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	// support providing end height as "sealed" or "final"
+	if req.EndHeight == request.FinalHeight || req.EndHeight == request.SealedHeight {
+		latest, err := backend.GetLatestBlockHeader(r.Context(), req.EndHeight == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		req.EndHeight = latest.Height
+		if req.StartHeight > req.EndHeight {
+			return nil, NewBadRequestError(fmt.Errorf("start height must be less than or equal to end height"))
+		}
+	}
+
+	startHeight := req.StartHeight
+	startIndex := uint64(0)
+	if req.Pagination.Cursor != nil {
+		startHeight = req.Pagination.Cursor.Height
+		startIndex = req.Pagination.Cursor.Index
+	}
+
+	scanEnd := req.EndHeight
+	if req.EndHeight-startHeight >= request.MaxBlockRequestHeightRange {
+		scanEnd = startHeight + request.MaxBlockRequestHeightRange - 1
+	}
+
+	transactions := make([]*flow.TransactionBody, 0)
+	var next *request.Cursor
+	for height := startHeight; height <= scanEnd; height++ {
+		header, err := backend.GetBlockHeaderByHeight(r.Context(), height)
+		if err != nil {
+			return nil, NewNotFoundError(fmt.Sprintf("error looking up block at height %d", height), err)
+		}
+
+		blockTransactions, err := backend.GetTransactionsByBlockID(r.Context(), header.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		count := uint64(len(blockTransactions))
+		if startIndex > count {
+			startIndex = count
+		}
+
+		// stop within the block if the page is full, the next page continues at the following index
+		remaining := req.Pagination.Limit - uint64(len(transactions))
+		if count-startIndex > remaining {
+			transactions = append(transactions, blockTransactions[startIndex:startIndex+remaining]...)
+			next = &request.Cursor{Height: height, Index: startIndex + remaining}
+			break
+		}
+
+		transactions = append(transactions, blockTransactions[startIndex:]...)
+		startIndex = 0
+	}
+
+	if next == nil && scanEnd < req.EndHeight {
+		next = &request.Cursor{Height: scanEnd + 1}
+	}
+
+	nextLink, err := nextPageLink(r, link.TransactionsPageLink, next, req.EndHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.Transactions
+	response.Build(transactions, link)
+	return models.Page{Items: response, NextLink: nextLink}, nil
+}
+
 // GetTransactionResultByID retrieves transaction result by the transaction ID.
 func GetTransactionResultByID(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
 	req, err := r.GetTransactionResultRequest()
@@ -65,3 +146,21 @@ func CreateTransaction(r *request.Request, backend access.API, link models.LinkG
 	response.Build(&req.Transaction, nil, link)
 	return response, nil
 }
+
+// SimulateTransaction executes the provided transaction against the latest sealed state without
+// submitting it, and returns its result and estimated fees.
+func SimulateTransaction(r *request.Request, backend access.API, link models.LinkGenerator) (interface{}, error) {
+	req, err := r.SimulateTransactionRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	result, err := backend.SimulateTransaction(r.Context(), &req.Transaction, req.SkipSignatureVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.SimulatedTransactionResult
+	response.Build(result)
+	return response, nil
+}
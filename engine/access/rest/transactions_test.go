@@ -9,7 +9,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/stretchr/testify/assert"
 	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
@@ -18,6 +21,7 @@ import (
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/access/mock"
 	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
 	"github.com/onflow/flow-go/engine/access/rest/util"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -47,6 +51,19 @@ func createTransactionReq(body interface{}) *http.Request {
 	return req
 }
 
+func simulateTransactionReq(body interface{}, skipSignatureVerification string) *http.Request {
+	u, _ := url.Parse("/v1/transactions/simulate")
+	if skipSignatureVerification != "" {
+		q := u.Query()
+		q.Add("skip_signature_verification", skipSignatureVerification)
+		u.RawQuery = q.Encode()
+	}
+
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
+	return req
+}
+
 func validCreateBody(tx flow.TransactionBody) map[string]interface{} {
 	tx.Arguments = [][]uint8{} // fix how fixture creates nil values
 	auth := make([]string, len(tx.Authorizers))
@@ -216,6 +233,73 @@ func TestGetTransactions(t *testing.T) {
 	})
 }
 
+func TestGetTransactionsByHeightRange(t *testing.T) {
+	backend := &mock.API{}
+
+	// blocks at heights 1 to 3 with 2, 0 and 3 transactions
+	var transactions []*flow.TransactionBody
+	for height, count := range []int{2, 0, 3} {
+		header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(uint64(height + 1)))
+		blockTransactions := make([]*flow.TransactionBody, count)
+		for i := range blockTransactions {
+			tx := unittest.TransactionBodyFixture()
+			blockTransactions[i] = &tx
+		}
+		transactions = append(transactions, blockTransactions...)
+
+		backend.Mock.
+			On("GetBlockHeaderByHeight", mocks.Anything, header.Height).
+			Return(&header, nil)
+		backend.Mock.
+			On("GetTransactionsByBlockID", mocks.Anything, header.ID()).
+			Return(blockTransactions, nil)
+	}
+
+	query := url.Values{}
+	query.Set("start_height", "1")
+	query.Set("end_height", "3")
+	query.Set("limit", "3")
+
+	// the first page ends within the last block, the next page continues at the following index
+	req := getTransactionsReq(query)
+	rr, err := executeRequest(req, backend)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assertTransactionIDs(t, transactions[:3], rr.Body.Bytes())
+
+	query.Set("cursor", request.Cursor{Height: 3, Index: 1}.String())
+	require.Equal(t, fmt.Sprintf(`</v1/transactions?%s>; rel="next"`, query.Encode()), rr.Header().Get("Link"))
+
+	rr, err = executeRequest(getTransactionsReq(query), backend)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assertTransactionIDs(t, transactions[3:], rr.Body.Bytes())
+	require.Empty(t, rr.Header().Get("Link"))
+
+	t.Run("invalid range", func(t *testing.T) {
+		query := url.Values{}
+		query.Set("start_height", "3")
+		query.Set("end_height", "1")
+
+		expected := `{"code":400, "message":"start height must be less than or equal to end height"}`
+		assertResponse(t, getTransactionsReq(query), http.StatusBadRequest, expected, backend)
+	})
+}
+
+func getTransactionsReq(query url.Values) *http.Request {
+	req, _ := http.NewRequest("GET", "/v1/transactions?"+query.Encode(), nil)
+	return req
+}
+
+func assertTransactionIDs(t *testing.T, expected []*flow.TransactionBody, body []byte) {
+	var response []models.Transaction
+	require.NoError(t, json.Unmarshal(body, &response))
+	require.Len(t, response, len(expected))
+	for i, tx := range expected {
+		assert.Equal(t, tx.ID().String(), response[i].Id)
+	}
+}
+
 func TestGetTransactionResult(t *testing.T) {
 
 	t.Run("get by ID", func(t *testing.T) {
@@ -404,6 +488,109 @@ func TestCreateTransaction(t *testing.T) {
 	})
 }
 
+func TestSimulateTransaction(t *testing.T) {
+	tx := unittest.TransactionBodyFixture()
+	tx.PayloadSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+	tx.Arguments = [][]uint8{}
+
+	event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID(), 255)
+	result := &access.SimulatedTransactionResult{
+		BlockID:         unittest.IdentifierFixture(),
+		BlockHeight:     42,
+		Events:          []flow.Event{event},
+		ErrorCode:       1101,
+		ErrorMessage:    "cadence runtime error",
+		ComputationUsed: 12,
+		MemoryUsed:      300,
+		ComputationIntensities: map[common.ComputationKind]uint{
+			common.ComputationKindStatement: 10,
+		},
+		MemoryIntensities: map[common.MemoryKind]uint{
+			common.MemoryKindString: 300,
+		},
+		Fees: 1000,
+	}
+
+	expected := fmt.Sprintf(`
+		{
+			"block_id": "%s",
+			"block_height": "42",
+			"error_code": "1101",
+			"error_message": "cadence runtime error",
+			"computation_used": "12",
+			"memory_used": "300",
+			"computation_intensities": {"%d": "10"},
+			"memory_intensities": {"%d": "300"},
+			"fees": "1000",
+			"events": [{
+				"type": "%s",
+				"transaction_id": "%s",
+				"transaction_index": "0",
+				"event_index": "0",
+				"payload": "%s"
+			}]
+		}`,
+		result.BlockID, common.ComputationKindStatement, common.MemoryKindString,
+		event.Type, event.TransactionID, util.ToBase64(event.Payload))
+
+	t.Run("simulate signed transaction", func(t *testing.T) {
+		backend := &mock.API{}
+		req := simulateTransactionReq(validCreateBody(tx), "")
+
+		backend.Mock.
+			On("SimulateTransaction", mocks.Anything, &tx, false).
+			Return(result, nil)
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("simulate unsigned transaction", func(t *testing.T) {
+		backend := &mock.API{}
+		body := validCreateBody(tx)
+		body["payload_signatures"] = []interface{}{}
+		body["envelope_signatures"] = []interface{}{}
+		req := simulateTransactionReq(body, "true")
+
+		backend.Mock.
+			On("SimulateTransaction", mocks.Anything, mocks.MatchedBy(func(simulated *flow.TransactionBody) bool {
+				return simulated.ReferenceBlockID == tx.ReferenceBlockID && len(simulated.EnvelopeSignatures) == 0
+			}), true).
+			Return(result, nil)
+
+		assertOKResponse(t, req, expected, backend)
+	})
+
+	t.Run("signatures required when verified", func(t *testing.T) {
+		backend := &mock.API{}
+		body := validCreateBody(tx)
+		body["envelope_signatures"] = []interface{}{}
+		req := simulateTransactionReq(body, "false")
+
+		expected := `{"code":400, "message":"envelope signatures not provided"}`
+		assertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+
+	t.Run("invalid skip signature verification", func(t *testing.T) {
+		backend := &mock.API{}
+		req := simulateTransactionReq(validCreateBody(tx), "maybe")
+
+		expected := `{"code":400, "message":"invalid value for skip_signature_verification"}`
+		assertResponse(t, req, http.StatusBadRequest, expected, backend)
+	})
+
+	t.Run("simulation not enabled", func(t *testing.T) {
+		backend := &mock.API{}
+		req := simulateTransactionReq(validCreateBody(tx), "")
+
+		backend.Mock.
+			On("SimulateTransaction", mocks.Anything, &tx, false).
+			Return(nil, status.Error(codes.Unimplemented, "transaction simulation is not enabled on this node"))
+
+		expected := `{"code":501, "message":"Not supported by this node: transaction simulation is not enabled on this node"}`
+		assertResponse(t, req, http.StatusNotImplemented, expected, backend)
+	})
+}
+
 func transactionResultFixture(tx flow.Transaction) *access.TransactionResult {
 	return &access.TransactionResult{
 		Status:     flow.TransactionStatusSealed,
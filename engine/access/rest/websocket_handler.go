@@ -0,0 +1,265 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// time allowed to write a message to the client
+	writeWait = 10 * time.Second
+
+	// time allowed to read the next pong message from the client
+	pongWait = 60 * time.Second
+
+	// send pings to the client with this period, must be less than pongWait
+	pingPeriod = (pongWait * 9) / 10
+
+	// maximum message size allowed from the client, clients are not expected to send any data
+	maxWSReadSize = 1024
+)
+
+// SubscribeHandlerFunc is a function that contains the logic of a streaming endpoint,
+// it validates the request and starts a new subscription on the backend.
+type SubscribeHandlerFunc func(
+	r *request.Request,
+	backend access.API,
+) (access.Subscription, error)
+
+// WSHandler is the websocket counterpart of Handler. It upgrades the HTTP connection to a
+// websocket connection and forwards all messages of the subscription to the client.
+//
+// Request validation errors are returned as regular HTTP error responses, before the
+// connection is upgraded. Errors ending the subscription are sent as websocket close messages.
+//
+// If bodyMessage is set, the client sends the body of the request as the first websocket message,
+// since the websocket handshake has no body. The connection is then upgraded before the request
+// is validated, and validation errors are sent as websocket close messages as well.
+type WSHandler struct {
+	*Handler
+	subscribeFunc SubscribeHandlerFunc
+	bodyMessage   bool
+	upgrader      websocket.Upgrader
+}
+
+func NewWSHandler(
+	logger zerolog.Logger,
+	backend access.API,
+	subscribeFunc SubscribeHandlerFunc,
+	bodyMessage bool,
+	generator models.LinkGenerator,
+	chain flow.Chain,
+) *WSHandler {
+	return &WSHandler{
+		Handler:       NewHandler(logger, backend, nil, generator, chain),
+		subscribeFunc: subscribeFunc,
+		bodyMessage:   bodyMessage,
+		upgrader: websocket.Upgrader{
+			// allow all origins, same as the CORS policy of the REST server
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// ServeHTTP upgrades the connection and streams the subscription to the client until either
+// the client disconnects or the subscription ends.
+func (h *WSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With().Str("request_url", r.URL.String()).Logger()
+
+	err := r.ParseForm()
+	if err != nil {
+		h.errorHandler(w, err, logger)
+		return
+	}
+
+	// the subscription is stopped once the connection is closed
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	if h.bodyMessage {
+		h.serveBodyMessage(ctx, cancel, w, r, logger)
+		return
+	}
+
+	sub, err := h.subscribeFunc(request.Decorate(r.WithContext(ctx), h.chain), h.backend)
+	if err != nil {
+		h.errorHandler(w, err, logger)
+		return
+	}
+	logger = logger.With().Str("subscription_id", sub.ID()).Logger()
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied to the client with an HTTP error
+		logger.Debug().Err(err).Msg("could not upgrade connection")
+		return
+	}
+	defer conn.Close()
+
+	go h.readMessages(conn, cancel)
+
+	h.writeMessages(conn, sub, logger)
+}
+
+// serveBodyMessage upgrades the connection, and starts the subscription with the first message of
+// the client as the body of the request.
+func (h *WSHandler) serveBodyMessage(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger zerolog.Logger,
+) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied to the client with an HTTP error
+		logger.Debug().Err(err).Msg("could not upgrade connection")
+		return
+	}
+	defer conn.Close()
+
+	conn.SetReadLimit(MaxRequestSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	_, body, err := conn.ReadMessage()
+	if err != nil {
+		logger.Debug().Err(err).Msg("could not read request body")
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	sub, err := h.subscribeFunc(request.Decorate(r.WithContext(ctx), h.chain), h.backend)
+	if err != nil {
+		h.closeConnection(conn, err, logger)
+		return
+	}
+	logger = logger.With().Str("subscription_id", sub.ID()).Logger()
+
+	go h.readMessages(conn, cancel)
+
+	h.writeMessages(conn, sub, logger)
+}
+
+// readMessages handles the control messages sent by the client and cancels the subscription
+// once the client closes the connection or stops answering pings.
+func (h *WSHandler) readMessages(conn *websocket.Conn, cancel context.CancelFunc) {
+	defer cancel()
+
+	conn.SetReadLimit(maxWSReadSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+// writeMessages forwards the subscription messages to the client and keeps the connection alive.
+func (h *WSHandler) writeMessages(conn *websocket.Conn, sub access.Subscription, logger zerolog.Logger) {
+	pingTicker := time.NewTicker(pingPeriod)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case <-pingTicker.C:
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+			if err != nil {
+				logger.Debug().Err(err).Msg("could not ping client")
+				return
+			}
+
+		case msg, ok := <-sub.Channel():
+			if !ok {
+				h.closeConnection(conn, sub.Err(), logger)
+				return
+			}
+
+			response, err := streamResponse(msg, h.linkGenerator)
+			if err != nil {
+				logger.Error().Err(err).Msg("could not build subscription response")
+				h.closeConnection(conn, err, logger)
+				return
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			err = conn.WriteJSON(response)
+			if err != nil {
+				logger.Debug().Err(err).Msg("could not write subscription response")
+				return
+			}
+		}
+	}
+}
+
+// closeConnection sends a close message to the client, including the reason if the
+// subscription ended with an error.
+func (h *WSHandler) closeConnection(conn *websocket.Conn, err error, logger zerolog.Logger) {
+	code := websocket.CloseNormalClosure
+	reason := ""
+	if err != nil {
+		code = websocket.CloseInternalServerErr
+		var restErr StatusError
+		if errors.As(err, &restErr) {
+			reason = restErr.UserMessage()
+			if restErr.Status() == http.StatusBadRequest || restErr.Status() == http.StatusNotFound {
+				code = websocket.ClosePolicyViolation
+			}
+		} else if se, ok := status.FromError(err); ok {
+			reason = se.Message()
+			if se.Code() == codes.InvalidArgument || se.Code() == codes.NotFound {
+				code = websocket.ClosePolicyViolation
+			}
+		} else {
+			reason = "internal server error"
+			logger.Error().Err(err).Msg("subscription failed")
+		}
+	}
+
+	// close frames are limited in size, truncate the reason if necessary
+	if len(reason) > maxCloseReasonSize {
+		reason = reason[:maxCloseReasonSize]
+	}
+
+	msg := websocket.FormatCloseMessage(code, reason)
+	err = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+	if err != nil {
+		logger.Debug().Err(err).Msg("could not send close message")
+	}
+}
+
+// maxCloseReasonSize is the maximum size of the close reason, control frames are limited to 125 bytes
+const maxCloseReasonSize = 123
+
+// streamResponse converts a message received from a subscription into its response model.
+func streamResponse(msg interface{}, link models.LinkGenerator) (interface{}, error) {
+	switch v := msg.(type) {
+	case *flow.BlockEvents:
+		var response models.BlockEvents
+		response.Build(*v)
+		return response, nil
+	case *access.TransactionResult:
+		var response models.TransactionResult
+		response.Build(v, v.TransactionID, link)
+		return response, nil
+	default:
+		return nil, fmt.Errorf("unexpected subscription message type %T", msg)
+	}
+}
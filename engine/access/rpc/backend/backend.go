@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
@@ -70,6 +71,7 @@ type Backend struct {
 	executionReceipts    storage.ExecutionReceipts
 	connFactory          ConnectionFactory
 	snapshotHistoryLimit int
+	broadcaster          *engine.Broadcaster
 }
 
 func New(
@@ -91,12 +93,15 @@ func New(
 	fixedExecutionNodeIDs []string,
 	log zerolog.Logger,
 	snapshotHistoryLimit int,
+	opts ...Option,
 ) *Backend {
 	retry := newRetry()
 	if retryEnabled {
 		retry.Activate()
 	}
 
+	broadcaster := engine.NewBroadcaster()
+
 	b := &Backend{
 		state: state,
 		// create the sub-backends
@@ -125,6 +130,9 @@ func New(
 			connFactory:          connFactory,
 			previousAccessNodes:  historicalAccessNodes,
 			log:                  log,
+			broadcaster:          broadcaster,
+			sendTimeout:          DefaultSendTimeout,
+			sendBufferSize:       DefaultSendBufferSize,
 		},
 		backendEvents: backendEvents{
 			state:             state,
@@ -133,6 +141,9 @@ func New(
 			connFactory:       connFactory,
 			log:               log,
 			maxHeightRange:    maxHeightRange,
+			broadcaster:       broadcaster,
+			sendTimeout:       DefaultSendTimeout,
+			sendBufferSize:    DefaultSendBufferSize,
 		},
 		backendBlockHeaders: backendBlockHeaders{
 			headers: headers,
@@ -157,8 +168,15 @@ func New(
 		connFactory:          connFactory,
 		chainID:              chainID,
 		snapshotHistoryLimit: snapshotHistoryLimit,
+		broadcaster:          broadcaster,
+	}
+
+	for _, opt := range opts {
+		opt(b)
 	}
 
+	b.backendAccounts.scripts = &b.backendScripts
+
 	retry.SetBackend(b)
 
 	var err error
@@ -175,6 +193,32 @@ func New(
 	return b
 }
 
+// Option configures an optional dependency of the backend.
+type Option func(*Backend)
+
+// WithScriptExecutor executes scripts locally against the indexed execution state, instead of
+// on an execution node.
+func WithScriptExecutor(scriptExecutor ScriptExecutor) Option {
+	return func(b *Backend) {
+		b.backendScripts.scriptExecutor = scriptExecutor
+	}
+}
+
+// WithTransactionSimulator simulates transactions locally against the indexed execution state.
+func WithTransactionSimulator(transactionSimulator TransactionSimulator) Option {
+	return func(b *Backend) {
+		b.backendTransactions.transactionSimulator = transactionSimulator
+	}
+}
+
+// WithEventIndex answers event queries locally from the indexed events, instead of from an
+// execution node.
+func WithEventIndex(eventIndex storage.EventIndex) Option {
+	return func(b *Backend) {
+		b.backendEvents.eventIndex = eventIndex
+	}
+}
+
 func identifierList(ids []string) (flow.IdentifierList, error) {
 	idList := make(flow.IdentifierList, len(ids))
 	for i, idStr := range ids {
@@ -204,6 +248,14 @@ func configureTransactionValidator(state protocol.State, chainID flow.ChainID) *
 	)
 }
 
+// NotifyFinalizedBlockHeight is called whenever a new block is finalized. It retries pending
+// transactions and wakes up the streaming subscriptions, since finalizing a block may seal
+// new blocks.
+func (b *Backend) NotifyFinalizedBlockHeight(height uint64) {
+	b.backendTransactions.NotifyFinalizedBlockHeight(height)
+	b.broadcaster.Publish()
+}
+
 // Ping responds to requests when the server is up.
 func (b *Backend) Ping(ctx context.Context) error {
 
@@ -2,15 +2,19 @@ package backend
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -22,6 +26,7 @@ type backendAccounts struct {
 	executionReceipts storage.ExecutionReceipts
 	connFactory       ConnectionFactory
 	log               zerolog.Logger
+	scripts           *backendScripts // executes the script reading account balances
 }
 
 func (b *backendAccounts) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
@@ -71,6 +76,280 @@ func (b *backendAccounts) GetAccountAtBlockHeight(
 	return account, nil
 }
 
+// accountBalanceScript returns the balance of the account with the given address.
+const accountBalanceScript = `
+pub fun main(address: Address): UFix64 {
+	return getAccount(address).balance
+}
+`
+
+// GetAccountBalanceAtLatestBlock returns the balance of the account at the latest sealed block.
+func (b *backendAccounts) GetAccountBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error) {
+	latestHeader, err := b.state.Sealed().Head()
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
+	}
+
+	return b.getAccountBalance(ctx, address, latestHeader)
+}
+
+// GetAccountBalanceAtBlockHeight returns the balance of the account at the given block height.
+func (b *backendAccounts) GetAccountBalanceAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+) (uint64, error) {
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		return 0, convertStorageError(err)
+	}
+
+	return b.getAccountBalance(ctx, address, header)
+}
+
+// getAccountBalance returns the balance of the account at the given block. The balance is read with
+// a script, so that only the balance is returned instead of the whole account with its contracts.
+func (b *backendAccounts) getAccountBalance(ctx context.Context, address flow.Address, header *flow.Header) (uint64, error) {
+	argument, err := jsoncdc.Encode(cadence.NewAddress(address))
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to encode address: %v", err)
+	}
+
+	result, err := b.scripts.executeScript(ctx, header, []byte(accountBalanceScript), [][]byte{argument})
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := jsoncdc.Decode(nil, result)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to decode account balance: %v", err)
+	}
+	balance, ok := value.(cadence.UFix64)
+	if !ok {
+		return 0, status.Errorf(codes.Internal, "unexpected account balance type %T", value)
+	}
+
+	return uint64(balance), nil
+}
+
+// GetAccountKeysAtLatestBlock returns the public keys of the account at the latest sealed block.
+func (b *backendAccounts) GetAccountKeysAtLatestBlock(
+	ctx context.Context,
+	address flow.Address,
+) ([]flow.AccountPublicKey, error) {
+	latestHeader, err := b.state.Sealed().Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
+	}
+
+	return b.getAccountKeys(ctx, address, latestHeader.ID())
+}
+
+// GetAccountKeysAtBlockHeight returns the public keys of the account at the given block height.
+func (b *backendAccounts) GetAccountKeysAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+) ([]flow.AccountPublicKey, error) {
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+
+	return b.getAccountKeys(ctx, address, header.ID())
+}
+
+// GetAccountKeyAtLatestBlock returns the public key with the given index of the account at the
+// latest sealed block.
+func (b *backendAccounts) GetAccountKeyAtLatestBlock(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint64,
+) (*flow.AccountPublicKey, error) {
+	latestHeader, err := b.state.Sealed().Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
+	}
+
+	return b.getAccountKey(ctx, address, keyIndex, latestHeader.ID())
+}
+
+// GetAccountKeyAtBlockHeight returns the public key with the given index of the account at the
+// given block height.
+func (b *backendAccounts) GetAccountKeyAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint64,
+	height uint64,
+) (*flow.AccountPublicKey, error) {
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+
+	return b.getAccountKey(ctx, address, keyIndex, header.ID())
+}
+
+// getAccountKeys returns the public keys of the account at the given block. The keys are read from the
+// registers of the account on the execution nodes, so that the contracts of the account are not fetched.
+func (b *backendAccounts) getAccountKeys(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+) ([]flow.AccountPublicKey, error) {
+	registers, err := b.getAccountRegisters(ctx, address, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := registers.publicKeyCount()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]flow.AccountPublicKey, 0, count)
+	for keyIndex := uint64(0); keyIndex < count; keyIndex++ {
+		key, err := registers.publicKey(keyIndex)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, nil
+}
+
+// getAccountKey returns the public key with the given index of the account at the given block.
+func (b *backendAccounts) getAccountKey(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint64,
+	blockID flow.Identifier,
+) (*flow.AccountPublicKey, error) {
+	registers, err := b.getAccountRegisters(ctx, address, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := registers.publicKeyCount()
+	if err != nil {
+		return nil, err
+	}
+	if keyIndex >= count {
+		return nil, status.Errorf(codes.NotFound, "failed to find key %d for account %s", keyIndex, address)
+	}
+
+	return registers.publicKey(keyIndex)
+}
+
+// getAccountRegisters returns a reader of the registers of the given account at the given block. It returns
+// a codes.NotFound error if the account does not exist.
+func (b *backendAccounts) getAccountRegisters(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+) (*accountRegisters, error) {
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return nil, getAccountError(err)
+	}
+
+	registers := &accountRegisters{
+		ctx:       ctx,
+		backend:   b,
+		execNodes: execNodes,
+		address:   address,
+		blockID:   blockID,
+	}
+
+	exists, err := registers.get(false, state.KeyExists)
+	if err != nil {
+		return nil, err
+	}
+	if len(exists) == 0 {
+		return nil, status.Errorf(codes.NotFound, "account %s does not exist at block %v", address, blockID)
+	}
+
+	return registers, nil
+}
+
+// accountRegisters reads the registers of an account at a block from the execution nodes.
+type accountRegisters struct {
+	ctx       context.Context
+	backend   *backendAccounts
+	execNodes flow.IdentityList
+	address   flow.Address
+	blockID   flow.Identifier
+}
+
+// get returns the value of the register of the account with the given key, which is empty if the
+// register is not set. Registers of the account controller are owned and controlled by the account.
+func (r *accountRegisters) get(isController bool, key string) ([]byte, error) {
+	owner := r.address.Bytes()
+	var controller []byte
+	if isController {
+		controller = owner
+	}
+
+	req := execproto.GetRegisterAtBlockIDRequest{
+		BlockId:            r.blockID[:],
+		RegisterOwner:      owner,
+		RegisterController: controller,
+		RegisterKey:        []byte(key),
+	}
+
+	var errors *multierror.Error
+	for _, execNode := range r.execNodes {
+		resp, err := r.backend.tryGetRegister(r.ctx, execNode, req)
+		if err == nil {
+			return resp.GetValue(), nil
+		}
+		r.backend.log.Error().
+			Str("execution_node", execNode.String()).
+			Hex("block_id", r.blockID[:]).
+			Hex("address", owner).
+			Str("register", key).
+			Err(err).
+			Msg("failed to execute GetRegisterAtBlockID")
+		errors = multierror.Append(errors, err)
+	}
+
+	return nil, status.Errorf(codes.Internal, "failed to get account register from the execution node: %v", errors.ErrorOrNil())
+}
+
+// publicKeyCount returns the number of public keys of the account.
+func (r *accountRegisters) publicKeyCount() (uint64, error) {
+	countBytes, err := r.get(true, state.KeyPublicKeyCount)
+	if err != nil {
+		return 0, err
+	}
+
+	count := new(big.Int).SetBytes(countBytes)
+	if !count.IsUint64() {
+		return 0, status.Errorf(codes.Internal, "invalid public key count %x of account %s", countBytes, r.address)
+	}
+
+	return count.Uint64(), nil
+}
+
+// publicKey returns the public key of the account with the given index.
+func (r *accountRegisters) publicKey(keyIndex uint64) (*flow.AccountPublicKey, error) {
+	encoded, err := r.get(true, state.KeyPublicKey(keyIndex))
+	if err != nil {
+		return nil, err
+	}
+	if len(encoded) == 0 {
+		return nil, status.Errorf(codes.NotFound, "failed to find key %d for account %s", keyIndex, r.address)
+	}
+
+	key, err := flow.DecodeAccountPublicKey(encoded, keyIndex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode key %d of account %s: %v", keyIndex, r.address, err)
+	}
+
+	return &key, nil
+}
+
 func (b *backendAccounts) getAccountAtBlockID(
 	ctx context.Context,
 	address flow.Address,
@@ -152,6 +431,15 @@ func (b *backendAccounts) getAccountFromAnyExeNode(ctx context.Context, execNode
 	return nil, status.Errorf(codes.NotFound, "failed to get account from the execution node: %v", errToReturn)
 }
 
+func (b *backendAccounts) tryGetRegister(ctx context.Context, execNode *flow.Identity, req execproto.GetRegisterAtBlockIDRequest) (*execproto.GetRegisterAtBlockIDResponse, error) {
+	execRPCClient, closer, err := b.connFactory.GetExecutionAPIClient(execNode.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return execRPCClient.GetRegisterAtBlockID(ctx, &req)
+}
+
 func (b *backendAccounts) tryGetAccount(ctx context.Context, execNode *flow.Identity, req execproto.GetAccountAtBlockIDRequest) (*execproto.GetAccountAtBlockIDResponse, error) {
 	execRPCClient, closer, err := b.connFactory.GetExecutionAPIClient(execNode.Address)
 	if err != nil {
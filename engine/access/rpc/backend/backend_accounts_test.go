@@ -0,0 +1,131 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountKeysAndBalanceAtBlockHeight tests that the account keys are read from the registers of the
+// account and the balance with a script, without fetching the whole account from the execution node.
+func (suite *Suite) TestGetAccountKeysAndBalanceAtBlockHeight() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	height := uint64(5)
+	account, err := unittest.AccountFixture()
+	suite.Require().NoError(err)
+	ctx := context.Background()
+
+	b := unittest.BlockFixture()
+	h := b.Header
+	suite.headers.
+		On("ByHeight", height).
+		Return(h, nil)
+
+	_, ids := suite.setupReceipts(&b)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	blockID := h.ID()
+	owner := account.Address.Bytes()
+	registers := map[string][]byte{
+		state.KeyExists:         {1},
+		state.KeyPublicKeyCount: new(big.Int).SetUint64(uint64(len(account.Keys))).Bytes(),
+	}
+	for _, key := range account.Keys {
+		encoded, err := flow.EncodeAccountPublicKey(key)
+		suite.Require().NoError(err)
+		registers[state.KeyPublicKey(uint64(key.Index))] = encoded
+	}
+	suite.execClient.
+		On("GetRegisterAtBlockID", ctx, mock.Anything).
+		Return(func(_ context.Context, req *execproto.GetRegisterAtBlockIDRequest, _ ...grpc.CallOption) *execproto.GetRegisterAtBlockIDResponse {
+			suite.Require().Equal(blockID[:], req.GetBlockId())
+			if !bytes.Equal(owner, req.GetRegisterOwner()) {
+				// registers of other accounts are not set
+				return &execproto.GetRegisterAtBlockIDResponse{}
+			}
+			return &execproto.GetRegisterAtBlockIDResponse{Value: registers[string(req.GetRegisterKey())]}
+		}, nil)
+
+	balance, err := jsoncdc.Encode(cadence.UFix64(account.Balance))
+	suite.Require().NoError(err)
+	address, err := jsoncdc.Encode(cadence.NewAddress(account.Address))
+	suite.Require().NoError(err)
+	scriptReq := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId:   blockID[:],
+		Script:    []byte(accountBalanceScript),
+		Arguments: [][]byte{address},
+	}
+	suite.execClient.
+		On("ExecuteScriptAtBlockID", ctx, scriptReq).
+		Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: balance}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		suite.headers,
+		nil,
+		nil,
+		suite.receipts,
+		suite.results,
+		flow.Testnet,
+		metrics.NewNoopCollector(),
+		suite.setupConnectionFactory(),
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+	)
+
+	suite.Run("balance", func() {
+		balance, err := backend.GetAccountBalanceAtBlockHeight(ctx, account.Address, height)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(account.Balance, balance)
+	})
+
+	suite.Run("all keys", func() {
+		keys, err := backend.GetAccountKeysAtBlockHeight(ctx, account.Address, height)
+		suite.Require().NoError(err)
+		suite.Require().Len(keys, len(account.Keys))
+		suite.Assert().Equal(account.Keys[0].PublicKey, keys[0].PublicKey)
+	})
+
+	suite.Run("key by index", func() {
+		key, err := backend.GetAccountKeyAtBlockHeight(ctx, account.Address, uint64(account.Keys[0].Index), height)
+		suite.checkResponse(key, err)
+		suite.Assert().Equal(account.Keys[0].PublicKey, key.PublicKey)
+		suite.Assert().Equal(account.Keys[0].Weight, key.Weight)
+	})
+
+	suite.Run("missing key index", func() {
+		_, err := backend.GetAccountKeyAtBlockHeight(ctx, account.Address, uint64(len(account.Keys)), height)
+		suite.Assert().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("missing account", func() {
+		_, err := backend.GetAccountKeysAtBlockHeight(ctx, unittest.RandomAddressFixture(), height)
+		suite.Assert().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.execClient.AssertNotCalled(suite.T(), "GetAccountAtBlockID", mock.Anything, mock.Anything)
+
+	suite.assertAllExpectations()
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
@@ -12,12 +14,18 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
 
+// MaxIndexedHeightRange is the maximum height range of event queries answered with the local
+// event index, which is much cheaper to query than execution nodes.
+const MaxIndexedHeightRange = 10_000
+
 type backendEvents struct {
 	headers           storage.Headers
 	executionReceipts storage.ExecutionReceipts
@@ -25,6 +33,12 @@ type backendEvents struct {
 	connFactory       ConnectionFactory
 	log               zerolog.Logger
 	maxHeightRange    uint
+	eventIndex        storage.EventIndex // optional, events are retrieved from execution nodes if nil
+
+	// streaming
+	broadcaster    *engine.Broadcaster // notified whenever a new block is finalized
+	sendTimeout    time.Duration
+	sendBufferSize int
 }
 
 // GetEventsForHeightRange retrieves events for all sealed blocks between the start block height and
@@ -39,9 +53,14 @@ func (b *backendEvents) GetEventsForHeightRange(
 		return nil, status.Error(codes.InvalidArgument, "invalid start or end height")
 	}
 
+	// wider ranges are allowed if the events are indexed locally
 	rangeSize := endHeight - startHeight + 1 // range is inclusive on both ends
-	if rangeSize > uint64(b.maxHeightRange) {
-		return nil, status.Errorf(codes.InvalidArgument, "requested block range (%d) exceeded maximum (%d)", rangeSize, b.maxHeightRange)
+	maxHeightRange := uint64(b.maxHeightRange)
+	if b.eventIndex != nil {
+		maxHeightRange = MaxIndexedHeightRange
+	}
+	if rangeSize > maxHeightRange {
+		return nil, status.Errorf(codes.InvalidArgument, "requested block range (%d) exceeded maximum (%d)", rangeSize, maxHeightRange)
 	}
 
 	// get the latest sealed block header
@@ -61,6 +80,15 @@ func (b *backendEvents) GetEventsForHeightRange(
 		endHeight = head.Height
 	}
 
+	if b.isIndexed(startHeight, endHeight) {
+		return b.getIndexedBlocksEvents(startHeight, endHeight, storage.EventQuery{Type: flow.EventType(eventType)})
+	}
+
+	rangeSize = endHeight - startHeight + 1
+	if rangeSize > uint64(b.maxHeightRange) {
+		return nil, status.Errorf(codes.InvalidArgument, "requested block range (%d) exceeded maximum (%d) for blocks which are not indexed", rangeSize, b.maxHeightRange)
+	}
+
 	// find the block headers for all the blocks between min and max height (inclusive)
 	blockHeaders := make([]*flow.Header, 0)
 
@@ -102,6 +130,334 @@ func (b *backendEvents) GetEventsForBlockIDs(
 	return b.getBlockEventsFromExecutionNode(ctx, blockHeaders, eventType)
 }
 
+// GetEventsByQuery retrieves the events matching the query for all sealed blocks between the start
+// and end height (inclusive) from the local event index. Unlike GetEventsForHeightRange, only the
+// blocks with matching events are returned.
+func (b *backendEvents) GetEventsByQuery(
+	_ context.Context,
+	startHeight, endHeight uint64,
+	query storage.EventQuery,
+) ([]flow.BlockEvents, error) {
+	if b.eventIndex == nil {
+		return nil, status.Error(codes.Unimplemented, "events are not indexed by this node")
+	}
+
+	if endHeight < startHeight {
+		return nil, status.Error(codes.InvalidArgument, "invalid start or end height")
+	}
+
+	rangeSize := endHeight - startHeight + 1 // range is inclusive on both ends
+	if rangeSize > MaxIndexedHeightRange {
+		return nil, status.Errorf(codes.InvalidArgument, "requested block range (%d) exceeded maximum (%d)", rangeSize, MaxIndexedHeightRange)
+	}
+
+	blocksEvents, err := b.eventIndex.ByHeightRange(startHeight, endHeight, query)
+	if errors.Is(err, storage.ErrHeightNotIndexed) {
+		return nil, status.Errorf(codes.OutOfRange, "failed to get events: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get events: %v", err)
+	}
+
+	for i := range blocksEvents {
+		header, err := b.headers.ByBlockID(blocksEvents[i].BlockID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get events: %v", err)
+		}
+		blocksEvents[i].BlockTimestamp = header.Timestamp
+	}
+
+	return blocksEvents, nil
+}
+
+// isIndexed returns true if the events of all blocks of the height range are indexed locally.
+func (b *backendEvents) isIndexed(startHeight, endHeight uint64) bool {
+	if b.eventIndex == nil {
+		return false
+	}
+
+	firstHeight, latestHeight, err := b.eventIndex.IndexedRange()
+	if err != nil {
+		return false
+	}
+
+	return startHeight >= firstHeight && endHeight <= latestHeight
+}
+
+// getIndexedBlocksEvents returns the events matching the query for all blocks between the start and
+// end height (inclusive) from the local event index. One flow.BlockEvents is returned for each
+// block, ordered by height, as execution nodes do.
+func (b *backendEvents) getIndexedBlocksEvents(
+	startHeight, endHeight uint64,
+	query storage.EventQuery,
+) ([]flow.BlockEvents, error) {
+	indexed, err := b.eventIndex.ByHeightRange(startHeight, endHeight, query)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get indexed events: %v", err)
+	}
+
+	eventsByHeight := make(map[uint64][]flow.Event, len(indexed))
+	for _, blockEvents := range indexed {
+		eventsByHeight[blockEvents.BlockHeight] = blockEvents.Events
+	}
+
+	results := make([]flow.BlockEvents, 0, endHeight-startHeight+1)
+	for height := startHeight; height <= endHeight; height++ {
+		header, err := b.headers.ByHeight(height)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get events: %v", err)
+		}
+
+		events, ok := eventsByHeight[height]
+		if !ok {
+			events = []flow.Event{}
+		}
+
+		results = append(results, flow.BlockEvents{
+			BlockID:        header.ID(),
+			BlockHeight:    header.Height,
+			BlockTimestamp: header.Timestamp,
+			Events:         events,
+		})
+	}
+
+	return results, nil
+}
+
+// SubscribeEvents streams the events matching the filter for every sealed block, in height order,
+// starting at the given start block ID or start height. At most one of startBlockID and startHeight
+// may be provided; if neither is, streaming starts at the latest sealed block.
+//
+// A flow.BlockEvents message is sent for every sealed block, even if no event matched the filter,
+// so that clients can use the last received block height as a cursor to resume the stream.
+func (b *backendEvents) SubscribeEvents(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	startHeight uint64,
+	filter access.EventFilter,
+) access.Subscription {
+	nextHeight, err := b.getStartHeight(startBlockID, startHeight)
+	if err != nil {
+		return NewFailedSubscription(err, "could not get start height")
+	}
+
+	sub := NewSubscription(b.sendBufferSize)
+	go b.streamEvents(ctx, sub, nextHeight, filter)
+
+	return sub
+}
+
+// getStartHeight returns the height of the first block to stream events for.
+func (b *backendEvents) getStartHeight(startBlockID flow.Identifier, startHeight uint64) (uint64, error) {
+	if startBlockID != flow.ZeroID && startHeight > 0 {
+		return 0, status.Error(codes.InvalidArgument, "only one of start block ID and start height may be provided")
+	}
+
+	if startBlockID != flow.ZeroID {
+		header, err := b.headers.ByBlockID(startBlockID)
+		if err != nil {
+			return 0, convertStorageError(err)
+		}
+		return header.Height, nil
+	}
+
+	if startHeight > 0 {
+		root, err := b.state.Params().Root()
+		if err != nil {
+			return 0, status.Errorf(codes.Internal, "could not get root block: %v", err)
+		}
+		if startHeight < root.Height {
+			return 0, status.Errorf(codes.InvalidArgument,
+				"start height %d is lower than the root block height %d", startHeight, root.Height)
+		}
+		return startHeight, nil
+	}
+
+	head, err := b.state.Sealed().Head()
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "could not get latest sealed block: %v", err)
+	}
+	return head.Height, nil
+}
+
+// streamEvents sends the events of all sealed blocks starting at nextHeight to the subscription.
+// It runs until the context is canceled, the client stops consuming messages, or an error occurs.
+func (b *backendEvents) streamEvents(
+	ctx context.Context,
+	sub *SubscriptionImpl,
+	nextHeight uint64,
+	filter access.EventFilter,
+) {
+	log := b.log.With().Str("subscription_id", sub.ID()).Logger()
+
+	notifier := engine.NewNotifier()
+	b.broadcaster.Subscribe(notifier)
+	defer b.broadcaster.Unsubscribe(notifier)
+
+	// blocks sealed before the subscription started should be streamed right away
+	notifier.Notify()
+
+	for {
+		select {
+		case <-ctx.Done():
+			sub.Fail(status.FromContextError(ctx.Err()).Err())
+			return
+		case <-notifier.Channel():
+		}
+
+		sealed, err := b.state.Sealed().Head()
+		if err != nil {
+			sub.Fail(status.Errorf(codes.Internal, "could not get latest sealed block: %v", err))
+			return
+		}
+
+		for nextHeight <= sealed.Height {
+			// fetch events in batches to reduce the number of round trips to execution nodes
+			// when the client is catching up
+			endHeight := sealed.Height
+			if endHeight-nextHeight >= uint64(b.maxHeightRange) {
+				endHeight = nextHeight + uint64(b.maxHeightRange) - 1
+			}
+
+			blocksEvents, err := b.getSealedBlocksEvents(ctx, nextHeight, endHeight, filter)
+			if err != nil {
+				sub.Fail(err)
+				return
+			}
+
+			for i := range blocksEvents {
+				err = sub.Send(ctx, &blocksEvents[i], b.sendTimeout)
+				if err != nil {
+					log.Debug().Err(err).Uint64("height", blocksEvents[i].BlockHeight).Msg("could not send events")
+					sub.Fail(status.FromContextError(err).Err())
+					return
+				}
+			}
+
+			nextHeight = endHeight + 1
+		}
+	}
+}
+
+// getSealedBlocksEvents returns the events matching the filter for all blocks between the start
+// and end height (inclusive). One flow.BlockEvents is returned for each block, ordered by height.
+func (b *backendEvents) getSealedBlocksEvents(
+	ctx context.Context,
+	startHeight, endHeight uint64,
+	filter access.EventFilter,
+) ([]flow.BlockEvents, error) {
+
+	if len(filter.EventTypes()) == 0 {
+		return b.getIndexedFilteredEvents(startHeight, endHeight, filter)
+	}
+
+	blockHeaders := make([]*flow.Header, 0, endHeight-startHeight+1)
+	for height := startHeight; height <= endHeight; height++ {
+		header, err := b.headers.ByHeight(height)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get block at height %d: %v", height, err)
+		}
+		blockHeaders = append(blockHeaders, header)
+	}
+
+	results := make([]flow.BlockEvents, len(blockHeaders))
+	for i, header := range blockHeaders {
+		results[i] = flow.BlockEvents{
+			BlockID:        header.ID(),
+			BlockHeight:    header.Height,
+			BlockTimestamp: header.Timestamp,
+			Events:         []flow.Event{},
+		}
+	}
+
+	// execution nodes index events by type, so each requested type is fetched separately
+	for _, eventType := range filter.EventTypes() {
+		blocksEvents, err := b.getBlockEventsFromExecutionNode(ctx, blockHeaders, string(eventType))
+		if err != nil {
+			return nil, err
+		}
+
+		// execution nodes are not required to return the results in the requested order
+		resultsByBlockID := make(map[flow.Identifier]flow.BlockEvents, len(blocksEvents))
+		for _, blockEvents := range blocksEvents {
+			resultsByBlockID[blockEvents.BlockID] = blockEvents
+		}
+
+		for i := range results {
+			results[i].Events = append(results[i].Events, filter.Filter(resultsByBlockID[results[i].BlockID].Events)...)
+		}
+	}
+
+	// restore the execution order of the events across all event types
+	for _, result := range results {
+		events := result.Events
+		sort.Slice(events, func(i, j int) bool {
+			if events[i].TransactionIndex != events[j].TransactionIndex {
+				return events[i].TransactionIndex < events[j].TransactionIndex
+			}
+			return events[i].EventIndex < events[j].EventIndex
+		})
+	}
+
+	return results, nil
+}
+
+// getIndexedFilteredEvents returns the events matching a filter without event types for all blocks
+// between the start and end height (inclusive) from the local event index, since execution nodes
+// can only be queried by event type. One flow.BlockEvents is returned for each block, ordered by height.
+func (b *backendEvents) getIndexedFilteredEvents(
+	startHeight, endHeight uint64,
+	filter access.EventFilter,
+) ([]flow.BlockEvents, error) {
+	if !b.isIndexed(startHeight, endHeight) {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"events of heights %d to %d are not indexed, filters without event types require the local event index", startHeight, endHeight)
+	}
+
+	// a contract filter selects events of the contract addresses, so the contracts are queried if given
+	var queries []storage.EventQuery
+	for _, contract := range filter.Contracts() {
+		queries = append(queries, storage.EventQuery{Contract: contract})
+	}
+	if len(queries) == 0 {
+		for _, address := range filter.Addresses() {
+			queries = append(queries, storage.EventQuery{Address: address})
+		}
+	}
+
+	var results []flow.BlockEvents
+	for _, query := range queries {
+		blocksEvents, err := b.getIndexedBlocksEvents(startHeight, endHeight, query)
+		if err != nil {
+			return nil, err
+		}
+
+		if results == nil {
+			results = blocksEvents
+			for i := range results {
+				results[i].Events = filter.Filter(results[i].Events)
+			}
+			continue
+		}
+		for i := range results {
+			results[i].Events = append(results[i].Events, filter.Filter(blocksEvents[i].Events)...)
+		}
+	}
+
+	// restore the execution order of the events across all queries
+	for _, result := range results {
+		events := result.Events
+		sort.Slice(events, func(i, j int) bool {
+			if events[i].TransactionIndex != events[j].TransactionIndex {
+				return events[i].TransactionIndex < events[j].TransactionIndex
+			}
+			return events[i].EventIndex < events[j].EventIndex
+		})
+	}
+
+	return results, nil
+}
+
 func (b *backendEvents) getBlockEventsFromExecutionNode(
 	ctx context.Context,
 	blockHeaders []*flow.Header,
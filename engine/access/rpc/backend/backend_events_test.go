@@ -0,0 +1,305 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSubscribeEvents tests that events of already sealed blocks are streamed in height order,
+// and that the subscription ends once the client goes away.
+func (suite *Suite) TestSubscribeEvents() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	// create a chain of sealed blocks
+	blocks := make([]*flow.Block, 3)
+	blockHeaders := make([]*flow.Header, len(blocks))
+	parent := unittest.BlockHeaderFixture()
+	for i := range blocks {
+		block := unittest.BlockWithParentFixture(&parent)
+		blocks[i] = block
+		blockHeaders[i] = block.Header
+		parent = *block.Header
+
+		suite.headers.On("ByHeight", block.Header.Height).Return(block.Header, nil)
+	}
+	suite.headers.On("ByBlockID", blocks[0].ID()).Return(blocks[0].Header, nil)
+	suite.snapshot.On("Head").Return(blocks[len(blocks)-1].Header, nil)
+
+	// the last block of the batch is used to choose the execution nodes
+	_, executorIDs := suite.setupReceipts(blocks[len(blocks)-1])
+	suite.snapshot.On("Identities", mock.Anything).Return(executorIDs, nil)
+
+	events := getEvents(2)
+	exeResults := make([]*execproto.GetEventsForBlockIDsResponse_Result, len(blockHeaders))
+	blockIDs := make([]flow.Identifier, len(blockHeaders))
+	for i, header := range blockHeaders {
+		blockIDs[i] = header.ID()
+		exeResults[i] = &execproto.GetEventsForBlockIDsResponse_Result{
+			BlockId:     convert.IdentifierToMessage(header.ID()),
+			BlockHeight: header.Height,
+			Events:      convert.EventsToMessages(events),
+		}
+	}
+
+	exeReq := &execproto.GetEventsForBlockIDsRequest{
+		BlockIds: convert.IdentifiersToMessages(blockIDs),
+		Type:     string(flow.EventAccountCreated),
+	}
+	suite.execClient.
+		On("GetEventsForBlockIDs", mock.Anything, exeReq).
+		Return(&execproto.GetEventsForBlockIDsResponse{Results: exeResults}, nil).
+		Once()
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		suite.headers,
+		nil,
+		nil,
+		suite.receipts,
+		nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		suite.setupConnectionFactory(),
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		flow.IdentifierList(executorIDs.NodeIDs()).Strings(),
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+	)
+
+	filter, err := access.NewEventFilter(suite.chainID.Chain(), []string{string(flow.EventAccountCreated)}, nil, nil)
+	suite.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := backend.SubscribeEvents(ctx, blocks[0].ID(), 0, filter)
+
+	for _, header := range blockHeaders {
+		select {
+		case msg, ok := <-sub.Channel():
+			suite.Require().True(ok, "subscription closed unexpectedly: %v", sub.Err())
+
+			blockEvents, ok := msg.(*flow.BlockEvents)
+			suite.Require().True(ok)
+			suite.Assert().Equal(header.ID(), blockEvents.BlockID)
+			suite.Assert().Equal(header.Height, blockEvents.BlockHeight)
+			suite.Assert().Equal(events, blockEvents.Events)
+		case <-time.After(time.Second):
+			suite.FailNow("timed out waiting for block events")
+		}
+	}
+
+	// stopping the client ends the subscription
+	cancel()
+	unittest.RequireReturnsBefore(suite.T(), func() {
+		for range sub.Channel() {
+		}
+	}, time.Second, "subscription was not closed")
+	suite.Assert().Equal(codes.Canceled, status.Code(sub.Err()))
+
+	suite.Run("with both start block ID and start height", func() {
+		sub := backend.SubscribeEvents(context.Background(), blocks[0].ID(), blocks[0].Header.Height, filter)
+
+		_, ok := <-sub.Channel()
+		suite.Require().False(ok)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(sub.Err()))
+	})
+
+	suite.assertAllExpectations()
+}
+
+// TestSubscribeEventsWithoutEventTypes tests that the events of a filter without event types are
+// streamed from the local event index, and that such a filter is rejected for blocks which are not indexed.
+func (suite *Suite) TestSubscribeEventsWithoutEventTypes() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+
+	blocks := make([]*flow.Block, 3)
+	parent := unittest.BlockHeaderFixture()
+	for i := range blocks {
+		block := unittest.BlockWithParentFixture(&parent)
+		blocks[i] = block
+		parent = *block.Header
+
+		suite.headers.On("ByHeight", block.Header.Height).Return(block.Header, nil)
+	}
+	suite.snapshot.On("Head").Return(blocks[len(blocks)-1].Header, nil)
+
+	startHeight := blocks[0].Header.Height
+	endHeight := blocks[len(blocks)-1].Header.Height
+
+	address := suite.chainID.Chain().ServiceAddress()
+	contract := fmt.Sprintf("A.%s.Foo", address)
+	events := []flow.Event{
+		{Type: flow.EventType(contract + ".Bar"), TransactionIndex: 0, EventIndex: 0},
+		{Type: flow.EventType(contract + ".Baz"), TransactionIndex: 1, EventIndex: 0},
+	}
+
+	eventIndex := new(storagemock.EventIndex)
+	eventIndex.
+		On("ByHeightRange", startHeight, endHeight, storage.EventQuery{Contract: contract}).
+		Return([]flow.BlockEvents{{BlockID: blocks[1].ID(), BlockHeight: blocks[1].Header.Height, Events: events}}, nil)
+
+	backend := New(suite.state, nil, nil, nil, suite.headers, nil, nil, suite.receipts, nil, suite.chainID,
+		metrics.NewNoopCollector(), nil, false, DefaultMaxHeightRange, nil, nil, suite.log,
+		DefaultSnapshotHistoryLimit, WithEventIndex(eventIndex))
+
+	filter, err := access.NewEventFilter(suite.chainID.Chain(), nil, nil, []string{contract})
+	suite.Require().NoError(err)
+
+	suite.Run("indexed blocks", func() {
+		eventIndex.On("IndexedRange").Return(startHeight, endHeight, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sub := backend.SubscribeEvents(ctx, flow.ZeroID, startHeight, filter)
+		for i, block := range blocks {
+			select {
+			case msg, ok := <-sub.Channel():
+				suite.Require().True(ok, "subscription closed unexpectedly: %v", sub.Err())
+
+				blockEvents, ok := msg.(*flow.BlockEvents)
+				suite.Require().True(ok)
+				suite.Assert().Equal(block.ID(), blockEvents.BlockID)
+				if i == 1 {
+					suite.Assert().Equal(events, blockEvents.Events)
+				} else {
+					suite.Assert().Empty(blockEvents.Events)
+				}
+			case <-time.After(time.Second):
+				suite.FailNow("timed out waiting for block events")
+			}
+		}
+	})
+
+	suite.Run("blocks which are not indexed", func() {
+		eventIndex.On("IndexedRange").Return(startHeight+1, endHeight, nil).Once()
+
+		sub := backend.SubscribeEvents(context.Background(), flow.ZeroID, startHeight, filter)
+		unittest.RequireReturnsBefore(suite.T(), func() {
+			for range sub.Channel() {
+			}
+		}, time.Second, "subscription was not closed")
+		suite.Assert().Equal(codes.FailedPrecondition, status.Code(sub.Err()))
+	})
+
+	eventIndex.AssertExpectations(suite.T())
+}
+
+// TestGetEventsFromIndex tests that events of indexed blocks are retrieved from the local event
+// index, with wider height ranges than allowed for execution nodes.
+func (suite *Suite) TestGetEventsFromIndex() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+
+	blocks := make([]*flow.Block, 3)
+	parent := unittest.BlockHeaderFixture()
+	for i := range blocks {
+		block := unittest.BlockWithParentFixture(&parent)
+		blocks[i] = block
+		parent = *block.Header
+
+		suite.headers.On("ByHeight", block.Header.Height).Return(block.Header, nil)
+		suite.headers.On("ByBlockID", block.ID()).Return(block.Header, nil)
+	}
+	suite.snapshot.On("Head").Return(blocks[len(blocks)-1].Header, nil)
+
+	startHeight := blocks[0].Header.Height
+	endHeight := blocks[len(blocks)-1].Header.Height
+
+	// only the second block has matching events
+	events := getEvents(2)
+	eventIndex := new(storagemock.EventIndex)
+	eventIndex.On("IndexedRange").Return(startHeight, endHeight, nil)
+	eventIndex.
+		On("ByHeightRange", startHeight, endHeight, storage.EventQuery{Type: flow.EventAccountCreated}).
+		Return([]flow.BlockEvents{{BlockID: blocks[1].ID(), BlockHeight: blocks[1].Header.Height, Events: events}}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		suite.headers,
+		nil,
+		nil,
+		suite.receipts,
+		nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		2, // lower than the requested range, which is allowed for indexed blocks
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+		WithEventIndex(eventIndex),
+	)
+
+	suite.Run("for height range", func() {
+		actual, err := backend.GetEventsForHeightRange(context.Background(), string(flow.EventAccountCreated), startHeight, endHeight)
+		suite.Require().NoError(err)
+		suite.Require().Len(actual, len(blocks))
+		for i, block := range blocks {
+			suite.Assert().Equal(block.ID(), actual[i].BlockID)
+			suite.Assert().Equal(block.Header.Timestamp, actual[i].BlockTimestamp)
+		}
+		suite.Assert().Empty(actual[0].Events)
+		suite.Assert().Equal(events, actual[1].Events)
+		suite.Assert().Empty(actual[2].Events)
+	})
+
+	suite.Run("by query", func() {
+		query := storage.EventQuery{Address: flow.HexToAddress("01"), TransactionID: events[0].TransactionID}
+		eventIndex.
+			On("ByHeightRange", startHeight, endHeight, query).
+			Return([]flow.BlockEvents{{BlockID: blocks[1].ID(), BlockHeight: blocks[1].Header.Height, Events: events[:1]}}, nil)
+
+		actual, err := backend.GetEventsByQuery(context.Background(), startHeight, endHeight, query)
+		suite.Require().NoError(err)
+		suite.Require().Len(actual, 1)
+		suite.Assert().Equal(blocks[1].Header.Timestamp, actual[0].BlockTimestamp)
+		suite.Assert().Equal(events[:1], actual[0].Events)
+	})
+
+	suite.Run("by query for blocks which are not indexed", func() {
+		query := storage.EventQuery{Type: flow.EventAccountCreated}
+		eventIndex.
+			On("ByHeightRange", startHeight, endHeight+1, query).
+			Return(nil, storage.ErrHeightNotIndexed)
+
+		_, err := backend.GetEventsByQuery(context.Background(), startHeight, endHeight+1, query)
+		suite.Assert().Equal(codes.OutOfRange, status.Code(err))
+	})
+
+	suite.Run("by query without event index", func() {
+		backend := New(suite.state, nil, nil, nil, suite.headers, nil, nil, suite.receipts, nil, suite.chainID,
+			metrics.NewNoopCollector(), nil, false, DefaultMaxHeightRange, nil, nil, suite.log,
+			DefaultSnapshotHistoryLimit)
+
+		_, err := backend.GetEventsByQuery(context.Background(), startHeight, endHeight, storage.EventQuery{})
+		suite.Assert().Equal(codes.Unimplemented, status.Code(err))
+	})
+
+	eventIndex.AssertExpectations(suite.T())
+}
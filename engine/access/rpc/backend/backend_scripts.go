@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 	"crypto/md5" //nolint:gosec
+	"errors"
 	"sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
@@ -20,6 +22,14 @@ import (
 // uniqueScriptLoggingTimeWindow is the duration for checking the uniqueness of scripts sent for execution
 const uniqueScriptLoggingTimeWindow = 10 * time.Minute
 
+// ScriptExecutor executes scripts locally, against the execution state indexed by the node.
+type ScriptExecutor interface {
+	// ExecuteAtBlockHeight executes the script at the given height and returns the JSON-CDC encoded
+	// result. It returns storage.ErrHeightNotIndexed if the state at the height is not indexed, and
+	// execution.ErrScriptFailed if the script failed.
+	ExecuteAtBlockHeight(ctx context.Context, script []byte, arguments [][]byte, height uint64) ([]byte, error)
+}
+
 type backendScripts struct {
 	headers           storage.Headers
 	executionReceipts storage.ExecutionReceipts
@@ -27,6 +37,7 @@ type backendScripts struct {
 	connFactory       ConnectionFactory
 	log               zerolog.Logger
 	seenScripts       *scriptMap
+	scriptExecutor    ScriptExecutor // optional, scripts are only executed on execution nodes if nil
 }
 
 type scriptMap struct {
@@ -59,11 +70,8 @@ func (b *backendScripts) ExecuteScriptAtLatestBlock(
 		return nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
 	}
 
-	// get the block id of the latest sealed header
-	latestBlockID := latestHeader.ID()
-
-	// execute script on the execution node at that block id
-	return b.executeScriptOnExecutionNode(ctx, latestBlockID, script, arguments)
+	// execute script at the latest sealed block
+	return b.executeScript(ctx, latestHeader, script, arguments)
 }
 
 func (b *backendScripts) ExecuteScriptAtBlockID(
@@ -72,8 +80,18 @@ func (b *backendScripts) ExecuteScriptAtBlockID(
 	script []byte,
 	arguments [][]byte,
 ) ([]byte, error) {
-	// execute script on the execution node at that block id
-	return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	if b.scriptExecutor == nil {
+		// execute script on the execution node at that block id
+		return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	}
+
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		err = convertStorageError(err)
+		return nil, err
+	}
+
+	return b.executeScript(ctx, header, script, arguments)
 }
 
 func (b *backendScripts) ExecuteScriptAtBlockHeight(
@@ -89,10 +107,40 @@ func (b *backendScripts) ExecuteScriptAtBlockHeight(
 		return nil, err
 	}
 
+	return b.executeScript(ctx, header, script, arguments)
+}
+
+// executeScript executes the script locally if the execution state at the block is indexed,
+// otherwise it forwards the request to the execution nodes.
+func (b *backendScripts) executeScript(
+	ctx context.Context,
+	header *flow.Header,
+	script []byte,
+	arguments [][]byte,
+) ([]byte, error) {
 	blockID := header.ID()
 
-	// execute script on the execution node at that block id
-	return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	if b.scriptExecutor == nil {
+		return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	}
+
+	result, err := b.scriptExecutor.ExecuteAtBlockHeight(ctx, script, arguments, header.Height)
+	if err == nil {
+		return result, nil
+	}
+
+	switch {
+	case errors.Is(err, storage.ErrHeightNotIndexed):
+		b.log.Debug().
+			Uint64("height", header.Height).
+			Msg("execution state not indexed at height, executing script on execution node")
+		return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	case errors.Is(err, execution.ErrScriptFailed):
+		return nil, status.Errorf(codes.InvalidArgument, "failed to execute script: %v", err)
+	default:
+		b.log.Error().Err(err).Uint64("height", header.Height).Msg("failed to execute script locally")
+		return nil, status.Errorf(codes.Internal, "failed to execute script: %v", err)
+	}
 }
 
 // executeScriptOnExecutionNode forwards the request to the execution node using the execution node
@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestExecuteScriptLocally tests that scripts are executed with the local script executor, and
+// forwarded to execution nodes if the execution state at the block is not indexed.
+func (suite *Suite) TestExecuteScriptLocally() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	ctx := context.Background()
+	script := []byte("dummy script")
+	arguments := [][]byte(nil)
+
+	b := unittest.BlockFixture()
+	h := b.Header
+	suite.headers.
+		On("ByHeight", h.Height).
+		Return(h, nil)
+
+	_, ids := suite.setupReceipts(&b)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	scriptExecutor := new(backendmock.ScriptExecutor)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		suite.headers,
+		nil,
+		nil,
+		suite.receipts,
+		suite.results,
+		flow.Testnet,
+		metrics.NewNoopCollector(),
+		suite.setupConnectionFactory(),
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+		WithScriptExecutor(scriptExecutor),
+	)
+
+	suite.Run("executes script locally", func() {
+		expected := []byte{1, 2, 3}
+		scriptExecutor.
+			On("ExecuteAtBlockHeight", ctx, script, arguments, h.Height).
+			Return(expected, nil).Once()
+
+		result, err := backend.ExecuteScriptAtBlockHeight(ctx, h.Height, script, arguments)
+		suite.checkResponse(result, err)
+		suite.Assert().Equal(expected, result)
+	})
+
+	suite.Run("forwards script to execution node if height is not indexed", func() {
+		expected := []byte{4, 5, 6}
+		scriptExecutor.
+			On("ExecuteAtBlockHeight", ctx, script, arguments, h.Height).
+			Return(nil, fmt.Errorf("not indexed: %w", storage.ErrHeightNotIndexed)).Once()
+
+		blockID := h.ID()
+		execReq := &execproto.ExecuteScriptAtBlockIDRequest{
+			BlockId:   blockID[:],
+			Script:    script,
+			Arguments: arguments,
+		}
+		suite.execClient.
+			On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: expected}, nil).Once()
+
+		result, err := backend.ExecuteScriptAtBlockHeight(ctx, h.Height, script, arguments)
+		suite.checkResponse(result, err)
+		suite.Assert().Equal(expected, result)
+	})
+
+	suite.Run("failed script returns status code InvalidArgument", func() {
+		scriptExecutor.
+			On("ExecuteAtBlockHeight", ctx, script, arguments, h.Height).
+			Return(nil, fmt.Errorf("%w: panic", execution.ErrScriptFailed)).Once()
+
+		_, err := backend.ExecuteScriptAtBlockHeight(ctx, h.Height, script, arguments)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	scriptExecutor.AssertExpectations(suite.T())
+	suite.assertAllExpectations()
+}
@@ -15,6 +15,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/fvm/blueprints"
 	"github.com/onflow/flow-go/model/flow"
@@ -25,6 +26,14 @@ import (
 
 const collectionNodesToTry uint = 3
 
+// TransactionSimulator executes transactions locally, against the execution state indexed by the
+// node, without committing their changes.
+type TransactionSimulator interface {
+	// Simulate executes the transaction at the latest indexed height. Failures of the transaction
+	// are reported in the result.
+	Simulate(ctx context.Context, tx *flow.TransactionBody, skipSignatureVerification bool) (*access.SimulatedTransactionResult, error)
+}
+
 type backendTransactions struct {
 	staticCollectionRPC  accessproto.AccessAPIClient // rpc client tied to a fixed collection node
 	transactions         storage.Transactions
@@ -38,8 +47,14 @@ type backendTransactions struct {
 	retry                *Retry
 	connFactory          ConnectionFactory
 
-	previousAccessNodes []accessproto.AccessAPIClient
-	log                 zerolog.Logger
+	previousAccessNodes  []accessproto.AccessAPIClient
+	log                  zerolog.Logger
+	transactionSimulator TransactionSimulator // optional, transactions can not be simulated if nil
+
+	// streaming
+	broadcaster    *engine.Broadcaster // notified whenever a new block is finalized
+	sendTimeout    time.Duration
+	sendBufferSize int
 }
 
 // SendTransaction forwards the transaction to the collection node
@@ -215,12 +230,12 @@ func (b *backendTransactions) GetTransactionsByBlockID(
 		transactions = append(transactions, collection.Transactions...)
 	}
 
-	systemTx, err := blueprints.SystemChunkTransaction(b.chainID.Chain())
+	systemTxs, err := blueprints.SystemChunkTransactions(b.chainID.Chain(), block.Header.Height, blueprints.SystemTasksForChain(b.chainID))
 	if err != nil {
-		return nil, fmt.Errorf("could not get system chunk transaction: %w", err)
+		return nil, fmt.Errorf("could not get system chunk transactions: %w", err)
 	}
 
-	transactions = append(transactions, systemTx)
+	transactions = append(transactions, systemTxs...)
 
 	return transactions, nil
 }
@@ -354,31 +369,36 @@ func (b *backendTransactions) GetTransactionResultsByBlockID(
 		}
 	}
 
-	// system chunk transaction
-	if i >= len(resp.TransactionResults) {
+	// system chunk transactions
+	systemTxs, err := blueprints.SystemChunkTransactions(b.chainID.Chain(), block.Header.Height, blueprints.SystemTasksForChain(b.chainID))
+	if err != nil {
+		return nil, fmt.Errorf("could not get system chunk transactions: %w", err)
+	}
+
+	if i+len(systemTxs) > len(resp.TransactionResults) {
 		return nil, errInsufficientResults
-	} else if i < len(resp.TransactionResults)-1 {
+	} else if i+len(systemTxs) < len(resp.TransactionResults) {
 		return nil, status.Errorf(codes.Internal, "number of transaction results returned by execution node is more than the number of transactions in the block")
 	}
 
-	systemTx, err := blueprints.SystemChunkTransaction(b.chainID.Chain())
-	if err != nil {
-		return nil, fmt.Errorf("could not get system chunk transaction: %w", err)
-	}
-	systemTxResult := resp.TransactionResults[len(resp.TransactionResults)-1]
-	systemTxStatus, err := b.deriveTransactionStatus(systemTx, true, block)
-	if err != nil {
-		return nil, convertStorageError(err)
-	}
+	for _, systemTx := range systemTxs {
+		systemTxResult := resp.TransactionResults[i]
+		systemTxStatus, err := b.deriveTransactionStatus(systemTx, true, block)
+		if err != nil {
+			return nil, convertStorageError(err)
+		}
 
-	results = append(results, &access.TransactionResult{
-		Status:        systemTxStatus,
-		StatusCode:    uint(systemTxResult.GetStatusCode()),
-		Events:        convert.MessagesToEvents(systemTxResult.GetEvents()),
-		ErrorMessage:  systemTxResult.GetErrorMessage(),
-		BlockID:       blockID,
-		TransactionID: systemTx.ID(),
-	})
+		results = append(results, &access.TransactionResult{
+			Status:        systemTxStatus,
+			StatusCode:    uint(systemTxResult.GetStatusCode()),
+			Events:        convert.MessagesToEvents(systemTxResult.GetEvents()),
+			ErrorMessage:  systemTxResult.GetErrorMessage(),
+			BlockID:       blockID,
+			TransactionID: systemTx.ID(),
+		})
+
+		i++
+	}
 
 	return results, nil
 }
@@ -434,6 +454,116 @@ func (b *backendTransactions) GetTransactionResultByIndex(
 	}, nil
 }
 
+// SubscribeTransactionStatuses streams the status transitions of the transaction with the given ID.
+// A message containing the transaction result is sent every time a change of the status of the
+// transaction is observed, and the subscription ends once the transaction is either sealed or expired.
+// Only observed statuses are sent: a transaction which advances by several statuses between two
+// finalized blocks (e.g. is executed and sealed) skips the intermediate statuses.
+func (b *backendTransactions) SubscribeTransactionStatuses(
+	ctx context.Context,
+	txID flow.Identifier,
+) access.Subscription {
+	sub := NewSubscription(b.sendBufferSize)
+	go b.streamTransactionStatuses(ctx, sub, txID)
+
+	return sub
+}
+
+// SendAndSubscribeTransactionStatuses sends the transaction to the collection nodes and streams
+// its status transitions. See SubscribeTransactionStatuses.
+func (b *backendTransactions) SendAndSubscribeTransactionStatuses(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+) access.Subscription {
+	err := b.SendTransaction(ctx, tx)
+	if err != nil {
+		return NewFailedSubscription(err, "failed to send transaction")
+	}
+
+	return b.SubscribeTransactionStatuses(ctx, tx.ID())
+}
+
+// SimulateTransaction executes the transaction against the latest indexed sealed execution state
+// without committing it, and returns its events, errors, computation used and fees.
+func (b *backendTransactions) SimulateTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	skipSignatureVerification bool,
+) (*access.SimulatedTransactionResult, error) {
+	if b.transactionSimulator == nil {
+		return nil, status.Errorf(codes.Unimplemented, "transaction simulation is not enabled on this node")
+	}
+
+	if len(tx.Script) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %s", access.IncompleteTransactionError{
+			MissingFields: []string{"script"},
+		}.Error())
+	}
+
+	result, err := b.transactionSimulator.Simulate(ctx, tx, skipSignatureVerification)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to simulate transaction: %v", err)
+	}
+
+	return result, nil
+}
+
+// streamTransactionStatuses sends a message to the subscription every time a new status of the
+// transaction is observed. The status is derived again every time a new block is finalized, since
+// new blocks may include, execute or seal the transaction, or expire it.
+func (b *backendTransactions) streamTransactionStatuses(
+	ctx context.Context,
+	sub *SubscriptionImpl,
+	txID flow.Identifier,
+) {
+	log := b.log.With().
+		Str("subscription_id", sub.ID()).
+		Hex("transaction_id", txID[:]).
+		Logger()
+
+	notifier := engine.NewNotifier()
+	b.broadcaster.Subscribe(notifier)
+	defer b.broadcaster.Unsubscribe(notifier)
+
+	// send the current status right away
+	notifier.Notify()
+
+	var lastStatus *flow.TransactionStatus
+	for {
+		select {
+		case <-ctx.Done():
+			sub.Fail(status.FromContextError(ctx.Err()).Err())
+			return
+		case <-notifier.Channel():
+		}
+
+		result, err := b.GetTransactionResult(ctx, txID)
+		if err != nil {
+			sub.Fail(err)
+			return
+		}
+
+		if lastStatus != nil && *lastStatus == result.Status {
+			continue
+		}
+
+		err = sub.Send(ctx, result, b.sendTimeout)
+		if err != nil {
+			log.Debug().Err(err).Str("status", result.Status.String()).Msg("could not send transaction status")
+			sub.Fail(status.FromContextError(err).Err())
+			return
+		}
+
+		currentStatus := result.Status
+		lastStatus = &currentStatus
+
+		if currentStatus == flow.TransactionStatusSealed || currentStatus == flow.TransactionStatusExpired {
+			sub.Close()
+			return
+		}
+	}
+}
+
 // deriveTransactionStatus derives the transaction status based on current protocol state
 func (b *backendTransactions) deriveTransactionStatus(
 	tx *flow.TransactionBody,
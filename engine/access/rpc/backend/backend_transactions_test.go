@@ -0,0 +1,187 @@
+package backend
+
+import (
+	"context"
+	"time"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSubscribeTransactionStatuses tests that the observed statuses of a transaction are streamed,
+// without the statuses skipped between two finalized blocks, and that the subscription ends once
+// the transaction is sealed.
+func (suite *Suite) TestSubscribeTransactionStatuses() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	collection := unittest.CollectionFixture(1)
+	transactionBody := collection.Transactions[0]
+	block := unittest.BlockFixture()
+	block.Header.Height = 2
+	headBlock := unittest.BlockFixture()
+	headBlock.Header.Height = block.Header.Height - 1 // head is behind the current block
+
+	suite.snapshot.
+		On("Head").
+		Return(headBlock.Header, nil)
+
+	light := collection.Light()
+	suite.transactions.
+		On("ByID", transactionBody.ID()).
+		Return(transactionBody, nil)
+	suite.collections.
+		On("LightByTransactionID", transactionBody.ID()).
+		Return(&light, nil)
+	suite.blocks.
+		On("ByCollectionID", collection.ID()).
+		Return(&block, nil)
+
+	txID := transactionBody.ID()
+	blockID := block.ID()
+	_, fixedENIDs := suite.setupReceipts(&block)
+	suite.snapshot.On("Identities", mock.Anything).Return(fixedENIDs, nil)
+
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionAPIClient", mock.Anything).Return(suite.execClient, &mockCloser{}, nil)
+
+	exeEventReq := execproto.GetTransactionResultRequest{
+		BlockId:       blockID[:],
+		TransactionId: txID[:],
+	}
+
+	// the transaction is not executed yet when subscribing
+	suite.execClient.
+		On("GetTransactionResult", mock.Anything, &exeEventReq).
+		Return(nil, status.Errorf(codes.NotFound, "not found")).
+		Once()
+	suite.execClient.
+		On("GetTransactionResult", mock.Anything, &exeEventReq).
+		Return(&execproto.GetTransactionResultResponse{}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.receipts,
+		suite.results,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		flow.IdentifierList(fixedENIDs.NodeIDs()).Strings(),
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+	)
+
+	sub := backend.SubscribeTransactionStatuses(context.Background(), txID)
+
+	receive := func() *access.TransactionResult {
+		select {
+		case msg, ok := <-sub.Channel():
+			suite.Require().True(ok, "subscription closed unexpectedly: %v", sub.Err())
+
+			result, ok := msg.(*access.TransactionResult)
+			suite.Require().True(ok)
+			suite.Assert().Equal(blockID, result.BlockID)
+			return result
+		case <-time.After(time.Second):
+			suite.FailNow("timed out waiting for transaction status")
+		}
+		return nil
+	}
+
+	// the current status is sent right away
+	suite.Assert().Equal(flow.TransactionStatusFinalized, receive().Status)
+
+	// the block gets sealed, which skips the executed status
+	headBlock.Header.Height = block.Header.Height + 1
+	backend.NotifyFinalizedBlockHeight(headBlock.Header.Height)
+
+	suite.Assert().Equal(flow.TransactionStatusSealed, receive().Status)
+
+	// the subscription ends once the transaction is sealed
+	unittest.RequireReturnsBefore(suite.T(), func() {
+		for range sub.Channel() {
+		}
+	}, time.Second, "subscription was not closed")
+	suite.Assert().NoError(sub.Err())
+
+	suite.assertAllExpectations()
+}
+
+// TestSimulateTransaction tests that transactions are simulated with the local transaction
+// simulator, and that simulation is reported as unimplemented if there is none.
+func (suite *Suite) TestSimulateTransaction() {
+	ctx := context.Background()
+	tx := unittest.TransactionBodyFixture()
+
+	newBackend := func(simulator TransactionSimulator) *Backend {
+		return New(
+			suite.state,
+			nil,
+			nil,
+			nil,
+			suite.headers,
+			nil,
+			nil,
+			suite.receipts,
+			suite.results,
+			suite.chainID,
+			metrics.NewNoopCollector(),
+			nil,
+			false,
+			DefaultMaxHeightRange,
+			nil,
+			nil,
+			suite.log,
+			DefaultSnapshotHistoryLimit,
+			WithTransactionSimulator(simulator),
+		)
+	}
+
+	suite.Run("simulates transaction locally", func() {
+		expected := &access.SimulatedTransactionResult{
+			BlockID:         unittest.IdentifierFixture(),
+			ComputationUsed: 10,
+			Fees:            100,
+		}
+
+		simulator := new(backendmock.TransactionSimulator)
+		simulator.On("Simulate", ctx, &tx, true).Return(expected, nil).Once()
+
+		result, err := newBackend(simulator).SimulateTransaction(ctx, &tx, true)
+		suite.checkResponse(result, err)
+		suite.Assert().Equal(expected, result)
+		simulator.AssertExpectations(suite.T())
+	})
+
+	suite.Run("missing script", func() {
+		simulator := new(backendmock.TransactionSimulator)
+
+		invalid := tx
+		invalid.Script = nil
+		_, err := newBackend(simulator).SimulateTransaction(ctx, &invalid, false)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+		simulator.AssertNotCalled(suite.T(), "Simulate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	suite.Run("simulation not enabled", func() {
+		_, err := newBackend(nil).SimulateTransaction(ctx, &tx, false)
+		suite.Assert().Equal(codes.Unimplemented, status.Code(err))
+	})
+}
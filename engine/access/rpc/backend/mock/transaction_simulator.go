@@ -0,0 +1,53 @@
+// Code generated by mockery v2.12.1. DO NOT EDIT.
+
+package mock
+
+import (
+	access "github.com/onflow/flow-go/access"
+
+	context "context"
+
+	flow "github.com/onflow/flow-go/model/flow"
+
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TransactionSimulator is an autogenerated mock type for the TransactionSimulator type
+type TransactionSimulator struct {
+	mock.Mock
+}
+
+// Simulate provides a mock function with given fields: ctx, tx, skipSignatureVerification
+func (_m *TransactionSimulator) Simulate(ctx context.Context, tx *flow.TransactionBody, skipSignatureVerification bool) (*access.SimulatedTransactionResult, error) {
+	ret := _m.Called(ctx, tx, skipSignatureVerification)
+
+	var r0 *access.SimulatedTransactionResult
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, bool) *access.SimulatedTransactionResult); ok {
+		r0 = rf(ctx, tx, skipSignatureVerification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.SimulatedTransactionResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, bool) error); ok {
+		r1 = rf(ctx, tx, skipSignatureVerification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTransactionSimulator creates a new instance of TransactionSimulator. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransactionSimulator(t testing.TB) *TransactionSimulator {
+	mock := &TransactionSimulator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
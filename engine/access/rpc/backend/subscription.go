@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+)
+
+// DefaultSendBufferSize is the default number of messages buffered for each subscription
+// before the streaming routine blocks waiting for the client to consume them.
+const DefaultSendBufferSize = 10
+
+// DefaultSendTimeout is the default time the streaming routine waits for a slow client to
+// consume a message before the subscription is terminated.
+const DefaultSendTimeout = 30 * time.Second
+
+var _ access.Subscription = (*SubscriptionImpl)(nil)
+
+// SubscriptionImpl is the backend implementation of access.Subscription.
+//
+// Messages are pushed by a single streaming routine using Send, which blocks while the
+// send buffer is full. This provides backpressure: a slow client slows down the streaming
+// routine instead of causing unbounded buffering on the access node.
+//
+// A blocked Send does not hold the lock guarding the state of the subscription, Fail wakes
+// it up through the done channel, and closes the channel only once no Send is in progress.
+type SubscriptionImpl struct {
+	id   string
+	ch   chan interface{}
+	done chan struct{}
+	err  error
+
+	mu     sync.RWMutex // guards err and closed
+	sendMu sync.Mutex   // held while sending to ch, ch is closed once no send is in progress
+	closed bool
+}
+
+// NewSubscription creates a new subscription with a send buffer of the given size.
+func NewSubscription(bufferSize int) *SubscriptionImpl {
+	return &SubscriptionImpl{
+		id:   uuid.New().String(),
+		ch:   make(chan interface{}, bufferSize),
+		done: make(chan struct{}),
+	}
+}
+
+// NewFailedSubscription returns a subscription that already ended with the given error.
+func NewFailedSubscription(err error, msg string) *SubscriptionImpl {
+	sub := NewSubscription(0)
+
+	// wrap the error while preserving the grpc status code, if any
+	if st, ok := status.FromError(err); ok {
+		sub.Fail(status.Errorf(st.Code(), "%s: %s", msg, st.Message()))
+	} else {
+		sub.Fail(status.Errorf(codes.Internal, "%s: %v", msg, err))
+	}
+
+	return sub
+}
+
+// ID returns the unique identifier of the subscription.
+func (sub *SubscriptionImpl) ID() string {
+	return sub.id
+}
+
+// Channel returns the channel from which the streamed data can be read.
+func (sub *SubscriptionImpl) Channel() <-chan interface{} {
+	return sub.ch
+}
+
+// Err returns the error that caused the subscription to end, if any.
+func (sub *SubscriptionImpl) Err() error {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+
+	return sub.err
+}
+
+// Send pushes the given value to the subscription, waiting at most the given timeout for
+// space in the send buffer. It returns an error if the subscription is closed, the context
+// is canceled or the client did not consume messages before the timeout elapsed.
+func (sub *SubscriptionImpl) Send(ctx context.Context, v interface{}, timeout time.Duration) error {
+	sub.sendMu.Lock()
+	defer sub.sendMu.Unlock()
+
+	sub.mu.RLock()
+	closed := sub.closed
+	sub.mu.RUnlock()
+
+	if closed {
+		return fmt.Errorf("subscription closed")
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	select {
+	case <-sub.done:
+		return fmt.Errorf("subscription closed")
+	case <-waitCtx.Done():
+		return waitCtx.Err()
+	case sub.ch <- v:
+		return nil
+	}
+}
+
+// Fail records the given error and closes the subscription. A pending Send is aborted.
+func (sub *SubscriptionImpl) Fail(err error) {
+	sub.mu.Lock()
+	if sub.closed {
+		sub.mu.Unlock()
+		return
+	}
+	sub.err = err
+	sub.closed = true
+	close(sub.done)
+	sub.mu.Unlock()
+
+	// wait for a pending Send to be aborted before closing the channel
+	sub.sendMu.Lock()
+	defer sub.sendMu.Unlock()
+	close(sub.ch)
+}
+
+// Close closes the subscription without an error.
+func (sub *SubscriptionImpl) Close() {
+	sub.Fail(nil)
+}
@@ -0,0 +1,76 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSubscription_Send(t *testing.T) {
+
+	t.Run("blocked send does not block reading the error", func(t *testing.T) {
+		sub := NewSubscription(0)
+
+		sent := make(chan error)
+		go func() {
+			sent <- sub.Send(context.Background(), 1, time.Minute)
+		}()
+
+		// the send is blocked as nobody consumes the messages
+		requireBlocked(t, sent)
+
+		unittest.RequireReturnsBefore(t, func() {
+			assert.NoError(t, sub.Err())
+		}, time.Second, "reading the error should not wait for the send")
+
+		msg := <-sub.Channel()
+		assert.Equal(t, 1, msg)
+		require.NoError(t, <-sent)
+	})
+
+	t.Run("fail aborts a blocked send", func(t *testing.T) {
+		sub := NewSubscription(0)
+
+		sent := make(chan error)
+		go func() {
+			sent <- sub.Send(context.Background(), 1, time.Minute)
+		}()
+		requireBlocked(t, sent)
+
+		expected := fmt.Errorf("failed")
+		unittest.RequireReturnsBefore(t, func() {
+			sub.Fail(expected)
+		}, time.Second, "fail should not wait for the send timeout")
+
+		require.Error(t, <-sent)
+
+		_, ok := <-sub.Channel()
+		assert.False(t, ok)
+		assert.ErrorIs(t, sub.Err(), expected)
+
+		// sending to a closed subscription fails without blocking
+		require.Error(t, sub.Send(context.Background(), 2, time.Minute))
+	})
+
+	t.Run("send times out when the client does not consume messages", func(t *testing.T) {
+		sub := NewSubscription(0)
+
+		err := sub.Send(context.Background(), 1, 10*time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// requireBlocked checks that no send completed within a short period.
+func requireBlocked(t *testing.T, sent <-chan error) {
+	select {
+	case err := <-sent:
+		t.Fatalf("send should block, returned: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -17,8 +17,11 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/extended"
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
 	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/access/graphql"
+	"github.com/onflow/flow-go/engine/access/quota"
 	"github.com/onflow/flow-go/engine/access/rest"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	"github.com/onflow/flow-go/model/flow"
@@ -37,6 +40,10 @@ type Config struct {
 	TransportCredentials      credentials.TransportCredentials // the secure GRPC credentials
 	HTTPListenAddr            string                           // the HTTP web proxy address as ip:port
 	RESTListenAddr            string                           // the REST server address as ip:port (if empty the REST server will not be started)
+	RESTValidateResponses     bool                             // whether REST responses are validated against the OpenAPI spec, invalid responses are replaced with an internal error
+	GraphQLListenAddr         string                           // the GraphQL server address as ip:port (if empty the GraphQL server will not be started)
+	GraphQLMaxDepth           int                              // max depth of GraphQL queries
+	GraphQLMaxCost            int                              // max cost of GraphQL queries
 	CollectionAddr            string                           // the address of the upstream collection node
 	HistoricalAccessAddrs     string                           // the list of all access nodes from previous spork
 	MaxMsgSize                int                              // GRPC max message size
@@ -58,6 +65,8 @@ type Engine struct {
 	secureGrpcServer   *grpc.Server     // the secure gRPC server
 	httpServer         *http.Server
 	restServer         *http.Server
+	graphqlServer      *http.Server
+	quotas             *quota.Limiter
 	config             Config
 	chain              flow.Chain
 
@@ -65,6 +74,29 @@ type Engine struct {
 	unsecureGrpcAddress net.Addr
 	secureGrpcAddress   net.Addr
 	restAPIAddress      net.Addr
+	graphqlAddress      net.Addr
+}
+
+// Option configures an optional dependency of the engine.
+type Option func(*options)
+
+type options struct {
+	backendOptions []backend.Option
+	quotas         *quota.Limiter
+}
+
+// WithBackendOptions configures the optional dependencies of the backend serving the Access API.
+func WithBackendOptions(opts ...backend.Option) Option {
+	return func(o *options) {
+		o.backendOptions = append(o.backendOptions, opts...)
+	}
+}
+
+// WithQuotas checks the requests against the quota of their client.
+func WithQuotas(quotas *quota.Limiter) Option {
+	return func(o *options) {
+		o.quotas = quotas
+	}
 }
 
 // New returns a new RPC engine.
@@ -87,10 +119,16 @@ func New(log zerolog.Logger,
 	rpcMetricsEnabled bool,
 	apiRatelimits map[string]int, // the api rate limit (max calls per second) for each of the Access API e.g. Ping->100, GetTransaction->300
 	apiBurstLimits map[string]int, // the api burst limit (max calls at the same time) for each of the Access API e.g. Ping->50, GetTransaction->10
+	opts ...Option,
 ) *Engine {
 
 	log = log.With().Str("engine", "rpc").Logger()
 
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	if config.MaxMsgSize == 0 {
 		config.MaxMsgSize = grpcutils.DefaultMaxMsgSize
 	}
@@ -102,6 +140,7 @@ func New(log zerolog.Logger,
 	}
 
 	var interceptors []grpc.UnaryServerInterceptor // ordered list of interceptors
+	var streamInterceptors []grpc.StreamServerInterceptor
 	// if rpc metrics is enabled, first create the grpc metrics interceptor
 	if rpcMetricsEnabled {
 		interceptors = append(interceptors, grpc_prometheus.UnaryServerInterceptor)
@@ -114,12 +153,19 @@ func New(log zerolog.Logger,
 		interceptors = append(interceptors, rateLimitInterceptor)
 	}
 
+	if options.quotas != nil {
+		// create a quota interceptor, which checks requests against the quota of their client
+		interceptors = append(interceptors, quotaInterceptor(options.quotas))
+		streamInterceptors = append(streamInterceptors, quotaStreamInterceptor(options.quotas))
+	}
+
 	// add the logging interceptor, ensure it is innermost wrapper
 	interceptors = append(interceptors, loggingInterceptor(log)...)
 
 	// create a chained unary interceptor
 	chainedInterceptors := grpc.ChainUnaryInterceptor(interceptors...)
 	grpcOpts = append(grpcOpts, chainedInterceptors)
+	grpcOpts = append(grpcOpts, grpc.ChainStreamInterceptor(streamInterceptors...))
 
 	// create an unsecured grpc server
 	unsecureGrpcServer := grpc.NewServer(grpcOpts...)
@@ -156,6 +202,7 @@ func New(log zerolog.Logger,
 		config.FixedExecutionNodeIDs,
 		log,
 		backend.DefaultSnapshotHistoryLimit,
+		options.backendOptions...,
 	)
 
 	eng := &Engine{
@@ -165,6 +212,7 @@ func New(log zerolog.Logger,
 		unsecureGrpcServer: unsecureGrpcServer,
 		secureGrpcServer:   secureGrpcServer,
 		httpServer:         httpServer,
+		quotas:             options.quotas,
 		config:             config,
 		chain:              chainID.Chain(),
 	}
@@ -179,6 +227,17 @@ func New(log zerolog.Logger,
 		access.NewHandler(backend, chainID.Chain()),
 	)
 
+	// the methods which are not part of the Flow Access API are served by a separate service
+	extended.RegisterExtendedAccessAPIServer(
+		eng.unsecureGrpcServer,
+		access.NewExtendedHandler(backend, chainID.Chain()),
+	)
+
+	extended.RegisterExtendedAccessAPIServer(
+		eng.secureGrpcServer,
+		access.NewExtendedHandler(backend, chainID.Chain()),
+	)
+
 	if rpcMetricsEnabled {
 		// Not interested in legacy metrics, so initialize here
 		grpc_prometheus.EnableHandlingTimeHistogram()
@@ -209,6 +268,9 @@ func (e *Engine) Ready() <-chan struct{} {
 	if e.config.RESTListenAddr != "" {
 		e.unit.Launch(e.serveREST)
 	}
+	if e.config.GraphQLListenAddr != "" {
+		e.unit.Launch(e.serveGraphQL)
+	}
 	return e.unit.Ready()
 }
 
@@ -231,6 +293,14 @@ func (e *Engine) Done() <-chan struct{} {
 					e.log.Error().Err(err).Msg("error stopping http REST server")
 				}
 			}
+		},
+		func() {
+			if e.graphqlServer != nil {
+				err := e.graphqlServer.Shutdown(context.Background())
+				if err != nil {
+					e.log.Error().Err(err).Msg("error stopping http GraphQL server")
+				}
+			}
 		})
 }
 
@@ -262,6 +332,12 @@ func (e *Engine) RestApiAddress() net.Addr {
 	return e.restAPIAddress
 }
 
+func (e *Engine) GraphQLAddress() net.Addr {
+	e.addrLock.RLock()
+	defer e.addrLock.RUnlock()
+	return e.graphqlAddress
+}
+
 // process processes the given ingestion engine event. Events that are given
 // to this function originate within the expulsion engine on the node with the
 // given origin ID.
@@ -345,7 +421,15 @@ func (e *Engine) serveREST() {
 
 	e.log.Info().Str("rest_api_address", e.config.RESTListenAddr).Msg("starting REST server on address")
 
-	r, err := rest.NewServer(e.backend, e.config.RESTListenAddr, e.log, e.chain)
+	var opts []rest.Option
+	if e.config.RESTValidateResponses {
+		opts = append(opts, rest.WithResponseValidation())
+	}
+	if e.quotas != nil {
+		opts = append(opts, rest.WithQuotas(e.quotas))
+	}
+
+	r, err := rest.NewServer(e.backend, e.config.RESTListenAddr, e.log, e.chain, opts...)
 	if err != nil {
 		e.log.Err(err).Msg("failed to initialize the REST server")
 		return
@@ -372,3 +456,40 @@ func (e *Engine) serveREST() {
 		e.log.Error().Err(err).Msg("fatal error in REST server")
 	}
 }
+
+// serveGraphQL starts the HTTP GraphQL server
+func (e *Engine) serveGraphQL() {
+
+	e.log.Info().Str("graphql_address", e.config.GraphQLListenAddr).Msg("starting GraphQL server on address")
+
+	limits := graphql.Limits{
+		MaxDepth: e.config.GraphQLMaxDepth,
+		MaxCost:  e.config.GraphQLMaxCost,
+	}
+	s, err := graphql.NewServer(e.backend, e.config.GraphQLListenAddr, e.log, limits, e.quotas)
+	if err != nil {
+		e.log.Err(err).Msg("failed to initialize the GraphQL server")
+		return
+	}
+	e.graphqlServer = s
+
+	l, err := net.Listen("tcp", e.config.GraphQLListenAddr)
+	if err != nil {
+		e.log.Err(err).Msg("failed to start the GraphQL server")
+		return
+	}
+
+	e.addrLock.Lock()
+	e.graphqlAddress = l.Addr()
+	e.addrLock.Unlock()
+
+	e.log.Debug().Str("graphql_address", e.graphqlAddress.String()).Msg("listening on port")
+
+	err = e.graphqlServer.Serve(l) // blocking call
+	if err != nil {
+		if errors.Is(err, http.ErrServerClosed) {
+			return
+		}
+		e.log.Error().Err(err).Msg("fatal error in GraphQL server")
+	}
+}
@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/quota"
+)
+
+// quotaInterceptor creates an interceptor which checks each request against the quota of its client.
+// Clients are identified by the API key in the request metadata, or by their IP address.
+func quotaInterceptor(limiter *quota.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := checkQuota(ctx, limiter, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// quotaStreamInterceptor creates the streaming counterpart of quotaInterceptor, which checks each new
+// stream against the same quota as the unary requests of its client.
+func quotaStreamInterceptor(limiter *quota.Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := checkQuota(stream.Context(), limiter, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+// checkQuota checks a call of the given method against the quota of the client of the request.
+func checkQuota(ctx context.Context, limiter *quota.Limiter, fullMethod string) error {
+	// remove the package name (e.g. "/flow.access.AccessAPI/Ping" to "Ping")
+	methodName := filepath.Base(fullMethod)
+
+	err := limiter.Allow(apiKeyFromContext(ctx), ipFromContext(ctx), methodName)
+	if err != nil {
+		return quotaError(err)
+	}
+	return nil
+}
+
+// quotaError converts an error of the quota limiter into the status returned to the client.
+func quotaError(err error) error {
+	switch {
+	case errors.Is(err, quota.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, quota.ErrUnknownKey), errors.Is(err, quota.ErrKeyRequired):
+		return status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "could not check quota: %v", err)
+	}
+}
+
+// apiKeyFromContext returns the API key in the metadata of the request, or an empty string if there is none.
+func apiKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(quota.APIKeyHeader)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// ipFromContext returns the IP address of the client of the request, or an empty string if it is unknown.
+func ipFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
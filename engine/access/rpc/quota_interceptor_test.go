@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/quota"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestQuotaInterceptor tests that requests exceeding the quota of their client are rejected with the
+// corresponding status codes.
+func TestQuotaInterceptor(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		path := filepath.Join(dir, "keys.json")
+		err := os.WriteFile(path, []byte(`{
+			"anonymous": {"rate": 0.001, "burst": 1},
+			"keys": [{"name": "partner", "key": "secret", "rate": 0.001, "burst": 2}]
+		}`), 0600)
+		require.NoError(t, err)
+
+		limiter, err := quota.NewLimiter(zerolog.Nop(), metrics.NewNoopCollector(), path)
+		require.NoError(t, err)
+
+		interceptor := quotaInterceptor(limiter)
+		info := &grpc.UnaryServerInfo{FullMethod: "/flow.access.AccessAPI/Ping"}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		}
+
+		call := func(apiKey string, ip string) error {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1000},
+			})
+			if apiKey != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(quota.APIKeyHeader, apiKey))
+			}
+			_, err := interceptor(ctx, nil, info, handler)
+			return err
+		}
+
+		requireCode := func(t *testing.T, code codes.Code, err error) {
+			require.Error(t, err)
+			require.Equal(t, code, status.Code(err))
+		}
+
+		t.Run("anonymous", func(t *testing.T) {
+			require.NoError(t, call("", "10.0.0.1"))
+			requireCode(t, codes.ResourceExhausted, call("", "10.0.0.1"))
+			require.NoError(t, call("", "10.0.0.2"))
+		})
+
+		t.Run("api key", func(t *testing.T) {
+			require.NoError(t, call("secret", "10.0.0.1"))
+			require.NoError(t, call("secret", "10.0.0.1"))
+			requireCode(t, codes.ResourceExhausted, call("secret", "10.0.0.3"))
+		})
+
+		t.Run("unknown api key", func(t *testing.T) {
+			requireCode(t, codes.Unauthenticated, call("unknown", "10.0.0.4"))
+		})
+	})
+}
+
+// TestQuotaStreamInterceptor tests that streams are checked against the same quota as unary requests.
+func TestQuotaStreamInterceptor(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		path := filepath.Join(dir, "keys.json")
+		err := os.WriteFile(path, []byte(`{"anonymous": {"rate": 0.001, "burst": 2}}`), 0600)
+		require.NoError(t, err)
+
+		limiter, err := quota.NewLimiter(zerolog.Nop(), metrics.NewNoopCollector(), path)
+		require.NoError(t, err)
+
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1000},
+		})
+
+		// the unary request uses the first unit of the quota
+		_, err = quotaInterceptor(limiter)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/flow.access.AccessAPI/Ping"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "ok", nil
+			})
+		require.NoError(t, err)
+
+		interceptor := quotaStreamInterceptor(limiter)
+		info := &grpc.StreamServerInfo{FullMethod: "/flow.access.extended.ExtendedAccessAPI/SubscribeEvents", IsServerStream: true}
+		handled := 0
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			handled++
+			return nil
+		}
+
+		require.NoError(t, interceptor(nil, &serverStream{ctx: ctx}, info, handler))
+
+		err = interceptor(nil, &serverStream{ctx: ctx}, info, handler)
+		require.Error(t, err)
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+		require.Equal(t, 1, handled)
+	})
+}
+
+// serverStream is a server stream which only provides its context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
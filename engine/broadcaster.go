@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"sync"
+)
+
+// Broadcaster fans out a notification to every subscribed Notifier. It is used to
+// wake up an arbitrary number of worker routines (e.g. one per streaming client)
+// when new work becomes available. Like the Notifier, notifications are never
+// blocking: subscribers that have not consumed a prior notification will simply
+// observe a single pending notification.
+type Broadcaster struct {
+	mu          sync.RWMutex
+	subscribers map[Notifier]struct{}
+}
+
+// NewBroadcaster creates a new Broadcaster without any subscribers.
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		subscribers: make(map[Notifier]struct{}),
+	}
+}
+
+// Subscribe adds the given Notifier to the set of notifiers receiving broadcasts.
+func (b *Broadcaster) Subscribe(n Notifier) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers[n] = struct{}{}
+}
+
+// Unsubscribe removes the given Notifier from the set of notifiers receiving broadcasts.
+// Unsubscribing a Notifier that was never subscribed is a no-op.
+func (b *Broadcaster) Unsubscribe(n Notifier) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subscribers, n)
+}
+
+// Publish sends a notification to all subscribed notifiers.
+func (b *Broadcaster) Publish() {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for n := range b.subscribers {
+		n.Notify()
+	}
+}
+
+// Size returns the number of currently subscribed notifiers.
+func (b *Broadcaster) Size() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers)
+}
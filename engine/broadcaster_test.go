@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcaster_PublishToAll verifies that every subscribed notifier receives a notification
+func TestBroadcaster_PublishToAll(t *testing.T) {
+	t.Parallel()
+	b := NewBroadcaster()
+
+	notifiers := make([]Notifier, 10)
+	for i := range notifiers {
+		notifiers[i] = NewNotifier()
+		b.Subscribe(notifiers[i])
+	}
+	require.Equal(t, len(notifiers), b.Size())
+
+	b.Publish()
+
+	for _, n := range notifiers {
+		select {
+		case <-n.Channel(): // expected
+		default:
+			t.Fail()
+		}
+	}
+}
+
+// TestBroadcaster_Unsubscribe verifies that unsubscribed notifiers no longer receive notifications
+func TestBroadcaster_Unsubscribe(t *testing.T) {
+	t.Parallel()
+	b := NewBroadcaster()
+
+	subscribed := NewNotifier()
+	unsubscribed := NewNotifier()
+	b.Subscribe(subscribed)
+	b.Subscribe(unsubscribed)
+	b.Unsubscribe(unsubscribed)
+	require.Equal(t, 1, b.Size())
+
+	b.Publish()
+
+	select {
+	case <-subscribed.Channel(): // expected
+	default:
+		t.Fail()
+	}
+
+	select {
+	case <-unsubscribed.Channel():
+		t.Fail()
+	default: // expected
+	}
+}
+
+// TestBroadcaster_ConcurrentPublish verifies that concurrent publishing never blocks,
+// even if the subscribers do not consume the notifications
+func TestBroadcaster_ConcurrentPublish(t *testing.T) {
+	t.Parallel()
+	b := NewBroadcaster()
+	b.Subscribe(NewNotifier())
+	b.Subscribe(NewNotifier())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.Publish()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done: // expected
+	case <-time.After(time.Second):
+		assert.Fail(t, "publishing notifications blocked")
+	}
+}
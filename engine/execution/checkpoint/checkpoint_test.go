@@ -0,0 +1,365 @@
+package checkpoint
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/p2p"
+	"github.com/onflow/flow-go/network/p2p/keyutils"
+	"github.com/onflow/flow-go/network/p2p/unicast"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testNetwork connects a serving and a requesting node with an in-process libp2p network.
+type testNetwork struct {
+	server    *flow.Identity
+	requester *flow.Identity
+	// streams opens streams of the checkpoint protocol from the requesting node
+	streams network.StreamProtocol
+}
+
+// newTestNetwork creates a network in which the serving node handles streams of the checkpoint protocol
+// with the given handler.
+func newTestNetwork(t *testing.T, requesterRole flow.Role, handler func(*flow.Identity) network.StreamHandler) *testNetwork {
+	protocolID := unicast.CheckpointProtocolId(unittest.IdentifierFixture())
+	mn := mocknet.New()
+	t.Cleanup(func() {
+		require.NoError(t, mn.Close())
+	})
+
+	identities := make(flow.IdentityList, 0, 2)
+	for i, role := range []flow.Role{flow.RoleExecution, requesterRole} {
+		key := unittest.NetworkingPrivKeyFixture()
+		identities = append(identities, &flow.Identity{
+			NodeID:        unittest.IdentifierFixture(),
+			Address:       fmt.Sprintf("%s:%d", role, i),
+			Role:          role,
+			NetworkPubKey: key.PublicKey(),
+		})
+
+		libp2pKey, err := keyutils.LibP2PPrivKeyFromFlow(key)
+		require.NoError(t, err)
+		_, err = mn.AddPeer(libp2pKey, multiaddr.StringCast(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", 4000+i)))
+		require.NoError(t, err)
+	}
+	require.NoError(t, mn.LinkAll())
+
+	translator, err := p2p.NewFixedTableIdentityTranslator(identities)
+	require.NoError(t, err)
+
+	hosts := mn.Hosts()
+	hostOf := func(identity *flow.Identity) int {
+		peerID, err := translator.GetPeerID(identity.NodeID)
+		require.NoError(t, err)
+		for i, h := range hosts {
+			if h.ID() == peerID {
+				return i
+			}
+		}
+		require.FailNow(t, "host not found")
+		return 0
+	}
+
+	n := &testNetwork{
+		server:    identities[0],
+		requester: identities[1],
+	}
+	serve := handler(n.requester)
+	p2p.NewStreamProtocol(hosts[hostOf(n.server)], protocolID, translator, zerolog.Nop(), func(originID flow.Identifier, stream libp2pnetwork.Stream) {
+		serve(originID, &mockStream{stream})
+	})
+	n.streams = p2p.NewStreamProtocol(hosts[hostOf(n.requester)], protocolID, translator, zerolog.Nop(), nil)
+
+	return n
+}
+
+// mockStream wraps a stream of the in-process libp2p network, which does not support deadlines.
+type mockStream struct {
+	libp2pnetwork.Stream
+}
+
+func (s *mockStream) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+// newServer returns a server of a ledger holding one trie, and the state commitment of this trie.
+func newServer(t *testing.T, requester *flow.Identity) (*Server, flow.StateCommitment) {
+	ldg, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	keys := []ledger.Key{
+		ledger.NewKey([]ledger.KeyPart{ledger.NewKeyPart(0, []byte("owner")), ledger.NewKeyPart(2, []byte("key 1"))}),
+		ledger.NewKey([]ledger.KeyPart{ledger.NewKeyPart(0, []byte("owner")), ledger.NewKeyPart(2, []byte("key 2"))}),
+	}
+	update, err := ledger.NewUpdate(ldg.InitialState(), keys, []ledger.Value{[]byte("value 1"), []byte("value 2")})
+	require.NoError(t, err)
+	state, _, err := ldg.Set(update)
+	require.NoError(t, err)
+
+	final := new(protocol.Snapshot)
+	final.On("Identity", requester.NodeID).Return(requester, nil)
+	final.On("Identity", mock.Anything).Return(nil, fmt.Errorf("unknown identity"))
+	protocolState := new(protocol.State)
+	protocolState.On("Final").Return(final)
+
+	return NewServer(zerolog.Nop(), ldg, protocolState), flow.StateCommitment(state)
+}
+
+func TestFetch(t *testing.T) {
+
+	t.Run("checkpoint of the first held state is fetched from an execution node and stored as root checkpoint", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			var commit flow.StateCommitment
+			n := newTestNetwork(t, flow.RoleExecution, func(requester *flow.Identity) network.StreamHandler {
+				server, c := newServer(t, requester)
+				commit = c
+				return server.HandleStream
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			// the latest sealed state is not held by the serving node
+			seals := []*flow.Seal{sealOf(unittest.StateCommitmentFixture()), sealOf(commit), sealOf(unittest.StateCommitmentFixture())}
+			seal, err := Fetch(ctx, zerolog.Nop(), n.streams, n.server.NodeID, seals, dir)
+			require.NoError(t, err)
+			assert.Equal(t, seals[1], seal)
+
+			log := zerolog.Nop()
+			tries, err := wal.LoadCheckpoint(filepath.Join(dir, bootstrapFilenames.FilenameWALRootCheckpoint), &log)
+			require.NoError(t, err)
+			require.Len(t, tries, 1)
+			assert.Equal(t, ledger.RootHash(commit), tries[0].RootHash())
+			payloads, err := tries[0].AllPayloads()
+			require.NoError(t, err)
+			assert.Len(t, payloads, 2)
+
+			// no temporary files are left behind
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, entries, 1)
+		})
+	})
+
+	t.Run("unknown execution state is not available", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			n := newTestNetwork(t, flow.RoleExecution, func(requester *flow.Identity) network.StreamHandler {
+				server, _ := newServer(t, requester)
+				return server.HandleStream
+			})
+
+			seals := []*flow.Seal{sealOf(unittest.StateCommitmentFixture())}
+			_, err := Fetch(context.Background(), zerolog.Nop(), n.streams, n.server.NodeID, seals, dir)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "execution state is not available")
+			require.NoFileExists(t, filepath.Join(dir, bootstrapFilenames.FilenameWALRootCheckpoint))
+		})
+	})
+
+	t.Run("nodes which are not execution nodes are not served", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			var commit flow.StateCommitment
+			n := newTestNetwork(t, flow.RoleAccess, func(requester *flow.Identity) network.StreamHandler {
+				server, c := newServer(t, requester)
+				commit = c
+				return server.HandleStream
+			})
+
+			_, err := Fetch(context.Background(), zerolog.Nop(), n.streams, n.server.NodeID, []*flow.Seal{sealOf(commit)}, dir)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "request was not authorized")
+		})
+	})
+
+	t.Run("checkpoint of a different execution state is rejected", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			var other flow.StateCommitment
+			n := newTestNetwork(t, flow.RoleExecution, func(requester *flow.Identity) network.StreamHandler {
+				server, c := newServer(t, requester)
+				other = c
+				// serve the trie of the other state, regardless of the requested state
+				return func(originID flow.Identifier, stream libp2pnetwork.Stream) {
+					tr, release, err := server.tries.AcquireTrie(ledger.RootHash(other))
+					require.NoError(t, err)
+					defer release()
+					serveTrie(t, stream, tr, 0)
+				}
+			})
+
+			seals := []*flow.Seal{sealOf(unittest.StateCommitmentFixture())}
+			_, err := Fetch(context.Background(), zerolog.Nop(), n.streams, n.server.NodeID, seals, dir)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "does not match state commitment")
+			require.NoFileExists(t, filepath.Join(dir, bootstrapFilenames.FilenameWALRootCheckpoint))
+		})
+	})
+
+	t.Run("checkpoint with a tampered payload is rejected", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			var commit flow.StateCommitment
+			n := newTestNetwork(t, flow.RoleExecution, func(requester *flow.Identity) network.StreamHandler {
+				server, c := newServer(t, requester)
+				commit = c
+				// serve the trie of the requested state with a changed payload, but the original node hashes
+				return func(originID flow.Identifier, stream libp2pnetwork.Stream) {
+					tr, release, err := server.tries.AcquireTrie(ledger.RootHash(commit))
+					require.NoError(t, err)
+					defer release()
+					tampered, _ := trie.NewMTrie(tamperLeaf(t, tr.RootNode()), tr.AllocatedRegCount(), tr.AllocatedRegSize())
+					serveTrie(t, stream, tampered, 0)
+				}
+			})
+
+			_, err := Fetch(context.Background(), zerolog.Nop(), n.streams, n.server.NodeID, []*flow.Seal{sealOf(commit)}, dir)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "node hashes of trie 0 do not match its payloads")
+			require.NoFileExists(t, filepath.Join(dir, bootstrapFilenames.FilenameWALRootCheckpoint))
+		})
+	})
+
+	t.Run("checkpoint is not read beyond its announced size", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			var commit flow.StateCommitment
+			n := newTestNetwork(t, flow.RoleExecution, func(requester *flow.Identity) network.StreamHandler {
+				server, c := newServer(t, requester)
+				commit = c
+				// announce a smaller checkpoint than the one served
+				return func(originID flow.Identifier, stream libp2pnetwork.Stream) {
+					tr, release, err := server.tries.AcquireTrie(ledger.RootHash(commit))
+					require.NoError(t, err)
+					defer release()
+					serveTrie(t, stream, tr, -10)
+				}
+			})
+
+			_, err := Fetch(context.Background(), zerolog.Nop(), n.streams, n.server.NodeID, []*flow.Seal{sealOf(commit)}, dir)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "could not load checkpoint")
+			require.NoFileExists(t, filepath.Join(dir, bootstrapFilenames.FilenameWALRootCheckpoint))
+		})
+	})
+
+	t.Run("checkpoint shorter than its announced size is rejected", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			var commit flow.StateCommitment
+			n := newTestNetwork(t, flow.RoleExecution, func(requester *flow.Identity) network.StreamHandler {
+				server, c := newServer(t, requester)
+				commit = c
+				return func(originID flow.Identifier, stream libp2pnetwork.Stream) {
+					tr, release, err := server.tries.AcquireTrie(ledger.RootHash(commit))
+					require.NoError(t, err)
+					defer release()
+					serveTrie(t, stream, tr, 10)
+				}
+			})
+
+			_, err := Fetch(context.Background(), zerolog.Nop(), n.streams, n.server.NodeID, []*flow.Seal{sealOf(commit)}, dir)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "checkpoint is truncated")
+			require.NoFileExists(t, filepath.Join(dir, bootstrapFilenames.FilenameWALRootCheckpoint))
+		})
+	})
+}
+
+func TestSealedStates(t *testing.T) {
+	// blocks 10 to 15 are finalized, block 10 is the root block. Each block references the latest seal of
+	// its fork, seals are included in blocks 12, 13 and 15.
+	root := unittest.BlockHeaderFixture()
+	root.Height = 10
+	seals := map[uint64]*flow.Seal{
+		10: unittest.Seal.Fixture(),
+		12: unittest.Seal.Fixture(),
+		13: unittest.Seal.Fixture(),
+		15: unittest.Seal.Fixture(),
+	}
+	latest := map[uint64]*flow.Seal{
+		10: seals[10], 11: seals[10], 12: seals[12], 13: seals[13], 14: seals[13], 15: seals[15],
+	}
+
+	params := new(protocol.Params)
+	params.On("Root").Return(&root, nil)
+	protocolState := new(protocol.State)
+	protocolState.On("Params").Return(params)
+	for height, seal := range latest {
+		snapshot := new(protocol.Snapshot)
+		snapshot.On("SealedResult").Return(unittest.ExecutionResultFixture(), seal, nil)
+		protocolState.On("AtHeight", height).Return(snapshot)
+	}
+	final := new(protocol.Snapshot)
+	final.On("Head").Return(&flow.Header{Height: 15}, nil)
+	protocolState.On("Final").Return(final)
+
+	sealed, err := SealedStates(protocolState)
+	require.NoError(t, err)
+	assert.Equal(t, []*flow.Seal{seals[15], seals[13], seals[12], seals[10]}, sealed)
+}
+
+// sealOf returns a seal of the given state commitment.
+func sealOf(commit flow.StateCommitment) *flow.Seal {
+	return unittest.Seal.Fixture(func(seal *flow.Seal) {
+		seal.FinalState = commit
+	})
+}
+
+// serveTrie serves a checkpoint of the given trie regardless of the requested states, announcing its size
+// with the given difference.
+func serveTrie(t *testing.T, stream libp2pnetwork.Stream, tr *trie.MTrie, sizeDiff int) {
+	defer stream.Close()
+
+	_, err := readRequest(stream)
+	require.NoError(t, err)
+
+	var checkpoint bytes.Buffer
+	require.NoError(t, wal.StoreCheckpoint(&checkpoint, tr))
+
+	header := make([]byte, 1+2+8)
+	header[0] = statusOK
+	binary.BigEndian.PutUint64(header[3:], uint64(checkpoint.Len()+sizeDiff))
+
+	w := bufio.NewWriter(stream)
+	_, _ = w.Write(header)
+	_, _ = w.Write(checkpoint.Bytes())
+	_ = w.Flush()
+}
+
+// tamperLeaf returns a copy of the given subtrie, in which the value of the leftmost leaf is changed
+// while all node hashes are kept.
+func tamperLeaf(t *testing.T, n *node.Node) *node.Node {
+	if n.IsLeaf() {
+		payload := n.Payload().DeepCopy()
+		payload.Value = ledger.Value("tampered")
+		return node.NewNode(n.Height(), nil, nil, *n.Path(), payload, n.Hash())
+	}
+	lChild, rChild, err := n.Children()
+	require.NoError(t, err)
+	if lChild != nil {
+		return node.NewNode(n.Height(), tamperLeaf(t, lChild), rChild, ledger.DummyPath, nil, n.Hash())
+	}
+	return node.NewNode(n.Height(), nil, tamperLeaf(t, rChild), ledger.DummyPath, nil, n.Hash())
+}
@@ -0,0 +1,249 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// SealedStates returns the seals of the latest sealed blocks known to the given protocol state, latest
+// first, for at most MaxRequestedStates blocks, down to the root block.
+func SealedStates(state protocol.State) ([]*flow.Seal, error) {
+	root, err := state.Params().Root()
+	if err != nil {
+		return nil, fmt.Errorf("could not get root block: %w", err)
+	}
+
+	final, err := state.Final().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get finalized block: %w", err)
+	}
+
+	// every finalized block references the latest seal of its fork, walking the finalized blocks backwards
+	// therefore visits the seals of the sealed blocks backwards
+	seals := make([]*flow.Seal, 0, MaxRequestedStates)
+	sealed := make(map[flow.Identifier]struct{})
+	for height := final.Height; height >= root.Height && len(seals) < MaxRequestedStates; height-- {
+		_, seal, err := state.AtHeight(height).SealedResult()
+		if err != nil {
+			return nil, fmt.Errorf("could not get latest seal at height %d: %w", height, err)
+		}
+
+		if _, ok := sealed[seal.BlockID]; !ok {
+			sealed[seal.BlockID] = struct{}{}
+			seals = append(seals, seal)
+		}
+
+		if height == 0 {
+			break
+		}
+	}
+
+	return seals, nil
+}
+
+// Fetch downloads the checkpoint of the first of the given sealed execution states held in memory by the
+// given execution node, and stores it as the root checkpoint in the given execution state directory. It
+// returns the seal of the execution state of the checkpoint.
+//
+// The seals must be trusted, such as the ones returned by SealedStates. The checkpoint is only stored if the
+// root hash of every trie it contains is the state commitment of the seal, and if the hashes of all nodes
+// match their children and payloads.
+func Fetch(
+	ctx context.Context,
+	log zerolog.Logger,
+	streams network.StreamProtocol,
+	nodeID flow.Identifier,
+	seals []*flow.Seal,
+	dir string,
+) (*flow.Seal, error) {
+	log = log.With().
+		Str("component", "checkpoint_client").
+		Hex("node_id", nodeID[:]).
+		Logger()
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("could not create execution state directory: %w", err)
+	}
+
+	file, err := ioutil.TempFile(dir, bootstrapFilenames.FilenameWALRootCheckpoint+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("could not create checkpoint file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	log.Info().Int("requested_states", len(seals)).Msg("downloading checkpoint")
+	start := time.Now()
+
+	commits := make([]flow.StateCommitment, 0, len(seals))
+	for _, seal := range seals {
+		commits = append(commits, seal.FinalState)
+	}
+
+	index, size, err := download(ctx, streams, nodeID, commits, file)
+	if err != nil {
+		return nil, fmt.Errorf("could not download checkpoint from node %v: %w", nodeID, err)
+	}
+
+	seal := seals[index]
+	log = log.With().
+		Hex("block_id", seal.BlockID[:]).
+		Hex("state_commitment", seal.FinalState[:]).
+		Logger()
+
+	err = file.Sync()
+	if err != nil {
+		return nil, fmt.Errorf("could not sync checkpoint file: %w", err)
+	}
+
+	log.Info().Int64("size", size).Dur("duration", time.Since(start)).Msg("checkpoint downloaded, verifying")
+
+	err = verify(log, file.Name(), seal.FinalState)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint from node %v: %w", nodeID, err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not close checkpoint file: %w", err)
+	}
+
+	err = os.Rename(file.Name(), filepath.Join(dir, bootstrapFilenames.FilenameWALRootCheckpoint))
+	if err != nil {
+		return nil, fmt.Errorf("could not store root checkpoint: %w", err)
+	}
+
+	log.Info().Msg("root checkpoint stored")
+
+	return seal, nil
+}
+
+// download requests the checkpoint of one of the given state commitments from the given node, and writes
+// it to the given writer. It returns the index of the state commitment of the checkpoint, and its size.
+func download(
+	ctx context.Context,
+	streams network.StreamProtocol,
+	nodeID flow.Identifier,
+	commits []flow.StateCommitment,
+	w io.Writer,
+) (int, int64, error) {
+	stream, err := streams.OpenStream(ctx, nodeID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// reset the stream if the download is cancelled, close it once it is complete
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = stream.Reset()
+		case <-done:
+			_ = stream.Close()
+		}
+	}()
+
+	err = writeRequest(stream, commits)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not send request: %w", err)
+	}
+
+	err = stream.CloseWrite()
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not send request: %w", err)
+	}
+
+	status := make([]byte, 1)
+	_, err = io.ReadFull(stream, status)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not read response: %w", err)
+	}
+
+	switch status[0] {
+	case statusOK:
+	case statusNotAvailable:
+		return 0, 0, fmt.Errorf("execution state is not available")
+	case statusUnauthorized:
+		return 0, 0, fmt.Errorf("request was not authorized")
+	default:
+		return 0, 0, fmt.Errorf("unknown response status %d", status[0])
+	}
+
+	header := make([]byte, 2+8)
+	_, err = io.ReadFull(stream, header)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not read response: %w", err)
+	}
+
+	index := int(binary.BigEndian.Uint16(header))
+	if index >= len(commits) {
+		return 0, 0, fmt.Errorf("served execution state %d was not requested", index)
+	}
+
+	expected := binary.BigEndian.Uint64(header[2:])
+	if expected > math.MaxInt64 {
+		return 0, 0, fmt.Errorf("invalid checkpoint size %d", expected)
+	}
+
+	size, err := io.Copy(w, io.LimitReader(stream, int64(expected)))
+	if err != nil {
+		return index, size, fmt.Errorf("could not read checkpoint: %w", err)
+	}
+
+	if size != int64(expected) {
+		return index, size, fmt.Errorf("checkpoint is truncated: expected %d bytes, got %d", expected, size)
+	}
+
+	return index, size, nil
+}
+
+// verify checks that the checkpoint file at the given path is complete, and that the root hash of every
+// trie it contains is the given state commitment. Since the node hashes stored in the checkpoint are not
+// recomputed when it is loaded, they are recomputed from the payloads, so the root hash covers the content.
+func verify(log zerolog.Logger, path string, commit flow.StateCommitment) error {
+	tries, err := wal.LoadCheckpoint(path, &log)
+	if err != nil {
+		return fmt.Errorf("could not load checkpoint: %w", err)
+	}
+
+	if len(tries) == 0 {
+		return fmt.Errorf("checkpoint contains no trie")
+	}
+
+	for i, t := range tries {
+		if t.RootHash() != ledger.RootHash(commit) {
+			return fmt.Errorf("root hash of trie %d does not match state commitment: expected %x, got %v",
+				i, commit[:], t.RootHash())
+		}
+
+		valid, err := t.IsAValidTrie()
+		if err != nil {
+			return fmt.Errorf("could not verify trie %d: %w", i, err)
+		}
+		if !valid {
+			return fmt.Errorf("node hashes of trie %d do not match its payloads", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+// Package checkpoint implements the protocol execution nodes use to bootstrap their execution state
+// from a checkpoint of a trusted execution node, instead of a root checkpoint copied manually.
+//
+// A node requesting a checkpoint opens a stream of the checkpoint protocol to the serving node, and
+// writes the number of requested execution states (2 bytes), followed by their state commitments
+// (32 bytes each), most preferred first. The serving node responds with a status byte. If the status is
+// statusOK, it is followed by the index of the first requested execution state it holds in memory
+// (2 bytes), the size of its checkpoint (8 bytes), and the checkpoint file containing the trie of this
+// execution state, in the format written by wal.StoreCheckpoint. The stream is then closed.
+package checkpoint
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+const (
+	statusOK byte = iota
+	statusNotAvailable
+	statusUnauthorized
+)
+
+// requestTimeout is the time a requesting node has to send its request once the stream is opened.
+const requestTimeout = 10 * time.Second
+
+// MaxRequestedStates is the maximum number of execution states a node can request at once.
+const MaxRequestedStates = 100
+
+// Tries provides the tries of the execution states held in memory.
+type Tries interface {
+	// AcquireTrie returns the trie with the given root hash, or an error if it is not held in memory,
+	// and a function releasing the trie, which must be called once it is not used anymore.
+	AcquireTrie(rootHash ledger.RootHash) (*trie.MTrie, func(), error)
+}
+
+// Server serves checkpoints of the execution states held in memory by the ledger to other execution nodes.
+type Server struct {
+	log   zerolog.Logger
+	tries Tries
+	state protocol.State
+}
+
+// NewServer returns a server for checkpoints of the given tries. Only execution nodes of the latest
+// finalized identity table are served.
+func NewServer(log zerolog.Logger, tries Tries, state protocol.State) *Server {
+	return &Server{
+		log:   log.With().Str("component", "checkpoint_server").Logger(),
+		tries: tries,
+		state: state,
+	}
+}
+
+// HandleStream serves a checkpoint request of the given node.
+func (s *Server) HandleStream(originID flow.Identifier, stream libp2pnetwork.Stream) {
+	log := s.log.With().Hex("origin_id", originID[:]).Logger()
+
+	err := s.serve(log, originID, stream)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to serve checkpoint")
+		err = stream.Reset()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to reset stream")
+		}
+		return
+	}
+
+	err = stream.Close()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to close stream")
+	}
+}
+
+func (s *Server) serve(log zerolog.Logger, originID flow.Identifier, stream libp2pnetwork.Stream) error {
+	err := stream.SetReadDeadline(time.Now().Add(requestTimeout))
+	if err != nil {
+		return err
+	}
+
+	commits, err := readRequest(stream)
+	if err != nil {
+		return err
+	}
+
+	identity, err := s.state.Final().Identity(originID)
+	if err != nil || identity.Role != flow.RoleExecution {
+		log.Warn().Msg("rejecting checkpoint request of node which is not an execution node")
+		return writeStatus(stream, statusUnauthorized)
+	}
+
+	index := -1
+	var t *trie.MTrie
+	var release func()
+	for i, commit := range commits {
+		t, release, err = s.tries.AcquireTrie(ledger.RootHash(commit))
+		if err == nil {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		log.Info().Int("requested_states", len(commits)).Msg("none of the requested execution states is available")
+		return writeStatus(stream, statusNotAvailable)
+	}
+	defer release()
+
+	log = log.With().Hex("state_commitment", commits[index][:]).Logger()
+
+	// the checkpoint is serialized twice, first to compute its size, so that the requesting node can
+	// bound the download without the checkpoint being buffered
+	counter := &countingWriter{}
+	err = wal.StoreCheckpoint(counter, t)
+	if err != nil {
+		return fmt.Errorf("could not compute checkpoint size: %w", err)
+	}
+
+	log.Info().Msg("serving checkpoint")
+	start := time.Now()
+
+	writer := bufio.NewWriter(stream)
+	err = writer.WriteByte(statusOK)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, 2+8)
+	binary.BigEndian.PutUint16(header, uint16(index))
+	binary.BigEndian.PutUint64(header[2:], counter.size)
+	_, err = writer.Write(header)
+	if err != nil {
+		return err
+	}
+
+	err = wal.StoreCheckpoint(writer, t)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	log.Info().Uint64("size", counter.size).Dur("duration", time.Since(start)).Msg("checkpoint served")
+
+	return nil
+}
+
+func writeStatus(w io.Writer, status byte) error {
+	_, err := w.Write([]byte{status})
+	return err
+}
+
+// readRequest reads the state commitments requested by a node.
+func readRequest(r io.Reader) ([]flow.StateCommitment, error) {
+	count := make([]byte, 2)
+	_, err := io.ReadFull(r, count)
+	if err != nil {
+		return nil, err
+	}
+
+	n := int(binary.BigEndian.Uint16(count))
+	if n == 0 || n > MaxRequestedStates {
+		return nil, fmt.Errorf("invalid number of requested execution states: %d", n)
+	}
+
+	commits := make([]flow.StateCommitment, n)
+	for i := range commits {
+		_, err = io.ReadFull(r, commits[i][:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return commits, nil
+}
+
+// writeRequest writes a request of the given state commitments.
+func writeRequest(w io.Writer, commits []flow.StateCommitment) error {
+	if len(commits) == 0 || len(commits) > MaxRequestedStates {
+		return fmt.Errorf("invalid number of requested execution states: %d", len(commits))
+	}
+
+	request := make([]byte, 2, 2+len(commits)*len(flow.StateCommitment{}))
+	binary.BigEndian.PutUint16(request, uint16(len(commits)))
+	for _, commit := range commits {
+		request = append(request, commit[:]...)
+	}
+
+	_, err := w.Write(request)
+	return err
+}
+
+// countingWriter discards the bytes written to it, and counts them.
+type countingWriter struct {
+	size uint64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.size += uint64(len(p))
+	return len(p), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -53,9 +54,45 @@ type blockComputer struct {
 	log            zerolog.Logger
 	systemChunkCtx fvm.Context
 	committer      ViewCommitter
+
+	// parallelWorkers is the number of workers executing the transactions of a collection in parallel,
+	// parallel execution is disabled if it is less than 2
+	parallelWorkers int
+
+	// logTopRegisterInteractions is the number of transactions of each block with the largest register
+	// interactions which are logged, logging is disabled if it is 0
+	logTopRegisterInteractions int
+}
+
+// BlockComputerOption configures a block computer.
+type BlockComputerOption func(*blockComputer)
+
+// WithParallelExecution enables the optimistic parallel execution of the transactions of each collection
+// with the given number of workers. Transactions are executed speculatively and re-executed if they read
+// registers written by a preceding transaction of the collection, so the results are identical to the
+// results of sequential execution. The system chunk is always executed sequentially.
+func WithParallelExecution(workers int) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.parallelWorkers = workers
+	}
 }
 
+// WithRegisterInteractionsLog enables logging the register interactions of the given number of transactions
+// of each block which read and wrote the most register bytes.
+func WithRegisterInteractionsLog(top int) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.logTopRegisterInteractions = top
+	}
+}
+
+// SystemChunkContext returns the context the system chunk transactions are executed in. The system tasks of
+// the given context are run in the system chunk, or the tasks scheduled on its chain if none are set.
 func SystemChunkContext(vmCtx fvm.Context, logger zerolog.Logger) fvm.Context {
+	tasks := vmCtx.SystemTasks
+	if tasks == nil {
+		tasks = blueprints.SystemTasksForChain(vmCtx.Chain.ChainID())
+	}
+
 	return fvm.NewContextFromParent(
 		vmCtx,
 		fvm.WithRestrictedDeployment(false),
@@ -64,6 +101,7 @@ func SystemChunkContext(vmCtx fvm.Context, logger zerolog.Logger) fvm.Context {
 		fvm.WithTransactionProcessors(fvm.NewTransactionInvoker(logger)),
 		fvm.WithMaxStateInteractionSize(SystemChunkLedgerIntractionLimit),
 		fvm.WithEventCollectionSizeLimit(SystemChunkEventCollectionMaxSize),
+		fvm.WithSystemTasks(tasks),
 	)
 }
 
@@ -75,8 +113,9 @@ func NewBlockComputer(
 	tracer module.Tracer,
 	logger zerolog.Logger,
 	committer ViewCommitter,
+	opts ...BlockComputerOption,
 ) (BlockComputer, error) {
-	return &blockComputer{
+	e := &blockComputer{
 		vm:             vm,
 		vmCtx:          vmCtx,
 		metrics:        metrics,
@@ -84,7 +123,13 @@ func NewBlockComputer(
 		log:            logger,
 		systemChunkCtx: SystemChunkContext(vmCtx, logger),
 		committer:      committer,
-	}, nil
+	}
+
+	for _, apply := range opts {
+		apply(e)
+	}
+
+	return e, nil
 }
 
 // ExecuteBlock executes a block and returns the resulting chunks.
@@ -225,9 +270,66 @@ func (e *blockComputer) executeBlock(
 	res.Proofs = proofs
 	res.TrieUpdates = trieUpdates
 
+	e.logRegisterInteractions(res)
+
 	return res, nil
 }
 
+// logRegisterInteractions logs the register interactions of the transactions of the block which read and
+// wrote the most register bytes.
+func (e *blockComputer) logRegisterInteractions(res *execution.ComputationResult) {
+	if e.logTopRegisterInteractions <= 0 {
+		return
+	}
+
+	totals := make([]state.AccountInteraction, len(res.RegisterInteractions))
+	indices := make([]int, len(res.RegisterInteractions))
+	for i, interactions := range res.RegisterInteractions {
+		totals[i] = interactions.Total()
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return totals[indices[i]].TotalBytes() > totals[indices[j]].TotalBytes()
+	})
+	if len(indices) > e.logTopRegisterInteractions {
+		indices = indices[:e.logTopRegisterInteractions]
+	}
+
+	for rank, i := range indices {
+		heaviest, heaviestInteraction := heaviestAccount(res.RegisterInteractions[i])
+		e.log.Info().
+			Hex("block_id", logging.Entity(res.ExecutableBlock)).
+			Uint64("height", res.ExecutableBlock.Block.Header.Height).
+			Int("rank", rank+1).
+			Hex("tx_id", res.TransactionResults[i].TransactionID[:]).
+			Uint64("registers_read", totals[i].RegistersRead).
+			Uint64("bytes_read", totals[i].BytesRead).
+			Uint64("registers_written", totals[i].RegistersWritten).
+			Uint64("bytes_written", totals[i].BytesWritten).
+			Int("accounts", len(res.RegisterInteractions[i])).
+			Str("heaviest_account", heaviest.Hex()).
+			Uint64("heaviest_account_bytes", heaviestInteraction.TotalBytes()).
+			Msg("transaction register interactions")
+	}
+}
+
+// heaviestAccount returns the account whose registers were read and written the most bytes.
+func heaviestAccount(interactions state.RegisterInteractions) (flow.Address, state.AccountInteraction) {
+	var heaviest flow.Address
+	var heaviestInteraction state.AccountInteraction
+	found := false
+	for address, interaction := range interactions {
+		bytes := interaction.TotalBytes()
+		if !found || bytes > heaviestInteraction.TotalBytes() ||
+			(bytes == heaviestInteraction.TotalBytes() && address.Hex() < heaviest.Hex()) {
+			heaviest = address
+			heaviestInteraction = interaction
+			found = true
+		}
+	}
+	return heaviest, heaviestInteraction
+}
+
 func (e *blockComputer) executeSystemCollection(
 	blockSpan opentracing.Span,
 	collectionIndex int,
@@ -241,29 +343,39 @@ func (e *blockComputer) executeSystemCollection(
 	colSpan := e.tracer.StartSpanFromParent(blockSpan, trace.EXEComputeSystemCollection)
 	defer colSpan.Finish()
 
-	tx, err := blueprints.SystemChunkTransaction(e.vmCtx.Chain)
+	height := systemChunkCtx.BlockHeader.Height
+	txs, err := blueprints.SystemChunkTransactions(e.vmCtx.Chain, height, systemChunkCtx.SystemTasks)
 	if err != nil {
-		return txIndex, fmt.Errorf("could not get system chunk transaction: %w", err)
+		return txIndex, fmt.Errorf("could not get system chunk transactions: %w", err)
 	}
+	tasks := systemChunkCtx.SystemTasks.ScheduledAt(height)
 
-	err = e.executeTransaction(tx, colSpan, collectionView, programs, systemChunkCtx, collectionIndex, txIndex, res, true)
-	txIndex++
+	for i, tx := range txs {
+		err = e.executeTransaction(tx, colSpan, collectionView, programs, systemChunkCtx, collectionIndex, txIndex, res, true)
+		txIndex++
 
-	if err != nil {
-		return txIndex, err
-	}
+		if err != nil {
+			return txIndex, err
+		}
+
+		name := "system_chunk_transaction"
+		if i > 0 {
+			name = tasks[i-1].Name
+		}
 
-	systemChunkTxResult := res.TransactionResults[len(res.TransactionResults)-1]
-	if systemChunkTxResult.ErrorMessage != "" {
-		// This log is used as the data source for an alert on grafana.
-		// The system_chunk_error field must not be changed without adding the corresponding
-		// changes in grafana. https://github.com/dapperlabs/flow-internal/issues/1546
-		e.log.Error().
-			Str("error_message", systemChunkTxResult.ErrorMessage).
-			Hex("block_id", logging.Entity(systemChunkCtx.BlockHeader)).
-			Bool("system_chunk_error", true).
-			Bool("critical_error", true).
-			Msg("error executing system chunk transaction")
+		systemChunkTxResult := res.TransactionResults[len(res.TransactionResults)-1]
+		if systemChunkTxResult.ErrorMessage != "" {
+			// This log is used as the data source for an alert on grafana.
+			// The system_chunk_error field must not be changed without adding the corresponding
+			// changes in grafana. https://github.com/dapperlabs/flow-internal/issues/1546
+			e.log.Error().
+				Str("error_message", systemChunkTxResult.ErrorMessage).
+				Hex("block_id", logging.Entity(systemChunkCtx.BlockHeader)).
+				Str("system_transaction", name).
+				Bool("system_chunk_error", true).
+				Bool("critical_error", true).
+				Msg("error executing system chunk transaction")
+		}
 	}
 
 	res.AddStateSnapshot(collectionView.(*delta.View).Interactions())
@@ -300,12 +412,20 @@ func (e *blockComputer) executeCollection(
 	}()
 
 	txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsReporter(e.metrics), fvm.WithTracer(e.tracer))
-	for _, txBody := range collection.Transactions {
-		err := e.executeTransaction(txBody, colSpan, collectionView, programs, txCtx, collectionIndex, txIndex, res, false)
-		txIndex++
+	if e.parallelWorkers > 1 && len(collection.Transactions) > 1 {
+		var err error
+		txIndex, err = e.executeTransactionsInParallel(colSpan, collectionIndex, txIndex, txCtx, collectionView, programs, collection.Transactions, res)
 		if err != nil {
 			return txIndex, err
 		}
+	} else {
+		for _, txBody := range collection.Transactions {
+			err := e.executeTransaction(txBody, colSpan, collectionView, programs, txCtx, collectionIndex, txIndex, res, false)
+			txIndex++
+			if err != nil {
+				return txIndex, err
+			}
+		}
 	}
 	res.AddStateSnapshot(collectionView.(*delta.View).Interactions())
 	e.log.Info().Str("collectionID", collection.Guarantee.CollectionID.String()).
@@ -380,31 +500,11 @@ func (e *blockComputer) executeTransaction(
 			err)
 	}
 
-	txResult := flow.TransactionResult{
-		TransactionID:   tx.ID,
-		ComputationUsed: tx.ComputationUsed,
-	}
-
-	if tx.Err != nil {
-		txResult.ErrorMessage = tx.Err.Error()
-	}
-
-	mergeSpan := e.tracer.StartSpanFromParent(txSpan, trace.EXEMergeTransactionView)
-	defer mergeSpan.Finish()
-
-	// always merge the view, fvm take cares of reverting changes
-	// of failed transaction invocation
-	err = collectionView.MergeView(txView)
+	txResult, err := e.mergeTransaction(txSpan, tx, txView, collectionView, collectionIndex, res)
 	if err != nil {
-		return fmt.Errorf("merging tx view to collection view failed for tx %v: %w",
-			txID.String(), err)
+		return err
 	}
 
-	res.AddEvents(collectionIndex, tx.Events)
-	res.AddServiceEvents(tx.ServiceEvents)
-	res.AddTransactionResult(&txResult)
-	res.AddComputationUsed(tx.ComputationUsed)
-
 	runtime.ReadMemStats(&m)
 	memAllocAfter := m.TotalAlloc
 
@@ -414,6 +514,8 @@ func (e *blockComputer) executeTransaction(
 		Str("traceID", traceID).
 		Uint64("computation_used", txResult.ComputationUsed).
 		Uint64("memory_used", tx.MemoryUsed).
+		Uint64("storage_bytes_read", txResult.StorageBytesRead).
+		Uint64("storage_bytes_written", txResult.StorageBytesWritten).
 		Uint64("memAlloc", memAllocAfter-memAllocBefore).
 		Int64("timeSpentInMS", time.Since(startedAt).Milliseconds())
 
@@ -433,6 +535,58 @@ func (e *blockComputer) executeTransaction(
 	return nil
 }
 
+// mergeTransaction merges the view of an executed transaction into the collection view, adds the
+// events, result and register interactions of the transaction to the computation result, and reports
+// the register interactions.
+func (e *blockComputer) mergeTransaction(
+	txSpan opentracing.Span,
+	tx *fvm.TransactionProcedure,
+	txView state.View,
+	collectionView state.View,
+	collectionIndex int,
+	res *execution.ComputationResult,
+) (*flow.TransactionResult, error) {
+
+	txResult := flow.TransactionResult{
+		TransactionID:       tx.ID,
+		ComputationUsed:     tx.ComputationUsed,
+		StorageBytesRead:    tx.StorageBytesRead,
+		StorageBytesWritten: tx.StorageBytesWritten,
+	}
+
+	if tx.Err != nil {
+		txResult.ErrorMessage = tx.Err.Error()
+	}
+
+	mergeSpan := e.tracer.StartSpanFromParent(txSpan, trace.EXEMergeTransactionView)
+	defer mergeSpan.Finish()
+
+	// always merge the view, fvm take cares of reverting changes
+	// of failed transaction invocation
+	err := collectionView.MergeView(txView)
+	if err != nil {
+		return nil, fmt.Errorf("merging tx view to collection view failed for tx %v: %w",
+			tx.ID.String(), err)
+	}
+
+	res.AddEvents(collectionIndex, tx.Events)
+	res.AddServiceEvents(tx.ServiceEvents)
+	res.AddTransactionResult(&txResult)
+	res.AddRegisterInteractions(tx.RegisterInteractions)
+	res.AddComputationUsed(tx.ComputationUsed)
+
+	interactions := tx.RegisterInteractions.Total()
+	e.metrics.ExecutionTransactionRegisterInteractions(
+		interactions.RegistersRead,
+		interactions.BytesRead,
+		interactions.RegistersWritten,
+		interactions.BytesWritten,
+		len(tx.RegisterInteractions),
+	)
+
+	return &txResult, nil
+}
+
 type blockCommitter struct {
 	tracer    module.Tracer
 	committer ViewCommitter
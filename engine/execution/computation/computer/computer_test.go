@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 
 	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
@@ -25,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/blueprints"
 	fvmErrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
@@ -54,6 +56,10 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 				tx := args[1].(*fvm.TransactionProcedure)
 
 				tx.Events = generateEvents(1, tx.TxIndex)
+				tx.RegisterInteractions = state.RegisterInteractions{
+					flow.EmptyAddress:       {RegistersRead: 1, BytesRead: 4},
+					flow.HexToAddress("01"): {RegistersRead: 2, BytesRead: 20, RegistersWritten: 1, BytesWritten: 30},
+				}
 			}).
 			Times(2 + 1) // 2 txs in collection + system chunk
 
@@ -71,7 +77,12 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 			Return(nil).
 			Times(2 + 1) // 2 txs in collection + system chunk tx
 
-		exe, err := computer.NewBlockComputer(vm, execCtx, metrics, trace.NewNoopTracer(), zerolog.Nop(), committer)
+		metrics.On("ExecutionTransactionRegisterInteractions", uint64(3), uint64(24), uint64(1), uint64(30), 2).
+			Return(nil).
+			Times(2 + 1) // 2 txs in collection + system chunk tx
+
+		exe, err := computer.NewBlockComputer(vm, execCtx, metrics, trace.NewNoopTracer(), zerolog.Nop(), committer,
+			computer.WithRegisterInteractionsLog(2))
 		require.NoError(t, err)
 
 		// create a block with 1 collection with 2 transactions
@@ -85,10 +96,12 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Len(t, result.StateSnapshots, 1+1) // +1 system chunk
 		assert.Len(t, result.TrieUpdates, 1+1)    // +1 system chunk
+		assert.Len(t, result.RegisterInteractions, 2+1)
 
 		assertEventHashesMatch(t, 1+1, result)
 
 		vm.AssertExpectations(t)
+		metrics.AssertNumberOfCalls(t, "ExecutionTransactionRegisterInteractions", 2+1)
 	})
 
 	t.Run("empty block still computes system chunk", func(t *testing.T) {
@@ -483,6 +496,213 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 	})
 }
 
+func TestBlockExecutor_ExecuteBlockInParallel(t *testing.T) {
+
+	t.Run("independent transactions are executed once", func(t *testing.T) {
+		execCtx := fvm.NewContext(zerolog.Nop())
+
+		vm := new(computermock.VirtualMachine)
+		vm.On("Run", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				tx := args[1].(*fvm.TransactionProcedure)
+
+				tx.Events = generateEvents(1, tx.TxIndex)
+			}).
+			Times(2*4 + 1) // 2 collections with 4 txs + system chunk
+
+		exe, err := computer.NewBlockComputer(vm, execCtx, metrics.NewNoopCollector(), trace.NewNoopTracer(), zerolog.Nop(),
+			committer.NewNoopViewCommitter(), computer.WithParallelExecution(4))
+		require.NoError(t, err)
+
+		block := generateBlock(2, 4, &RandomAddressGenerator{})
+
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return nil, nil
+		})
+
+		result, err := exe.ExecuteBlock(context.Background(), block, view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		assert.Len(t, result.StateSnapshots, 2+1) // +1 system chunk
+		assert.Len(t, result.TransactionResults, 2*4+1)
+
+		assertEventHashesMatch(t, 2+1, result)
+
+		// transactions are indexed in order
+		for i, txResult := range result.TransactionResults {
+			assert.EqualValues(t, i, result.Events[i/4][i%4].TransactionIndex)
+			if i < 2*4 {
+				assert.Equal(t, block.Collections()[i/4].Transactions[i%4].ID(), txResult.TransactionID)
+			}
+		}
+
+		vm.AssertExpectations(t)
+	})
+
+	t.Run("results match sequential execution", func(t *testing.T) {
+		chain := flow.Localnet.Chain()
+		execCtx := fvm.NewContext(
+			zerolog.Nop(),
+			fvm.WithChain(chain),
+			fvm.WithBlocks(&fvm.NoopBlockFinder{}),
+		)
+
+		vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+		ledger := testutil.RootBootstrappedLedger(vm, execCtx)
+
+		privateKeys, err := testutil.GenerateAccountPrivateKeys(4)
+		require.NoError(t, err)
+		accounts, err := testutil.CreateAccounts(vm, ledger, programs.NewEmptyPrograms(), privateKeys, chain)
+		require.NoError(t, err)
+
+		block := generateConflictingBlock(t, chain, accounts, privateKeys)
+		txCount := 0
+		for _, collection := range block.Collections() {
+			txCount += len(collection.Transactions)
+		}
+
+		execute := func(vm computer.VirtualMachine, opts ...computer.BlockComputerOption) (*execution.ComputationResult, *delta.View) {
+			exe, err := computer.NewBlockComputer(vm, execCtx, metrics.NewNoopCollector(), trace.NewNoopTracer(), zerolog.Nop(),
+				committer.NewNoopViewCommitter(), opts...)
+			require.NoError(t, err)
+
+			view := delta.NewView(ledger.Get)
+			result, err := exe.ExecuteBlock(context.Background(), block, view, programs.NewEmptyPrograms())
+			require.NoError(t, err)
+
+			return result, view
+		}
+
+		expected, expectedView := execute(vm)
+
+		// the block contains failing transactions and events of the contract deployed in the block
+		require.NotEmpty(t, expected.TransactionResults[4].ErrorMessage)
+		require.Len(t, expected.Events[1], 4)
+
+		for _, workers := range []int{2, 4, 16} {
+			t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+				counter := &runCountingVM{VirtualMachine: vm}
+
+				result, view := execute(counter, computer.WithParallelExecution(workers))
+
+				assert.Equal(t, expected.TransactionResults, result.TransactionResults)
+				assert.Equal(t, expected.Events, result.Events)
+				assert.Equal(t, expected.EventsHashes, result.EventsHashes)
+				assert.Equal(t, expected.ServiceEvents, result.ServiceEvents)
+				assert.Equal(t, expected.StateSnapshots, result.StateSnapshots)
+				assert.Equal(t, expected.ComputationUsed, result.ComputationUsed)
+				assert.Equal(t, expected.StateReads, result.StateReads)
+				assert.Equal(t, expectedView.Delta(), view.Delta())
+				assert.Equal(t, expectedView.SpockSecret(), view.SpockSecret())
+
+				// conflicting transactions were re-executed
+				assert.Greater(t, int(counter.runs.Load()), txCount+1)
+			})
+		}
+	})
+}
+
+// generateConflictingBlock generates a block with 2 collections, whose transactions deploy and use a
+// contract, and conflict on its storage and the sequence numbers of the proposal keys.
+func generateConflictingBlock(t *testing.T, chain flow.Chain, accounts []flow.Address, privateKeys []flow.AccountPrivateKey) *entity.ExecutableBlock {
+	contract := `
+		pub contract Counter {
+			pub event Incremented(count: Int)
+
+			pub var count: Int
+
+			init() {
+				self.count = 0
+			}
+
+			pub fun increment() {
+				self.count = self.count + 1
+				emit Incremented(count: self.count)
+			}
+		}`
+
+	increment := []byte(fmt.Sprintf(`
+		import Counter from 0x%s
+
+		transaction {
+			prepare(signer: AuthAccount) {}
+			execute {
+				Counter.increment()
+			}
+		}`, chain.ServiceAddress()))
+
+	save := []byte(`
+		transaction {
+			prepare(signer: AuthAccount) {
+				signer.save(42, to: /storage/answer)
+			}
+		}`)
+
+	fail := []byte(`
+		transaction {
+			prepare(signer: AuthAccount) {}
+			execute {
+				panic("failing transaction")
+			}
+		}`)
+
+	sequenceNumbers := make([]uint64, len(accounts))
+	tx := func(script []byte, signer int) *flow.TransactionBody {
+		txBody := flow.NewTransactionBody().
+			SetScript(script).
+			AddAuthorizer(accounts[signer])
+
+		err := testutil.SignTransaction(txBody, accounts[signer], privateKeys[signer], sequenceNumbers[signer])
+		require.NoError(t, err)
+		sequenceNumbers[signer]++
+
+		return txBody
+	}
+
+	deploy := blueprints.DeployContractTransaction(chain.ServiceAddress(), []byte(contract), "Counter")
+	err := testutil.SignTransactionAsServiceAccount(deploy, 0, chain)
+	require.NoError(t, err)
+
+	incrementAsService := flow.NewTransactionBody().
+		SetScript(increment).
+		AddAuthorizer(chain.ServiceAddress())
+	err = testutil.SignTransactionAsServiceAccount(incrementAsService, 1, chain)
+	require.NoError(t, err)
+
+	block := unittest.ExecutableBlockFromTransactions(chain.ChainID(), [][]*flow.TransactionBody{
+		{
+			deploy,
+			tx(increment, 0),
+			tx(increment, 1),
+			tx(save, 2),
+			tx(fail, 3),
+			incrementAsService,
+		},
+		{
+			tx(increment, 0),
+			tx(save, 1),
+			tx(increment, 2),
+			tx(increment, 3),
+			tx(increment, 0),
+			tx(save, 3),
+		},
+	})
+	block.StartState = unittest.StateCommitmentPointerFixture()
+
+	return block
+}
+
+// runCountingVM counts the procedures run by the virtual machine.
+type runCountingVM struct {
+	computer.VirtualMachine
+	runs atomic.Int64
+}
+
+func (vm *runCountingVM) Run(ctx fvm.Context, proc fvm.Procedure, view state.View, programs *programs.Programs) error {
+	vm.runs.Inc()
+	return vm.VirtualMachine.Run(ctx, proc, view, programs)
+}
+
 func assertEventHashesMatch(t *testing.T, expectedNoOfChunks int, result *execution.ComputationResult) {
 
 	require.Len(t, result.Events, expectedNoOfChunks)
@@ -669,6 +889,10 @@ func Test_ExecutingSystemCollection(t *testing.T) {
 		Return(nil).
 		Times(1) // system chunk tx
 
+	metrics.On("ExecutionTransactionRegisterInteractions", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Times(1) // system chunk tx
+
 	exe, err := computer.NewBlockComputer(vm, execCtx, metrics, trace.NewNoopTracer(), zerolog.Nop(), committer)
 	require.NoError(t, err)
 
@@ -684,6 +908,96 @@ func Test_ExecutingSystemCollection(t *testing.T) {
 
 	assert.Empty(t, result.TransactionResults[0].ErrorMessage)
 
+	// the system chunk transaction reads the registers of the service account
+	require.Len(t, result.RegisterInteractions, 1)
+	assert.NotZero(t, result.RegisterInteractions[0][execCtx.Chain.ServiceAddress()].RegistersRead)
+
+	committer.AssertExpectations(t)
+}
+
+func Test_ExecutingSystemTasks(t *testing.T) {
+
+	tasks, err := blueprints.NewSystemTasks(
+		blueprints.SystemTask{
+			Name:        "loop",
+			StartHeight: 40,
+			Interval:    2,
+			GasLimit:    10,
+			Script: []byte(`
+				transaction {
+					prepare(serviceAccount: AuthAccount) {
+						var i = 0
+						while i < 1000 {
+							i = i + 1
+						}
+					}
+				}`),
+		},
+		blueprints.SystemTask{
+			Name:        "create_account",
+			StartHeight: 42,
+			GasLimit:    1000,
+			Script: []byte(`
+				transaction {
+					prepare(serviceAccount: AuthAccount) {
+						AuthAccount(payer: serviceAccount)
+					}
+				}`),
+		},
+		blueprints.SystemTask{
+			Name:        "later",
+			StartHeight: 43,
+			Interval:    1,
+			GasLimit:    1000,
+			Script:      []byte(`transaction { prepare(serviceAccount: AuthAccount) { panic("not scheduled") } }`),
+		},
+	)
+	require.NoError(t, err)
+
+	execCtx := fvm.NewContext(
+		zerolog.Nop(),
+		fvm.WithChain(flow.Localnet.Chain()),
+		fvm.WithBlocks(&fvm.NoopBlockFinder{}),
+		fvm.WithSystemTasks(tasks),
+	)
+
+	vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+	ledger := testutil.RootBootstrappedLedger(vm, execCtx)
+
+	committer := new(computermock.ViewCommitter)
+	committer.On("CommitView", mock.Anything, mock.Anything).
+		Return(nil, nil, nil, nil).
+		Times(1) // only system chunk
+
+	exe, err := computer.NewBlockComputer(vm, execCtx, metrics.NewNoopCollector(), trace.NewNoopTracer(), zerolog.Nop(), committer)
+	require.NoError(t, err)
+
+	// create empty block at height 42, it will have system collection attached while executing
+	block := generateBlock(0, 0, &RandomAddressGenerator{})
+
+	result, err := exe.ExecuteBlock(context.Background(), block, delta.NewView(ledger.Get), programs.NewEmptyPrograms())
+	require.NoError(t, err)
+	assert.Len(t, result.StateSnapshots, 1) // all system transactions run in the system chunk
+
+	// the system chunk transaction runs first, followed by the scheduled tasks ordered by name
+	require.Len(t, result.TransactionResults, 3)
+	assert.Empty(t, result.TransactionResults[0].ErrorMessage)
+	assert.Empty(t, result.TransactionResults[1].ErrorMessage)
+	// the computation of each task is limited by its own limit
+	assert.Contains(t, result.TransactionResults[2].ErrorMessage, "computation")
+
+	// the events of the tasks are part of the system chunk events
+	require.Len(t, result.Events, 1)
+	var created []flow.Event
+	for _, event := range result.Events[0] {
+		if event.Type == flow.EventAccountCreated {
+			created = append(created, event)
+		}
+	}
+	require.Len(t, created, 1)
+	assert.Equal(t, uint32(1), created[0].TransactionIndex)
+	assert.Equal(t, result.TransactionResults[1].TransactionID, created[0].TransactionID)
+
 	committer.AssertExpectations(t)
 }
 
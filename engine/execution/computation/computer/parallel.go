@@ -0,0 +1,384 @@
+package computer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// registerVersion identifies the execution of a transaction which wrote a register value.
+type registerVersion struct {
+	// txIndex is the index of the transaction within its collection,
+	// or -1 if the value was read from the state at the start of the collection
+	txIndex     int
+	incarnation int
+}
+
+// baseVersion is the version of register values read from the state at the start of the collection.
+var baseVersion = registerVersion{txIndex: -1}
+
+type registerWrite struct {
+	incarnation int
+	value       flow.RegisterValue
+}
+
+type registerRead struct {
+	version registerVersion
+	value   flow.RegisterValue
+}
+
+// versionedRegisters holds the register values written by the latest execution of each transaction
+// of a collection, so that transactions read the values written by the transactions preceding them.
+type versionedRegisters struct {
+	lock    sync.RWMutex
+	writes  map[flow.RegisterID]map[int]registerWrite
+	written map[int][]flow.RegisterID
+
+	// base reads the state at the start of the collection, it is not safe for concurrent use
+	base     delta.GetRegisterFunc
+	baseLock sync.Mutex
+}
+
+func newVersionedRegisters(base delta.GetRegisterFunc) *versionedRegisters {
+	return &versionedRegisters{
+		writes:  make(map[flow.RegisterID]map[int]registerWrite),
+		written: make(map[int][]flow.RegisterID),
+		base:    base,
+	}
+}
+
+// read returns the version and value of the register as read by the transaction with the given index.
+func (r *versionedRegisters) read(id flow.RegisterID, txIndex int) (registerVersion, flow.RegisterValue, error) {
+	version, value := r.latest(id, txIndex)
+	if version != baseVersion {
+		return version, value, nil
+	}
+
+	r.baseLock.Lock()
+	defer r.baseLock.Unlock()
+
+	value, err := r.base(id.Owner, id.Controller, id.Key)
+	if err != nil {
+		return baseVersion, nil, err
+	}
+	return baseVersion, value, nil
+}
+
+// latest returns the version and value of the register written by the transaction with the highest
+// index lower than txIndex, or the base version if no such transaction wrote the register.
+func (r *versionedRegisters) latest(id flow.RegisterID, txIndex int) (registerVersion, flow.RegisterValue) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	version := baseVersion
+	var value flow.RegisterValue
+	for writer, write := range r.writes[id] {
+		if writer < txIndex && writer > version.txIndex {
+			version = registerVersion{txIndex: writer, incarnation: write.incarnation}
+			value = write.value
+		}
+	}
+
+	return version, value
+}
+
+// record replaces the register values written by the transaction with the given index by the values
+// written by the given incarnation of the transaction.
+func (r *versionedRegisters) record(txIndex int, incarnation int, writes delta.Delta) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, id := range r.written[txIndex] {
+		delete(r.writes[id], txIndex)
+	}
+
+	ids := make([]flow.RegisterID, 0, len(writes.Data))
+	for _, entry := range writes.Data {
+		versions, ok := r.writes[entry.Key]
+		if !ok {
+			versions = make(map[int]registerWrite)
+			r.writes[entry.Key] = versions
+		}
+		versions[txIndex] = registerWrite{incarnation: incarnation, value: entry.Value}
+		ids = append(ids, entry.Key)
+	}
+	r.written[txIndex] = ids
+}
+
+// valid returns whether all registers read by the given incarnation are still at the version it read,
+// in which case its execution is identical to an execution after the preceding transactions.
+func (r *versionedRegisters) valid(inc *transactionIncarnation) bool {
+	for id, read := range inc.reads {
+		version, _ := r.latest(id, inc.txIndex)
+		if version != read.version {
+			return false
+		}
+	}
+	return true
+}
+
+// transactionIncarnation is a speculative execution of a transaction of a collection.
+type transactionIncarnation struct {
+	txIndex     int
+	incarnation int
+	proc        *fvm.TransactionProcedure
+	view        *delta.View
+	programs    *programs.Programs
+	reads       map[flow.RegisterID]registerRead
+	err         error
+	panic       interface{}
+	duration    time.Duration
+}
+
+// readFunc returns the register read function of the incarnation. The first value read for each register
+// is recorded and returned for later reads, so the execution sees a consistent state.
+func (inc *transactionIncarnation) readFunc(registers *versionedRegisters) delta.GetRegisterFunc {
+	return func(owner, controller, key string) (flow.RegisterValue, error) {
+		id := flow.NewRegisterID(owner, controller, key)
+		if read, ok := inc.reads[id]; ok {
+			return read.value, nil
+		}
+
+		version, value, err := registers.read(id, inc.txIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		inc.reads[id] = registerRead{version: version, value: value}
+		return value, nil
+	}
+}
+
+// updatedContracts returns the contracts updated by the incarnation.
+func (inc *transactionIncarnation) updatedContracts() []programs.ContractUpdateKey {
+	var keys []programs.ContractUpdateKey
+	for _, entry := range inc.view.Delta().Data {
+		if !strings.HasPrefix(entry.Key.Key, state.KeyCode+".") {
+			continue
+		}
+		keys = append(keys, programs.ContractUpdateKey{
+			Address: flow.BytesToAddress([]byte(entry.Key.Owner)),
+			Name:    strings.TrimPrefix(entry.Key.Key, state.KeyCode+"."),
+		})
+	}
+	return keys
+}
+
+// executeTransactionsInParallel executes the transactions of a collection optimistically in parallel.
+//
+// In each round, the uncommitted transactions which were not executed yet, or read registers that have
+// been written since, are executed speculatively by the workers against the register values written by
+// the preceding transactions. The transactions are then committed in order as long as the registers they
+// read are still at the version they read. The first transaction which is not committed is re-executed
+// in the next round after all its preceding transactions were committed, so each round commits at least
+// one transaction. Transactions following a contract update are re-executed, since they might have used
+// cached programs of the updated contract.
+func (e *blockComputer) executeTransactionsInParallel(
+	colSpan opentracing.Span,
+	collectionIndex int,
+	txIndex uint32,
+	ctx fvm.Context,
+	collectionView state.View,
+	programs *programs.Programs,
+	transactions []*flow.TransactionBody,
+	res *execution.ComputationResult,
+) (uint32, error) {
+
+	view, ok := collectionView.(*delta.View)
+	if !ok {
+		return txIndex, fmt.Errorf("parallel execution requires a delta view (given: %T)", collectionView)
+	}
+
+	// registers not written by the preceding transactions are read from the collection view, which is
+	// only modified by committing transactions while no transaction is executed
+	registers := newVersionedRegisters(view.Peek)
+	incarnations := make([]*transactionIncarnation, len(transactions))
+	incarnationCounts := make([]int, len(transactions))
+	executions := 0
+	rounds := 0
+
+	next := 0
+	for next < len(transactions) {
+		rounds++
+
+		var pending []*transactionIncarnation
+		for i := next; i < len(transactions); i++ {
+			if incarnations[i] != nil && registers.valid(incarnations[i]) {
+				continue
+			}
+
+			inc := &transactionIncarnation{
+				txIndex:     i,
+				incarnation: incarnationCounts[i],
+				proc:        fvm.Transaction(transactions[i], txIndex+uint32(i)),
+				programs:    programs.ChildPrograms(),
+				reads:       make(map[flow.RegisterID]registerRead),
+			}
+			inc.view = delta.NewView(inc.readFunc(registers))
+
+			incarnations[i] = inc
+			incarnationCounts[i]++
+			pending = append(pending, inc)
+		}
+
+		e.executeIncarnations(colSpan, ctx, registers, pending)
+		executions += len(pending)
+
+		// the committed transactions are final, so the validation of the next transaction is final too
+		for next < len(transactions) {
+			inc := incarnations[next]
+			if !registers.valid(inc) {
+				break
+			}
+
+			// the execution failed although it read the same state as a sequential execution
+			if inc.panic != nil {
+				panic(inc.panic)
+			}
+			if inc.err != nil {
+				return txIndex + uint32(next), fmt.Errorf("failed to execute transaction %v for block %v at height %v: %w",
+					inc.proc.ID.String(),
+					res.ExecutableBlock.ID(),
+					res.ExecutableBlock.Block.Header.Height,
+					inc.err)
+			}
+
+			err := e.commitIncarnation(colSpan, inc, collectionView, collectionIndex, res)
+			if err != nil {
+				return txIndex + uint32(next), err
+			}
+			next++
+
+			updatedContracts := inc.updatedContracts()
+			if len(updatedContracts) == 0 {
+				programs.Merge(inc.programs)
+				continue
+			}
+
+			programs.Cleanup(updatedContracts)
+			for i := next; i < len(transactions); i++ {
+				incarnations[i] = nil
+			}
+			break
+		}
+	}
+
+	e.log.Debug().
+		Hex("block_id", logging.Entity(res.ExecutableBlock)).
+		Int("collection_index", collectionIndex).
+		Int("transactions", len(transactions)).
+		Int("executions", executions).
+		Int("rounds", rounds).
+		Msg("collection executed in parallel")
+
+	return txIndex + uint32(len(transactions)), nil
+}
+
+// executeIncarnations executes the given incarnations with the workers of the block computer.
+func (e *blockComputer) executeIncarnations(
+	colSpan opentracing.Span,
+	ctx fvm.Context,
+	registers *versionedRegisters,
+	incarnations []*transactionIncarnation,
+) {
+	queue := make(chan *transactionIncarnation, len(incarnations))
+	for _, inc := range incarnations {
+		queue <- inc
+	}
+	close(queue)
+
+	workers := e.parallelWorkers
+	if workers > len(incarnations) {
+		workers = len(incarnations)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for inc := range queue {
+				e.executeIncarnation(colSpan, ctx, inc)
+				registers.record(inc.txIndex, inc.incarnation, inc.view.Delta())
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// executeIncarnation executes the transaction of the given incarnation. Errors and panics are recorded,
+// since they might be caused by reading an inconsistent state.
+func (e *blockComputer) executeIncarnation(colSpan opentracing.Span, ctx fvm.Context, inc *transactionIncarnation) {
+	startedAt := time.Now()
+
+	txSpan := e.tracer.StartSpanFromParent(colSpan, trace.EXEComputeTransaction)
+	txSpan.LogFields(
+		log.String("tx_id", inc.proc.ID.String()),
+		log.Uint32("tx_index", inc.proc.TxIndex),
+		log.Int("incarnation", inc.incarnation),
+	)
+	defer txSpan.Finish()
+
+	defer func() {
+		if r := recover(); r != nil {
+			inc.panic = r
+		}
+		inc.duration = time.Since(startedAt)
+	}()
+
+	inc.err = e.vm.Run(ctx, inc.proc, inc.view, inc.programs)
+}
+
+// commitIncarnation merges the view of the given incarnation into the collection view, and adds the
+// events and result of its transaction to the computation result.
+func (e *blockComputer) commitIncarnation(
+	colSpan opentracing.Span,
+	inc *transactionIncarnation,
+	collectionView state.View,
+	collectionIndex int,
+	res *execution.ComputationResult,
+) error {
+	tx := inc.proc
+
+	txResult, err := e.mergeTransaction(colSpan, tx, inc.view, collectionView, collectionIndex, res)
+	if err != nil {
+		return err
+	}
+
+	lg := e.log.With().
+		Hex("tx_id", txResult.TransactionID[:]).
+		Str("block_id", res.ExecutableBlock.ID().String()).
+		Uint32("tx_index", tx.TxIndex).
+		Int("incarnation", inc.incarnation).
+		Uint64("computation_used", txResult.ComputationUsed).
+		Uint64("memory_used", tx.MemoryUsed).
+		Uint64("storage_bytes_read", txResult.StorageBytesRead).
+		Uint64("storage_bytes_written", txResult.StorageBytesWritten).
+		Int64("timeSpentInMS", inc.duration.Milliseconds()).
+		Logger()
+
+	if tx.Err != nil {
+		lg.Info().
+			Str("error_message", txResult.ErrorMessage).
+			Uint16("error_code", uint16(tx.Err.Code())).
+			Msg("transaction executed failed")
+	} else {
+		lg.Info().Msg("transaction executed successfully")
+	}
+
+	e.metrics.ExecutionTransactionExecuted(inc.duration, tx.ComputationUsed, len(tx.Events), tx.Err != nil)
+	return nil
+}
@@ -0,0 +1,362 @@
+package uploader
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/compressor"
+)
+
+// ArchiveVersion is the version of the block data archive format written by this package.
+//
+// A block data archive is a directory holding the block data of executed blocks. Each archived block
+// consists of two files, named after the height of the block, zero-padded to 20 digits so that the files
+// are ordered by height, and the ID of the block:
+//
+//	<height>-<block ID>.json           the manifest of the archived block
+//	<height>-<block ID>.cbor[.gz|.lz4] the block data of the block
+//
+// The block data file holds the BlockData of the block in deterministic CBOR encoding, as written by
+// WriteBlockDataTo, compressed with the compression named in the manifest. The manifest is a JSON object:
+//
+//	{
+//	  "version": 1,
+//	  "block_id": "<hex>",
+//	  "parent_id": "<hex>",
+//	  "height": 1000,
+//	  "final_state_commitment": "<hex>",
+//	  "collections": 2,
+//	  "transactions": 11,
+//	  "events": 25,
+//	  "trie_updates": 3,
+//	  "data": "00000000000000001000-<block ID>.cbor.lz4",
+//	  "compression": "lz4",
+//	  "size": 12345,
+//	  "sha256": "<hex>",
+//	  "created_at": "2022-05-01T10:00:00Z"
+//	}
+//
+// where size and sha256 are the size and the SHA-256 checksum of the block data file as stored, after
+// compression. Both files are written to temporary files which are renamed once complete, and the block
+// data file is written before the manifest, so a block is archived if and only if its manifest exists.
+const ArchiveVersion uint16 = 1
+
+const (
+	archiveManifestExtension = ".json"
+	archiveDataExtension     = ".cbor"
+	archiveTempExtension     = ".tmp"
+)
+
+// ErrNotArchived is returned when a block is not found in a block data archive.
+var ErrNotArchived = errors.New("block is not archived")
+
+// ArchiveCompression is the compression of the block data files of an archive.
+type ArchiveCompression string
+
+const (
+	ArchiveCompressionNone ArchiveCompression = "none"
+	ArchiveCompressionGzip ArchiveCompression = "gzip"
+	ArchiveCompressionLz4  ArchiveCompression = "lz4"
+)
+
+// ParseArchiveCompression returns the archive compression with the given name.
+func ParseArchiveCompression(name string) (ArchiveCompression, error) {
+	compression := ArchiveCompression(name)
+	_, err := compression.extension()
+	if err != nil {
+		return "", err
+	}
+	return compression, nil
+}
+
+func (c ArchiveCompression) extension() (string, error) {
+	switch c {
+	case ArchiveCompressionNone:
+		return "", nil
+	case ArchiveCompressionGzip:
+		return ".gz", nil
+	case ArchiveCompressionLz4:
+		return ".lz4", nil
+	default:
+		return "", fmt.Errorf("unsupported archive compression: %q", string(c))
+	}
+}
+
+// compressor returns the compressor of the compression, or nil if the block data is not compressed.
+func (c ArchiveCompression) compressor() (network.Compressor, error) {
+	switch c {
+	case ArchiveCompressionNone:
+		return nil, nil
+	case ArchiveCompressionGzip:
+		return compressor.GzipStreamCompressor{}, nil
+	case ArchiveCompressionLz4:
+		return compressor.NewLz4Compressor(), nil
+	default:
+		return nil, fmt.Errorf("unsupported archive compression: %q", string(c))
+	}
+}
+
+// ArchiveManifest describes a block archived in a block data archive.
+type ArchiveManifest struct {
+	Version              uint16             `json:"version"`
+	BlockID              flow.Identifier    `json:"block_id"`
+	ParentID             flow.Identifier    `json:"parent_id"`
+	Height               uint64             `json:"height"`
+	FinalStateCommitment string             `json:"final_state_commitment"`
+	Collections          int                `json:"collections"`
+	Transactions         int                `json:"transactions"`
+	Events               int                `json:"events"`
+	TrieUpdates          int                `json:"trie_updates"`
+	Data                 string             `json:"data"`
+	Compression          ArchiveCompression `json:"compression"`
+	Size                 int64              `json:"size"`
+	SHA256               string             `json:"sha256"`
+	CreatedAt            time.Time          `json:"created_at"`
+}
+
+func archiveBlockName(height uint64, blockID flow.Identifier) string {
+	return fmt.Sprintf("%020d-%s", height, blockID)
+}
+
+// WriteArchivedBlock writes the given block data to the block data archive in the given directory,
+// and returns the manifest of the archived block. Blocks which are already archived are overwritten.
+func WriteArchivedBlock(dir string, blockData *BlockData, compression ArchiveCompression) (*ArchiveManifest, error) {
+	extension, err := compression.extension()
+	if err != nil {
+		return nil, err
+	}
+	comp, err := compression.compressor()
+	if err != nil {
+		return nil, err
+	}
+
+	header := blockData.Block.Header
+	blockID := header.ID()
+	name := archiveBlockName(header.Height, blockID)
+	dataName := name + archiveDataExtension + extension
+
+	size, checksum, err := writeArchiveFile(filepath.Join(dir, dataName), func(w io.Writer) error {
+		if comp == nil {
+			return WriteBlockDataTo(blockData, w)
+		}
+
+		cw, err := comp.NewWriter(w)
+		if err != nil {
+			return fmt.Errorf("cannot create compressor: %w", err)
+		}
+		err = WriteBlockDataTo(blockData, cw)
+		if err != nil {
+			return err
+		}
+		return cw.Close()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot write block data of block %v: %w", blockID, err)
+	}
+
+	manifest := &ArchiveManifest{
+		Version:              ArchiveVersion,
+		BlockID:              blockID,
+		ParentID:             header.ParentID,
+		Height:               header.Height,
+		FinalStateCommitment: hex.EncodeToString(blockData.FinalStateCommitment[:]),
+		Collections:          len(blockData.Collections),
+		Transactions:         len(blockData.TxResults),
+		Events:               len(blockData.Events),
+		TrieUpdates:          len(blockData.TrieUpdates),
+		Data:                 dataName,
+		Compression:          compression,
+		Size:                 size,
+		SHA256:               checksum,
+		CreatedAt:            time.Now().UTC(),
+	}
+
+	_, _, err = writeArchiveFile(filepath.Join(dir, name+archiveManifestExtension), func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(manifest)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot write manifest of block %v: %w", blockID, err)
+	}
+
+	return manifest, nil
+}
+
+// writeArchiveFile writes a file of an archive through a temporary file, and returns the size
+// and the hex-encoded SHA-256 checksum of the written file.
+func writeArchiveFile(path string, write func(io.Writer) error) (int64, string, error) {
+	tmpPath := path + archiveTempExtension
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return 0, "", fmt.Errorf("cannot create file: %w", err)
+	}
+	defer func() {
+		// the temporary file is only left if writing failed
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+	}()
+
+	hasher := sha256.New()
+	writer := bufio.NewWriter(io.MultiWriter(file, hasher))
+
+	err = write(writer)
+	if err != nil {
+		return 0, "", err
+	}
+	err = writer.Flush()
+	if err != nil {
+		return 0, "", fmt.Errorf("cannot write file: %w", err)
+	}
+	err = file.Sync()
+	if err != nil {
+		return 0, "", fmt.Errorf("cannot sync file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, "", fmt.Errorf("cannot stat file: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return 0, "", fmt.Errorf("cannot close file: %w", err)
+	}
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return 0, "", fmt.Errorf("cannot rename file: %w", err)
+	}
+
+	return info.Size(), hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// ArchiveReader reads the blocks of a block data archive.
+type ArchiveReader struct {
+	dir string
+}
+
+// NewArchiveReader returns a reader of the block data archive in the given directory.
+func NewArchiveReader(dir string) *ArchiveReader {
+	return &ArchiveReader{
+		dir: dir,
+	}
+}
+
+// Manifests returns the manifests of the archived blocks, ordered by height.
+func (r *ArchiveReader) Manifests() ([]*ArchiveManifest, error) {
+	entries, err := ioutil.ReadDir(r.dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list archive directory: %w", err)
+	}
+
+	var manifests []*ArchiveManifest
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), archiveManifestExtension) {
+			continue
+		}
+
+		manifest, err := r.readManifest(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return manifests[i].Height < manifests[j].Height
+	})
+
+	return manifests, nil
+}
+
+// ByBlockID returns the manifest of the archived block with the given ID.
+// Returns ErrNotArchived if the block is not archived.
+func (r *ArchiveReader) ByBlockID(blockID flow.Identifier) (*ArchiveManifest, error) {
+	matches, err := filepath.Glob(filepath.Join(r.dir, "*-"+blockID.String()+archiveManifestExtension))
+	if err != nil {
+		return nil, fmt.Errorf("cannot look up manifest: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("could not find block %v: %w", blockID, ErrNotArchived)
+	}
+
+	return r.readManifest(filepath.Base(matches[0]))
+}
+
+func (r *ArchiveReader) readManifest(name string) (*ArchiveManifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(r.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read manifest %s: %w", name, err)
+	}
+
+	var manifest ArchiveManifest
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode manifest %s: %w", name, err)
+	}
+
+	return &manifest, nil
+}
+
+// Read reads the block data of the archived block with the given manifest. The size and the checksum
+// of the block data file are verified before the block data is decoded.
+func (r *ArchiveReader) Read(manifest *ArchiveManifest) (*BlockData, error) {
+	if manifest.Version == 0 || manifest.Version > ArchiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d of block %v", manifest.Version, manifest.BlockID)
+	}
+
+	comp, err := manifest.Compression.compressor()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(r.dir, manifest.Data))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read block data of block %v: %w", manifest.BlockID, err)
+	}
+
+	if int64(len(data)) != manifest.Size {
+		return nil, fmt.Errorf("invalid size of block data of block %v: expected %d, got %d",
+			manifest.BlockID, manifest.Size, len(data))
+	}
+	checksum := sha256.Sum256(data)
+	if hex.EncodeToString(checksum[:]) != manifest.SHA256 {
+		return nil, fmt.Errorf("invalid checksum of block data of block %v", manifest.BlockID)
+	}
+
+	var reader io.Reader = bytes.NewReader(data)
+	if comp != nil {
+		decompressed, err := comp.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decompress block data of block %v: %w", manifest.BlockID, err)
+		}
+		defer decompressed.Close()
+		reader = decompressed
+	}
+
+	blockData, err := ReadBlockDataFrom(reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read block data of block %v: %w", manifest.BlockID, err)
+	}
+
+	if blockData.Block.ID() != manifest.BlockID {
+		return nil, fmt.Errorf("archived block %v does not match manifest of block %v", blockData.Block.ID(), manifest.BlockID)
+	}
+
+	return blockData, nil
+}
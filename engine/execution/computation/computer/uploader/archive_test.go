@@ -0,0 +1,206 @@
+package uploader
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func Test_BlockDataArchive(t *testing.T) {
+
+	for _, compression := range []ArchiveCompression{ArchiveCompressionNone, ArchiveCompressionGzip, ArchiveCompressionLz4} {
+		compression := compression
+
+		t.Run("round trip with compression "+string(compression), func(t *testing.T) {
+			unittest.RunWithTempDir(t, func(dir string) {
+				cr := generateComputationResult(t)
+				blockData := ComputationResultToBlockData(cr)
+
+				manifest, err := WriteArchivedBlock(dir, blockData, compression)
+				require.NoError(t, err)
+
+				assert.Equal(t, ArchiveVersion, manifest.Version)
+				assert.Equal(t, cr.ExecutableBlock.ID(), manifest.BlockID)
+				assert.Equal(t, cr.ExecutableBlock.Height(), manifest.Height)
+				assert.Equal(t, compression, manifest.Compression)
+				assert.Equal(t, len(blockData.Events), manifest.Events)
+
+				reader := NewArchiveReader(dir)
+				manifests, err := reader.Manifests()
+				require.NoError(t, err)
+				require.Len(t, manifests, 1)
+				assert.Equal(t, manifest.SHA256, manifests[0].SHA256)
+
+				read, err := reader.Read(manifests[0])
+				require.NoError(t, err)
+
+				assert.Equal(t, blockData, read)
+			})
+		})
+	}
+
+	t.Run("corrupted block data is detected", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			manifest, err := WriteArchivedBlock(dir, ComputationResultToBlockData(generateComputationResult(t)), ArchiveCompressionNone)
+			require.NoError(t, err)
+
+			path := filepath.Join(dir, manifest.Data)
+			data, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+			data[len(data)/2] ^= 0xff
+			require.NoError(t, ioutil.WriteFile(path, data, 0644))
+
+			_, err = NewArchiveReader(dir).Read(manifest)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid checksum")
+		})
+	})
+
+	t.Run("unsupported versions are rejected", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			manifest, err := WriteArchivedBlock(dir, ComputationResultToBlockData(generateComputationResult(t)), ArchiveCompressionLz4)
+			require.NoError(t, err)
+
+			manifest.Version = ArchiveVersion + 1
+			_, err = NewArchiveReader(dir).Read(manifest)
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("incomplete blocks are ignored and blocks are ordered by height", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			heights := []uint64{20, 3, 100}
+			for _, height := range heights {
+				_, err := WriteArchivedBlock(dir, ComputationResultToBlockData(computationResultAtHeight(t, height)), ArchiveCompressionLz4)
+				require.NoError(t, err)
+			}
+
+			// block data left by an interrupted write
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, archiveBlockName(50, unittest.IdentifierFixture())+archiveDataExtension), []byte{1}, 0644))
+
+			manifests, err := NewArchiveReader(dir).Manifests()
+			require.NoError(t, err)
+			require.Len(t, manifests, 3)
+			assert.Equal(t, uint64(3), manifests[0].Height)
+			assert.Equal(t, uint64(20), manifests[1].Height)
+			assert.Equal(t, uint64(100), manifests[2].Height)
+		})
+	})
+
+	t.Run("blocks are looked up by ID", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			cr := generateComputationResult(t)
+			_, err := WriteArchivedBlock(dir, ComputationResultToBlockData(cr), ArchiveCompressionGzip)
+			require.NoError(t, err)
+
+			reader := NewArchiveReader(dir)
+			manifest, err := reader.ByBlockID(cr.ExecutableBlock.ID())
+			require.NoError(t, err)
+			assert.Equal(t, cr.ExecutableBlock.ID(), manifest.BlockID)
+
+			_, err = reader.ByBlockID(unittest.IdentifierFixture())
+			require.True(t, errors.Is(err, ErrNotArchived))
+		})
+	})
+}
+
+func Test_LocalArchiver(t *testing.T) {
+
+	t.Run("blocks outside of the retained heights are removed", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			archiver, err := NewLocalArchiver(dir, ArchiveCompressionLz4, ArchiveRetention{Heights: 3}, zerolog.Nop())
+			require.NoError(t, err)
+
+			for height := uint64(1); height <= 5; height++ {
+				require.NoError(t, archiver.Upload(computationResultAtHeight(t, height)))
+			}
+
+			manifests, err := NewArchiveReader(dir).Manifests()
+			require.NoError(t, err)
+			require.Len(t, manifests, 3)
+			assert.Equal(t, uint64(3), manifests[0].Height)
+			assert.Equal(t, uint64(5), manifests[2].Height)
+
+			// the block data of removed blocks is removed as well
+			entries, err := ioutil.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, entries, 6)
+		})
+	})
+
+	t.Run("blocks older than the maximum age are removed", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			archiver, err := NewLocalArchiver(dir, ArchiveCompressionNone, ArchiveRetention{MaxAge: time.Hour}, zerolog.Nop())
+			require.NoError(t, err)
+
+			require.NoError(t, archiver.Upload(computationResultAtHeight(t, 1)))
+
+			archiver.now = func() time.Time {
+				return time.Now().Add(2 * time.Hour)
+			}
+			require.NoError(t, archiver.Upload(computationResultAtHeight(t, 2)))
+
+			// the block just archived is removed as well, since the clock is ahead
+			manifests, err := NewArchiveReader(dir).Manifests()
+			require.NoError(t, err)
+			require.Empty(t, manifests)
+
+			archiver.now = time.Now
+			require.NoError(t, archiver.Upload(computationResultAtHeight(t, 3)))
+
+			manifests, err = NewArchiveReader(dir).Manifests()
+			require.NoError(t, err)
+			require.Len(t, manifests, 1)
+			assert.Equal(t, uint64(3), manifests[0].Height)
+		})
+	})
+
+	t.Run("blocks archived before a restart are removed", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			archiver, err := NewLocalArchiver(dir, ArchiveCompressionNone, ArchiveRetention{}, zerolog.Nop())
+			require.NoError(t, err)
+			for height := uint64(1); height <= 3; height++ {
+				require.NoError(t, archiver.Upload(computationResultAtHeight(t, height)))
+			}
+
+			// the archived blocks are loaded once when the archiver is created
+			archiver, err = NewLocalArchiver(dir, ArchiveCompressionNone, ArchiveRetention{Heights: 2}, zerolog.Nop())
+			require.NoError(t, err)
+			require.Len(t, archiver.archived, 3)
+
+			require.NoError(t, archiver.Upload(computationResultAtHeight(t, 4)))
+
+			manifests, err := NewArchiveReader(dir).Manifests()
+			require.NoError(t, err)
+			require.Len(t, manifests, 2)
+			assert.Equal(t, uint64(3), manifests[0].Height)
+			assert.Equal(t, uint64(4), manifests[1].Height)
+			assert.Equal(t, manifests, archiver.archived)
+		})
+	})
+
+	t.Run("unsupported compression is rejected", func(t *testing.T) {
+		_, err := NewLocalArchiver(os.TempDir(), ArchiveCompression("zip"), ArchiveRetention{}, zerolog.Nop())
+		require.Error(t, err)
+	})
+}
+
+func computationResultAtHeight(t *testing.T, height uint64) *execution.ComputationResult {
+	cr := generateComputationResult(t)
+	cr.ExecutableBlock = unittest.ExecutableBlockFixtureWithParent(
+		[][]flow.Identifier{{unittest.IdentifierFixture()}},
+		&flow.Header{Height: height - 1},
+	)
+	return cr
+}
@@ -0,0 +1,167 @@
+package uploader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution"
+)
+
+var _ Uploader = (*LocalArchiver)(nil)
+
+// ArchiveRetention is the retention policy of a local block data archive.
+// Archived blocks are removed as soon as they are outside of any of the limits.
+type ArchiveRetention struct {
+	// Heights is the number of heights, up to the highest archived height, whose blocks are kept.
+	// Zero keeps blocks of all heights.
+	Heights uint64
+	// MaxAge is the duration for which archived blocks are kept. Zero keeps blocks forever.
+	MaxAge time.Duration
+}
+
+// LocalArchiver is an uploader which archives the block data of executed blocks in a local block data
+// archive, and removes archived blocks according to its retention policy.
+type LocalArchiver struct {
+	lock        sync.Mutex
+	dir         string
+	compression ArchiveCompression
+	retention   ArchiveRetention
+	log         zerolog.Logger
+	now         func() time.Time
+
+	// archived holds the manifests of the archived blocks ordered by height. It is loaded from the archive
+	// once on startup, so that the archive directory is not read again on each upload.
+	archived []*ArchiveManifest
+}
+
+// NewLocalArchiver returns a local archiver writing to the block data archive in the given directory,
+// which is created if it does not exist.
+func NewLocalArchiver(dir string, compression ArchiveCompression, retention ArchiveRetention, log zerolog.Logger) (*LocalArchiver, error) {
+	_, err := compression.compressor()
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create archive directory: %w", err)
+	}
+
+	archived, err := NewArchiveReader(dir).Manifests()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read archived blocks: %w", err)
+	}
+
+	return &LocalArchiver{
+		dir:         dir,
+		compression: compression,
+		retention:   retention,
+		log:         log.With().Str("subcomponent", "local_archiver").Logger(),
+		now:         time.Now,
+		archived:    archived,
+	}, nil
+}
+
+// Upload archives the block data of the given computation result.
+func (a *LocalArchiver) Upload(computationResult *execution.ComputationResult) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	manifest, err := WriteArchivedBlock(a.dir, ComputationResultToBlockData(computationResult), a.compression)
+	if err != nil {
+		return err
+	}
+
+	a.log.Debug().
+		Hex("block_id", manifest.BlockID[:]).
+		Uint64("height", manifest.Height).
+		Int64("size", manifest.Size).
+		Msg("block data archived")
+
+	a.add(manifest)
+
+	// failing to remove expired blocks does not fail the upload, they are removed with the next upload
+	err = a.prune()
+	if err != nil {
+		a.log.Warn().Err(err).Msg("could not remove expired blocks from archive")
+	}
+
+	return nil
+}
+
+// add adds the manifest of an archived block to the archived blocks, replacing the manifest of the block
+// if it was archived before.
+func (a *LocalArchiver) add(manifest *ArchiveManifest) {
+	for i, archived := range a.archived {
+		if archived.BlockID == manifest.BlockID {
+			a.archived = append(a.archived[:i], a.archived[i+1:]...)
+			break
+		}
+	}
+
+	i := sort.Search(len(a.archived), func(i int) bool {
+		return a.archived[i].Height > manifest.Height
+	})
+	a.archived = append(a.archived, nil)
+	copy(a.archived[i+1:], a.archived[i:])
+	a.archived[i] = manifest
+}
+
+// prune removes the archived blocks which are outside of the retention policy. The manifest of a block
+// is removed before its block data, so readers never find a manifest without block data. Blocks which
+// could not be removed are kept in the archived blocks, so that they are removed with the next upload.
+func (a *LocalArchiver) prune() error {
+	if a.retention.Heights == 0 && a.retention.MaxAge == 0 || len(a.archived) == 0 {
+		return nil
+	}
+
+	highest := a.archived[len(a.archived)-1].Height
+	now := a.now()
+
+	kept := a.archived[:0]
+	defer func() {
+		a.archived = kept
+	}()
+
+	for i, manifest := range a.archived {
+		expired := a.retention.Heights > 0 && manifest.Height+a.retention.Heights <= highest ||
+			a.retention.MaxAge > 0 && now.Sub(manifest.CreatedAt) > a.retention.MaxAge
+		if !expired {
+			kept = append(kept, manifest)
+			continue
+		}
+
+		err := a.remove(manifest)
+		if err != nil {
+			kept = append(kept, a.archived[i:]...)
+			return err
+		}
+
+		a.log.Debug().
+			Hex("block_id", manifest.BlockID[:]).
+			Uint64("height", manifest.Height).
+			Msg("expired block removed from archive")
+	}
+
+	return nil
+}
+
+// remove removes the archived block with the given manifest from the archive directory.
+func (a *LocalArchiver) remove(manifest *ArchiveManifest) error {
+	name := archiveBlockName(manifest.Height, manifest.BlockID)
+	err := os.Remove(filepath.Join(a.dir, name+archiveManifestExtension))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("cannot remove manifest of block %v: %w", manifest.BlockID, err)
+	}
+	err = os.Remove(filepath.Join(a.dir, manifest.Data))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("cannot remove block data of block %v: %w", manifest.BlockID, err)
+	}
+	return nil
+}
@@ -46,8 +46,11 @@ func ComputationResultToBlockData(computationResult *execution.ComputationResult
 }
 
 func WriteComputationResultsTo(computationResult *execution.ComputationResult, writer io.Writer) error {
-	blockData := ComputationResultToBlockData(computationResult)
+	return WriteBlockDataTo(ComputationResultToBlockData(computationResult), writer)
+}
 
+// WriteBlockDataTo writes the given block data to the writer in deterministic CBOR encoding.
+func WriteBlockDataTo(blockData *BlockData, writer io.Writer) error {
 	mode, err := cbor.CoreDetEncOptions().EncMode()
 	if err != nil {
 		return fmt.Errorf("cannot create deterministic cbor encoding mode: %w", err)
@@ -56,3 +59,13 @@ func WriteComputationResultsTo(computationResult *execution.ComputationResult, w
 
 	return encoder.Encode(blockData)
 }
+
+// ReadBlockDataFrom reads block data written by WriteBlockDataTo from the reader.
+func ReadBlockDataFrom(reader io.Reader) (*BlockData, error) {
+	var blockData BlockData
+	err := cbor.NewDecoder(reader).Decode(&blockData)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode block data: %w", err)
+	}
+	return &blockData, nil
+}
@@ -57,6 +57,19 @@ var DefaultScriptExecutionTimeLimit = 10 * time.Second
 
 const MaxScriptErrorMessageSize = 1000 // 1000 chars
 
+// ComputationConfig is the configuration of the computation manager.
+type ComputationConfig struct {
+	// ProgramsCacheSize is the number of blocks whose contract programs are cached in memory.
+	ProgramsCacheSize uint
+	// PersistentPrograms persists the locations of the loaded contract programs across restarts,
+	// it is disabled if nil.
+	PersistentPrograms *PersistentProgramsCache
+	// ScriptLogThreshold is the duration above which script executions are logged.
+	ScriptLogThreshold time.Duration
+	// ScriptExecutionTimeLimit is the duration after which script executions are aborted.
+	ScriptExecutionTimeLimit time.Duration
+}
+
 // Manager manages computation and execution
 type Manager struct {
 	log                      zerolog.Logger
@@ -67,6 +80,8 @@ type Manager struct {
 	vmCtx                    fvm.Context
 	blockComputer            computer.BlockComputer
 	programsCache            *ProgramsCache
+	persistentPrograms       *PersistentProgramsCache
+	warmUp                   *programsWarmUp
 	scriptLogThreshold       time.Duration
 	scriptExecutionTimeLimit time.Duration
 	uploaders                []uploader.Uploader
@@ -82,16 +97,19 @@ func New(
 	protoState protocol.State,
 	vm VirtualMachine,
 	vmCtx fvm.Context,
-	programsCacheSize uint,
+	config ComputationConfig,
 	committer computer.ViewCommitter,
-	scriptLogThreshold time.Duration,
-	scriptExecutionTimeLimit time.Duration,
 	uploaders []uploader.Uploader,
 	eds state_synchronization.ExecutionDataService,
 	edCache state_synchronization.ExecutionDataCIDCache,
+	computerOpts ...computer.BlockComputerOption,
 ) (*Manager, error) {
 	log := logger.With().Str("engine", "computation").Logger()
 
+	if config.PersistentPrograms != nil {
+		vmCtx = fvm.NewContextFromParent(vmCtx, fvm.WithContractUpdateObserver(config.PersistentPrograms.OnContractUpdated))
+	}
+
 	blockComputer, err := computer.NewBlockComputer(
 		vm,
 		vmCtx,
@@ -99,13 +117,14 @@ func New(
 		tracer,
 		log.With().Str("component", "block_computer").Logger(),
 		committer,
+		computerOpts...,
 	)
 
 	if err != nil {
 		return nil, fmt.Errorf("cannot create block computer: %w", err)
 	}
 
-	programsCache, err := NewProgramsCache(programsCacheSize)
+	programsCache, err := NewProgramsCache(config.ProgramsCacheSize)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create programs cache: %w", err)
 	}
@@ -119,8 +138,9 @@ func New(
 		vmCtx:                    vmCtx,
 		blockComputer:            blockComputer,
 		programsCache:            programsCache,
-		scriptLogThreshold:       scriptLogThreshold,
-		scriptExecutionTimeLimit: scriptExecutionTimeLimit,
+		persistentPrograms:       config.PersistentPrograms,
+		scriptLogThreshold:       config.ScriptLogThreshold,
+		scriptExecutionTimeLimit: config.ScriptExecutionTimeLimit,
 		uploaders:                uploaders,
 		eds:                      eds,
 		edCache:                  edCache,
@@ -129,6 +149,49 @@ func New(
 	return &e, nil
 }
 
+// programsWarmUp tracks the loading of the persistent programs cache at a block.
+type programsWarmUp struct {
+	blockID flow.Identifier
+	done    chan struct{}
+}
+
+// WarmUpPrograms loads the programs of the persistent programs cache at the given block in the background, and
+// caches them for the execution of the children of the block, which waits until the programs are loaded.
+// It must be called before blocks are executed, and is a no-op if the persistent programs cache is disabled.
+func (e *Manager) WarmUpPrograms(header *flow.Header, view state.View) {
+	if e.persistentPrograms == nil {
+		return
+	}
+
+	warmUp := &programsWarmUp{
+		blockID: header.ID(),
+		done:    make(chan struct{}),
+	}
+	e.warmUp = warmUp
+
+	go func() {
+		defer close(warmUp.done)
+
+		warm := e.persistentPrograms.WarmUp(e.vm, e.vmCtx, header, view)
+		if e.programsCache.Get(warmUp.blockID) == nil {
+			e.programsCache.Set(warmUp.blockID, warm)
+		}
+	}()
+}
+
+// waitForWarmUp waits until the programs of the given block are loaded, if they are being loaded
+// from the persistent programs cache.
+func (e *Manager) waitForWarmUp(ctx context.Context, blockID flow.Identifier) {
+	if e.warmUp == nil || e.warmUp.blockID != blockID {
+		return
+	}
+
+	select {
+	case <-e.warmUp.done:
+	case <-ctx.Done():
+	}
+}
+
 func (e *Manager) getChildProgramsOrEmpty(blockID flow.Identifier) *programs.Programs {
 	blockPrograms := e.programsCache.Get(blockID)
 	if blockPrograms == nil {
@@ -238,6 +301,8 @@ func (e *Manager) ComputeBlock(
 		Hex("block_id", logging.Entity(block.Block)).
 		Msg("received complete block")
 
+	e.waitForWarmUp(ctx, block.ParentID())
+
 	var blockPrograms *programs.Programs
 	fromCache := e.programsCache.Get(block.ParentID())
 
@@ -266,6 +331,10 @@ func (e *Manager) ComputeBlock(
 
 	e.programsCache.Set(block.ID(), toInsert)
 
+	if e.persistentPrograms != nil {
+		e.persistentPrograms.Record(block.Height(), blockPrograms.ContractLocations())
+	}
+
 	group, uploadCtx := errgroup.WithContext(ctx)
 	var rootID flow.Identifier
 	var blobTree [][]cid.Cid
@@ -230,10 +230,12 @@ func TestExecuteScript(t *testing.T) {
 		nil,
 		vm,
 		execCtx,
-		DefaultProgramsCacheSize,
+		ComputationConfig{
+			ProgramsCacheSize:        DefaultProgramsCacheSize,
+			ScriptLogThreshold:       scriptLogThreshold,
+			ScriptExecutionTimeLimit: DefaultScriptExecutionTimeLimit,
+		},
 		committer.NewNoopViewCommitter(),
-		scriptLogThreshold,
-		DefaultScriptExecutionTimeLimit,
 		nil,
 		eds,
 		edCache)
@@ -265,10 +267,12 @@ func TestExecuteScripPanicsAreHandled(t *testing.T) {
 		nil,
 		vm,
 		ctx,
-		DefaultProgramsCacheSize,
+		ComputationConfig{
+			ProgramsCacheSize:        DefaultProgramsCacheSize,
+			ScriptLogThreshold:       scriptLogThreshold,
+			ScriptExecutionTimeLimit: DefaultScriptExecutionTimeLimit,
+		},
 		committer.NewNoopViewCommitter(),
-		scriptLogThreshold,
-		DefaultScriptExecutionTimeLimit,
 		nil,
 		eds,
 		edCache)
@@ -302,10 +306,12 @@ func TestExecuteScript_LongScriptsAreLogged(t *testing.T) {
 		nil,
 		vm,
 		ctx,
-		DefaultProgramsCacheSize,
+		ComputationConfig{
+			ProgramsCacheSize:        DefaultProgramsCacheSize,
+			ScriptLogThreshold:       1 * time.Millisecond,
+			ScriptExecutionTimeLimit: DefaultScriptExecutionTimeLimit,
+		},
 		committer.NewNoopViewCommitter(),
-		1*time.Millisecond,
-		DefaultScriptExecutionTimeLimit,
 		nil,
 		eds,
 		edCache)
@@ -339,10 +345,12 @@ func TestExecuteScript_ShortScriptsAreNotLogged(t *testing.T) {
 		nil,
 		vm,
 		ctx,
-		DefaultProgramsCacheSize,
+		ComputationConfig{
+			ProgramsCacheSize:        DefaultProgramsCacheSize,
+			ScriptLogThreshold:       1 * time.Second,
+			ScriptExecutionTimeLimit: DefaultScriptExecutionTimeLimit,
+		},
 		committer.NewNoopViewCommitter(),
-		1*time.Second,
-		DefaultScriptExecutionTimeLimit,
 		nil,
 		eds,
 		edCache)
@@ -420,10 +428,12 @@ func TestExecuteScriptTimeout(t *testing.T) {
 		nil,
 		fvm.NewVirtualMachine(fvm.NewInterpreterRuntime()),
 		fvm.NewContext(zerolog.Nop()),
-		DefaultProgramsCacheSize,
+		ComputationConfig{
+			ProgramsCacheSize:        DefaultProgramsCacheSize,
+			ScriptLogThreshold:       DefaultScriptLogThreshold,
+			ScriptExecutionTimeLimit: timeout,
+		},
 		committer.NewNoopViewCommitter(),
-		DefaultScriptLogThreshold,
-		timeout,
 		nil,
 		nil,
 		nil)
@@ -459,10 +469,12 @@ func TestExecuteScriptCancelled(t *testing.T) {
 		nil,
 		fvm.NewVirtualMachine(fvm.NewInterpreterRuntime()),
 		fvm.NewContext(zerolog.Nop()),
-		DefaultProgramsCacheSize,
+		ComputationConfig{
+			ProgramsCacheSize:        DefaultProgramsCacheSize,
+			ScriptLogThreshold:       DefaultScriptLogThreshold,
+			ScriptExecutionTimeLimit: timeout,
+		},
 		committer.NewNoopViewCommitter(),
-		DefaultScriptLogThreshold,
-		timeout,
 		nil,
 		nil,
 		nil)
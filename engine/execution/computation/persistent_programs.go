@@ -0,0 +1,349 @@
+package computation
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// PersistentProgramsCache persists the locations of the contract programs loaded by the execution of blocks, so
+// that the programs can be loaded again in the background when the node restarts, instead of while executing the
+// first blocks after the restart.
+//
+// Cadence programs can not be serialized, so the cache stores the contract locations, together with the hash of
+// the contract code, and the programs are parsed and checked again when they are loaded. The cached program of
+// a contract is removed when the contract is updated, or when the contract code differs from the cached hash.
+// The cache is bounded in size, the programs used least recently are evicted first.
+//
+// The hit rate of the warm-up is reported as metrics: the first use of a contract program after the node started is
+// a hit if the program was loaded by the warm-up, and a miss otherwise.
+type PersistentProgramsCache struct {
+	log     zerolog.Logger
+	db      *badger.DB
+	metrics module.ExecutionProgramsCacheMetrics
+	size    uint
+
+	mu       sync.Mutex
+	programs map[programs.ContractUpdateKey]*badgermodel.CachedProgram
+	// warm are the programs loaded by the warm-up, which were not used yet
+	warm map[programs.ContractUpdateKey]struct{}
+	// used are the programs used since the node started
+	used map[programs.ContractUpdateKey]struct{}
+}
+
+// NewPersistentProgramsCache creates a persistent programs cache holding up to size programs, and loads the
+// cached program locations from the database.
+func NewPersistentProgramsCache(
+	log zerolog.Logger,
+	db *badger.DB,
+	metrics module.ExecutionProgramsCacheMetrics,
+	size uint,
+) (*PersistentProgramsCache, error) {
+	var stored []*badgermodel.CachedProgram
+	err := db.View(operation.LookupCachedPrograms(&stored))
+	if err != nil {
+		return nil, fmt.Errorf("could not load cached programs: %w", err)
+	}
+
+	c := &PersistentProgramsCache{
+		log:      log.With().Str("component", "persistent_programs_cache").Logger(),
+		db:       db,
+		metrics:  metrics,
+		size:     size,
+		programs: make(map[programs.ContractUpdateKey]*badgermodel.CachedProgram, len(stored)),
+		warm:     make(map[programs.ContractUpdateKey]struct{}),
+		used:     make(map[programs.ContractUpdateKey]struct{}),
+	}
+	for _, program := range stored {
+		c.programs[cacheKey(program)] = program
+	}
+
+	return c, nil
+}
+
+// OnContractUpdated removes the cached program of an updated or removed contract.
+func (c *PersistentProgramsCache) OnContractUpdated(update programs.ContractUpdate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	program, ok := c.programs[update.ContractUpdateKey]
+	if !ok {
+		return
+	}
+
+	err := c.remove(program)
+	if err != nil {
+		c.log.Error().Err(err).
+			Str("address", update.Address.String()).
+			Str("name", update.Name).
+			Msg("could not remove cached program of updated contract")
+	}
+}
+
+// Record caches the locations of the contract programs used by the execution of the block at the given height,
+// whether they were loaded by the block or taken from the programs of its ancestors.
+func (c *PersistentProgramsCache) Record(height uint64, locations []common.AddressLocation) {
+	if len(locations) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, location := range locations {
+		key := programs.ContractUpdateKey{
+			Address: flow.Address(location.Address),
+			Name:    location.Name,
+		}
+
+		c.reportUse(key)
+
+		program, ok := c.programs[key]
+		if ok && program.Height >= height {
+			continue
+		}
+
+		var err error
+		if ok {
+			updated := *program
+			updated.Height = height
+			err = c.db.Update(operation.UpdateCachedProgram(&updated))
+			program = &updated
+		} else {
+			program = &badgermodel.CachedProgram{
+				Address: key.Address,
+				Name:    key.Name,
+				Height:  height,
+			}
+			err = c.db.Update(operation.InsertCachedProgram(program))
+		}
+		if err != nil {
+			c.log.Error().Err(err).Str("location", location.String()).Msg("could not cache program")
+			continue
+		}
+		c.programs[key] = program
+	}
+
+	c.evict()
+}
+
+// WarmUp loads the cached programs at the given block, and returns them. Cached programs which can not be
+// loaded are removed from the cache.
+//
+// The programs are loaded by transactions importing the contracts, so that the programs hold the same state
+// as if they were loaded by the execution of a block.
+func (c *PersistentProgramsCache) WarmUp(
+	vm VirtualMachine,
+	vmCtx fvm.Context,
+	header *flow.Header,
+	view state.View,
+) *programs.Programs {
+	start := time.Now()
+
+	ctx := fvm.NewContextFromParent(
+		vmCtx,
+		fvm.WithBlockHeader(header),
+		fvm.WithTransactionFeesEnabled(false),
+		fvm.WithAccountStorageLimit(false),
+		fvm.WithTransactionProcessors(fvm.NewTransactionInvoker(zerolog.Nop())),
+	)
+	accounts := state.NewAccounts(state.NewStateHolder(state.NewState(view.NewChild())))
+	warm := programs.NewEmptyPrograms()
+
+	loaded := 0
+	for _, program := range c.mostRecent() {
+		log := c.log.With().Str("address", program.Address.String()).Str("name", program.Name).Logger()
+
+		code, err := accounts.GetContract(program.Name, program.Address)
+		if err != nil {
+			log.Error().Err(err).Msg("could not get contract code, aborting programs cache warm-up")
+			break
+		}
+
+		codeHash := flow.HashToID(hash.NewSHA3_256().ComputeHash(code))
+		if len(code) == 0 || (program.CodeHash != flow.ZeroID && program.CodeHash != codeHash) {
+			log.Debug().Msg("contract was removed or updated, removing cached program")
+			c.invalidate(program)
+			continue
+		}
+
+		tx := flow.NewTransactionBody().SetScript([]byte(fmt.Sprintf(
+			"import %s from %s\n\ntransaction {}",
+			program.Name,
+			program.Address.HexWithPrefix(),
+		)))
+		proc := fvm.Transaction(tx, 0)
+		err = vm.Run(ctx, proc, view.NewChild(), warm)
+		if err == nil {
+			err = proc.Err
+		}
+		if err != nil {
+			log.Warn().Err(err).Msg("could not load cached program, removing it")
+			c.invalidate(program)
+			continue
+		}
+
+		c.validate(program, codeHash)
+		c.warmedUp(program)
+		loaded++
+	}
+
+	// drop the programs of the warm-up transactions
+	warm.Cleanup(nil)
+
+	duration := time.Since(start)
+	c.metrics.ExecutionProgramsCacheWarmedUp(loaded, duration)
+	c.log.Info().
+		Hex("block_id", logging.ID(header.ID())).
+		Int("programs", loaded).
+		Dur("duration", duration).
+		Msg("programs cache warmed up")
+
+	return warm
+}
+
+// mostRecent returns the cached programs, most recently loaded first.
+func (c *PersistentProgramsCache) mostRecent() []badgermodel.CachedProgram {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached := make([]badgermodel.CachedProgram, 0, len(c.programs))
+	for _, program := range c.programs {
+		cached = append(cached, *program)
+	}
+	sort.Slice(cached, func(i, j int) bool {
+		return cached[i].Height > cached[j].Height
+	})
+	return cached
+}
+
+// reportUse reports the first use of a program since the node started as a hit if the program was loaded
+// by the warm-up, and as a miss otherwise. The caller must hold the lock.
+func (c *PersistentProgramsCache) reportUse(key programs.ContractUpdateKey) {
+	if _, ok := c.used[key]; ok {
+		return
+	}
+	c.used[key] = struct{}{}
+
+	if _, ok := c.warm[key]; ok {
+		delete(c.warm, key)
+		c.metrics.ExecutionProgramsCacheHit()
+		return
+	}
+	c.metrics.ExecutionProgramsCacheMiss()
+}
+
+// warmedUp marks a program as loaded by the warm-up, unless it was used already.
+func (c *PersistentProgramsCache) warmedUp(program badgermodel.CachedProgram) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := cacheKey(&program)
+	if _, ok := c.used[key]; !ok {
+		c.warm[key] = struct{}{}
+	}
+}
+
+// invalidate removes a cached program which could not be loaded, unless it was loaded again meanwhile.
+func (c *PersistentProgramsCache) invalidate(program badgermodel.CachedProgram) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current, ok := c.programs[cacheKey(&program)]
+	if !ok || current.Height != program.Height {
+		return
+	}
+
+	err := c.remove(current)
+	if err != nil {
+		c.log.Error().Err(err).
+			Str("address", program.Address.String()).
+			Str("name", program.Name).
+			Msg("could not remove invalid cached program")
+	}
+}
+
+// validate stores the hash of the code a cached program was loaded from.
+func (c *PersistentProgramsCache) validate(program badgermodel.CachedProgram, codeHash flow.Identifier) {
+	if program.CodeHash == codeHash {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current, ok := c.programs[cacheKey(&program)]
+	if !ok || current.Height != program.Height {
+		return
+	}
+
+	updated := *current
+	updated.CodeHash = codeHash
+	err := c.db.Update(operation.UpdateCachedProgram(&updated))
+	if err != nil {
+		c.log.Error().Err(err).
+			Str("address", program.Address.String()).
+			Str("name", program.Name).
+			Msg("could not store code hash of cached program")
+		return
+	}
+	c.programs[cacheKey(&program)] = &updated
+}
+
+// evict removes the least recently loaded programs exceeding the size of the cache,
+// the caller must hold the lock.
+func (c *PersistentProgramsCache) evict() {
+	if uint(len(c.programs)) <= c.size {
+		return
+	}
+
+	cached := make([]*badgermodel.CachedProgram, 0, len(c.programs))
+	for _, program := range c.programs {
+		cached = append(cached, program)
+	}
+	sort.Slice(cached, func(i, j int) bool {
+		return cached[i].Height < cached[j].Height
+	})
+
+	for _, program := range cached[:uint(len(cached))-c.size] {
+		err := c.remove(program)
+		if err != nil {
+			c.log.Error().Err(err).
+				Str("address", program.Address.String()).
+				Str("name", program.Name).
+				Msg("could not evict cached program")
+		}
+	}
+}
+
+// remove removes a cached program, the caller must hold the lock.
+func (c *PersistentProgramsCache) remove(program *badgermodel.CachedProgram) error {
+	err := c.db.Update(operation.RemoveCachedProgram(program.Address, program.Name))
+	if err != nil {
+		return err
+	}
+	delete(c.programs, cacheKey(program))
+	return nil
+}
+
+func cacheKey(program *badgermodel.CachedProgram) programs.ContractUpdateKey {
+	return programs.ContractUpdateKey{
+		Address: program.Address,
+		Name:    program.Name,
+	}
+}
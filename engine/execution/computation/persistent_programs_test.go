@@ -0,0 +1,179 @@
+package computation
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/engine/execution/testutil"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	module "github.com/onflow/flow-go/module/mock"
+	state_synchronization "github.com/onflow/flow-go/module/state_synchronization/mock"
+	"github.com/onflow/flow-go/module/trace"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPersistentProgramsCache_WarmUp(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		rt := fvm.NewInterpreterRuntime()
+		chain := flow.Mainnet.Chain()
+		vm := fvm.NewVirtualMachine(rt)
+		execCtx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(chain))
+
+		privateKeys, err := testutil.GenerateAccountPrivateKeys(1)
+		require.NoError(t, err)
+		ledger := testutil.RootBootstrappedLedger(vm, execCtx)
+		accounts, err := testutil.CreateAccounts(vm, ledger, programs.NewEmptyPrograms(), privateKeys, chain)
+		require.NoError(t, err)
+		account := accounts[0]
+		privKey := privateKeys[0]
+		contract := common.AddressLocation{Address: common.Address(account), Name: "EventContract"}
+
+		newManager := func(cache *PersistentProgramsCache) *Manager {
+			ctx := execCtx
+			if cache != nil {
+				ctx = fvm.NewContextFromParent(execCtx, fvm.WithContractUpdateObserver(cache.OnContractUpdated))
+			}
+			blockComputer, err := computer.NewBlockComputer(vm, ctx, metrics.NewNoopCollector(), trace.NewNoopTracer(), zerolog.Nop(), committer.NewNoopViewCommitter())
+			require.NoError(t, err)
+			programsCache, err := NewProgramsCache(10)
+			require.NoError(t, err)
+
+			me := new(module.Local)
+			me.On("NodeID").Return(flow.ZeroID)
+			eds := new(state_synchronization.ExecutionDataService)
+			eds.On("Add", mock.Anything, mock.Anything).Return(flow.ZeroID, nil, nil)
+			edCache := new(state_synchronization.ExecutionDataCIDCache)
+			edCache.On("Insert", mock.AnythingOfType("*flow.Header"), mock.AnythingOfType("BlobTree"))
+
+			return &Manager{
+				vm:                 vm,
+				vmCtx:              ctx,
+				blockComputer:      blockComputer,
+				me:                 me,
+				programsCache:      programsCache,
+				persistentPrograms: cache,
+				eds:                eds,
+				edCache:            edCache,
+			}
+		}
+
+		cache, err := NewPersistentProgramsCache(zerolog.Nop(), db, metrics.NewNoopCollector(), 100)
+		require.NoError(t, err)
+		engine := newManager(cache)
+
+		view := delta.NewView(ledger.Get)
+		parent := &flow.Block{Header: &flow.Header{}, Payload: &flow.Payload{}}
+
+		// block1 deploys the contract and calls it, which loads its program
+		tx1 := testutil.DeployEventContractTransaction(account, chain, 1)
+		prepareTx(t, tx1, account, privKey, 0, chain)
+		tx2 := testutil.CreateEmitEventTransaction(account, account)
+		prepareTx(t, tx2, account, privKey, 1, chain)
+		block1View := view.NewChild()
+		block1, _ := createTestBlockAndRun(t, engine, parent, flow.Collection{Transactions: []*flow.TransactionBody{tx1, tx2}}, block1View)
+
+		var stored []*badgermodel.CachedProgram
+		require.NoError(t, db.View(operation.LookupCachedPrograms(&stored)))
+		require.Contains(t, stored, &badgermodel.CachedProgram{Address: account, Name: "EventContract"})
+
+		// a program of a contract which does not exist is removed when warming up
+		cache.Record(0, []common.AddressLocation{{Address: common.Address(account), Name: "Missing"}})
+
+		// restart with the persisted programs
+		restartMetrics := new(module.ExecutionProgramsCacheMetrics)
+		restartMetrics.On("ExecutionProgramsCacheHit")
+		restartMetrics.On("ExecutionProgramsCacheMiss")
+		restartMetrics.On("ExecutionProgramsCacheWarmedUp", mock.Anything, mock.Anything)
+		restartedCache, err := NewPersistentProgramsCache(zerolog.Nop(), db, restartMetrics, 100)
+		require.NoError(t, err)
+		restarted := newManager(restartedCache)
+		restarted.WarmUpPrograms(block1.Header, block1View.NewChild())
+
+		// block2 calls the contract again, the restarted node must compute the same results as a node
+		// without persisted programs
+		tx3 := testutil.CreateEmitEventTransaction(account, account)
+		prepareTx(t, tx3, account, privKey, 2, chain)
+		col2 := flow.Collection{Transactions: []*flow.TransactionBody{tx3}}
+		_, warmResult := createTestBlockAndRun(t, restarted, block1, col2, block1View.NewChild())
+
+		warm := restarted.programsCache.Get(block1.ID())
+		require.NotNil(t, warm)
+		_, _, has := warm.Get(contract)
+		assert.True(t, has)
+
+		// the contract used by block2 was loaded by the warm-up, and taken from the programs of its parent
+		// instead of being loaded by block2. The epoch contract used by the system chunk is not deployed in
+		// the test ledger, so it can not be loaded by the warm-up.
+		restartMetrics.AssertNumberOfCalls(t, "ExecutionProgramsCacheHit", 1)
+		restartMetrics.AssertNumberOfCalls(t, "ExecutionProgramsCacheMiss", 1)
+		restartMetrics.AssertCalled(t, "ExecutionProgramsCacheWarmedUp", mock.Anything, mock.Anything)
+
+		_, coldResult := createTestBlockAndRun(t, newManager(nil), block1, col2, block1View.NewChild())
+		hasValidEventValue(t, warmResult.Events[0][0], 1)
+		require.Equal(t, coldResult.Events, warmResult.Events)
+		require.Equal(t, coldResult.TransactionResults, warmResult.TransactionResults)
+		require.Equal(t, coldResult.StateSnapshots, warmResult.StateSnapshots)
+
+		// the program of the missing contract was removed, and the program of the contract was validated
+		// by the hash of its code
+		stored = nil
+		require.NoError(t, db.View(operation.LookupCachedPrograms(&stored)))
+		for _, program := range stored {
+			assert.NotEqual(t, "Missing", program.Name)
+			if program.Name == contract.Name {
+				assert.NotEqual(t, flow.ZeroID, program.CodeHash)
+			}
+		}
+
+		// block3, a sibling of block2, updates the contract, which removes its program
+		tx4 := testutil.UpdateEventContractTransaction(account, chain, 2)
+		prepareTx(t, tx4, account, privKey, 2, chain)
+		col3 := flow.Collection{Transactions: []*flow.TransactionBody{tx4}}
+		_, res := createTestBlockAndRun(t, restarted, block1, col3, block1View.NewChild())
+		assert.EqualValues(t, "flow.AccountContractUpdated", res.Events[0][0].Type)
+
+		stored = nil
+		require.NoError(t, db.View(operation.LookupCachedPrograms(&stored)))
+		for _, program := range stored {
+			assert.NotEqual(t, contract.Name, program.Name)
+		}
+	})
+}
+
+func TestPersistentProgramsCache_Eviction(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		cache, err := NewPersistentProgramsCache(zerolog.Nop(), db, metrics.NewNoopCollector(), 2)
+		require.NoError(t, err)
+
+		address := common.Address(flow.HexToAddress("01"))
+		a := common.AddressLocation{Address: address, Name: "A"}
+		b := common.AddressLocation{Address: address, Name: "B"}
+		c := common.AddressLocation{Address: address, Name: "C"}
+
+		cache.Record(1, []common.AddressLocation{a, b})
+		cache.Record(2, []common.AddressLocation{a})
+		// the least recently used program is evicted
+		cache.Record(3, []common.AddressLocation{c})
+
+		var stored []*badgermodel.CachedProgram
+		require.NoError(t, db.View(operation.LookupCachedPrograms(&stored)))
+		require.ElementsMatch(t, []*badgermodel.CachedProgram{
+			{Address: flow.Address(address), Name: "A", Height: 2},
+			{Address: flow.Address(address), Name: "C", Height: 3},
+		}, stored)
+	})
+}
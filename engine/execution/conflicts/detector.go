@@ -0,0 +1,373 @@
+// Package conflicts detects execution results of other execution nodes which conflict with the results
+// computed by this node, i.e. forks of the execution state which this node is not part of.
+package conflicts
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// Detector compares the execution results computed by this node with the results of other execution nodes
+// incorporated in finalized blocks, and with sealed results. Conflicts are logged as "execution fork detected"
+// events, persisted in the database and reported as metrics. If halting is enabled, the detector reports
+// execution as halted once a sealed result conflicts with the own result, including conflicts detected
+// before a restart, until execution is resumed by an operator.
+type Detector struct {
+	notifications.NoopConsumer // satisfy the FinalizationConsumer interface
+
+	unit           *engine.Unit
+	log            zerolog.Logger
+	db             *badger.DB
+	me             module.Local
+	headers        storage.Headers
+	payloads       storage.Payloads
+	receipts       storage.ExecutionReceipts
+	results        storage.ExecutionResults
+	metrics        module.ExecutionConflictMetrics
+	haltOnConflict bool
+
+	mu              sync.Mutex
+	conflicts       map[flow.Identifier]*badgermodel.ExecutionResultConflict
+	halted          *atomic.Bool
+	resumeConsumers []func()
+}
+
+// New creates a detector and loads the conflicts detected so far from the database.
+func New(
+	log zerolog.Logger,
+	db *badger.DB,
+	me module.Local,
+	headers storage.Headers,
+	payloads storage.Payloads,
+	receipts storage.ExecutionReceipts,
+	results storage.ExecutionResults,
+	metrics module.ExecutionConflictMetrics,
+	haltOnConflict bool,
+) (*Detector, error) {
+	var stored []*badgermodel.ExecutionResultConflict
+	err := db.View(operation.LookupExecutionResultConflicts(&stored))
+	if err != nil {
+		return nil, fmt.Errorf("could not load execution result conflicts: %w", err)
+	}
+
+	halting := 0
+	for _, conflict := range stored {
+		if halts(conflict) {
+			halting++
+		}
+	}
+
+	d := &Detector{
+		unit:           engine.NewUnit(),
+		log:            log.With().Str("component", "execution_conflict_detector").Logger(),
+		db:             db,
+		me:             me,
+		headers:        headers,
+		payloads:       payloads,
+		receipts:       receipts,
+		results:        results,
+		metrics:        metrics,
+		haltOnConflict: haltOnConflict,
+		conflicts:      make(map[flow.Identifier]*badgermodel.ExecutionResultConflict, len(stored)),
+		halted:         atomic.NewBool(haltOnConflict && halting > 0),
+	}
+
+	for _, conflict := range stored {
+		d.conflicts[conflict.BlockID] = conflict
+	}
+
+	d.reportMetrics()
+	if d.halted.Load() {
+		d.log.Warn().Int("conflicting_blocks", halting).
+			Msg("execution is halted because of sealed execution result conflicts detected before restart")
+	}
+
+	return d, nil
+}
+
+func (d *Detector) Ready() <-chan struct{} {
+	return d.unit.Ready()
+}
+
+func (d *Detector) Done() <-chan struct{} {
+	return d.unit.Done()
+}
+
+// ExecutionHalted returns whether block execution should be halted because of conflicting results.
+func (d *Detector) ExecutionHalted() bool {
+	return d.halted.Load()
+}
+
+// AddResumeConsumer adds a function which is called once execution is resumed after it was halted.
+func (d *Detector) AddResumeConsumer(consumer func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.resumeConsumers = append(d.resumeConsumers, consumer)
+}
+
+// Resume resumes execution after it was halted. The sealed conflicts known so far are marked as resumed,
+// so they don't halt execution again after a restart, while conflicts detected later still halt it.
+// It returns the number of conflicts marked as resumed.
+func (d *Detector) Resume() (int, error) {
+	d.mu.Lock()
+
+	resumed := 0
+	for blockID, conflict := range d.conflicts {
+		if !halts(conflict) {
+			continue
+		}
+
+		updated := *conflict
+		updated.Resumed = true
+		err := d.db.Update(operation.UpdateExecutionResultConflict(&updated))
+		if err != nil {
+			d.mu.Unlock()
+			return resumed, fmt.Errorf("could not store resumed conflict of block %v: %w", blockID, err)
+		}
+		d.conflicts[blockID] = &updated
+		resumed++
+	}
+
+	wasHalted := d.halted.Swap(false)
+	d.reportMetrics()
+	consumers := d.resumeConsumers
+
+	d.mu.Unlock()
+
+	if !wasHalted {
+		return resumed, nil
+	}
+
+	d.log.Warn().Int("resumed_conflicts", resumed).Msg("execution resumed after it was halted because of conflicting execution results")
+	for _, consumer := range consumers {
+		consumer()
+	}
+
+	return resumed, nil
+}
+
+// Conflicts returns the blocks with conflicting results, ordered by height.
+func (d *Detector) Conflicts() []*badgermodel.ExecutionResultConflict {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	conflicts := make([]*badgermodel.ExecutionResultConflict, 0, len(d.conflicts))
+	for _, conflict := range d.conflicts {
+		c := *conflict
+		c.ConflictingResultIDs = append([]flow.Identifier(nil), conflict.ConflictingResultIDs...)
+		conflicts = append(conflicts, &c)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Height < conflicts[j].Height
+	})
+
+	return conflicts
+}
+
+// OnResultComputed compares the given result computed by this node with the results of other execution
+// nodes for the same block which are known already.
+func (d *Detector) OnResultComputed(result *flow.ExecutionResult) {
+	blockID := result.BlockID
+	resultID := result.ID()
+
+	receipts, err := d.receipts.ByBlockID(blockID)
+	if err != nil {
+		d.log.Error().Err(err).Hex("block_id", blockID[:]).Msg("could not get execution receipts to detect conflicts")
+		return
+	}
+
+	var conflicting []flow.Identifier
+	for _, receipt := range receipts {
+		if receipt.ExecutorID == d.me.NodeID() {
+			continue
+		}
+		otherID := receipt.ExecutionResult.ID()
+		if otherID != resultID {
+			conflicting = append(conflicting, otherID)
+		}
+	}
+
+	if len(conflicting) == 0 {
+		return
+	}
+
+	err = d.record(blockID, resultID, conflicting, flow.ZeroID)
+	if err != nil {
+		d.log.Error().Err(err).Hex("block_id", blockID[:]).Msg("could not record execution result conflict")
+	}
+}
+
+// OnFinalizedBlock compares the results of other execution nodes incorporated in the finalized block,
+// and the results it seals, with the results computed by this node.
+func (d *Detector) OnFinalizedBlock(block *model.Block) {
+	err := d.checkFinalized(block.BlockID)
+	if err != nil {
+		d.log.Error().Err(err).Hex("finalized_block_id", block.BlockID[:]).
+			Msg("could not check finalized block for execution result conflicts")
+	}
+}
+
+func (d *Detector) checkFinalized(finalizedID flow.Identifier) error {
+	payload, err := d.payloads.ByBlockID(finalizedID)
+	if err != nil {
+		return fmt.Errorf("could not get payload: %w", err)
+	}
+
+	for _, meta := range payload.Receipts {
+		if meta.ExecutorID == d.me.NodeID() {
+			continue
+		}
+
+		receipt, err := d.receipts.ByID(meta.ID())
+		if err != nil {
+			return fmt.Errorf("could not get receipt %v: %w", meta.ID(), err)
+		}
+
+		err = d.check(receipt.ExecutionResult.BlockID, meta.ResultID, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, seal := range payload.Seals {
+		err = d.check(seal.BlockID, seal.ResultID, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// check compares the given result of a block with the result computed by this node. Blocks which were
+// not executed yet are checked once they are executed.
+func (d *Detector) check(blockID flow.Identifier, otherID flow.Identifier, sealed bool) error {
+	own, err := d.results.ByBlockID(blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not get own result for block %v: %w", blockID, err)
+	}
+
+	ownID := own.ID()
+	if ownID == otherID {
+		return nil
+	}
+
+	sealedID := flow.ZeroID
+	if sealed {
+		sealedID = otherID
+	}
+
+	return d.record(blockID, ownID, []flow.Identifier{otherID}, sealedID)
+}
+
+// record persists the given conflicting results of a block, and logs them if they were not known yet.
+func (d *Detector) record(blockID flow.Identifier, ownID flow.Identifier, conflicting []flow.Identifier, sealedID flow.Identifier) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	conflict, known := d.conflicts[blockID]
+	if !known {
+		header, err := d.headers.ByBlockID(blockID)
+		if err != nil {
+			return fmt.Errorf("could not get header: %w", err)
+		}
+		conflict = &badgermodel.ExecutionResultConflict{
+			BlockID:     blockID,
+			Height:      header.Height,
+			OwnResultID: ownID,
+		}
+	}
+
+	updated := *conflict
+	updated.ConflictingResultIDs = append([]flow.Identifier(nil), conflict.ConflictingResultIDs...)
+	added := 0
+	for _, id := range conflicting {
+		if !containsID(updated.ConflictingResultIDs, id) {
+			updated.ConflictingResultIDs = append(updated.ConflictingResultIDs, id)
+			added++
+		}
+	}
+	sealedNow := sealedID != flow.ZeroID && !updated.Sealed()
+	if sealedNow {
+		updated.SealedResultID = sealedID
+	}
+
+	if known && added == 0 && !sealedNow {
+		return nil
+	}
+
+	store := operation.InsertExecutionResultConflict
+	if known {
+		store = operation.UpdateExecutionResultConflict
+	}
+	err := d.db.Update(store(&updated))
+	if err != nil {
+		return fmt.Errorf("could not store conflict: %w", err)
+	}
+	d.conflicts[blockID] = &updated
+
+	// This log is meant to be used as the data source for alerts.
+	d.log.Error().
+		Bool("execution_fork_detected", true).
+		Hex("block_id", blockID[:]).
+		Uint64("height", updated.Height).
+		Hex("own_result_id", ownID[:]).
+		Strs("conflicting_result_ids", flow.IdentifierList(updated.ConflictingResultIDs).Strings()).
+		Bool("sealed", updated.Sealed()).
+		Hex("sealed_result_id", updated.SealedResultID[:]).
+		Msg("execution fork detected")
+
+	if d.haltOnConflict && halts(&updated) && !d.halted.Load() {
+		d.halted.Store(true)
+		d.log.Warn().Hex("block_id", blockID[:]).Msg("halting execution because of a sealed conflicting execution result")
+	}
+
+	d.reportMetrics()
+
+	return nil
+}
+
+// reportMetrics reports the conflicts and halting state, the caller must hold the lock or own the detector.
+func (d *Detector) reportMetrics() {
+	sealed := 0
+	for _, conflict := range d.conflicts {
+		if conflict.Sealed() {
+			sealed++
+		}
+	}
+	d.metrics.ExecutionResultConflicts(len(d.conflicts), sealed)
+	d.metrics.ExecutionHalted(d.halted.Load())
+}
+
+// halts returns whether the given conflict halts execution. Results of other execution nodes which are not
+// sealed may just be wrong, only a sealed conflicting result shows that the own execution state forked.
+func halts(conflict *badgermodel.ExecutionResultConflict) bool {
+	return conflict.Sealed() && !conflict.Resumed
+}
+
+func containsID(ids []flow.Identifier, id flow.Identifier) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+package conflicts
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/storage"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type detectorSuite struct {
+	db       *badger.DB
+	myID     flow.Identifier
+	me       *module.Local
+	headers  *storagemock.Headers
+	payloads *storagemock.Payloads
+	receipts *storagemock.ExecutionReceipts
+	results  *storagemock.ExecutionResults
+	metrics  *module.ExecutionConflictMetrics
+
+	block     *flow.Header
+	ownResult *flow.ExecutionResult
+}
+
+func newDetectorSuite(t *testing.T, db *badger.DB) *detectorSuite {
+	s := &detectorSuite{
+		db:       db,
+		myID:     unittest.IdentifierFixture(),
+		me:       new(module.Local),
+		headers:  new(storagemock.Headers),
+		payloads: new(storagemock.Payloads),
+		receipts: new(storagemock.ExecutionReceipts),
+		results:  new(storagemock.ExecutionResults),
+		metrics:  new(module.ExecutionConflictMetrics),
+	}
+	header := unittest.BlockHeaderFixture()
+	s.block = &header
+	s.ownResult = unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(s.block.ID()))
+
+	s.me.On("NodeID").Return(s.myID)
+	s.headers.On("ByBlockID", s.block.ID()).Return(s.block, nil)
+	s.metrics.On("ExecutionResultConflicts", mock.Anything, mock.Anything)
+	s.metrics.On("ExecutionHalted", mock.Anything)
+
+	return s
+}
+
+func (s *detectorSuite) detector(t *testing.T, halt bool) *Detector {
+	d, err := New(zerolog.Nop(), s.db, s.me, s.headers, s.payloads, s.receipts, s.results, s.metrics, halt)
+	require.NoError(t, err)
+	return d
+}
+
+// receipt returns a receipt of another execution node with a result for the block of the suite.
+func (s *detectorSuite) receipt(result *flow.ExecutionResult) *flow.ExecutionReceipt {
+	return unittest.ExecutionReceiptFixture(
+		unittest.WithResult(result),
+		unittest.WithExecutorID(unittest.IdentifierFixture()),
+	)
+}
+
+func TestDetector_OnResultComputed(t *testing.T) {
+
+	t.Run("matching results are not conflicts", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			s := newDetectorSuite(t, db)
+			d := s.detector(t, true)
+
+			s.receipts.On("ByBlockID", s.block.ID()).Return(flow.ExecutionReceiptList{s.receipt(s.ownResult)}, nil)
+			d.OnResultComputed(s.ownResult)
+
+			assert.Empty(t, d.Conflicts())
+			assert.False(t, d.ExecutionHalted())
+		})
+	})
+
+	t.Run("differing results are recorded, but don't halt execution until they are sealed", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			s := newDetectorSuite(t, db)
+			d := s.detector(t, true)
+
+			other := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(s.block.ID()))
+			own := unittest.ExecutionReceiptFixture(
+				unittest.WithExecutorID(s.myID),
+				unittest.WithResult(unittest.ExecutionResultFixture()),
+			)
+			s.receipts.On("ByBlockID", s.block.ID()).Return(flow.ExecutionReceiptList{
+				s.receipt(s.ownResult),
+				s.receipt(other),
+				s.receipt(other),
+				own, // receipts of this node are ignored
+			}, nil)
+
+			d.OnResultComputed(s.ownResult)
+
+			conflicts := d.Conflicts()
+			require.Len(t, conflicts, 1)
+			assert.Equal(t, s.block.ID(), conflicts[0].BlockID)
+			assert.Equal(t, s.block.Height, conflicts[0].Height)
+			assert.Equal(t, s.ownResult.ID(), conflicts[0].OwnResultID)
+			assert.Equal(t, []flow.Identifier{other.ID()}, conflicts[0].ConflictingResultIDs)
+			assert.False(t, conflicts[0].Sealed())
+			assert.False(t, d.ExecutionHalted())
+			s.metrics.AssertCalled(t, "ExecutionResultConflicts", 1, 0)
+			s.metrics.AssertNotCalled(t, "ExecutionHalted", true)
+
+			var stored badgermodel.ExecutionResultConflict
+			err := db.View(operation.RetrieveExecutionResultConflict(s.block.ID(), &stored))
+			require.NoError(t, err)
+			assert.Equal(t, *conflicts[0], stored)
+		})
+	})
+
+	t.Run("execution is not halted if halting is disabled", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			s := newDetectorSuite(t, db)
+			d := s.detector(t, false)
+
+			other := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(s.block.ID()))
+			s.receipts.On("ByBlockID", s.block.ID()).Return(flow.ExecutionReceiptList{s.receipt(other)}, nil)
+
+			d.OnResultComputed(s.ownResult)
+
+			assert.Len(t, d.Conflicts(), 1)
+			assert.False(t, d.ExecutionHalted())
+		})
+	})
+}
+
+func TestDetector_OnFinalizedBlock(t *testing.T) {
+
+	t.Run("results of not executed blocks are ignored", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			s := newDetectorSuite(t, db)
+			d := s.detector(t, true)
+
+			other := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(s.block.ID()))
+			receipt := s.receipt(other)
+			finalized := unittest.IdentifierFixture()
+			payload := unittest.PayloadFixture(unittest.WithReceipts(receipt))
+			s.payloads.On("ByBlockID", finalized).Return(&payload, nil)
+			s.receipts.On("ByID", receipt.ID()).Return(receipt, nil)
+			s.results.On("ByBlockID", s.block.ID()).Return(nil, storage.ErrNotFound)
+
+			d.OnFinalizedBlock(&model.Block{BlockID: finalized})
+
+			assert.Empty(t, d.Conflicts())
+		})
+	})
+
+	t.Run("incorporated and sealed results are compared with own results", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			s := newDetectorSuite(t, db)
+			d := s.detector(t, true)
+			s.results.On("ByBlockID", s.block.ID()).Return(s.ownResult, nil)
+
+			// a finalized block incorporating a conflicting result
+			other := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(s.block.ID()))
+			receipt := s.receipt(other)
+			matching := s.receipt(s.ownResult)
+			first := unittest.IdentifierFixture()
+			payload := unittest.PayloadFixture(unittest.WithReceipts(receipt, matching))
+			s.payloads.On("ByBlockID", first).Return(&payload, nil)
+			s.receipts.On("ByID", receipt.ID()).Return(receipt, nil)
+			s.receipts.On("ByID", matching.ID()).Return(matching, nil)
+
+			d.OnFinalizedBlock(&model.Block{BlockID: first})
+
+			conflicts := d.Conflicts()
+			require.Len(t, conflicts, 1)
+			assert.Equal(t, []flow.Identifier{other.ID()}, conflicts[0].ConflictingResultIDs)
+			assert.False(t, conflicts[0].Sealed())
+			assert.False(t, d.ExecutionHalted())
+
+			// a finalized block sealing the conflicting result
+			second := unittest.IdentifierFixture()
+			seal := unittest.Seal.Fixture(unittest.Seal.WithBlockID(s.block.ID()), unittest.Seal.WithResult(other))
+			payload = unittest.PayloadFixture(unittest.WithSeals(seal))
+			s.payloads.On("ByBlockID", second).Return(&payload, nil)
+
+			d.OnFinalizedBlock(&model.Block{BlockID: second})
+
+			conflicts = d.Conflicts()
+			require.Len(t, conflicts, 1)
+			assert.Equal(t, []flow.Identifier{other.ID()}, conflicts[0].ConflictingResultIDs)
+			assert.True(t, conflicts[0].Sealed())
+			assert.Equal(t, other.ID(), conflicts[0].SealedResultID)
+			assert.True(t, d.ExecutionHalted())
+			s.metrics.AssertCalled(t, "ExecutionResultConflicts", 1, 1)
+			s.metrics.AssertCalled(t, "ExecutionHalted", true)
+		})
+	})
+}
+
+func TestDetector_Restart(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		s := newDetectorSuite(t, db)
+
+		conflict := &badgermodel.ExecutionResultConflict{
+			BlockID:              s.block.ID(),
+			Height:               s.block.Height,
+			OwnResultID:          s.ownResult.ID(),
+			ConflictingResultIDs: []flow.Identifier{unittest.IdentifierFixture()},
+		}
+		require.NoError(t, db.Update(operation.InsertExecutionResultConflict(conflict)))
+
+		// conflicts detected before a restart are loaded, but only sealed ones halt execution
+		d := s.detector(t, true)
+		assert.False(t, d.ExecutionHalted())
+		require.Len(t, d.Conflicts(), 1)
+		assert.Equal(t, conflict, d.Conflicts()[0])
+		s.metrics.AssertCalled(t, "ExecutionResultConflicts", 1, 0)
+
+		conflict.SealedResultID = conflict.ConflictingResultIDs[0]
+		require.NoError(t, db.Update(operation.UpdateExecutionResultConflict(conflict)))
+
+		d = s.detector(t, true)
+		assert.True(t, d.ExecutionHalted())
+
+		d = s.detector(t, false)
+		assert.False(t, d.ExecutionHalted())
+	})
+}
+
+func TestDetector_Resume(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		s := newDetectorSuite(t, db)
+
+		sealedID := unittest.IdentifierFixture()
+		conflict := &badgermodel.ExecutionResultConflict{
+			BlockID:              s.block.ID(),
+			Height:               s.block.Height,
+			OwnResultID:          s.ownResult.ID(),
+			ConflictingResultIDs: []flow.Identifier{sealedID},
+			SealedResultID:       sealedID,
+		}
+		require.NoError(t, db.Update(operation.InsertExecutionResultConflict(conflict)))
+
+		d := s.detector(t, true)
+		require.True(t, d.ExecutionHalted())
+
+		resumes := 0
+		d.AddResumeConsumer(func() { resumes++ })
+
+		resumed, err := d.Resume()
+		require.NoError(t, err)
+		assert.Equal(t, 1, resumed)
+		assert.False(t, d.ExecutionHalted())
+		assert.Equal(t, 1, resumes)
+		s.metrics.AssertCalled(t, "ExecutionHalted", false)
+
+		// resuming execution which is not halted is a no-op
+		resumed, err = d.Resume()
+		require.NoError(t, err)
+		assert.Equal(t, 0, resumed)
+		assert.Equal(t, 1, resumes)
+
+		// the resumed conflict is kept, but doesn't halt execution after a restart
+		var stored badgermodel.ExecutionResultConflict
+		require.NoError(t, db.View(operation.RetrieveExecutionResultConflict(s.block.ID(), &stored)))
+		assert.True(t, stored.Resumed)
+
+		d = s.detector(t, true)
+		assert.False(t, d.ExecutionHalted())
+		require.Len(t, d.Conflicts(), 1)
+		assert.True(t, d.Conflicts()[0].Sealed())
+	})
+}
@@ -0,0 +1,186 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	ledgerhistory "github.com/onflow/flow-go/ledger/complete/history"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+const DefaultCheckInterval = 10 * time.Second
+
+// Config is the configuration of the indexer.
+type Config struct {
+	// CheckInterval is the interval in which the indexer checks for heights to index.
+	CheckInterval time.Duration
+}
+
+// Tries provides the tries of the ledger, the index is bootstrapped with the trie of a sealed block.
+type Tries interface {
+	// AcquireTrie returns the trie with the given root hash, and a function releasing the trie, which must
+	// be called once it is not used anymore.
+	AcquireTrie(rootHash ledger.RootHash) (*trie.MTrie, func(), error)
+}
+
+// Indexer indexes the register history of the sealed and executed blocks, in which the ledger records its
+// updates (see ledgerhistory.Index).
+//
+// If the index is empty, it is bootstrapped with the trie of the highest sealed and executed block, which is
+// still held by the ledger. Blocks which were executed before the register history was enabled and whose updates
+// are not in the write-ahead log anymore can't be indexed: the index must then be built from the checkpoints and
+// the write-ahead log with the register-history util command.
+type Indexer struct {
+	unit   *engine.Unit
+	log    zerolog.Logger
+	config Config
+
+	index          *ledgerhistory.Index
+	tries          Tries
+	state          protocol.State
+	headers        storage.Headers
+	executionState state.ReadOnlyExecutionState
+}
+
+// New returns an indexer of the register history of the sealed blocks in the given index.
+func New(
+	log zerolog.Logger,
+	config Config,
+	index *ledgerhistory.Index,
+	tries Tries,
+	state protocol.State,
+	headers storage.Headers,
+	executionState state.ReadOnlyExecutionState,
+) *Indexer {
+	return &Indexer{
+		unit:           engine.NewUnit(),
+		log:            log.With().Str("engine", "register_history_indexer").Logger(),
+		config:         config,
+		index:          index,
+		tries:          tries,
+		state:          state,
+		headers:        headers,
+		executionState: executionState,
+	}
+}
+
+// Ready starts checking for heights to index in the configured interval.
+func (i *Indexer) Ready() <-chan struct{} {
+	i.unit.LaunchPeriodically(func() {
+		err := i.indexSealed()
+		if err != nil {
+			i.log.Error().Err(err).Msg("could not index register history")
+		}
+	}, i.config.CheckInterval, 0)
+	return i.unit.Ready()
+}
+
+// Done stops the indexer after the height being indexed is completed.
+func (i *Indexer) Done() <-chan struct{} {
+	return i.unit.Done()
+}
+
+// indexSealed indexes all the heights up to the highest height that is both sealed and executed. It returns
+// early if the indexer is shut down.
+func (i *Indexer) indexSealed() error {
+	target, err := i.indexTarget()
+	if err != nil {
+		return err
+	}
+
+	_, latest, err := i.index.IndexedRange()
+	if errors.Is(err, ledgerhistory.ErrNotIndexed) {
+		return i.bootstrap(target)
+	}
+	if err != nil {
+		return err
+	}
+
+	if latest >= target {
+		return nil
+	}
+
+	start := time.Now()
+	for height := latest + 1; height <= target; height++ {
+		select {
+		case <-i.unit.Quit():
+			return nil
+		default:
+		}
+
+		commit, err := i.commit(height)
+		if err != nil {
+			return err
+		}
+
+		err = i.index.IndexHeight(height, commit)
+		if err != nil {
+			return fmt.Errorf("could not index height %d: %w", height, err)
+		}
+	}
+
+	i.log.Info().
+		Uint64("from_height", latest+1).
+		Uint64("to_height", target).
+		Dur("duration", time.Since(start)).
+		Msg("register history indexed")
+
+	return nil
+}
+
+// bootstrap bootstraps the index with the trie of the given height.
+func (i *Indexer) bootstrap(height uint64) error {
+	commit, err := i.commit(height)
+	if err != nil {
+		return err
+	}
+
+	t, release, err := i.tries.AcquireTrie(ledger.RootHash(commit))
+	if err != nil {
+		return fmt.Errorf("could not get trie of height %d to bootstrap the register history: %w", height, err)
+	}
+	defer release()
+
+	return i.index.Bootstrap(height, commit, t)
+}
+
+// indexTarget returns the highest height that is both sealed and executed.
+func (i *Indexer) indexTarget() (uint64, error) {
+	sealed, err := i.state.Sealed().Head()
+	if err != nil {
+		return 0, fmt.Errorf("could not get sealed block: %w", err)
+	}
+
+	executed, _, err := i.executionState.GetHighestExecutedBlockID(i.unit.Ctx())
+	if err != nil {
+		return 0, fmt.Errorf("could not get highest executed block: %w", err)
+	}
+
+	if executed < sealed.Height {
+		return executed, nil
+	}
+	return sealed.Height, nil
+}
+
+// commit returns the state commitment of the finalized block at the given height.
+func (i *Indexer) commit(height uint64) (ledger.State, error) {
+	header, err := i.headers.ByHeight(height)
+	if err != nil {
+		return ledger.State{}, fmt.Errorf("could not get finalized block at height %d: %w", height, err)
+	}
+
+	commit, err := i.executionState.StateCommitmentByBlockID(i.unit.Ctx(), header.ID())
+	if err != nil {
+		return ledger.State{}, fmt.Errorf("could not get state commitment of block %v at height %d: %w", header.ID(), height, err)
+	}
+
+	return ledger.State(commit), nil
+}
@@ -0,0 +1,174 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete"
+	ledgerhistory "github.com/onflow/flow-go/ledger/complete/history"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// indexerSuite executes a chain of finalized blocks on a ledger recording its updates in a register history index.
+type indexerSuite struct {
+	t      *testing.T
+	led    *complete.Ledger
+	index  *ledgerhistory.Index
+	blocks []*flow.Header
+	states map[flow.Identifier]ledger.State
+	keys   []ledger.Key
+
+	sealed   *flow.Header
+	executed uint64
+}
+
+func newIndexerSuite(t *testing.T, index *ledgerhistory.Index) *indexerSuite {
+	led, err := complete.NewLedgerWithHistory(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion, index)
+	require.NoError(t, err)
+
+	root := unittest.BlockHeaderFixture()
+	root.Height = 0
+
+	s := &indexerSuite{
+		t:      t,
+		led:    led,
+		index:  index,
+		blocks: []*flow.Header{&root},
+		states: map[flow.Identifier]ledger.State{root.ID(): led.InitialState()},
+	}
+	s.sealed = &root
+	return s
+}
+
+// execute executes the given number of blocks, and seals them.
+func (s *indexerSuite) execute(count int) {
+	for i := 0; i < count; i++ {
+		parent := s.blocks[len(s.blocks)-1]
+		header := unittest.BlockHeaderWithParentFixture(parent)
+
+		keys := utils.RandomUniqueKeys(5, 2, 1, 10)
+		update, err := ledger.NewUpdate(s.states[parent.ID()], keys, utils.RandomValues(len(keys), 1, 10))
+		require.NoError(s.t, err)
+		state, _, err := s.led.Set(update)
+		require.NoError(s.t, err)
+
+		s.blocks = append(s.blocks, &header)
+		s.states[header.ID()] = state
+		s.keys = append(s.keys, keys...)
+	}
+
+	s.sealed = s.blocks[len(s.blocks)-1]
+	s.executed = s.sealed.Height
+}
+
+func (s *indexerSuite) newIndexer() *Indexer {
+	sealed := new(protocol.Snapshot)
+	sealed.On("Head").Return(
+		func() *flow.Header { return s.sealed },
+		nil,
+	)
+	state := new(protocol.State)
+	state.On("Sealed").Return(sealed)
+
+	headers := new(storagemock.Headers)
+	headers.On("ByHeight", mock.Anything).Return(
+		func(height uint64) *flow.Header { return s.blocks[height] },
+		nil,
+	)
+
+	executionState := new(statemock.ReadOnlyExecutionState)
+	executionState.On("GetHighestExecutedBlockID", mock.Anything).Return(
+		func(context.Context) uint64 { return s.executed },
+		flow.ZeroID,
+		nil,
+	)
+	executionState.On("StateCommitmentByBlockID", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, blockID flow.Identifier) flow.StateCommitment {
+			return flow.StateCommitment(s.states[blockID])
+		},
+		nil,
+	)
+
+	return New(zerolog.Nop(), Config{CheckInterval: DefaultCheckInterval}, s.index, s.led, state, headers, executionState)
+}
+
+// requireIndexed checks that the registers read from the index at each height from the given height up to the
+// sealed height are the registers of the state of the height.
+func (s *indexerSuite) requireIndexed(from uint64) {
+	first, latest, err := s.index.IndexedRange()
+	require.NoError(s.t, err)
+	require.Equal(s.t, from, first)
+	require.Equal(s.t, s.sealed.Height, latest)
+
+	for _, header := range s.blocks[from:] {
+		query, err := ledger.NewQuery(s.states[header.ID()], s.keys)
+		require.NoError(s.t, err)
+		expected, err := s.led.Get(query)
+		require.NoError(s.t, err)
+
+		actual, err := s.led.GetAtHeight(header.Height, s.keys)
+		require.NoError(s.t, err)
+		require.Equal(s.t, len(expected), len(actual))
+		for i := range expected {
+			require.True(s.t, bytes.Equal(expected[i], actual[i]), "register %d at height %d", i, header.Height)
+		}
+	}
+}
+
+func TestIndexer(t *testing.T) {
+
+	t.Run("empty index is bootstrapped at the sealed height", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			index, err := ledgerhistory.New(dir, zerolog.Nop())
+			require.NoError(t, err)
+			defer index.Close()
+
+			s := newIndexerSuite(t, index)
+			s.execute(5)
+			indexer := s.newIndexer()
+
+			require.NoError(t, indexer.indexSealed())
+			s.requireIndexed(5)
+
+			s.execute(5)
+			require.NoError(t, indexer.indexSealed())
+			s.requireIndexed(5)
+		})
+	})
+
+	t.Run("heights are indexed up to the highest sealed and executed height", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			index, err := ledgerhistory.New(dir, zerolog.Nop())
+			require.NoError(t, err)
+			defer index.Close()
+
+			s := newIndexerSuite(t, index)
+			indexer := s.newIndexer()
+			require.NoError(t, indexer.indexSealed())
+
+			s.execute(5)
+			s.executed = 3
+			require.NoError(t, indexer.indexSealed())
+			_, latest, err := index.IndexedRange()
+			require.NoError(t, err)
+			require.Equal(t, uint64(3), latest)
+
+			s.executed = s.sealed.Height
+			require.NoError(t, indexer.indexSealed())
+			s.requireIndexed(0)
+		})
+	})
+}
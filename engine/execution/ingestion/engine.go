@@ -35,6 +35,15 @@ import (
 	"github.com/onflow/flow-go/utils/logging"
 )
 
+// ResultConflictDetector detects results of other execution nodes which conflict with the results computed by
+// the engine, and decides whether block execution is halted because of them.
+type ResultConflictDetector interface {
+	// OnResultComputed is called with every execution result computed by the engine.
+	OnResultComputed(result *flow.ExecutionResult)
+	// ExecutionHalted returns whether the execution of blocks is halted.
+	ExecutionHalted() bool
+}
+
 // An Engine receives and saves incoming blocks.
 type Engine struct {
 	psEvents.Noop // satisfy protocol events consumer interface
@@ -65,6 +74,7 @@ type Engine struct {
 	syncFast               bool                // sync fast allows execution node to skip fetching collection during state syncing, and rely on state syncing to catch up
 	checkAuthorizedAtBlock func(blockID flow.Identifier) (bool, error)
 	pauseExecution         bool
+	conflictDetector       ResultConflictDetector // optional, nil if conflicts are not detected
 }
 
 func New(
@@ -90,6 +100,7 @@ func New(
 	syncFast bool,
 	checkAuthorizedAtBlock func(blockID flow.Identifier) (bool, error),
 	pauseExecution bool,
+	conflictDetector ResultConflictDetector,
 ) (*Engine, error) {
 	log := logger.With().Str("engine", "ingestion").Logger()
 
@@ -121,6 +132,7 @@ func New(
 		syncFast:               syncFast,
 		checkAuthorizedAtBlock: checkAuthorizedAtBlock,
 		pauseExecution:         pauseExecution,
+		conflictDetector:       conflictDetector,
 	}
 
 	// move to state syncing engine
@@ -593,6 +605,10 @@ func (e *Engine) executeBlock(ctx context.Context, executableBlock *entity.Execu
 		return
 	}
 
+	if e.conflictDetector != nil {
+		e.conflictDetector.OnResultComputed(&receipt.ExecutionResult)
+	}
+
 	// if the receipt is for a sealed block, then no need to broadcast it.
 	lastSealed, err := e.state.Sealed().Head()
 	if err != nil {
@@ -731,6 +747,25 @@ func (e *Engine) onBlockExecuted(executed *entity.ExecutableBlock, finalState fl
 	return nil
 }
 
+// ResumeExecution executes the blocks at the heads of the execution queues which are ready to be executed.
+// It is called once execution is resumed after it was halted because of conflicting execution results,
+// since the blocks which became executable while execution was halted were skipped.
+func (e *Engine) ResumeExecution() {
+	err := e.mempool.Run(
+		func(
+			blockByCollection *stdmap.BlockByCollectionBackdata,
+			executionQueues *stdmap.QueuesBackdata,
+		) error {
+			for _, queue := range executionQueues.All() {
+				e.executeBlockIfComplete(queue.Head.Item.(*entity.ExecutableBlock))
+			}
+			return nil
+		})
+	if err != nil {
+		e.log.Err(err).Msg("could not resume execution")
+	}
+}
+
 // executeBlockIfComplete checks whether the block is ready to be executed.
 // if yes, execute the block
 // return a bool indicates whether the block was completed
@@ -740,6 +775,14 @@ func (e *Engine) executeBlockIfComplete(eb *entity.ExecutableBlock) bool {
 		return false
 	}
 
+	if e.conflictDetector != nil && e.conflictDetector.ExecutionHalted() {
+		e.log.Warn().
+			Hex("block_id", logging.Entity(eb)).
+			Uint64("height", eb.Block.Header.Height).
+			Msg("not executing block, execution is halted because of conflicting execution results")
+		return false
+	}
+
 	// if the eb has parent statecommitment, and we have the delta for this block
 	// then apply the delta
 	// note the block ID is the delta's ID
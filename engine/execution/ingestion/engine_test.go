@@ -173,6 +173,7 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 		false,
 		checkAuthorizedAtBlock,
 		false,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -1177,6 +1178,7 @@ func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.Executi
 		false,
 		checkAuthorizedAtBlock,
 		false,
+		nil,
 	)
 
 	require.NoError(t, err)
@@ -2,6 +2,7 @@ package execution
 
 import (
 	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/mempool/entity"
@@ -29,6 +30,9 @@ type ComputationResult struct {
 	StateReads         uint64
 	TrieUpdates        []*ledger.TrieUpdate
 	ExecutionDataID    flow.Identifier
+	// RegisterInteractions are the register reads and writes of each transaction by account,
+	// in the order of TransactionResults
+	RegisterInteractions []state.RegisterInteractions
 }
 
 func (cr *ComputationResult) AddEvents(chunkIndex int, inp []flow.Event) {
@@ -43,6 +47,10 @@ func (cr *ComputationResult) AddTransactionResult(inp *flow.TransactionResult) {
 	cr.TransactionResults = append(cr.TransactionResults, *inp)
 }
 
+func (cr *ComputationResult) AddRegisterInteractions(inp state.RegisterInteractions) {
+	cr.RegisterInteractions = append(cr.RegisterInteractions, inp)
+}
+
 func (cr *ComputationResult) AddComputationUsed(inp uint64) {
 	cr.ComputationUsed += inp
 }
@@ -0,0 +1,350 @@
+package pruner
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+const (
+	DefaultBatchSize     = 100
+	DefaultBatchInterval = time.Second
+	DefaultCheckInterval = time.Minute
+)
+
+// Config is the configuration of the pruner.
+type Config struct {
+	// RetentionHeights is the number of sealed heights, up to the highest sealed and executed height,
+	// whose execution data is kept. It must be positive, since the execution result of the highest
+	// executed block is needed to execute its children.
+	RetentionHeights uint64
+	// BatchSize is the number of heights pruned before the pruner pauses.
+	BatchSize uint
+	// BatchInterval is the pause between two batches, which gives badger the time to compact the
+	// removed data before more data is removed.
+	BatchInterval time.Duration
+	// CheckInterval is the interval in which the pruner checks for heights to prune.
+	CheckInterval time.Duration
+}
+
+// Progress is the progress of the pruner.
+type Progress struct {
+	// PrunedHeight is the highest height whose execution data was pruned.
+	PrunedHeight uint64
+	// TargetHeight is the height up to which execution data is going to be pruned.
+	TargetHeight uint64
+}
+
+// Pruner removes the execution data of sealed blocks which are outside of the retention window from the
+// execution node's database: chunk data packs, the state deltas of the blocks (their events, service
+// events, transaction results and execution state interactions), and the indexes of the execution
+// results and own receipts of the blocks. The execution results and receipts themselves are kept, since
+// they are referenced by the receipts incorporated in block payloads. State commitments are small and
+// kept, so the state of pruned blocks can still be referenced.
+//
+// The highest pruned height is stored as consumer progress, so pruning is resumed after a restart.
+// Heights are pruned in batches with a pause in between, to spread the compaction load on badger.
+type Pruner struct {
+	unit   *engine.Unit
+	log    zerolog.Logger
+	config Config
+
+	db             *badger.DB
+	state          protocol.State
+	executionState state.ReadOnlyExecutionState
+	headers        *bstorage.Headers
+	results        *bstorage.ExecutionResults
+	myReceipts     *bstorage.MyExecutionReceipts
+	chunkDataPacks *bstorage.ChunkDataPacks
+	events         *bstorage.Events
+	serviceEvents  *bstorage.ServiceEvents
+	txResults      *bstorage.TransactionResults
+	progress       storage.ConsumerProgress
+	metrics        module.ExecutionPrunerMetrics
+
+	mu           sync.RWMutex
+	prunedHeight uint64
+	targetHeight uint64
+}
+
+// New returns a pruner for the execution data stored in the given database. The pruning progress is
+// initialized with the root height of the protocol state, if the pruner was not run before.
+func New(
+	log zerolog.Logger,
+	config Config,
+	db *badger.DB,
+	state protocol.State,
+	executionState state.ReadOnlyExecutionState,
+	headers *bstorage.Headers,
+	results *bstorage.ExecutionResults,
+	myReceipts *bstorage.MyExecutionReceipts,
+	chunkDataPacks *bstorage.ChunkDataPacks,
+	events *bstorage.Events,
+	serviceEvents *bstorage.ServiceEvents,
+	txResults *bstorage.TransactionResults,
+	metrics module.ExecutionPrunerMetrics,
+) (*Pruner, error) {
+	if config.RetentionHeights == 0 {
+		return nil, fmt.Errorf("retention heights must be positive")
+	}
+	if config.BatchSize == 0 {
+		return nil, fmt.Errorf("batch size must be positive")
+	}
+
+	root, err := state.Params().Root()
+	if err != nil {
+		return nil, fmt.Errorf("could not get root block: %w", err)
+	}
+
+	progress := bstorage.NewConsumerProgress(db, module.ConsumeProgressExecutionPrunerHeight)
+	err = progress.InitProcessedIndex(root.Height)
+	if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+		return nil, fmt.Errorf("could not initialize pruned height: %w", err)
+	}
+
+	prunedHeight, err := progress.ProcessedIndex()
+	if err != nil {
+		return nil, fmt.Errorf("could not read pruned height: %w", err)
+	}
+
+	return &Pruner{
+		unit:           engine.NewUnit(),
+		log:            log.With().Str("engine", "pruner").Logger(),
+		config:         config,
+		db:             db,
+		state:          state,
+		executionState: executionState,
+		headers:        headers,
+		results:        results,
+		myReceipts:     myReceipts,
+		chunkDataPacks: chunkDataPacks,
+		events:         events,
+		serviceEvents:  serviceEvents,
+		txResults:      txResults,
+		progress:       progress,
+		metrics:        metrics,
+		prunedHeight:   prunedHeight,
+		targetHeight:   prunedHeight,
+	}, nil
+}
+
+// Ready starts checking for heights to prune in the configured interval.
+func (p *Pruner) Ready() <-chan struct{} {
+	p.unit.LaunchPeriodically(func() {
+		err := p.prune()
+		if err != nil {
+			p.log.Error().Err(err).Msg("could not prune execution data")
+		}
+	}, p.config.CheckInterval, 0)
+	return p.unit.Ready()
+}
+
+// Done stops the pruner after the batch being pruned is completed.
+func (p *Pruner) Done() <-chan struct{} {
+	return p.unit.Done()
+}
+
+// Progress returns the progress of the pruner.
+func (p *Pruner) Progress() Progress {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return Progress{
+		PrunedHeight: p.prunedHeight,
+		TargetHeight: p.targetHeight,
+	}
+}
+
+// RetentionHeights returns the number of sealed heights whose execution data is kept.
+func (p *Pruner) RetentionHeights() uint64 {
+	return p.config.RetentionHeights
+}
+
+// prune prunes all heights up to the target height, batch by batch. It returns early if the pruner
+// is shut down.
+func (p *Pruner) prune() error {
+	target, err := p.pruneTarget()
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.targetHeight = target
+	pruned := p.prunedHeight
+	p.mu.Unlock()
+
+	p.metrics.ExecutionPrunerTargetHeight(target)
+
+	for pruned < target {
+		batchEnd := pruned + uint64(p.config.BatchSize)
+		if batchEnd > target {
+			batchEnd = target
+		}
+
+		err = p.pruneBatch(pruned+1, batchEnd)
+		if err != nil {
+			return err
+		}
+		pruned = batchEnd
+
+		if pruned == target {
+			break
+		}
+
+		select {
+		case <-p.unit.Quit():
+			return nil
+		case <-time.After(p.config.BatchInterval):
+		}
+	}
+
+	return nil
+}
+
+// pruneTarget returns the highest height which can be pruned, which is the retention window below
+// the highest height that is both sealed and executed.
+func (p *Pruner) pruneTarget() (uint64, error) {
+	sealed, err := p.state.Sealed().Head()
+	if err != nil {
+		return 0, fmt.Errorf("could not get sealed block: %w", err)
+	}
+
+	executed, _, err := p.executionState.GetHighestExecutedBlockID(p.unit.Ctx())
+	if err != nil {
+		return 0, fmt.Errorf("could not get highest executed block: %w", err)
+	}
+
+	highest := sealed.Height
+	if executed < highest {
+		highest = executed
+	}
+
+	p.mu.RLock()
+	pruned := p.prunedHeight
+	p.mu.RUnlock()
+
+	if highest < pruned+p.config.RetentionHeights {
+		return pruned, nil
+	}
+	return highest - p.config.RetentionHeights, nil
+}
+
+// pruneBatch prunes the heights in the given range, and stores the progress once all of them are pruned.
+// Since pruning a height twice is a no-op, heights pruned before a crash are pruned again after a restart.
+func (p *Pruner) pruneBatch(from uint64, to uint64) error {
+	start := time.Now()
+
+	for height := from; height <= to; height++ {
+		header, err := p.headers.ByHeight(height)
+		if err != nil {
+			return fmt.Errorf("could not get finalized block at height %d: %w", height, err)
+		}
+
+		err = p.pruneBlock(header.ID())
+		if err != nil {
+			return fmt.Errorf("could not prune block %v at height %d: %w", header.ID(), height, err)
+		}
+	}
+
+	err := p.progress.SetProcessedIndex(to)
+	if err != nil {
+		return fmt.Errorf("could not store pruned height: %w", err)
+	}
+
+	p.mu.Lock()
+	p.prunedHeight = to
+	p.mu.Unlock()
+
+	p.metrics.ExecutionPrunerBatchPruned(to, int(to-from+1), time.Since(start))
+
+	p.log.Info().
+		Uint64("from_height", from).
+		Uint64("to_height", to).
+		Dur("duration", time.Since(start)).
+		Msg("execution data pruned")
+
+	return nil
+}
+
+// pruneBlock removes the execution data of the given block.
+func (p *Pruner) pruneBlock(blockID flow.Identifier) error {
+	result, err := p.results.ByBlockID(blockID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not get execution result: %w", err)
+	}
+
+	if result != nil {
+		for _, chunk := range result.Chunks {
+			chunkID := chunk.ID()
+
+			err = p.chunkDataPacks.Remove(chunkID)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return fmt.Errorf("could not remove chunk data pack of chunk %v: %w", chunkID, err)
+			}
+
+			err = p.headers.RemoveChunkBlockIndexByChunkID(chunkID)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return fmt.Errorf("could not remove block index of chunk %v: %w", chunkID, err)
+			}
+		}
+	}
+
+	err = p.pruneStateDelta(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove state delta: %w", err)
+	}
+
+	// the indexes of the receipt and the result are removed last, since the result is needed to find
+	// the chunks if pruning the block is retried after a crash
+	err = p.myReceipts.RemoveIndexByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove own execution receipt: %w", err)
+	}
+
+	err = p.results.RemoveIndexByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove execution result index: %w", err)
+	}
+
+	return nil
+}
+
+// pruneStateDelta removes the data of the state delta of the given block returned by RetrieveStateDelta:
+// its events, service events, transaction results and execution state interactions. The block, its
+// collections and its state commitment are kept.
+func (p *Pruner) pruneStateDelta(blockID flow.Identifier) error {
+	err := p.events.RemoveByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove events: %w", err)
+	}
+
+	err = p.serviceEvents.RemoveByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove service events: %w", err)
+	}
+
+	err = p.txResults.RemoveByBlockID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not remove transaction results: %w", err)
+	}
+
+	err = p.db.Update(operation.RemoveExecutionStateInteractions(blockID))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not remove execution state interactions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/trace"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// prunerSuite stores the execution data of a chain of finalized blocks, with the root block at height 0.
+// The payload of each block incorporates a receipt of its own execution result.
+type prunerSuite struct {
+	t      *testing.T
+	db     *badger.DB
+	chain  []*flow.Header
+	chunks map[flow.Identifier][]flow.Identifier
+	txIDs  map[flow.Identifier]flow.Identifier
+
+	resultIDs map[flow.Identifier]flow.Identifier
+
+	sealed   *flow.Header
+	executed uint64
+
+	headers        *bstorage.Headers
+	blocks         *bstorage.Blocks
+	payloads       *bstorage.Payloads
+	commits        *bstorage.Commits
+	executionState state.ExecutionState
+	results        *bstorage.ExecutionResults
+	myReceipts     *bstorage.MyExecutionReceipts
+	chunkDataPacks *bstorage.ChunkDataPacks
+	events         *bstorage.Events
+	serviceEvents  *bstorage.ServiceEvents
+	txResults      *bstorage.TransactionResults
+}
+
+func newPrunerSuite(t *testing.T, db *badger.DB, count int) *prunerSuite {
+	collector := metrics.NewNoopCollector()
+	headers := bstorage.NewHeaders(collector, db)
+	results := bstorage.NewExecutionResults(collector, db)
+	receipts := bstorage.NewExecutionReceipts(collector, db, results, 10)
+	payloads := bstorage.NewPayloads(db, bstorage.NewIndex(collector, db), bstorage.NewGuarantees(collector, db, 10),
+		bstorage.NewSeals(collector, db), receipts, results)
+	collections := bstorage.NewCollections(db, bstorage.NewTransactions(collector, db))
+	s := &prunerSuite{
+		t:              t,
+		db:             db,
+		chunks:         make(map[flow.Identifier][]flow.Identifier),
+		txIDs:          make(map[flow.Identifier]flow.Identifier),
+		resultIDs:      make(map[flow.Identifier]flow.Identifier),
+		headers:        headers,
+		blocks:         bstorage.NewBlocks(db, headers, payloads),
+		payloads:       payloads,
+		commits:        bstorage.NewCommits(collector, db),
+		results:        results,
+		myReceipts:     bstorage.NewMyExecutionReceipts(collector, db, receipts),
+		chunkDataPacks: bstorage.NewChunkDataPacks(collector, db, collections, 10),
+		events:         bstorage.NewEvents(collector, db),
+		serviceEvents:  bstorage.NewServiceEvents(collector, db),
+		txResults:      bstorage.NewTransactionResults(collector, db, 10),
+	}
+	s.executionState = state.NewExecutionState(nil, s.commits, s.blocks, s.headers, collections, s.chunkDataPacks,
+		s.results, s.myReceipts, s.events, s.serviceEvents, s.txResults, db, trace.NewNoopTracer())
+
+	root := unittest.BlockHeaderFixture()
+	root.Height = 0
+	parent := &root
+	for i := 0; i < count; i++ {
+		header := parent
+		if i > 0 {
+			child := unittest.BlockHeaderWithParentFixture(parent)
+			header = &child
+		}
+		s.storeBlock(header)
+		parent = header
+	}
+
+	s.sealed = s.chain[len(s.chain)-1]
+	s.executed = s.sealed.Height
+
+	return s
+}
+
+func (s *prunerSuite) storeBlock(header *flow.Header) {
+	blockID := header.ID()
+	result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(blockID))
+
+	// the receipt of another execution node is incorporated in the payload of the block
+	block := &flow.Block{
+		Header: header,
+		Payload: &flow.Payload{
+			Receipts: []*flow.ExecutionReceiptMeta{unittest.ExecutionReceiptFixture(unittest.WithResult(result)).Meta()},
+			Results:  []*flow.ExecutionResult{result},
+		},
+	}
+	require.NoError(s.t, s.blocks.Store(block))
+	require.NoError(s.t, s.db.Update(operation.IndexBlockHeight(header.Height, blockID)))
+	require.NoError(s.t, s.commits.Store(blockID, unittest.StateCommitmentFixture()))
+
+	require.NoError(s.t, s.results.Store(result))
+	require.NoError(s.t, s.results.Index(blockID, result.ID()))
+	require.NoError(s.t, s.myReceipts.StoreMyReceipt(unittest.ExecutionReceiptFixture(unittest.WithResult(result))))
+
+	for _, chunk := range result.Chunks {
+		chunkID := chunk.ID()
+		require.NoError(s.t, s.chunkDataPacks.Store(unittest.ChunkDataPackFixture(chunkID)))
+		require.NoError(s.t, s.headers.IndexByChunkID(blockID, chunkID))
+		s.chunks[blockID] = append(s.chunks[blockID], chunkID)
+	}
+
+	txID := unittest.IdentifierFixture()
+	event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID, 0)
+	batch := bstorage.NewBatch(s.db)
+	require.NoError(s.t, s.events.BatchStore(blockID, []flow.EventsList{{event}}, batch))
+	require.NoError(s.t, s.serviceEvents.BatchStore(blockID, []flow.Event{event}, batch))
+	require.NoError(s.t, s.txResults.BatchStore(blockID, []flow.TransactionResult{{TransactionID: txID}}, batch))
+	require.NoError(s.t, batch.Flush())
+
+	require.NoError(s.t, s.db.Update(operation.InsertExecutionStateInteractions(blockID, nil)))
+
+	s.txIDs[blockID] = txID
+	s.resultIDs[blockID] = result.ID()
+	s.chain = append(s.chain, header)
+
+	// reading the execution data fills the caches, which must not serve it after pruning
+	s.requireKept(blockID, true)
+}
+
+// requireKept checks whether the execution data of the given block is kept, reading it through
+// the storage caches.
+func (s *prunerSuite) requireKept(blockID flow.Identifier, kept bool) {
+	events, err := s.events.ByBlockID(blockID)
+	require.NoError(s.t, err)
+	require.Equal(s.t, kept, len(events) > 0, "events of block %v", blockID)
+
+	serviceEvents, err := s.serviceEvents.ByBlockID(blockID)
+	require.NoError(s.t, err)
+	require.Equal(s.t, kept, len(serviceEvents) > 0, "service events of block %v", blockID)
+
+	txResults, err := s.txResults.ByBlockID(blockID)
+	require.NoError(s.t, err)
+	require.Equal(s.t, kept, len(txResults) > 0, "transaction results of block %v", blockID)
+
+	_, err = s.txResults.ByBlockIDTransactionID(blockID, s.txIDs[blockID])
+	requireRemoved(s.t, !kept, err)
+
+	_, err = s.txResults.ByBlockIDTransactionIndex(blockID, 0)
+	requireRemoved(s.t, !kept, err)
+
+	_, err = s.results.ByBlockID(blockID)
+	requireRemoved(s.t, !kept, err)
+
+	_, err = s.myReceipts.MyReceipt(blockID)
+	requireRemoved(s.t, !kept, err)
+}
+
+func (s *prunerSuite) newPruner(retention uint64) *Pruner {
+	params := new(protocol.Params)
+	params.On("Root").Return(s.chain[0], nil)
+
+	protocolState := new(protocol.State)
+	protocolState.On("Params").Return(params)
+	protocolState.On("Sealed").Return(unittest.StateSnapshotForKnownBlock(s.sealed, nil))
+
+	executionState := new(statemock.ReadOnlyExecutionState)
+	executionState.On("GetHighestExecutedBlockID", mock.Anything).Return(
+		func(context.Context) uint64 { return s.executed },
+		flow.ZeroID,
+		nil,
+	)
+
+	pruner, err := New(
+		zerolog.Nop(),
+		Config{
+			RetentionHeights: retention,
+			BatchSize:        3,
+			BatchInterval:    time.Millisecond,
+			CheckInterval:    time.Hour,
+		},
+		s.db,
+		protocolState,
+		executionState,
+		s.headers,
+		s.results,
+		s.myReceipts,
+		s.chunkDataPacks,
+		s.events,
+		s.serviceEvents,
+		s.txResults,
+		metrics.NewNoopCollector(),
+	)
+	require.NoError(s.t, err)
+	return pruner
+}
+
+// requirePrunedUpTo checks that the execution data of all blocks up to the given height is pruned,
+// and the execution data of all blocks above it is kept.
+func (s *prunerSuite) requirePrunedUpTo(height uint64) {
+	for _, header := range s.chain[1:] {
+		blockID := header.ID()
+		pruned := header.Height <= height
+
+		for _, chunkID := range s.chunks[blockID] {
+			var chunkDataPack badgermodel.StoredChunkDataPack
+			err := s.db.View(operation.RetrieveChunkDataPack(chunkID, &chunkDataPack))
+			requireRemoved(s.t, pruned, err)
+
+			_, err = s.headers.IDByChunkID(chunkID)
+			requireRemoved(s.t, pruned, err)
+		}
+
+		var events []flow.Event
+		require.NoError(s.t, s.db.View(operation.LookupEventsByBlockID(blockID, &events)))
+		require.Equal(s.t, pruned, len(events) == 0, "events of block at height %d", header.Height)
+
+		var results []flow.TransactionResult
+		require.NoError(s.t, s.db.View(operation.LookupTransactionResultsByBlockID(blockID, &results)))
+		require.Equal(s.t, pruned, len(results) == 0, "transaction results of block at height %d", header.Height)
+
+		results = nil
+		require.NoError(s.t, s.db.View(operation.LookupTransactionResultsByBlockIDUsingIndex(blockID, &results)))
+		require.Equal(s.t, pruned, len(results) == 0, "indexed transaction results of block at height %d", header.Height)
+
+		var interactions []*delta.Snapshot
+		err := s.db.View(operation.RetrieveExecutionStateInteractions(blockID, &interactions))
+		requireRemoved(s.t, pruned, err)
+
+		// the state delta of the block can't be retrieved anymore
+		_, err = s.executionState.RetrieveStateDelta(context.Background(), blockID)
+		requireRemoved(s.t, pruned, err)
+
+		// the execution result is kept, since it is referenced by the receipt in the payload of the block
+		payload, err := s.payloads.ByBlockID(blockID)
+		require.NoError(s.t, err, "payload of block at height %d", header.Height)
+		require.Len(s.t, payload.Results, 1)
+		require.Equal(s.t, s.resultIDs[blockID], payload.Results[0].ID())
+		require.Equal(s.t, s.resultIDs[blockID], payload.Receipts[0].ResultID)
+
+		s.requireKept(blockID, !pruned)
+	}
+}
+
+func requireRemoved(t *testing.T, removed bool, err error) {
+	if removed {
+		require.ErrorIs(t, err, storage.ErrNotFound)
+	} else {
+		require.NoError(t, err)
+	}
+}
+
+func TestPruner(t *testing.T) {
+
+	t.Run("heights outside of the retention window are pruned in batches", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			s := newPrunerSuite(t, db, 11)
+			pruner := s.newPruner(3)
+
+			require.NoError(t, pruner.prune())
+
+			require.Equal(t, Progress{PrunedHeight: 7, TargetHeight: 7}, pruner.Progress())
+			s.requirePrunedUpTo(7)
+
+			// nothing to prune until more blocks are sealed
+			require.NoError(t, pruner.prune())
+			require.Equal(t, Progress{PrunedHeight: 7, TargetHeight: 7}, pruner.Progress())
+		})
+	})
+
+	t.Run("heights which are not executed yet are not pruned", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			s := newPrunerSuite(t, db, 11)
+			s.executed = 5
+			pruner := s.newPruner(3)
+
+			require.NoError(t, pruner.prune())
+
+			require.Equal(t, Progress{PrunedHeight: 2, TargetHeight: 2}, pruner.Progress())
+			s.requirePrunedUpTo(2)
+		})
+	})
+
+	t.Run("the execution result of the highest executed block is never pruned", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			s := newPrunerSuite(t, db, 3)
+			_, err := New(zerolog.Nop(), Config{BatchSize: 1}, db, nil, nil, s.headers, s.results, s.myReceipts,
+				s.chunkDataPacks, s.events, s.serviceEvents, s.txResults, metrics.NewNoopCollector())
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("pruning is resumed from the stored progress", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			s := newPrunerSuite(t, db, 11)
+			s.sealed = s.chain[5]
+			require.NoError(t, s.newPruner(1).prune())
+			s.requirePrunedUpTo(4)
+
+			s.sealed = s.chain[10]
+			pruner := s.newPruner(1)
+			require.Equal(t, uint64(4), pruner.Progress().PrunedHeight)
+
+			require.NoError(t, pruner.prune())
+			require.Equal(t, Progress{PrunedHeight: 9, TargetHeight: 9}, pruner.Progress())
+			s.requirePrunedUpTo(9)
+		})
+	})
+}
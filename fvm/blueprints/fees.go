@@ -188,6 +188,29 @@ transaction(surgeFactor: UFix64, inclusionEffortCost: UFix64, executionEffortCos
 }
 `
 
+const computeFeesScriptTemplate = `
+import FlowFees from 0x%s
+
+pub fun main(inclusionEffort: UFix64, executionEffort: UFix64): UFix64 {
+    return FlowFees.computeFees(inclusionEffort: inclusionEffort, executionEffort: executionEffort)
+}
+`
+
+// ComputeFeesScript creates a script that computes the fees of a transaction with the given
+// inclusion and execution effort, using the current fee parameters.
+func ComputeFeesScript(flowFees flow.Address, inclusionEffort, executionEffort cadence.UFix64) ([]byte, [][]byte) {
+	inclusionEffortArg, err := jsoncdc.Encode(inclusionEffort)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode inclusion effort: %s", err.Error()))
+	}
+	executionEffortArg, err := jsoncdc.Encode(executionEffort)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode execution effort: %s", err.Error()))
+	}
+
+	return []byte(fmt.Sprintf(computeFeesScriptTemplate, flowFees)), [][]byte{inclusionEffortArg, executionEffortArg}
+}
+
 // SetExecutionEffortWeightsTransaction creates a transaction that sets up weights for the weighted Meter.
 func SetExecutionEffortWeightsTransaction(
 	service flow.Address,
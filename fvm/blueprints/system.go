@@ -2,6 +2,7 @@ package blueprints
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/onflow/flow-go/fvm/systemcontracts"
 	"github.com/onflow/flow-go/model/flow"
@@ -39,3 +40,101 @@ func SystemChunkTransaction(chain flow.Chain) (*flow.TransactionBody, error) {
 
 	return tx, nil
 }
+
+// SystemTask is an additional transaction of the service account which runs in the system chunk,
+// after the system chunk transaction, at the blocks it is scheduled at.
+type SystemTask struct {
+	// Name identifies the task, and orders the tasks running in the same block.
+	Name string
+	// StartHeight is the height of the first block the task runs at.
+	StartHeight uint64
+	// Interval is the number of blocks between two runs of the task, the task runs only once if it is zero.
+	Interval uint64
+	// GasLimit is the computation limit of the task transaction.
+	GasLimit uint64
+	// Script is the transaction script, the service account is its only authorizer.
+	Script []byte
+}
+
+// ScheduledAt returns whether the task runs in the block at the given height.
+func (t SystemTask) ScheduledAt(height uint64) bool {
+	if height < t.StartHeight {
+		return false
+	}
+	if t.Interval == 0 {
+		return height == t.StartHeight
+	}
+	return (height-t.StartHeight)%t.Interval == 0
+}
+
+// SystemTasks is a schedule of system tasks, ordered by name.
+type SystemTasks []SystemTask
+
+// NewSystemTasks creates a schedule of the given system tasks. The names of the tasks must be unique.
+func NewSystemTasks(tasks ...SystemTask) (SystemTasks, error) {
+	names := make(map[string]struct{}, len(tasks))
+	for _, task := range tasks {
+		if task.Name == "" {
+			return nil, fmt.Errorf("system task has no name")
+		}
+		if _, ok := names[task.Name]; ok {
+			return nil, fmt.Errorf("duplicate system task %s", task.Name)
+		}
+		if task.GasLimit == 0 {
+			return nil, fmt.Errorf("system task %s has no gas limit", task.Name)
+		}
+		if len(task.Script) == 0 {
+			return nil, fmt.Errorf("system task %s has no script", task.Name)
+		}
+		names[task.Name] = struct{}{}
+	}
+
+	scheduled := make(SystemTasks, len(tasks))
+	copy(scheduled, tasks)
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Name < scheduled[j].Name
+	})
+
+	return scheduled, nil
+}
+
+// ScheduledAt returns the tasks which run in the block at the given height, ordered by name.
+func (s SystemTasks) ScheduledAt(height uint64) SystemTasks {
+	var scheduled SystemTasks
+	for _, task := range s {
+		if task.ScheduledAt(height) {
+			scheduled = append(scheduled, task)
+		}
+	}
+	return scheduled
+}
+
+// SystemTasksForChain returns the system tasks scheduled on the given chain. All nodes of a chain must run the
+// same schedule, tasks are therefore registered here rather than configured per node.
+func SystemTasksForChain(chainID flow.ChainID) SystemTasks {
+	return systemTasks[chainID]
+}
+
+// systemTasks holds the system tasks scheduled on each chain.
+var systemTasks = map[flow.ChainID]SystemTasks{}
+
+// SystemChunkTransactions creates and returns the transactions of the system chunk of the block at the given
+// height: the system chunk transaction, followed by the given tasks scheduled at the height, ordered by name.
+func SystemChunkTransactions(chain flow.Chain, height uint64, tasks SystemTasks) ([]*flow.TransactionBody, error) {
+	systemTx, err := SystemChunkTransaction(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := tasks.ScheduledAt(height)
+	txs := make([]*flow.TransactionBody, 0, 1+len(scheduled))
+	txs = append(txs, systemTx)
+	for _, task := range scheduled {
+		txs = append(txs, flow.NewTransactionBody().
+			SetScript(task.Script).
+			AddAuthorizer(chain.ServiceAddress()).
+			SetGasLimit(task.GasLimit))
+	}
+
+	return txs, nil
+}
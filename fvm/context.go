@@ -5,6 +5,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"github.com/onflow/flow-go/fvm/blueprints"
 	"github.com/onflow/flow-go/fvm/handler"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -38,6 +39,8 @@ type Context struct {
 	ExtensiveTracing              bool
 	TransactionProcessors         []TransactionProcessor
 	ScriptProcessors              []ScriptProcessor
+	ContractUpdateObserver        handler.ContractUpdateObserverFunc
+	SystemTasks                   blueprints.SystemTasks
 	Logger                        zerolog.Logger
 }
 
@@ -284,6 +287,26 @@ func WithAccountStorageLimit(enabled bool) Option {
 	}
 }
 
+// WithContractUpdateObserver sets a function which is called for each contract update committed by a transaction.
+//
+// The updates of transactions which fail afterwards, for example when deducting fees, are observed as well.
+func WithContractUpdateObserver(observer handler.ContractUpdateObserverFunc) Option {
+	return func(ctx Context) Context {
+		ctx.ContractUpdateObserver = observer
+		return ctx
+	}
+}
+
+// WithSystemTasks sets the system tasks run in the system chunk, instead of the tasks scheduled on the chain.
+//
+// This option is meant for testing, all nodes of a chain must run the same system tasks.
+func WithSystemTasks(tasks blueprints.SystemTasks) Option {
+	return func(ctx Context) Context {
+		ctx.SystemTasks = tasks
+		return ctx
+	}
+}
+
 // WithTransactionFeesEnabled enables or disables deduction of transaction fees
 func WithTransactionFeesEnabled(enabled bool) Option {
 	return func(ctx Context) Context {
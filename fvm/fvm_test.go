@@ -3696,6 +3696,68 @@ func TestSettingExecutionWeights(t *testing.T) {
 		},
 	))
 
+	storageTransaction := func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, view state.View, programs *programs.Programs, gasLimit uint64) *flow.TransactionBody {
+		privateKeys, err := testutil.GenerateAccountPrivateKeys(1)
+		require.NoError(t, err)
+
+		accounts, err := testutil.CreateAccounts(vm, view, programs, privateKeys, chain)
+		require.NoError(t, err)
+
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(`
+				transaction {
+                  prepare(signer: AuthAccount) {
+					signer.save("some value", to: /storage/value)
+                  }
+                }
+			`)).
+			SetProposalKey(accounts[0], 0, 0).
+			AddAuthorizer(accounts[0]).
+			SetPayer(accounts[0]).
+			SetGasLimit(gasLimit)
+
+		err = testutil.SignTransaction(txBody, accounts[0], privateKeys[0], 0)
+		require.NoError(t, err)
+
+		return txBody
+	}
+
+	t.Run("storage interactions are reported", newVMTest().withBootstrapProcedureOptions(
+		fvm.WithMinimumStorageReservation(fvm.DefaultMinimumStorageReservation),
+		fvm.WithAccountCreationFee(fvm.DefaultAccountCreationFee),
+		fvm.WithStorageMBPerFLOW(fvm.DefaultStorageMBPerFLOW),
+	).run(
+		func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, view state.View, programs *programs.Programs) {
+			tx := fvm.Transaction(storageTransaction(t, vm, chain, view, programs, fvm.DefaultComputationLimit), 0)
+			err := vm.Run(ctx, tx, view, programs)
+			require.NoError(t, err)
+			require.NoError(t, tx.Err)
+
+			require.Greater(t, tx.StorageBytesRead, uint64(0))
+			require.Greater(t, tx.StorageBytesWritten, uint64(0))
+		},
+	))
+
+	t.Run("transaction should fail with high storage write weights", newVMTest().withBootstrapProcedureOptions(
+		fvm.WithMinimumStorageReservation(fvm.DefaultMinimumStorageReservation),
+		fvm.WithAccountCreationFee(fvm.DefaultAccountCreationFee),
+		fvm.WithStorageMBPerFLOW(fvm.DefaultStorageMBPerFLOW),
+		fvm.WithExecutionEffortWeights(
+			weightedMeter.ExecutionEffortWeights{
+				meter.ComputationKindStorageWrite: 1 << weightedMeter.MeterExecutionInternalPrecisionBytes,
+			},
+		),
+	).run(
+		func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, view state.View, programs *programs.Programs) {
+			// one unit of computation per byte written exceeds the limit of the transaction
+			tx := fvm.Transaction(storageTransaction(t, vm, chain, view, programs, 100), 0)
+			err := vm.Run(ctx, tx, view, programs)
+			require.NoError(t, err)
+
+			assert.True(t, errors.IsComputationLimitExceededError(tx.Err))
+		},
+	))
+
 	t.Run("transaction should fail if create account weight is high", newVMTest().withBootstrapProcedureOptions(
 		fvm.WithMinimumStorageReservation(fvm.DefaultMinimumStorageReservation),
 		fvm.WithAccountCreationFee(fvm.DefaultAccountCreationFee),
@@ -19,6 +19,10 @@ type RestrictedDeploymentEnabledFunc func() bool
 type AuthorizedAccountsFunc func() []common.Address
 type UseContractAuditVoucherFunc func(address runtime.Address, code []byte) (bool, error)
 
+// ContractUpdateObserverFunc is called for each contract update committed by a contract handler,
+// the code of removed contracts is empty.
+type ContractUpdateObserverFunc func(update programs.ContractUpdate)
+
 // ContractHandler handles all interaction
 // with smart contracts such as get/set/update
 // it also captures all changes as deltas and
@@ -31,6 +35,7 @@ type ContractHandler struct {
 	authorizedDeploymentAccounts AuthorizedAccountsFunc
 	authorizedRemovalAccounts    AuthorizedAccountsFunc
 	useContractAuditVoucher      UseContractAuditVoucherFunc
+	contractUpdateObserver       ContractUpdateObserverFunc
 	// handler doesn't have to be thread safe and right now
 	// is only used in a single thread but a mutex has been added
 	// here to prevent accidental multi-thread use in the future
@@ -42,6 +47,7 @@ func NewContractHandler(accounts state.Accounts,
 	authorizedDeploymentAccounts AuthorizedAccountsFunc,
 	authorizedRemovalAccounts AuthorizedAccountsFunc,
 	useContractAuditVoucher UseContractAuditVoucherFunc,
+	contractUpdateObserver ContractUpdateObserverFunc,
 ) *ContractHandler {
 	return &ContractHandler{
 		accounts:                     accounts,
@@ -50,6 +56,7 @@ func NewContractHandler(accounts state.Accounts,
 		authorizedDeploymentAccounts: authorizedDeploymentAccounts,
 		authorizedRemovalAccounts:    authorizedRemovalAccounts,
 		useContractAuditVoucher:      useContractAuditVoucher,
+		contractUpdateObserver:       contractUpdateObserver,
 	}
 }
 
@@ -184,6 +191,12 @@ func (h *ContractHandler) Commit() ([]programs.ContractUpdateKey, error) {
 		}
 	}
 
+	if h.contractUpdateObserver != nil {
+		for _, v := range updateList {
+			h.contractUpdateObserver(v)
+		}
+	}
+
 	// reset draft
 	h.draftUpdates = make(map[programs.ContractUpdateKey]programs.ContractUpdate)
 	return updatedKeys, nil
@@ -26,7 +26,7 @@ func TestContract_ChildMergeFunctionality(t *testing.T) {
 	err := accounts.Create(nil, address)
 	require.NoError(t, err)
 
-	contractHandler := handler.NewContractHandler(accounts, func() bool { return false }, nil, nil, nil)
+	contractHandler := handler.NewContractHandler(accounts, func() bool { return false }, nil, nil, nil, nil)
 
 	// no contract initially
 	names, err := contractHandler.GetContractNames(rAdd)
@@ -117,7 +117,8 @@ func TestContract_AuthorizationFunctionality(t *testing.T) {
 			func() bool { return true },
 			func() []common.Address { return []common.Address{rAdd, rBoth} },
 			func() []common.Address { return []common.Address{rRemove, rBoth} },
-			func(address runtime.Address, code []byte) (bool, error) { return false, nil })
+			func(address runtime.Address, code []byte) (bool, error) { return false, nil },
+			nil)
 	}
 
 	t.Run("try to set contract with unauthorized account", func(t *testing.T) {
@@ -234,7 +235,8 @@ func TestContract_DeploymentVouchers(t *testing.T) {
 				return true, nil
 			}
 			return false, nil
-		})
+		},
+		nil)
 
 	// set contract without voucher
 	err = contractHandler.SetContract(
@@ -290,6 +292,7 @@ func TestContract_ContractUpdate(t *testing.T) {
 			authorizationChecked = true
 			return true, nil
 		},
+		nil,
 	)
 
 	// deploy contract with voucher
@@ -347,6 +350,7 @@ func TestContract_DeterministicErrorOnCommit(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 
 	address1 := runtime.Address(flow.HexToAddress("0000000000000001"))
@@ -364,3 +368,52 @@ func TestContract_DeterministicErrorOnCommit(t *testing.T) {
 	_, err = contractHandler.Commit()
 	require.EqualError(t, err, "A 0000000000000001")
 }
+
+func TestContract_ContractUpdateObserver(t *testing.T) {
+	sth := state.NewStateHolder(state.NewState(utils.NewSimpleView()))
+	accounts := state.NewAccounts(sth)
+	address := flow.HexToAddress("01")
+	rAdd := runtime.Address(address)
+	err := accounts.Create(nil, address)
+	require.NoError(t, err)
+
+	var observed []programs.ContractUpdate
+	contractHandler := handler.NewContractHandler(accounts, func() bool { return false }, nil, nil, nil,
+		func(update programs.ContractUpdate) {
+			observed = append(observed, update)
+		})
+
+	err = contractHandler.SetContract(rAdd, "B", []byte("ABC"), nil)
+	require.NoError(t, err)
+	err = contractHandler.SetContract(rAdd, "A", []byte("DEF"), nil)
+	require.NoError(t, err)
+
+	// draft updates are not observed
+	require.Empty(t, observed)
+
+	_, err = contractHandler.Commit()
+	require.NoError(t, err)
+	require.Equal(t, []programs.ContractUpdate{
+		{ContractUpdateKey: programs.ContractUpdateKey{Address: address, Name: "A"}, Code: []byte("DEF")},
+		{ContractUpdateKey: programs.ContractUpdateKey{Address: address, Name: "B"}, Code: []byte("ABC")},
+	}, observed)
+
+	// rolled back updates are not observed
+	observed = nil
+	err = contractHandler.SetContract(rAdd, "C", []byte("GHI"), nil)
+	require.NoError(t, err)
+	err = contractHandler.Rollback()
+	require.NoError(t, err)
+	_, err = contractHandler.Commit()
+	require.NoError(t, err)
+	require.Empty(t, observed)
+
+	// removals are observed without code
+	err = contractHandler.RemoveContract(rAdd, "A", nil)
+	require.NoError(t, err)
+	_, err = contractHandler.Commit()
+	require.NoError(t, err)
+	require.Equal(t, []programs.ContractUpdate{
+		{ContractUpdateKey: programs.ContractUpdateKey{Address: address, Name: "A"}},
+	}, observed)
+}
@@ -18,6 +18,9 @@ type Meter struct {
 	memoryUsed       uint
 	memoryLimit      uint
 
+	storageBytesRead    uint
+	storageBytesWritten uint
+
 	computationIntensities interfaceMeter.MeteredComputationIntensities
 	memoryIntensities      interfaceMeter.MeteredMemoryIntensities
 }
@@ -60,6 +63,9 @@ func (m *Meter) MergeMeter(child interfaceMeter.Meter, enforceLimits bool) error
 		m.memoryIntensities[key] += intensity
 	}
 
+	m.storageBytesRead += child.TotalBytesReadFromStorage()
+	m.storageBytesWritten += child.TotalBytesWrittenToStorage()
+
 	return nil
 }
 
@@ -121,3 +127,34 @@ func (m *Meter) TotalMemoryUsed() uint {
 func (m *Meter) TotalMemoryLimit() uint {
 	return m.memoryLimit
 }
+
+// MeterStorageRead captures the bytes read from storage as the ComputationKindStorageRead intensity,
+// which is not one of the kinds counting towards the computation limit
+func (m *Meter) MeterStorageRead(byteSize uint) error {
+	m.storageBytesRead += byteSize
+	return m.MeterComputation(interfaceMeter.ComputationKindStorageRead, byteSize)
+}
+
+// MeterStorageWrite captures the bytes written to storage as the ComputationKindStorageWrite intensity,
+// which is not one of the kinds counting towards the computation limit. The bytes of the replaced write
+// are no longer counted.
+func (m *Meter) MeterStorageWrite(byteSize uint, replacedByteSize uint) error {
+	m.storageBytesWritten = m.storageBytesWritten - replacedByteSize + byteSize
+	m.computationIntensities[interfaceMeter.ComputationKindStorageWrite] -= replacedByteSize
+	return m.MeterComputation(interfaceMeter.ComputationKindStorageWrite, byteSize)
+}
+
+// TotalBytesReadFromStorage returns the total number of bytes read from storage
+func (m *Meter) TotalBytesReadFromStorage() uint {
+	return m.storageBytesRead
+}
+
+// TotalBytesWrittenToStorage returns the total number of bytes written to storage
+func (m *Meter) TotalBytesWrittenToStorage() uint {
+	return m.storageBytesWritten
+}
+
+// TotalBytesOfStorageInteractions returns the total number of bytes read from and written to storage
+func (m *Meter) TotalBytesOfStorageInteractions() uint {
+	return m.storageBytesRead + m.storageBytesWritten
+}
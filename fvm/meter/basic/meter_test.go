@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/meter"
 	"github.com/onflow/flow-go/fvm/meter/basic"
 )
 
@@ -98,4 +99,33 @@ func TestBasicComputationMetering(t *testing.T) {
 		require.Equal(t, uint(1+1), m.TotalComputationUsed())
 		require.Equal(t, uint(1+1), m.ComputationIntensities()[compKind])
 	})
+
+	t.Run("meter storage", func(t *testing.T) {
+		m := basic.NewMeter(1, 0)
+
+		err := m.MeterStorageRead(8)
+		require.NoError(t, err)
+		err = m.MeterStorageWrite(3, 0)
+		require.NoError(t, err)
+
+		// storage interactions do not count towards the computation limit
+		require.Equal(t, uint(0), m.TotalComputationUsed())
+		require.Equal(t, uint(8), m.TotalBytesReadFromStorage())
+		require.Equal(t, uint(3), m.TotalBytesWrittenToStorage())
+		require.Equal(t, uint(8+3), m.TotalBytesOfStorageInteractions())
+
+		child := m.NewChild()
+		err = child.MeterStorageWrite(2, 0)
+		require.NoError(t, err)
+
+		err = m.MergeMeter(child, true)
+		require.NoError(t, err)
+		require.Equal(t, uint(3+2), m.TotalBytesWrittenToStorage())
+
+		// the replaced write is no longer counted
+		err = m.MeterStorageWrite(4, 2)
+		require.NoError(t, err)
+		require.Equal(t, uint(3+4), m.TotalBytesWrittenToStorage())
+		require.Equal(t, uint(3+4), m.ComputationIntensities()[meter.ComputationKindStorageWrite])
+	})
 }
@@ -36,6 +36,10 @@ const (
 	ComputationKindUpdateAccountContractCode
 	ComputationKindValidatePublicKey
 	ComputationKindValueExists
+	// ComputationKindStorageRead and ComputationKindStorageWrite meter the bytes read from and written to
+	// storage, so that storage interactions count towards computation limits if they are weighted.
+	ComputationKindStorageRead
+	ComputationKindStorageWrite
 )
 
 type MeteredComputationIntensities map[common.ComputationKind]uint
@@ -58,10 +62,12 @@ type Meter interface {
 	TotalMemoryUsed() uint
 	TotalMemoryLimit() uint
 
-	// TODO move storage metering to here
-	// MeterStorageRead(byteSize uint) error
-	// MeterStorageWrite(byteSize uint) error
-	// TotalBytesReadFromStorage() int
-	// TotalBytesWroteToStorage() int
-	// TotalBytesOfStorageInteractions() int
+	// storage metering
+	MeterStorageRead(byteSize uint) error
+	// MeterStorageWrite meters a write to storage, which replaces a previous write of the given size
+	// to the same register, if any. Only the latest write of a register counts.
+	MeterStorageWrite(byteSize uint, replacedByteSize uint) error
+	TotalBytesReadFromStorage() uint
+	TotalBytesWrittenToStorage() uint
+	TotalBytesOfStorageInteractions() uint
 }
@@ -65,3 +65,28 @@ func (m *Meter) TotalMemoryUsed() uint {
 func (m *Meter) TotalMemoryLimit() uint {
 	return 0
 }
+
+// MeterStorageRead is a noop
+func (m *Meter) MeterStorageRead(_ uint) error {
+	return nil
+}
+
+// MeterStorageWrite is a noop
+func (m *Meter) MeterStorageWrite(_ uint, _ uint) error {
+	return nil
+}
+
+// TotalBytesReadFromStorage always returns zero
+func (m *Meter) TotalBytesReadFromStorage() uint {
+	return 0
+}
+
+// TotalBytesWrittenToStorage always returns zero
+func (m *Meter) TotalBytesWrittenToStorage() uint {
+	return 0
+}
+
+// TotalBytesOfStorageInteractions always returns zero
+func (m *Meter) TotalBytesOfStorageInteractions() uint {
+	return 0
+}
@@ -251,12 +251,17 @@ var _ interfaceMeter.Meter = &Meter{}
 // Meter collects memory and computation usage and enforces limits
 // for any each memory/computation usage call it sums intensity multiplied by the weight of the intensity to the total
 // memory/computation usage metrics and returns error if limits are not met.
+// Bytes read from and written to storage are metered as the ComputationKindStorageRead and
+// ComputationKindStorageWrite computation intensities.
 type Meter struct {
 	computationUsed  uint64
 	computationLimit uint64
 	memoryUsed       uint64
 	memoryLimit      uint64
 
+	storageBytesRead    uint64
+	storageBytesWritten uint64
+
 	computationIntensities interfaceMeter.MeteredComputationIntensities
 	memoryIntensities      interfaceMeter.MeteredMemoryIntensities
 
@@ -343,6 +348,10 @@ func (m *Meter) MergeMeter(child interfaceMeter.Meter, enforceLimits bool) error
 	for key, intensity := range child.MemoryIntensities() {
 		m.memoryIntensities[key] += intensity
 	}
+
+	m.storageBytesRead += uint64(child.TotalBytesReadFromStorage())
+	m.storageBytesWritten += uint64(child.TotalBytesWrittenToStorage())
+
 	return nil
 }
 
@@ -413,3 +422,33 @@ func (m *Meter) TotalMemoryUsed() uint {
 func (m *Meter) TotalMemoryLimit() uint {
 	return uint(m.memoryLimit)
 }
+
+// MeterStorageRead captures the bytes read from storage and meters them as computation
+func (m *Meter) MeterStorageRead(byteSize uint) error {
+	m.storageBytesRead += uint64(byteSize)
+	return m.MeterComputation(interfaceMeter.ComputationKindStorageRead, byteSize)
+}
+
+// MeterStorageWrite captures the bytes written to storage and meters them as computation. The bytes of the
+// replaced write, and the computation they were metered as, are no longer counted.
+func (m *Meter) MeterStorageWrite(byteSize uint, replacedByteSize uint) error {
+	m.storageBytesWritten = m.storageBytesWritten - uint64(replacedByteSize) + uint64(byteSize)
+	m.computationIntensities[interfaceMeter.ComputationKindStorageWrite] -= replacedByteSize
+	m.computationUsed -= m.computationWeights[interfaceMeter.ComputationKindStorageWrite] * uint64(replacedByteSize)
+	return m.MeterComputation(interfaceMeter.ComputationKindStorageWrite, byteSize)
+}
+
+// TotalBytesReadFromStorage returns the total number of bytes read from storage
+func (m *Meter) TotalBytesReadFromStorage() uint {
+	return uint(m.storageBytesRead)
+}
+
+// TotalBytesWrittenToStorage returns the total number of bytes written to storage
+func (m *Meter) TotalBytesWrittenToStorage() uint {
+	return uint(m.storageBytesWritten)
+}
+
+// TotalBytesOfStorageInteractions returns the total number of bytes read from and written to storage
+func (m *Meter) TotalBytesOfStorageInteractions() uint {
+	return uint(m.storageBytesRead + m.storageBytesWritten)
+}
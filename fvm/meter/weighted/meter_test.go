@@ -9,6 +9,7 @@ import (
 	"github.com/onflow/cadence/runtime/common"
 
 	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/meter"
 	"github.com/onflow/flow-go/fvm/meter/weighted"
 )
 
@@ -172,6 +173,90 @@ func TestWeightedComputationMetering(t *testing.T) {
 		require.Equal(t, uint(1+1), m.ComputationIntensities()[compKind])
 	})
 
+	t.Run("meter storage", func(t *testing.T) {
+		m := weighted.NewMeter(
+			10,
+			0,
+			weighted.WithComputationWeights(map[common.ComputationKind]uint64{
+				meter.ComputationKindStorageRead:  1 << (weighted.MeterExecutionInternalPrecisionBytes - 1),
+				meter.ComputationKindStorageWrite: 1 << weighted.MeterExecutionInternalPrecisionBytes,
+			}),
+		)
+
+		err := m.MeterStorageRead(8)
+		require.NoError(t, err)
+		require.Equal(t, uint(8), m.TotalBytesReadFromStorage())
+		require.Equal(t, uint(8/2), m.TotalComputationUsed())
+
+		err = m.MeterStorageWrite(3, 0)
+		require.NoError(t, err)
+		require.Equal(t, uint(3), m.TotalBytesWrittenToStorage())
+		require.Equal(t, uint(8+3), m.TotalBytesOfStorageInteractions())
+		require.Equal(t, uint(8/2+3), m.TotalComputationUsed())
+		require.Equal(t, uint(8), m.ComputationIntensities()[meter.ComputationKindStorageRead])
+		require.Equal(t, uint(3), m.ComputationIntensities()[meter.ComputationKindStorageWrite])
+
+		err = m.MeterStorageWrite(4, 0)
+		require.Error(t, err)
+		require.True(t, errors.IsComputationLimitExceededError(err))
+	})
+
+	t.Run("meter storage - replaced writes", func(t *testing.T) {
+		m := weighted.NewMeter(
+			10,
+			0,
+			weighted.WithComputationWeights(map[common.ComputationKind]uint64{
+				meter.ComputationKindStorageWrite: 1 << weighted.MeterExecutionInternalPrecisionBytes,
+			}),
+		)
+
+		err := m.MeterStorageWrite(6, 0)
+		require.NoError(t, err)
+
+		// the replaced write is no longer counted, so the limit is not exceeded
+		err = m.MeterStorageWrite(8, 6)
+		require.NoError(t, err)
+		require.Equal(t, uint(8), m.TotalBytesWrittenToStorage())
+		require.Equal(t, uint(8), m.TotalComputationUsed())
+		require.Equal(t, uint(8), m.ComputationIntensities()[meter.ComputationKindStorageWrite])
+	})
+
+	t.Run("meter storage without weights", func(t *testing.T) {
+		m := weighted.NewMeter(1, 0)
+
+		err := m.MeterStorageRead(100)
+		require.NoError(t, err)
+		err = m.MeterStorageWrite(100, 0)
+		require.NoError(t, err)
+		require.Equal(t, uint(100+100), m.TotalBytesOfStorageInteractions())
+		require.Equal(t, uint(0), m.TotalComputationUsed())
+	})
+
+	t.Run("merge meters - storage", func(t *testing.T) {
+		m := weighted.NewMeter(
+			10,
+			0,
+			weighted.WithComputationWeights(map[common.ComputationKind]uint64{
+				meter.ComputationKindStorageRead: 1 << weighted.MeterExecutionInternalPrecisionBytes,
+			}),
+		)
+
+		err := m.MeterStorageRead(2)
+		require.NoError(t, err)
+
+		child := m.NewChild()
+		err = child.MeterStorageRead(3)
+		require.NoError(t, err)
+		err = child.MeterStorageWrite(5, 0)
+		require.NoError(t, err)
+
+		err = m.MergeMeter(child, true)
+		require.NoError(t, err)
+		require.Equal(t, uint(2+3), m.TotalBytesReadFromStorage())
+		require.Equal(t, uint(5), m.TotalBytesWrittenToStorage())
+		require.Equal(t, uint(2+3), m.TotalComputationUsed())
+	})
+
 	t.Run("merge meters - large values - computation", func(t *testing.T) {
 		m := weighted.NewMeter(
 			math.MaxUint32,
@@ -42,6 +42,10 @@ type Programs struct {
 	programs   map[common.LocationID]ProgramEntry
 	parentFunc ProgramGetFunc
 	cleaned    bool
+
+	// used are the contract programs returned or stored by this programs, including programs of the parent
+	usedLock sync.Mutex
+	used     map[common.LocationID]common.AddressLocation
 }
 
 func NewEmptyPrograms() *Programs {
@@ -70,6 +74,7 @@ func (p *Programs) Get(location common.Location) (*interpreter.Program, *state.S
 	programEntry, has := p.get(location)
 
 	if has {
+		p.use(location, programEntry.Program)
 		return programEntry.Program, programEntry.State, true
 	}
 
@@ -98,6 +103,64 @@ func (p *Programs) Set(location common.Location, program *interpreter.Program, s
 		Program:  program,
 		State:    state,
 	}
+	p.use(location, program)
+}
+
+// use records the use of the program of a contract. Programs which failed to load are not recorded.
+func (p *Programs) use(location common.Location, program *interpreter.Program) {
+	addressLocation, is := location.(common.AddressLocation)
+	if !is || program == nil {
+		return
+	}
+
+	p.recordUse(addressLocation)
+}
+
+func (p *Programs) recordUse(location common.AddressLocation) {
+	p.usedLock.Lock()
+	defer p.usedLock.Unlock()
+
+	if p.used == nil {
+		p.used = make(map[common.LocationID]common.AddressLocation)
+	}
+	p.used[location.ID()] = location
+}
+
+// Merge adds the contract programs stored in the given child to this programs.
+// It must only be called for children which were used to execute transactions
+// that did not update any contracts, since this programs is not cleaned up.
+func (p *Programs) Merge(child *Programs) {
+	child.lock.RLock()
+	defer child.lock.RUnlock()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for id, entry := range child.programs {
+		if _, is := entry.Location.(common.AddressLocation); is {
+			p.programs[id] = entry
+		}
+	}
+
+	child.usedLock.Lock()
+	defer child.usedLock.Unlock()
+
+	for _, location := range child.used {
+		p.recordUse(location)
+	}
+}
+
+// ContractLocations returns the locations of the contract programs used through this programs, both
+// the programs stored in it and the programs of the parent it returned.
+func (p *Programs) ContractLocations() []common.AddressLocation {
+	p.usedLock.Lock()
+	defer p.usedLock.Unlock()
+
+	locations := make([]common.AddressLocation, 0, len(p.used))
+	for _, location := range p.used {
+		locations = append(locations, location)
+	}
+	return locations
 }
 
 // HasChanges indicates if any changes has been introduced
@@ -142,6 +205,13 @@ func (p *Programs) Cleanup(changedContracts []ContractUpdateKey) {
 
 		// start with empty storage
 		p.programs = make(map[common.LocationID]ProgramEntry)
+
+		// the programs of the changed contracts used so far are outdated
+		p.usedLock.Lock()
+		for _, key := range changedContracts {
+			delete(p.used, common.AddressLocation{Address: common.Address(key.Address), Name: key.Name}.ID())
+		}
+		p.usedLock.Unlock()
 		return
 	}
 
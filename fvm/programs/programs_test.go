@@ -169,4 +169,50 @@ func Test_Programs(t *testing.T) {
 		require.True(t, child.HasChanges())
 	})
 
+	t.Run("merge", func(t *testing.T) {
+		parent := NewEmptyPrograms()
+
+		child := parent.ChildPrograms()
+		child.Set(someLocation, someProgram, newState)
+		child.Set(addressLocation, someProgram, newState)
+
+		parent.Merge(child)
+		require.True(t, parent.HasChanges())
+
+		// only contract programs are merged
+		retrieved, _, has := parent.Get(someLocation)
+		require.Nil(t, retrieved)
+		require.False(t, has)
+
+		retrieved, state, has := parent.Get(addressLocation)
+		require.Equal(t, someProgram, retrieved)
+		require.Equal(t, newState, state)
+		require.True(t, has)
+	})
+
+	t.Run("contract locations", func(t *testing.T) {
+		parent := NewEmptyPrograms()
+		parent.Set(common.AddressLocation{Address: addressLocation.Address, Name: "parent"}, someProgram, newState)
+
+		unused := common.AddressLocation{Address: addressLocation.Address, Name: "unused"}
+		parent.Set(unused, someProgram, newState)
+
+		child := parent.ChildPrograms()
+		child.Set(someLocation, someProgram, newState)
+		child.Set(addressLocation, someProgram, newState)
+		_, _, has := child.Get(common.AddressLocation{Address: addressLocation.Address, Name: "parent"})
+		require.True(t, has)
+
+		// contract programs stored in the child and returned from the parent are used by the child
+		require.ElementsMatch(t, []common.AddressLocation{
+			addressLocation,
+			{Address: addressLocation.Address, Name: "parent"},
+		}, child.ContractLocations())
+
+		// the uses of merged children are recorded
+		other := NewEmptyPrograms()
+		other.Merge(child)
+		require.ElementsMatch(t, child.ContractLocations(), other.ContractLocations())
+	})
+
 }
@@ -92,7 +92,9 @@ func NewScriptEnvironment(
 		},
 		func() []common.Address { return []common.Address{} },
 		func() []common.Address { return []common.Address{} },
-		func(address runtime.Address, code []byte) (bool, error) { return false, nil })
+		func(address runtime.Address, code []byte) (bool, error) { return false, nil },
+		// changes of scripts are discarded, so contract updates are not observed
+		nil)
 
 	if fvmContext.BlockHeader != nil {
 		env.seedRNG(fvmContext.BlockHeader)
@@ -29,7 +29,8 @@ const (
 	AccountNotFrozenValue = 0
 )
 
-func keyPublicKey(index uint64) string {
+// KeyPublicKey returns the register key of the account public key with the given index.
+func KeyPublicKey(index uint64) string {
 	return fmt.Sprintf("public_key_%d", index)
 }
 
@@ -178,7 +179,7 @@ func (a *StatefulAccounts) Create(publicKeys []flow.AccountPublicKey, newAddress
 }
 
 func (a *StatefulAccounts) GetPublicKey(address flow.Address, keyIndex uint64) (flow.AccountPublicKey, error) {
-	publicKey, err := a.getValue(address, true, keyPublicKey(keyIndex))
+	publicKey, err := a.getValue(address, true, KeyPublicKey(keyIndex))
 	if err != nil {
 		return flow.AccountPublicKey{}, err
 	}
@@ -254,7 +255,7 @@ func (a *StatefulAccounts) SetPublicKey(
 		return nil, errors.NewValueErrorf(string(encoded), "invalid public key value: %w", err)
 	}
 
-	err = a.setValue(address, true, keyPublicKey(keyIndex), encodedPublicKey)
+	err = a.setValue(address, true, KeyPublicKey(keyIndex), encodedPublicKey)
 
 	return encodedPublicKey, err
 }
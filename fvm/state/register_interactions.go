@@ -0,0 +1,79 @@
+package state
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountInteraction is the number and size of the registers of an account read and written
+// by a procedure. Sizes include the register ID, like the ledger interaction limit.
+type AccountInteraction struct {
+	RegistersRead    uint64
+	BytesRead        uint64
+	RegistersWritten uint64
+	BytesWritten     uint64
+}
+
+// TotalBytes returns the number of bytes read and written.
+func (a AccountInteraction) TotalBytes() uint64 {
+	return a.BytesRead + a.BytesWritten
+}
+
+func (a AccountInteraction) add(other AccountInteraction) AccountInteraction {
+	return AccountInteraction{
+		RegistersRead:    a.RegistersRead + other.RegistersRead,
+		BytesRead:        a.BytesRead + other.BytesRead,
+		RegistersWritten: a.RegistersWritten + other.RegistersWritten,
+		BytesWritten:     a.BytesWritten + other.BytesWritten,
+	}
+}
+
+// RegisterInteractions are the register interactions of a procedure by the account owning the registers.
+// Interactions with registers which are not owned by an account, such as the UUID counter,
+// are recorded for flow.EmptyAddress.
+type RegisterInteractions map[flow.Address]AccountInteraction
+
+// Total returns the interactions with the registers of all accounts.
+func (r RegisterInteractions) Total() AccountInteraction {
+	var total AccountInteraction
+	for _, interaction := range r {
+		total = total.add(interaction)
+	}
+	return total
+}
+
+// Copy returns a copy of the interactions.
+func (r RegisterInteractions) Copy() RegisterInteractions {
+	c := make(RegisterInteractions, len(r))
+	for address, interaction := range r {
+		c[address] = interaction
+	}
+	return c
+}
+
+func (r RegisterInteractions) read(owner string, size uint64) {
+	address, _ := addressFromOwner(owner)
+	interaction := r[address]
+	interaction.RegistersRead++
+	interaction.BytesRead += size
+	r[address] = interaction
+}
+
+// write records a register write. The previous write of the same register by the procedure, of the given
+// size, is replaced if overwrite is set.
+func (r RegisterInteractions) write(owner string, size uint64, overwrite bool, previousSize uint64) {
+	address, _ := addressFromOwner(owner)
+	interaction := r[address]
+	if overwrite {
+		interaction.RegistersWritten--
+		interaction.BytesWritten -= previousSize
+	}
+	interaction.RegistersWritten++
+	interaction.BytesWritten += size
+	r[address] = interaction
+}
+
+func (r RegisterInteractions) merge(other RegisterInteractions) {
+	for address, interaction := range other {
+		r[address] = r[address].add(interaction)
+	}
+}
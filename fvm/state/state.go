@@ -40,6 +40,7 @@ type State struct {
 	WriteCounter          uint64
 	TotalBytesRead        uint64
 	TotalBytesWritten     uint64
+	registerInteractions  RegisterInteractions
 }
 
 func defaultState(view View) *State {
@@ -51,6 +52,7 @@ func defaultState(view View) *State {
 		maxKeySizeAllowed:     DefaultMaxKeySize,
 		maxValueSizeAllowed:   DefaultMaxValueSize,
 		maxInteractionAllowed: DefaultMaxInteractionSize,
+		registerInteractions:  make(RegisterInteractions),
 	}
 }
 
@@ -110,6 +112,11 @@ func (s *State) InteractionUsed() uint64 {
 	return s.TotalBytesRead + s.TotalBytesWritten
 }
 
+// RegisterInteractions returns the register reads and writes by account
+func (s *State) RegisterInteractions() RegisterInteractions {
+	return s.registerInteractions.Copy()
+}
+
 // Get returns a register value given owner, controller and key
 func (s *State) Get(owner, controller, key string, enforceLimit bool) (flow.RegisterValue, error) {
 	var value []byte
@@ -130,9 +137,16 @@ func (s *State) Get(owner, controller, key string, enforceLimit bool) (flow.Regi
 
 	// if not part of recent updates count them as read
 	if _, ok := s.updateSize[mapKey{owner, controller, key}]; !ok {
+		readSize := uint64(len(owner) + len(controller) + len(key) + len(value))
 		s.ReadCounter++
-		s.TotalBytesRead += uint64(len(owner) +
-			len(controller) + len(key) + len(value))
+		s.TotalBytesRead += readSize
+		s.registerInteractions.read(owner, readSize)
+
+		// storage is metered even if limits are not enforced, so that the metered storage does not depend
+		// on the transaction which loaded a cached program
+		if err = s.meter.MeterStorageRead(uint(readSize)); err != nil && enforceLimit {
+			return nil, err
+		}
 	}
 
 	if enforceLimit {
@@ -168,7 +182,8 @@ func (s *State) Set(owner, controller, key string, value flow.RegisterValue, enf
 	}
 
 	mapKey := mapKey{owner, controller, key}
-	if old, ok := s.updateSize[mapKey]; ok {
+	old, overwrite := s.updateSize[mapKey]
+	if overwrite {
 		s.WriteCounter--
 		s.TotalBytesWritten -= old
 	}
@@ -177,6 +192,12 @@ func (s *State) Set(owner, controller, key string, value flow.RegisterValue, enf
 	s.WriteCounter++
 	s.TotalBytesWritten += updateSize
 	s.updateSize[mapKey] = updateSize
+	s.registerInteractions.write(owner, updateSize, overwrite, old)
+
+	// like the interactions, the metered bytes only count the latest write of the register
+	if err := s.meter.MeterStorageWrite(uint(updateSize), uint(old)); err != nil && enforceLimit {
+		return err
+	}
 
 	return nil
 }
@@ -231,6 +252,16 @@ func (s *State) TotalMemoryLimit() uint {
 	return s.meter.TotalMemoryLimit()
 }
 
+// TotalBytesReadFromStorage returns the bytes read from storage metered by the meter
+func (s *State) TotalBytesReadFromStorage() uint {
+	return s.meter.TotalBytesReadFromStorage()
+}
+
+// TotalBytesWrittenToStorage returns the bytes written to storage metered by the meter
+func (s *State) TotalBytesWrittenToStorage() uint {
+	return s.meter.TotalBytesWrittenToStorage()
+}
+
 // NewChild generates a new child state
 func (s *State) NewChild() *State {
 	return NewState(s.view.NewChild(),
@@ -268,6 +299,7 @@ func (s *State) MergeState(other *State, enforceLimit bool) error {
 	s.WriteCounter += other.WriteCounter
 	s.TotalBytesRead += other.TotalBytesRead
 	s.TotalBytesWritten += other.TotalBytesWritten
+	s.registerInteractions.merge(other.registerInteractions)
 
 	// check max interaction as last step
 	if enforceLimit {
@@ -8,8 +8,12 @@ import (
 
 	"github.com/onflow/atree"
 
+	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/meter"
+	"github.com/onflow/flow-go/fvm/meter/weighted"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/fvm/utils"
+	"github.com/onflow/flow-go/model/flow"
 )
 
 func TestState_ChildMergeFunctionality(t *testing.T) {
@@ -114,6 +118,127 @@ func TestState_InteractionMeasuring(t *testing.T) {
 	require.Equal(t, keySize, st.TotalBytesRead)
 }
 
+func TestState_StorageMetering(t *testing.T) {
+	view := utils.NewSimpleView()
+	m := weighted.NewMeter(
+		100,
+		0,
+		weighted.WithComputationWeights(weighted.ExecutionEffortWeights{
+			meter.ComputationKindStorageRead:  1 << weighted.MeterExecutionInternalPrecisionBytes,
+			meter.ComputationKindStorageWrite: 2 << weighted.MeterExecutionInternalPrecisionBytes,
+		}),
+	)
+	st := state.NewState(view, state.WithMeter(m))
+
+	// write of 4 bytes
+	err := st.Set("1", "2", "3", []byte{'A'}, true)
+	require.NoError(t, err)
+	require.Equal(t, uint(4), st.TotalBytesWrittenToStorage())
+	require.Equal(t, uint(4*2), st.TotalComputationUsed())
+
+	// read of a written register is not metered
+	_, err = st.Get("1", "2", "3", true)
+	require.NoError(t, err)
+	require.Equal(t, uint(0), st.TotalBytesReadFromStorage())
+
+	// read of 3 bytes
+	_, err = st.Get("2", "3", "4", true)
+	require.NoError(t, err)
+	require.Equal(t, uint(3), st.TotalBytesReadFromStorage())
+	require.Equal(t, uint(4*2+3), st.TotalComputationUsed())
+
+	// interactions exceeding the computation limit fail
+	err = st.Set("4", "5", "6", createByteArray(100), true)
+	require.Error(t, err)
+	require.True(t, errors.IsComputationLimitExceededError(err))
+
+	// interactions are metered but do not fail if limits are not enforced
+	_, err = st.Get("5", "6", "7", false)
+	require.NoError(t, err)
+	err = st.Set("5", "6", "7", []byte{'B'}, false)
+	require.NoError(t, err)
+	require.Equal(t, uint(3+3), st.TotalBytesReadFromStorage())
+	require.Equal(t, uint(4+103+4), st.TotalBytesWrittenToStorage())
+}
+
+func TestState_StorageMeteringOfOverwrites(t *testing.T) {
+	view := utils.NewSimpleView()
+	m := weighted.NewMeter(
+		100,
+		0,
+		weighted.WithComputationWeights(weighted.ExecutionEffortWeights{
+			meter.ComputationKindStorageWrite: 1 << weighted.MeterExecutionInternalPrecisionBytes,
+		}),
+	)
+	st := state.NewState(view, state.WithMeter(m))
+
+	// write of 4 bytes, then of 6 bytes to the same register, only the latest write counts
+	require.NoError(t, st.Set("1", "2", "3", []byte{'A'}, true))
+	require.NoError(t, st.Set("1", "2", "3", []byte{'A', 'B', 'C'}, true))
+	require.Equal(t, uint64(6), st.TotalBytesWritten)
+	require.Equal(t, uint(6), st.TotalBytesWrittenToStorage())
+	require.Equal(t, uint(6), st.TotalComputationUsed())
+	require.Equal(t, uint(6), st.ComputationIntensities()[meter.ComputationKindStorageWrite])
+
+	// overwriting a register with a smaller value reduces the written bytes
+	require.NoError(t, st.Set("1", "2", "3", []byte{}, true))
+	require.Equal(t, uint64(3), st.TotalBytesWritten)
+	require.Equal(t, uint(3), st.TotalBytesWrittenToStorage())
+	require.Equal(t, uint(3), st.TotalComputationUsed())
+
+	// overwrites are counted the same once merged
+	child := st.NewChild()
+	require.NoError(t, child.Set("4", "5", "6", []byte{'A'}, true))
+	require.NoError(t, st.MergeState(child, true))
+	require.NoError(t, st.Set("4", "5", "6", []byte{'A', 'B'}, true))
+	require.Equal(t, uint64(3+5), st.TotalBytesWritten)
+	require.Equal(t, uint(3+5), st.TotalBytesWrittenToStorage())
+	require.Equal(t, uint(3+5), st.TotalComputationUsed())
+}
+
+func TestState_RegisterInteractions(t *testing.T) {
+	view := utils.NewSimpleView()
+	st := state.NewState(view)
+
+	address := flow.HexToAddress("01")
+	owner := string(address.Bytes())
+	keySize := uint64(len(owner) + len(owner) + len("key"))
+
+	// write a register twice, only the last write counts
+	require.NoError(t, st.Set(owner, owner, "key", createByteArray(1), true))
+	require.NoError(t, st.Set(owner, owner, "key", createByteArray(5), true))
+
+	// registers which are not owned by an account are recorded for the empty address
+	_, err := st.Get("", "", "uuid", true)
+	require.NoError(t, err)
+
+	// reads and writes of a child are merged
+	child := st.NewChild()
+	_, err = child.Get(owner, "", "other", true)
+	require.NoError(t, err)
+	require.NoError(t, st.MergeState(child, true))
+
+	interactions := st.RegisterInteractions()
+	require.Len(t, interactions, 2)
+	require.Equal(t, state.AccountInteraction{
+		RegistersRead:    1,
+		BytesRead:        uint64(len(owner) + len("other")),
+		RegistersWritten: 1,
+		BytesWritten:     keySize + 5,
+	}, interactions[address])
+	require.Equal(t, state.AccountInteraction{
+		RegistersRead: 1,
+		BytesRead:     uint64(len("uuid")),
+	}, interactions[flow.EmptyAddress])
+
+	total := interactions.Total()
+	require.Equal(t, st.ReadCounter, total.RegistersRead)
+	require.Equal(t, st.TotalBytesRead, total.BytesRead)
+	require.Equal(t, st.WriteCounter, total.RegistersWritten)
+	require.Equal(t, st.TotalBytesWritten, total.BytesWritten)
+	require.Equal(t, st.InteractionUsed(), total.TotalBytes())
+}
+
 func TestState_MaxValueSize(t *testing.T) {
 	view := utils.NewSimpleView()
 	st := state.NewState(view, state.WithMaxValueSizeAllowed(6))
@@ -8,6 +8,7 @@ import (
 	"github.com/opentracing/opentracing-go"
 
 	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/meter"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -34,9 +35,20 @@ type TransactionProcedure struct {
 	ServiceEvents   []flow.Event
 	ComputationUsed uint64
 	MemoryUsed      uint64
-	Err             errors.Error
-	Retried         int
-	TraceSpan       opentracing.Span
+	// ComputationIntensities and MemoryIntensities are the metered intensities of the transaction
+	// itself, excluding fee deduction and storage limit checks.
+	ComputationIntensities meter.MeteredComputationIntensities
+	MemoryIntensities      meter.MeteredMemoryIntensities
+	Err                    errors.Error
+	Retried                int
+	TraceSpan              opentracing.Span
+	// RegisterInteractions are the register reads and writes of the transaction by account,
+	// including the ones of signature verification, sequence number checks and fee deduction.
+	RegisterInteractions state.RegisterInteractions
+	// StorageBytesRead and StorageBytesWritten are the metered bytes read from and written to storage
+	// by the transaction itself, excluding fee deduction and storage limit checks.
+	StorageBytesRead    uint64
+	StorageBytesWritten uint64
 }
 
 func (proc *TransactionProcedure) SetTraceSpan(traceSpan opentracing.Span) {
@@ -78,6 +90,8 @@ func (proc *TransactionProcedure) Run(vm *VirtualMachine, ctx Context, st *state
 		}
 	}
 
+	proc.RegisterInteractions = st.State().RegisterInteractions()
+
 	return nil
 }
 
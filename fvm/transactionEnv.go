@@ -109,6 +109,7 @@ func NewTransactionEnvironment(
 		env.GetAccountsAuthorizedForContractUpdate,
 		env.GetAccountsAuthorizedForContractRemoval,
 		env.useContractAuditVoucher,
+		ctx.ContractUpdateObserver,
 	)
 
 	if ctx.BlockHeader != nil {
@@ -18,6 +18,7 @@ import (
 
 	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/extralog"
+	"github.com/onflow/flow-go/fvm/meter"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -166,6 +167,10 @@ func (i *TransactionInvoker) Process(
 	// read computationUsed from the environment. This will be used to charge fees.
 	computationUsed := env.ComputationUsed()
 	memoryUsed := env.MemoryUsed()
+	storageBytesRead := uint64(sth.State().TotalBytesReadFromStorage())
+	storageBytesWritten := uint64(sth.State().TotalBytesWrittenToStorage())
+	computationIntensities := copyComputationIntensities(sth.State().ComputationIntensities())
+	memoryIntensities := copyMemoryIntensities(sth.State().MemoryIntensities())
 
 	// log te execution intensities here, so tha they do not contain data from storage limit checks and
 	// transaction deduction, because the payer is not charged for those.
@@ -248,6 +253,10 @@ func (i *TransactionInvoker) Process(
 	proc.Logs = append(proc.Logs, env.Logs()...)
 	proc.ComputationUsed = proc.ComputationUsed + computationUsed
 	proc.MemoryUsed = proc.MemoryUsed + memoryUsed
+	proc.ComputationIntensities = computationIntensities
+	proc.MemoryIntensities = memoryIntensities
+	proc.StorageBytesRead = proc.StorageBytesRead + storageBytesRead
+	proc.StorageBytesWritten = proc.StorageBytesWritten + storageBytesWritten
 
 	// based on the contract updates we decide how to clean up the programs
 	// for failed transactions we also do the same as
@@ -261,6 +270,10 @@ func (i *TransactionInvoker) Process(
 	return txError
 }
 
+// InclusionEffort is the inclusion effort charged for every transaction, hardcoded to 1.0 UFix64.
+// Eventually this will be dynamic. Execution effort is connected to the computation used.
+const InclusionEffort = uint64(100_000_000)
+
 func (i *TransactionInvoker) deductTransactionFees(
 	env *TransactionEnv,
 	proc *TransactionProcedure,
@@ -275,10 +288,7 @@ func (i *TransactionInvoker) deductTransactionFees(
 	}
 
 	deductTxFees := DeductTransactionFeesInvocation(env, proc.TraceSpan)
-	// Hardcoded inclusion effort (of 1.0 UFix). Eventually this will be dynamic.
-	// Execution effort will be connected to computation used.
-	inclusionEffort := uint64(100_000_000)
-	_, err = deductTxFees(proc.Transaction.Payer, inclusionEffort, computationUsed)
+	_, err = deductTxFees(proc.Transaction.Payer, InclusionEffort, computationUsed)
 
 	if err != nil {
 		return errors.NewTransactionFeeDeductionFailedError(proc.Transaction.Payer, err)
@@ -439,6 +449,24 @@ func (i *TransactionInvoker) dumpRuntimeError(runtimeErr *runtime.Error, procedu
 }
 
 // logExecutionIntensities logs execution intensities of the transaction
+// copyComputationIntensities copies the intensities, as the meter keeps updating them during
+// fee deduction and storage limit checks.
+func copyComputationIntensities(intensities meter.MeteredComputationIntensities) meter.MeteredComputationIntensities {
+	c := make(meter.MeteredComputationIntensities, len(intensities))
+	for kind, intensity := range intensities {
+		c[kind] = intensity
+	}
+	return c
+}
+
+func copyMemoryIntensities(intensities meter.MeteredMemoryIntensities) meter.MeteredMemoryIntensities {
+	c := make(meter.MeteredMemoryIntensities, len(intensities))
+	for kind, intensity := range intensities {
+		c[kind] = intensity
+	}
+	return c
+}
+
 func (i *TransactionInvoker) logExecutionIntensities(sth *state.StateHolder, txHash string) {
 	if i.logger.Debug().Enabled() {
 		computation := zerolog.Dict()
@@ -454,6 +482,8 @@ func (i *TransactionInvoker) logExecutionIntensities(sth *state.StateHolder, txH
 			Uint64("ledgerInteractionUsed", sth.State().InteractionUsed()).
 			Uint("computationUsed", sth.State().TotalComputationUsed()).
 			Uint("memoryUsed", sth.State().TotalMemoryUsed()).
+			Uint("storageBytesRead", sth.State().TotalBytesReadFromStorage()).
+			Uint("storageBytesWritten", sth.State().TotalBytesWrittenToStorage()).
 			Dict("computationIntensities", computation).
 			Dict("memoryIntensities", memory).
 			Msg("transaction execution data")
@@ -0,0 +1,13 @@
+package client
+
+import (
+	"fmt"
+
+	restclient "github.com/onflow/flow-go/engine/access/rest/client"
+)
+
+// NewAccessRESTClient initializes a client of the REST API of the access node listening on the
+// given address as host:port. The client is generated from the OpenAPI spec served by the node.
+func NewAccessRESTClient(addr string) (*restclient.ClientWithResponses, error) {
+	return restclient.NewClientWithResponses(fmt.Sprintf("http://%s/v1", addr))
+}
@@ -43,3 +43,20 @@ func ClearBit(b []byte, i int) {
 func MakeBitVector(numberBits int) []byte {
 	return make([]byte, (numberBits+7)>>3)
 }
+
+// HasPrefix returns true if the first `bitLen` bits of the byte slices `b` and `prefix`
+// are equal. The function panics, if a byte slice is too short.
+func HasPrefix(b []byte, prefix []byte, bitLen int) bool {
+	byteLen := bitLen >> 3
+	for i := 0; i < byteLen; i++ {
+		if b[i] != prefix[i] {
+			return false
+		}
+	}
+	bitLen &= 7
+	if bitLen == 0 {
+		return true
+	}
+	mask := byte(0xff << (8 - bitLen))
+	return b[byteLen]&mask == prefix[byteLen]&mask
+}
@@ -100,4 +100,18 @@ func TestBitTools(t *testing.T) {
 		randomBig.SetBytes(bytes)
 		assert.Equal(t, 0, randomBig.Cmp(&b))
 	})
+
+	t.Run("testing HasPrefix", func(t *testing.T) {
+		bytes := MakeBitVector(maxBits)
+		rand.Read(bytes)
+		prefix := make([]byte, len(bytes))
+		copy(prefix, bytes)
+
+		// flip a random bit: the slices have a common prefix of exactly `idx` bits
+		idx := rand.Intn(maxBits)
+		WriteBit(prefix, idx, 1-ReadBit(bytes, idx))
+		for bitLen := 0; bitLen <= maxBits; bitLen++ {
+			assert.Equal(t, bitLen <= idx, HasPrefix(bytes, prefix, bitLen), "prefix of %d bits", bitLen)
+		}
+	})
 }
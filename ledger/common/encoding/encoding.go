@@ -21,6 +21,7 @@ const (
 	TrieUpdateVersion     = uint16(0) // Use payload version 0 encoding
 	TrieProofVersion      = uint16(0) // Use payload version 0 encoding
 	TrieBatchProofVersion = uint16(0) // Use payload version 0 encoding
+	TrieRangeProofVersion = uint16(0) // Use payload version 0 encoding
 )
 
 // Type capture the type of encoded entity (e.g. State, Key, Value, Path)
@@ -52,12 +53,15 @@ const (
 	TypeUpdate
 	// TypeTrieUpdate - type for trie update
 	TypeTrieUpdate
+	// TypeRangeProof - type for RangeProofs
+	// (all data needed to verify the registers in a path range at specific state)
+	TypeRangeProof
 	// this is used to flag types from the future
 	typeUnsuported
 )
 
 func (e Type) String() string {
-	return [...]string{"Unknown", "State", "KeyPart", "Key", "Value", "Path", "Payload", "Proof", "BatchProof", "Query", "Update", "Trie Update"}[e]
+	return [...]string{"Unknown", "State", "KeyPart", "Key", "Value", "Path", "Payload", "Proof", "BatchProof", "Query", "Update", "Trie Update", "RangeProof"}[e]
 }
 
 // CheckVersion extracts encoding bytes from a raw encoded message
@@ -871,3 +875,182 @@ func decodeTrieBatchProof(inp []byte, version uint16) (*ledger.TrieBatchProof, e
 	}
 	return bp, nil
 }
+
+// EncodeTrieRangeProof encodes a range proof into a byte slice
+func EncodeTrieRangeProof(p *ledger.TrieRangeProof) []byte {
+	if p == nil {
+		return []byte{}
+	}
+	// encode version
+	buffer := utils.AppendUint16([]byte{}, TrieRangeProofVersion)
+
+	// encode range proof entity type
+	buffer = utils.AppendUint8(buffer, TypeRangeProof)
+
+	// append encoded range proof content
+	buffer = append(buffer, encodeTrieRangeProof(p, TrieRangeProofVersion)...)
+
+	return buffer
+}
+
+func encodeTrieRangeProof(p *ledger.TrieRangeProof, version uint16) []byte {
+	buffer := make([]byte, 0)
+
+	// include start and end path size and content
+	buffer = utils.AppendUint16(buffer, uint16(ledger.PathLen))
+	buffer = append(buffer, p.StartPath[:]...)
+	buffer = utils.AppendUint16(buffer, uint16(ledger.PathLen))
+	buffer = append(buffer, p.EndPath[:]...)
+
+	// include number of registers, and the path and encoded payload of each register
+	buffer = utils.AppendUint32(buffer, uint32(len(p.Paths)))
+	for i, path := range p.Paths {
+		buffer = utils.AppendUint16(buffer, uint16(ledger.PathLen))
+		buffer = append(buffer, path[:]...)
+
+		encPayload := encodePayload(p.Payloads[i], version)
+		buffer = utils.AppendUint64(buffer, uint64(len(encPayload)))
+		buffer = append(buffer, encPayload...)
+	}
+
+	// include flags size and content
+	buffer = utils.AppendUint16(buffer, uint16(len(p.Flags)))
+	buffer = append(buffer, p.Flags...)
+
+	// and finally include all interims (hash values)
+	// number of interims
+	buffer = utils.AppendUint16(buffer, uint16(len(p.Interims)))
+	for _, inter := range p.Interims {
+		buffer = utils.AppendUint16(buffer, uint16(len(inter)))
+		buffer = append(buffer, inter[:]...)
+	}
+
+	return buffer
+}
+
+// DecodeTrieRangeProof constructs a range proof from an encoded byte slice
+func DecodeTrieRangeProof(encodedRangeProof []byte) (*ledger.TrieRangeProof, error) {
+	// check the enc dec version
+	rest, version, err := CheckVersion(encodedRangeProof, TrieRangeProofVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding range proof: %w", err)
+	}
+	// check the encoding type
+	rest, err = CheckType(rest, TypeRangeProof)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding range proof: %w", err)
+	}
+
+	// decode the range proof content
+	p, err := decodeTrieRangeProof(rest, version)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding range proof: %w", err)
+	}
+	return p, nil
+}
+
+func decodeTrieRangeProof(inp []byte, version uint16) (*ledger.TrieRangeProof, error) {
+	// read start and end path
+	start, rest, err := decodePathWithSize(inp)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+	}
+	end, rest, err := decodePathWithSize(rest)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+	}
+	p := ledger.NewTrieRangeProof(start, end)
+
+	// read registers
+	numOfRegisters, rest, err := utils.ReadUint32(rest)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+	}
+
+	for i := 0; i < int(numOfRegisters); i++ {
+		var path ledger.Path
+		path, rest, err = decodePathWithSize(rest)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+		}
+
+		var encPayloadSize uint64
+		encPayloadSize, rest, err = utils.ReadUint64(rest)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+		}
+
+		var encPayload []byte
+		encPayload, rest, err = utils.ReadSlice(rest, int(encPayloadSize))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+		}
+
+		// Decode payload (zerocopy)
+		payload, err := decodePayload(encPayload, true, version)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+		}
+
+		p.Paths = append(p.Paths, path)
+		p.Payloads = append(p.Payloads, payload)
+	}
+
+	// read flags
+	flagsSize, rest, err := utils.ReadUint16(rest)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+	}
+	flags, rest, err := utils.ReadSlice(rest, int(flagsSize))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+	}
+	p.Flags = flags
+
+	// read interims
+	interimsLen, rest, err := utils.ReadUint16(rest)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+	}
+
+	interims := make([]hash.Hash, interimsLen)
+
+	var interimSize uint16
+	var interimBytes []byte
+
+	for i := 0; i < int(interimsLen); i++ {
+		interimSize, rest, err = utils.ReadUint16(rest)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+		}
+
+		interimBytes, rest, err = utils.ReadSlice(rest, int(interimSize))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+		}
+		interims[i], err = hash.ToHash(interimBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding range proof (content): %w", err)
+		}
+	}
+	p.Interims = interims
+
+	return p, nil
+}
+
+// decodePathWithSize reads a path preceded by its size
+func decodePathWithSize(inp []byte) (ledger.Path, []byte, error) {
+	pathSize, rest, err := utils.ReadUint16(inp)
+	if err != nil {
+		return ledger.DummyPath, rest, err
+	}
+	pathBytes, rest, err := utils.ReadSlice(rest, int(pathSize))
+	if err != nil {
+		return ledger.DummyPath, rest, err
+	}
+	path, err := ledger.ToPath(pathBytes)
+	if err != nil {
+		return ledger.DummyPath, rest, err
+	}
+	return path, rest, nil
+}
@@ -617,3 +617,78 @@ func TestTrieUpdateSerialization(t *testing.T) {
 		require.True(t, decodedtu.Equals(tu))
 	})
 }
+
+// TestTrieRangeProofSerialization tests encoding and decoding functionality of a range proof
+func TestTrieRangeProofSerialization(t *testing.T) {
+	interim1Bytes, _ := hex.DecodeString("accb0399dd2b3a7a48618b2376f5e61d822e0c7736b044c364a05c2904a2f315")
+
+	var interim1 hash.Hash
+	copy(interim1[:], interim1Bytes)
+
+	p := &ledger.TrieRangeProof{
+		StartPath: utils.PathByUint16(1),
+		EndPath:   utils.PathByUint16(3),
+		Paths:     []ledger.Path{utils.PathByUint16(2)},
+		Payloads:  []*ledger.Payload{utils.LightPayload8('A', 'A')},
+		Interims:  []hash.Hash{interim1},
+		Flags:     []byte{byte(128)},
+	}
+
+	encodedV0 := []byte{
+		0x00, 0x00, // version 0
+		0x0c,       // type
+		0x00, 0x20, // length of path
+		0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // start path
+		0x00, 0x20, // length of path
+		0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // end path
+		0x00, 0x00, 0x00, 0x01, // number of registers
+		0x00, 0x20, // length of path
+		0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // path
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x16, // length of encoded payload
+		0x00, 0x00, 0x00, 0x09, // length of encoded payload key
+		0x00, 0x01, // number of payload key parts
+		0x00, 0x00, 0x00, 0x03, // length of encoded payload key part 0
+		0x00, 0x00, // payload key part type
+		0x41,                                           // payload key part value
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, // length of encoded payload value
+		0x41,       // payload value
+		0x00, 0x01, // length of flags
+		0x80,       // flags
+		0x00, 0x01, // number of interims
+		0x00, 0x20, // length of encoded interim
+		0xac, 0xcb, 0x03, 0x99, 0xdd, 0x2b, 0x3a, 0x7a,
+		0x48, 0x61, 0x8b, 0x23, 0x76, 0xf5, 0xe6, 0x1d,
+		0x82, 0x2e, 0x0c, 0x77, 0x36, 0xb0, 0x44, 0xc3,
+		0x64, 0xa0, 0x5c, 0x29, 0x04, 0xa2, 0xf3, 0x15, // interim
+	}
+
+	t.Run("encoding", func(t *testing.T) {
+		encoded := encoding.EncodeTrieRangeProof(p)
+		require.Equal(t, encodedV0, encoded)
+	})
+
+	t.Run("decoding", func(t *testing.T) {
+		decodedp, err := encoding.DecodeTrieRangeProof(encodedV0)
+		require.NoError(t, err)
+		require.True(t, decodedp.Equals(p))
+	})
+
+	t.Run("roundtrip", func(t *testing.T) {
+		empty := ledger.NewTrieRangeProof(utils.PathByUint16(1), utils.PathByUint16(3))
+		for _, p := range []*ledger.TrieRangeProof{p, empty} {
+			encoded := encoding.EncodeTrieRangeProof(p)
+			newp, err := encoding.DecodeTrieRangeProof(encoded)
+			require.NoError(t, err)
+			require.True(t, newp.Equals(p))
+		}
+	})
+}
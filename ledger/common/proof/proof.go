@@ -1,6 +1,9 @@
 package proof
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/bitutils"
 	"github.com/onflow/flow-go/ledger/common/hash"
@@ -59,3 +62,141 @@ func VerifyTrieBatchProof(bp *ledger.TrieBatchProof, expectedState ledger.State)
 	}
 	return true
 }
+
+// VerifyTrieRangeProof verifies the range proof, by constructing the hash of the root
+// from the registers in the range and the sub-tries next to the range, and comparing
+// it with the expected state. A valid proof proves that the registers of the proof are
+// all the allocated registers in the range.
+func VerifyTrieRangeProof(p *ledger.TrieRangeProof, expectedState ledger.State) bool {
+	if bytes.Compare(p.StartPath[:], p.EndPath[:]) > 0 {
+		return false
+	}
+	if len(p.Paths) != len(p.Payloads) {
+		return false
+	}
+	// the registers must be allocated, within the range and in ascending order
+	for i, path := range p.Paths {
+		if p.Payloads[i].IsEmpty() {
+			return false
+		}
+		if bytes.Compare(path[:], p.StartPath[:]) < 0 || bytes.Compare(path[:], p.EndPath[:]) > 0 {
+			return false
+		}
+		if i > 0 && bytes.Compare(p.Paths[i-1][:], path[:]) >= 0 {
+			return false
+		}
+	}
+
+	v := &rangeVerifier{proof: p}
+	computed, ok := v.compute(ledger.NodeMaxHeight, ledger.Path{}, true, true)
+	if !ok {
+		return false
+	}
+
+	// all the registers, interims and flags of the proof must be used
+	if v.registers != len(p.Paths) || v.interims != len(p.Interims) {
+		return false
+	}
+	if len(p.Flags) != len(bitutils.MakeBitVector(v.siblings)) {
+		return false
+	}
+	for i := v.siblings; i < len(p.Flags)*8; i++ {
+		if bitutils.ReadBit(p.Flags, i) == 1 {
+			return false
+		}
+	}
+
+	return computed == hash.Hash(expectedState)
+}
+
+// rangeVerifier computes the hash of the root from a range proof, walking the trie down
+// along the start and the end path of the range like the prover.
+type rangeVerifier struct {
+	proof     *ledger.TrieRangeProof
+	registers int // number of registers of the proof used
+	interims  int // number of interims of the proof used
+	siblings  int // number of sub-tries next to the range
+}
+
+// compute returns the hash of the sub-trie of the given height whose paths start with the given prefix.
+// onStart (onEnd) is true if the prefix is a prefix of the start (end) path of the range. It returns
+// false if the proof is missing a sub-trie next to the range.
+func (v *rangeVerifier) compute(height int, prefix ledger.Path, onStart, onEnd bool) (hash.Hash, bool) {
+	depth := ledger.NodeMaxHeight - height
+
+	// the sub-trie is within the range, its registers are the next registers of the proof with the prefix
+	if height == 0 || (!onStart && !onEnd) {
+		first := v.registers
+		for v.registers < len(v.proof.Paths) && bitutils.HasPrefix(v.proof.Paths[v.registers][:], prefix[:], depth) {
+			v.registers++
+		}
+		return registersHash(v.proof.Paths[first:v.registers], v.proof.Payloads[first:v.registers], height), true
+	}
+
+	lPrefix, rPrefix := prefix, prefix
+	bitutils.SetBit(rPrefix[:], depth)
+
+	startBit := bitutils.ReadBit(v.proof.StartPath[:], depth)
+	endBit := bitutils.ReadBit(v.proof.EndPath[:], depth)
+
+	var left, right hash.Hash
+	var ok bool
+	if onStart && startBit == 1 {
+		left, ok = v.sibling(height - 1)
+	} else {
+		left, ok = v.compute(height-1, lPrefix, onStart, onEnd && endBit == 0)
+	}
+	if !ok {
+		return hash.DummyHash, false
+	}
+
+	if onEnd && endBit == 0 {
+		right, ok = v.sibling(height - 1)
+	} else {
+		right, ok = v.compute(height-1, rPrefix, onStart && startBit == 1, onEnd)
+	}
+	if !ok {
+		return hash.DummyHash, false
+	}
+
+	return hash.HashInterNode(left, right), true
+}
+
+// sibling returns the hash of the next sub-trie next to the range, of the given height.
+func (v *rangeVerifier) sibling(height int) (hash.Hash, bool) {
+	index := v.siblings
+	v.siblings++
+	if index>>3 >= len(v.proof.Flags) {
+		return hash.DummyHash, false
+	}
+
+	// if flag is set, the hash is stored in the proof, otherwise it is a default hash
+	if bitutils.ReadBit(v.proof.Flags, index) == 0 {
+		return ledger.GetDefaultHashForHeight(height), true
+	}
+	if v.interims >= len(v.proof.Interims) {
+		return hash.DummyHash, false
+	}
+	h := v.proof.Interims[v.interims]
+	v.interims++
+	return h, true
+}
+
+// registersHash returns the hash of the sub-trie of the given height holding the given registers,
+// which have distinct paths in ascending order sharing the prefix of the sub-trie.
+func registersHash(paths []ledger.Path, payloads []*ledger.Payload, height int) hash.Hash {
+	switch len(paths) {
+	case 0:
+		return ledger.GetDefaultHashForHeight(height)
+	case 1:
+		return ledger.ComputeCompactValue(hash.Hash(paths[0]), payloads[0].Value, height)
+	}
+
+	depth := ledger.NodeMaxHeight - height
+	split := sort.Search(len(paths), func(i int) bool {
+		return bitutils.ReadBit(paths[i][:], depth) == 1
+	})
+	left := registersHash(paths[:split], payloads[:split], height-1)
+	right := registersHash(paths[split:], payloads[split:], height-1)
+	return hash.HashInterNode(left, right)
+}
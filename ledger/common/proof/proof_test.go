@@ -1,12 +1,16 @@
 package proof_test
 
 import (
+	"bytes"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/proof"
 	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 )
 
 // Test_ProofVerify tests proof verification
@@ -20,3 +24,197 @@ func Test_TrieBatchProofVerify(t *testing.T) {
 	bp, sc := utils.TrieBatchProofFixture()
 	require.True(t, proof.VerifyTrieBatchProof(bp, sc))
 }
+
+// rangeProofFixture returns a trie with the given number of random registers, and the payloads of the registers by path.
+func rangeProofFixture(t *testing.T, n int) (*trie.MTrie, map[ledger.Path]*ledger.Payload) {
+	paths := utils.RandomPaths(n)
+	payloads := make([]ledger.Payload, n)
+	registers := make(map[ledger.Path]*ledger.Payload, n)
+	for i, path := range paths {
+		payload := utils.RandomPayload(1, 10)
+		payloads[i] = *payload
+		registers[path] = payload
+	}
+
+	tr, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+	return tr, registers
+}
+
+// requireRangeProof requires the range proof of the given range to be valid, and to include exactly the
+// registers in the range.
+func requireRangeProof(t *testing.T, tr *trie.MTrie, registers map[ledger.Path]*ledger.Payload, start, end ledger.Path) *ledger.TrieRangeProof {
+	p, err := tr.RangeProof(start, end)
+	require.NoError(t, err)
+	require.True(t, proof.VerifyTrieRangeProof(p, ledger.State(tr.RootHash())))
+
+	var expected []ledger.Path
+	for path := range registers {
+		if bytes.Compare(path[:], start[:]) >= 0 && bytes.Compare(path[:], end[:]) <= 0 {
+			expected = append(expected, path)
+		}
+	}
+	sort.Slice(expected, func(i, j int) bool {
+		return bytes.Compare(expected[i][:], expected[j][:]) < 0
+	})
+
+	require.Equal(t, len(expected), len(p.Paths))
+	for i, path := range expected {
+		require.Equal(t, path, p.Paths[i])
+		require.True(t, registers[path].Equals(p.Payloads[i]))
+	}
+	return p
+}
+
+// Test_TrieRangeProofVerify tests range proof verification
+func Test_TrieRangeProofVerify(t *testing.T) {
+	tr, registers := rangeProofFixture(t, 200)
+
+	var min, max ledger.Path
+	for i := range max {
+		max[i] = 0xff
+	}
+
+	t.Run("random ranges", func(t *testing.T) {
+		for i := 0; i < 50; i++ {
+			paths := utils.RandomPaths(2)
+			start, end := paths[0], paths[1]
+			if bytes.Compare(start[:], end[:]) > 0 {
+				start, end = end, start
+			}
+			requireRangeProof(t, tr, registers, start, end)
+		}
+	})
+
+	t.Run("ranges bounded by registers", func(t *testing.T) {
+		paths := make([]ledger.Path, 0, len(registers))
+		for path := range registers {
+			paths = append(paths, path)
+		}
+		sort.Slice(paths, func(i, j int) bool {
+			return bytes.Compare(paths[i][:], paths[j][:]) < 0
+		})
+
+		requireRangeProof(t, tr, registers, paths[10], paths[20])
+		p := requireRangeProof(t, tr, registers, paths[5], paths[5])
+		require.Len(t, p.Paths, 1)
+	})
+
+	t.Run("full range", func(t *testing.T) {
+		p := requireRangeProof(t, tr, registers, min, max)
+		require.Len(t, p.Paths, len(registers))
+		require.Empty(t, p.Interims)
+	})
+
+	t.Run("prefix without registers", func(t *testing.T) {
+		// with 200 random registers, most prefixes of 16 bits have no register
+		for prefix := uint16(0); ; prefix++ {
+			start, end := ledger.PrefixRange(utils.PathByUint16(prefix), 16)
+			p, err := tr.RangeProof(start, end)
+			require.NoError(t, err)
+			if len(p.Paths) > 0 {
+				continue
+			}
+
+			requireRangeProof(t, tr, registers, start, end)
+			break
+		}
+	})
+
+	t.Run("empty trie", func(t *testing.T) {
+		empty := trie.NewEmptyMTrie()
+		requireRangeProof(t, empty, nil, min, max)
+		requireRangeProof(t, empty, nil, utils.PathByUint16(1), utils.PathByUint16(2))
+	})
+
+	t.Run("unallocated registers", func(t *testing.T) {
+		removed := make(map[ledger.Path]*ledger.Payload)
+		var paths []ledger.Path
+		var payloads []ledger.Payload
+		for path, payload := range registers {
+			if len(paths) < 50 {
+				paths = append(paths, path)
+				payloads = append(payloads, *ledger.EmptyPayload())
+				continue
+			}
+			removed[path] = payload
+		}
+
+		// the unallocated registers are kept in the trie without pruning
+		updated, _, err := trie.NewTrieWithUpdatedRegisters(tr, paths, payloads, false)
+		require.NoError(t, err)
+
+		requireRangeProof(t, updated, removed, min, max)
+		for i := 0; i < 20; i++ {
+			start, end := ledger.PrefixRange(utils.RandomPaths(1)[0], 4)
+			requireRangeProof(t, updated, removed, start, end)
+		}
+	})
+
+	t.Run("invalid proofs", func(t *testing.T) {
+		start, end := ledger.PrefixRange(utils.PathByUint16(0x4000), 2)
+		state := ledger.State(tr.RootHash())
+		valid := requireRangeProof(t, tr, registers, start, end)
+		require.NotEmpty(t, valid.Paths)
+		require.NotEmpty(t, valid.Interims)
+
+		proofFor := func(start, end ledger.Path) *ledger.TrieRangeProof {
+			p, err := tr.RangeProof(start, end)
+			require.NoError(t, err)
+			return p
+		}
+
+		// wrong state
+		require.False(t, proof.VerifyTrieRangeProof(valid, ledger.State(utils.RootHashFixture())))
+
+		// missing register
+		p := proofFor(start, end)
+		p.Paths = p.Paths[1:]
+		p.Payloads = p.Payloads[1:]
+		require.False(t, proof.VerifyTrieRangeProof(p, state))
+
+		// modified register
+		p = proofFor(start, end)
+		p.Payloads[0] = utils.RandomPayload(1, 10)
+		require.False(t, proof.VerifyTrieRangeProof(p, state))
+
+		// unallocated register
+		p = proofFor(start, end)
+		p.Payloads[0] = ledger.EmptyPayload()
+		require.False(t, proof.VerifyTrieRangeProof(p, state))
+
+		// registers out of order
+		p = proofFor(start, end)
+		p.Paths[0], p.Paths[1] = p.Paths[1], p.Paths[0]
+		p.Payloads[0], p.Payloads[1] = p.Payloads[1], p.Payloads[0]
+		require.False(t, proof.VerifyTrieRangeProof(p, state))
+
+		// smaller range
+		p = proofFor(start, end)
+		p.EndPath = p.Paths[len(p.Paths)-1]
+		require.False(t, proof.VerifyTrieRangeProof(p, state))
+
+		// larger range
+		p = proofFor(start, end)
+		p.EndPath[0] = 0xff
+		require.False(t, proof.VerifyTrieRangeProof(p, state))
+
+		// missing interim
+		p = proofFor(start, end)
+		p.Interims = p.Interims[1:]
+		require.False(t, proof.VerifyTrieRangeProof(p, state))
+
+		// additional flag
+		p = proofFor(start, end)
+		p.Flags = append(p.Flags, 0)
+		require.False(t, proof.VerifyTrieRangeProof(p, state))
+
+		// invalid range
+		p = proofFor(start, end)
+		p.StartPath, p.EndPath = p.EndPath, p.StartPath
+		require.False(t, proof.VerifyTrieRangeProof(p, state))
+
+		_, err := tr.RangeProof(end, start)
+		require.Error(t, err)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -338,6 +339,127 @@ func benchmarkNewCheckpointRandomData(b *testing.B, segmentCount int) {
 	b.ReportAllocs()
 }
 
+// checkpointWorkers are the numbers of workers writing partitioned checkpoints in the benchmarks
+// comparing them with single file checkpoints.
+var checkpointWorkers = []int{1, 2, 4, 8, 16}
+
+// BenchmarkStoreCheckpoint benchmarks writing random tries to a single checkpoint file, and to a
+// partitioned checkpoint with different numbers of workers.
+func BenchmarkStoreCheckpoint(b *testing.B) {
+	tries := randomCheckpointTries(b)
+
+	dir, err := os.MkdirTemp("", "test-checkpoint-")
+	require.NoError(b, err)
+	defer os.RemoveAll(dir)
+
+	logger := zerolog.Nop()
+
+	b.Run("single file", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			writer, err := wal.CreateCheckpointWriterForFile(dir, wal.NumberToFilename(i), &logger)
+			require.NoError(b, err)
+
+			err = wal.StoreCheckpoint(writer, tries...)
+			require.NoError(b, err)
+			require.NoError(b, writer.Close())
+
+			b.StopTimer()
+			require.NoError(b, removeCheckpointFiles(dir))
+			b.StartTimer()
+		}
+	})
+
+	for _, workers := range checkpointWorkers {
+		b.Run(fmt.Sprintf("partitioned %d workers", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				err := wal.StorePartitionedCheckpoint(dir, wal.NumberToFilename(i), &logger, workers, tries...)
+				require.NoError(b, err)
+
+				b.StopTimer()
+				require.NoError(b, removeCheckpointFiles(dir))
+				b.StartTimer()
+			}
+		})
+	}
+}
+
+// BenchmarkLoadCheckpoint benchmarks loading random tries from a single checkpoint file, and from a
+// partitioned checkpoint, whose partitions are read concurrently.
+func BenchmarkLoadCheckpoint(b *testing.B) {
+	tries := randomCheckpointTries(b)
+
+	dir, err := os.MkdirTemp("", "test-checkpoint-")
+	require.NoError(b, err)
+	defer os.RemoveAll(dir)
+
+	logger := zerolog.Nop()
+
+	singleFile := wal.NumberToFilename(0)
+	writer, err := wal.CreateCheckpointWriterForFile(dir, singleFile, &logger)
+	require.NoError(b, err)
+	require.NoError(b, wal.StoreCheckpoint(writer, tries...))
+	require.NoError(b, writer.Close())
+
+	partitioned := wal.NumberToFilename(1)
+	require.NoError(b, wal.StorePartitionedCheckpoint(dir, partitioned, &logger, len(checkpointWorkers), tries...))
+
+	for name, filename := range map[string]string{"single file": singleFile, "partitioned": partitioned} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				loadedTries, err := wal.LoadCheckpoint(filepath.Join(dir, filename), &logger)
+				require.NoError(b, err)
+				require.Equal(b, len(tries), len(loadedTries))
+			}
+		})
+	}
+}
+
+// randomCheckpointTries returns a sequence of tries with random registers, each sharing most of
+// its nodes with the previous trie, like the tries of a checkpoint.
+func randomCheckpointTries(b *testing.B) []*trie.MTrie {
+	const (
+		trieCount     = 50
+		pathsPerTrie  = 5000
+		maxValueBytes = 256
+	)
+
+	seed := uint64(0x9E3779B97F4A7C15) // golden ratio
+	rand.Seed(int64(seed))
+
+	tries := make([]*trie.MTrie, 0, trieCount)
+	parent := trie.NewEmptyMTrie()
+	for i := 0; i < trieCount; i++ {
+		paths := utils.RandomPaths(pathsPerTrie)
+		payloads := utils.RandomPayloads(len(paths), 1, maxValueBytes)
+		values := make([]ledger.Payload, len(payloads))
+		for j, payload := range payloads {
+			values[j] = *payload
+		}
+
+		t, _, err := trie.NewTrieWithUpdatedRegisters(parent, paths, values, true)
+		require.NoError(b, err)
+
+		tries = append(tries, t)
+		parent = t
+	}
+	return tries
+}
+
+// removeCheckpointFiles removes all files from the given checkpoint directory.
+func removeCheckpointFiles(dir string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		err := os.Remove(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type randKeyValueOptions struct {
 	keyNumberOfParts   int
 	keyPartMinByteSize int
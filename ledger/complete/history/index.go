@@ -0,0 +1,569 @@
+// Package history implements an index of the history of the ledger registers, which allows reading
+// registers at past heights whose tries are no longer held in the forest.
+package history
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// ErrNotIndexed is returned for heights outside of the indexed range, and by IndexedRange if the index
+// was not bootstrapped yet.
+var ErrNotIndexed = errors.New("height is not indexed")
+
+// ErrMissingUpdate is returned when a height can not be indexed, because an update leading to its state
+// was not recorded.
+var ErrMissingUpdate = errors.New("missing ledger update")
+
+const (
+	codeFirstHeight byte = iota + 1
+	codeLatestHeight
+	codeCommit
+	codeUpdate
+	codeRegister
+	codeUpdateParent
+)
+
+const encHeightSize = 8
+
+// updateQueueSize is the number of ledger updates queued by RecordUpdate before it blocks.
+const updateQueueSize = 1000
+
+// Index is a disk-backed index of the history of the ledger registers. For each register path, it stores
+// the values the register was set to, ordered by height, so that the value of a register at any indexed
+// height is the value of its latest change at or below that height.
+//
+// The index is built in two steps. The ledger updates are recorded as they are applied, keyed by their
+// resulting state, since the height they belong to is not known yet. Once the state commitment of a height
+// is known, e.g. when the block is sealed, the height is indexed: the recorded updates are followed back
+// from the state commitment of the height to the state commitment of the previous height, and the register
+// changes of these updates are stored at the height. Updates which don't follow from the state commitment of
+// the indexed height, i.e. the updates of blocks executed on abandoned forks at or below this height, can
+// never be indexed and are removed.
+//
+// The ledger records its updates in the background with RecordUpdate, so that a failure of the index
+// doesn't fail the execution of blocks. A height whose updates could not be recorded can't be indexed, the
+// index must then be rebuilt with the register-history util command.
+//
+// The first indexed height is bootstrapped with all the registers of the trie at this height, heights can
+// then only be indexed in sequence.
+type Index struct {
+	db  *badger.DB
+	log zerolog.Logger
+
+	mu sync.Mutex // serializes the indexing of heights
+
+	queue    chan queuedUpdate // updates to record in the background
+	recorded chan struct{}     // closed once all the queued updates are recorded
+}
+
+// queuedUpdate is a ledger update queued to be recorded, or a marker of the updates queued before it if
+// flushed is set.
+type queuedUpdate struct {
+	state   ledger.State
+	update  *ledger.TrieUpdate
+	flushed chan struct{}
+}
+
+// New opens the register history index in the given directory.
+func New(dir string, log zerolog.Logger) (*Index, error) {
+	db, err := badger.Open(badger.DefaultOptions(dir).WithLogger(nil))
+	if err != nil {
+		return nil, fmt.Errorf("could not open register history index: %w", err)
+	}
+
+	x := &Index{
+		db:       db,
+		log:      log.With().Str("component", "register_history_index").Logger(),
+		queue:    make(chan queuedUpdate, updateQueueSize),
+		recorded: make(chan struct{}),
+	}
+	go x.recordQueued()
+
+	return x, nil
+}
+
+// Close records the queued updates and closes the index. No update must be recorded once it is called.
+func (x *Index) Close() error {
+	close(x.queue)
+	<-x.recorded
+	return x.db.Close()
+}
+
+// IndexedRange returns the first and latest indexed heights, or ErrNotIndexed if the index was not
+// bootstrapped yet.
+func (x *Index) IndexedRange() (uint64, uint64, error) {
+	var first, latest uint64
+	err := x.db.View(func(tx *badger.Txn) error {
+		var err error
+		first, err = readHeight(tx, []byte{codeFirstHeight})
+		if err != nil {
+			return err
+		}
+		latest, err = readHeight(tx, []byte{codeLatestHeight})
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return 0, 0, ErrNotIndexed
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not read indexed range: %w", err)
+	}
+	return first, latest, nil
+}
+
+// Bootstrap indexes the given height with all the registers of the given trie, which is the trie of the
+// given state commitment. It fails if the index was already bootstrapped.
+func (x *Index) Bootstrap(height uint64, commit ledger.State, t *trie.MTrie) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	_, _, err := x.IndexedRange()
+	if err == nil {
+		return fmt.Errorf("register history index is already bootstrapped")
+	}
+	if !errors.Is(err, ErrNotIndexed) {
+		return err
+	}
+
+	if ledger.State(t.RootHash()) != commit {
+		return fmt.Errorf("trie root hash %x does not match state commitment %x", t.RootHash(), commit)
+	}
+
+	batch := x.db.NewWriteBatch()
+	defer batch.Cancel()
+
+	count := 0
+	itr := flattener.NewNodeIterator(t)
+	for itr.Next() {
+		n := itr.Value()
+		if !n.IsLeaf() || n.Payload() == nil || n.Payload().IsEmpty() {
+			continue
+		}
+
+		err = batch.Set(registerKey(*n.Path(), height), n.Payload().Value)
+		if err != nil {
+			return fmt.Errorf("could not index register: %w", err)
+		}
+		count++
+	}
+	if err := itr.Err(); err != nil {
+		return fmt.Errorf("could not iterate trie: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not index registers: %w", err)
+	}
+
+	err = x.db.Update(func(tx *badger.Txn) error {
+		err := tx.Set(commitKey(height), commit[:])
+		if err != nil {
+			return err
+		}
+		err = tx.Set([]byte{codeFirstHeight}, encodeHeight(height))
+		if err != nil {
+			return err
+		}
+		return tx.Set([]byte{codeLatestHeight}, encodeHeight(height))
+	})
+	if err != nil {
+		return fmt.Errorf("could not store bootstrapped height: %w", err)
+	}
+
+	x.log.Info().Uint64("height", height).Int("registers", count).Msg("register history index bootstrapped")
+
+	return nil
+}
+
+// StoreUpdate records the given ledger update, which resulted in the given state, until the height it
+// belongs to is indexed.
+func (x *Index) StoreUpdate(state ledger.State, update *ledger.TrieUpdate) error {
+	if ledger.State(update.RootHash) == state {
+		// the update didn't change any register
+		return nil
+	}
+
+	err := x.db.Update(func(tx *badger.Txn) error {
+		err := tx.Set(updateKey(state), encoding.EncodeTrieUpdate(update))
+		if err != nil {
+			return err
+		}
+		return tx.Set(updateParentKey(state), update.RootHash[:])
+	})
+	if err != nil {
+		return fmt.Errorf("could not store ledger update: %w", err)
+	}
+	return nil
+}
+
+// RecordUpdate queues the given ledger update, which resulted in the given state, to be recorded in the
+// background like StoreUpdate. It only blocks if the queue is full. Updates which can't be recorded are
+// logged, the heights they belong to then can't be indexed.
+func (x *Index) RecordUpdate(state ledger.State, update *ledger.TrieUpdate) {
+	x.queue <- queuedUpdate{state: state, update: update}
+}
+
+// recordQueued records the queued updates until the queue is closed.
+func (x *Index) recordQueued() {
+	defer close(x.recorded)
+
+	for queued := range x.queue {
+		if queued.flushed != nil {
+			close(queued.flushed)
+			continue
+		}
+
+		err := x.StoreUpdate(queued.state, queued.update)
+		if err != nil {
+			x.log.Error().Err(err).
+				Hex("state", queued.state[:]).
+				Msg("could not record ledger update, the height it belongs to can not be indexed")
+		}
+	}
+}
+
+// flush waits until the updates queued so far are recorded.
+func (x *Index) flush() {
+	flushed := make(chan struct{})
+	x.queue <- queuedUpdate{flushed: flushed}
+	<-flushed
+}
+
+// RecordedUpdates returns the number of recorded updates which are not indexed yet.
+func (x *Index) RecordedUpdates() (int, error) {
+	x.flush()
+
+	parents, err := x.updateParents()
+	if err != nil {
+		return 0, err
+	}
+	return len(parents), nil
+}
+
+// IndexHeight indexes the register changes of the given height, whose state commitment is given. The
+// previous height must be indexed, and all the updates from its state commitment to the given state
+// commitment must have been recorded, otherwise ErrMissingUpdate is returned.
+func (x *Index) IndexHeight(height uint64, commit ledger.State) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	_, latest, err := x.IndexedRange()
+	if err != nil {
+		return err
+	}
+	if height != latest+1 {
+		return fmt.Errorf("can not index height %d, next height to index is %d", height, latest+1)
+	}
+
+	parent, err := x.commit(latest)
+	if err != nil {
+		return err
+	}
+
+	// the updates of the height might still be queued
+	x.flush()
+
+	// follow the updates back to the state of the previous height
+	var updates []*ledger.TrieUpdate
+	visited := make(map[ledger.State]struct{})
+	for state := commit; state != parent; {
+		if _, ok := visited[state]; ok {
+			return fmt.Errorf("could not index height %d: updates resulting in state %x form a cycle", height, state[:])
+		}
+		visited[state] = struct{}{}
+
+		update, err := x.update(state)
+		if err != nil {
+			return fmt.Errorf("could not index height %d: %w", height, err)
+		}
+		updates = append(updates, update)
+		state = ledger.State(update.RootHash)
+	}
+
+	// apply the updates from the oldest to the latest, the latest change of a register is indexed
+	changes := make(map[ledger.Path]ledger.Value)
+	for i := len(updates) - 1; i >= 0; i-- {
+		for j, path := range updates[i].Paths {
+			changes[path] = updates[i].Payloads[j].Value
+		}
+	}
+
+	batch := x.db.NewWriteBatch()
+	defer batch.Cancel()
+
+	for path, value := range changes {
+		err = batch.Set(registerKey(path, height), value)
+		if err != nil {
+			return fmt.Errorf("could not index register: %w", err)
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not index registers: %w", err)
+	}
+
+	// the height is only marked as indexed once all its registers are stored
+	err = x.db.Update(func(tx *badger.Txn) error {
+		err := tx.Set(commitKey(height), commit[:])
+		if err != nil {
+			return err
+		}
+		return tx.Set([]byte{codeLatestHeight}, encodeHeight(height))
+	})
+	if err != nil {
+		return fmt.Errorf("could not store indexed height: %w", err)
+	}
+
+	// the indexed updates and the updates of abandoned forks are not needed anymore
+	pruned, err := x.pruneUpdates(commit)
+	if err != nil {
+		x.log.Warn().Err(err).Uint64("height", height).Msg("could not remove indexed ledger updates")
+	} else {
+		x.log.Debug().Uint64("height", height).Int("updates", pruned).Msg("removed indexed ledger updates")
+	}
+
+	return nil
+}
+
+// pruneUpdates removes the recorded updates which don't follow from the given state commitment of the
+// latest indexed height, and returns their number. These are the indexed updates and the updates of
+// blocks executed on abandoned forks at or below the latest indexed height, which can't be indexed
+// anymore.
+func (x *Index) pruneUpdates(commit ledger.State) (int, error) {
+	parents, err := x.updateParents()
+	if err != nil {
+		return 0, err
+	}
+
+	// an update follows from the commitment if following its parents leads to the commitment
+	follows := make(map[ledger.State]bool, len(parents))
+	for state := range parents {
+		var chain []ledger.State
+		result := false
+		for current := state; ; {
+			known, ok := follows[current]
+			if ok {
+				result = known
+				break
+			}
+			chain = append(chain, current)
+			follows[current] = false // guards against cycles
+
+			parent, ok := parents[current]
+			if !ok {
+				break
+			}
+			if parent == commit {
+				result = true
+				break
+			}
+			current = parent
+		}
+		for _, s := range chain {
+			follows[s] = result
+		}
+	}
+
+	batch := x.db.NewWriteBatch()
+	defer batch.Cancel()
+
+	pruned := 0
+	for state := range parents {
+		if follows[state] {
+			continue
+		}
+		err = batch.Delete(updateKey(state))
+		if err != nil {
+			return 0, err
+		}
+		err = batch.Delete(updateParentKey(state))
+		if err != nil {
+			return 0, err
+		}
+		pruned++
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
+// updateParents returns the states the recorded updates were applied to, by resulting state.
+func (x *Index) updateParents() (map[ledger.State]ledger.State, error) {
+	parents := make(map[ledger.State]ledger.State)
+	err := x.db.View(func(tx *badger.Txn) error {
+		prefix := []byte{codeUpdateParent}
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			state, err := hash.ToHash(item.Key()[1:])
+			if err != nil {
+				return err
+			}
+			err = item.Value(func(val []byte) error {
+				parent, err := hash.ToHash(val)
+				parents[ledger.State(state)] = ledger.State(parent)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read recorded ledger updates: %w", err)
+	}
+	return parents, nil
+}
+
+// Get returns the values of the registers with the given paths at the given height. Registers which were
+// not set at this height have an empty value. It returns ErrNotIndexed if the height is not indexed.
+func (x *Index) Get(height uint64, paths []ledger.Path) ([]ledger.Value, error) {
+	first, latest, err := x.IndexedRange()
+	if err != nil {
+		return nil, err
+	}
+	if height < first || height > latest {
+		return nil, fmt.Errorf("height %d is outside of indexed range [%d, %d]: %w", height, first, latest, ErrNotIndexed)
+	}
+
+	values := make([]ledger.Value, len(paths))
+	err = x.db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		opts.PrefetchValues = false
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for i, path := range paths {
+			prefix := registerKey(path, 0)[:1+ledger.PathLen]
+
+			// in reverse mode, the iterator is positioned at the latest change at or below the height
+			it.Seek(registerKey(path, height))
+			if !it.ValidForPrefix(prefix) {
+				continue
+			}
+
+			value, err := it.Item().ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if len(value) > 0 {
+				values[i] = value
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read registers at height %d: %w", height, err)
+	}
+
+	return values, nil
+}
+
+// commit returns the state commitment of the given indexed height.
+func (x *Index) commit(height uint64) (ledger.State, error) {
+	var commit ledger.State
+	err := x.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(commitKey(height))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			h, err := hash.ToHash(val)
+			commit = ledger.State(h)
+			return err
+		})
+	})
+	if err != nil {
+		return ledger.State(hash.DummyHash), fmt.Errorf("could not read state commitment of height %d: %w", height, err)
+	}
+	return commit, nil
+}
+
+// update returns the recorded update which resulted in the given state.
+func (x *Index) update(state ledger.State) (*ledger.TrieUpdate, error) {
+	var update *ledger.TrieUpdate
+	err := x.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(updateKey(state))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			update, err = encoding.DecodeTrieUpdate(val)
+			return err
+		})
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, fmt.Errorf("update resulting in state %x: %w", state[:], ErrMissingUpdate)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read update resulting in state %x: %w", state[:], err)
+	}
+	return update, nil
+}
+
+func readHeight(tx *badger.Txn, key []byte) (uint64, error) {
+	item, err := tx.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	var height uint64
+	err = item.Value(func(val []byte) error {
+		if len(val) != encHeightSize {
+			return fmt.Errorf("invalid height encoding of %d bytes", len(val))
+		}
+		height = binary.BigEndian.Uint64(val)
+		return nil
+	})
+	return height, err
+}
+
+func encodeHeight(height uint64) []byte {
+	buf := make([]byte, encHeightSize)
+	binary.BigEndian.PutUint64(buf, height)
+	return buf
+}
+
+func commitKey(height uint64) []byte {
+	return append([]byte{codeCommit}, encodeHeight(height)...)
+}
+
+func updateKey(state ledger.State) []byte {
+	return append([]byte{codeUpdate}, state[:]...)
+}
+
+func updateParentKey(state ledger.State) []byte {
+	return append([]byte{codeUpdateParent}, state[:]...)
+}
+
+// registerKey returns the key of the change of the register with the given path at the given height.
+// The height is encoded in big endian, so that the changes of a register are ordered by height.
+func registerKey(path ledger.Path, height uint64) []byte {
+	key := make([]byte, 0, 1+ledger.PathLen+encHeightSize)
+	key = append(key, codeRegister)
+	key = append(key, path[:]...)
+	return append(key, encodeHeight(height)...)
+}
@@ -0,0 +1,241 @@
+package history_test
+
+import (
+	"bytes"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/history"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const heights = 10
+
+// runWithLedger runs the given function with a ledger recording its updates in a register history index.
+func runWithLedger(t *testing.T, f func(led *complete.Ledger, index *history.Index)) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		index, err := history.New(dir, zerolog.Nop())
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, index.Close())
+		}()
+
+		led, err := complete.NewLedgerWithHistory(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion, index)
+		require.NoError(t, err)
+
+		f(led, index)
+	})
+}
+
+// executeHeights applies random updates to the ledger for the given number of heights, several updates per
+// height, and an update of an abandoned fork at each height. It returns the state of each height, and all
+// the updated keys.
+func executeHeights(t *testing.T, led *complete.Ledger, count int) ([]ledger.State, []ledger.Key) {
+	state := led.InitialState()
+	states := []ledger.State{state}
+	var keys []ledger.Key
+
+	for h := 1; h <= count; h++ {
+		// an update of an abandoned fork
+		forkKeys := utils.RandomUniqueKeys(5, 2, 1, 10)
+		update, err := ledger.NewUpdate(state, forkKeys, utils.RandomValues(len(forkKeys), 1, 10))
+		require.NoError(t, err)
+		_, _, err = led.Set(update)
+		require.NoError(t, err)
+
+		for chunk := 0; chunk < 3; chunk++ {
+			updatedKeys := utils.RandomUniqueKeys(5, 2, 1, 10)
+			values := utils.RandomValues(len(updatedKeys), 1, 10)
+
+			// overwrite and remove some of the previously updated registers
+			for i := 0; i < 3 && len(keys) > 0; i++ {
+				updatedKeys = append(updatedKeys, keys[rand.Intn(len(keys))])
+				value := utils.RandomValues(1, 1, 10)[0]
+				if i == 0 {
+					value = ledger.Value{}
+				}
+				values = append(values, value)
+			}
+
+			update, err := ledger.NewUpdate(state, updatedKeys, values)
+			require.NoError(t, err)
+			state, _, err = led.Set(update)
+			require.NoError(t, err)
+
+			keys = append(keys, updatedKeys...)
+		}
+
+		states = append(states, state)
+	}
+
+	return states, keys
+}
+
+// requireHistory requires the values read from the index at the given heights to be the values read from
+// the ledger at the states of these heights.
+func requireHistory(t *testing.T, led *complete.Ledger, states []ledger.State, keys []ledger.Key, from int) {
+	for h := from; h < len(states); h++ {
+		query, err := ledger.NewQuery(states[h], keys)
+		require.NoError(t, err)
+		expected, err := led.Get(query)
+		require.NoError(t, err)
+
+		actual, err := led.GetAtHeight(uint64(h), keys)
+		require.NoError(t, err)
+
+		require.Equal(t, len(expected), len(actual))
+		for i := range expected {
+			require.True(t, bytes.Equal(expected[i], actual[i]), "value of key %d at height %d", i, h)
+		}
+	}
+}
+
+// TestIndex_RegisterHistory tests that registers read from the index at past heights are the registers of
+// the tries of these heights.
+func TestIndex_RegisterHistory(t *testing.T) {
+	runWithLedger(t, func(led *complete.Ledger, index *history.Index) {
+		initial, release, err := led.AcquireTrie(ledger.RootHash(led.InitialState()))
+		require.NoError(t, err)
+		defer release()
+		require.NoError(t, index.Bootstrap(0, led.InitialState(), initial))
+
+		states, keys := executeHeights(t, led, heights)
+		for h := 1; h <= heights; h++ {
+			require.NoError(t, index.IndexHeight(uint64(h), states[h]))
+		}
+
+		first, latest, err := index.IndexedRange()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), first)
+		require.Equal(t, uint64(heights), latest)
+
+		requireHistory(t, led, states, keys, 0)
+
+		_, err = led.GetAtHeight(heights+1, keys)
+		require.ErrorIs(t, err, history.ErrNotIndexed)
+	})
+}
+
+// TestIndex_PrunedUpdates tests that indexing a height removes its updates and the updates of abandoned forks
+// at or below this height, and keeps the updates of the following heights.
+func TestIndex_PrunedUpdates(t *testing.T) {
+	runWithLedger(t, func(led *complete.Ledger, index *history.Index) {
+		initial, release, err := led.AcquireTrie(ledger.RootHash(led.InitialState()))
+		require.NoError(t, err)
+		defer release()
+		require.NoError(t, index.Bootstrap(0, led.InitialState(), initial))
+
+		states, _ := executeHeights(t, led, heights)
+
+		// three updates and an update of an abandoned fork at each height
+		recorded, err := index.RecordedUpdates()
+		require.NoError(t, err)
+		require.Equal(t, 4*heights, recorded)
+
+		for h := 1; h <= heights; h++ {
+			require.NoError(t, index.IndexHeight(uint64(h), states[h]))
+
+			recorded, err = index.RecordedUpdates()
+			require.NoError(t, err)
+			require.Equal(t, 4*(heights-h), recorded, "recorded updates after indexing height %d", h)
+		}
+	})
+}
+
+// TestIndex_Bootstrap tests that an index bootstrapped with the trie of a past height contains the registers
+// of the following heights.
+func TestIndex_Bootstrap(t *testing.T) {
+	runWithLedger(t, func(led *complete.Ledger, index *history.Index) {
+		_, _, err := index.IndexedRange()
+		require.ErrorIs(t, err, history.ErrNotIndexed)
+
+		states, keys := executeHeights(t, led, heights)
+
+		bootstrapHeight := heights / 2
+		bootstrapTrie, release, err := led.AcquireTrie(ledger.RootHash(states[bootstrapHeight]))
+		require.NoError(t, err)
+		defer release()
+
+		// the trie must be the trie of the given state commitment
+		err = index.Bootstrap(uint64(bootstrapHeight), states[bootstrapHeight-1], bootstrapTrie)
+		require.Error(t, err)
+
+		require.NoError(t, index.Bootstrap(uint64(bootstrapHeight), states[bootstrapHeight], bootstrapTrie))
+		require.Error(t, index.Bootstrap(uint64(bootstrapHeight), states[bootstrapHeight], bootstrapTrie))
+
+		// heights can only be indexed in sequence
+		require.Error(t, index.IndexHeight(uint64(bootstrapHeight+2), states[bootstrapHeight+2]))
+
+		for h := bootstrapHeight + 1; h <= heights; h++ {
+			require.NoError(t, index.IndexHeight(uint64(h), states[h]))
+		}
+
+		requireHistory(t, led, states, keys, bootstrapHeight)
+
+		_, err = led.GetAtHeight(uint64(bootstrapHeight-1), keys)
+		require.ErrorIs(t, err, history.ErrNotIndexed)
+	})
+}
+
+// TestIndex_ReplayedUpdates tests that a height can't be indexed if an update leading to its state was not
+// recorded, and that the updates replayed from the write-ahead log are recorded.
+func TestIndex_ReplayedUpdates(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		index, err := history.New(filepath.Join(dir, "history"), zerolog.Nop())
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, index.Close())
+		}()
+
+		walDir := filepath.Join(dir, "wal")
+		diskWAL, err := wal.NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), walDir, 100, pathfinder.PathByteSize, wal.SegmentSize)
+		require.NoError(t, err)
+
+		// the updates of height 1 are not recorded
+		led, err := complete.NewLedger(diskWAL, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+
+		initial, release, err := led.AcquireTrie(ledger.RootHash(led.InitialState()))
+
+		require.NoError(t, err)
+
+		defer release()
+		require.NoError(t, index.Bootstrap(0, led.InitialState(), initial))
+
+		states, keys := executeHeights(t, led, 1)
+
+		err = index.IndexHeight(1, states[1])
+		require.ErrorIs(t, err, history.ErrMissingUpdate)
+
+		_, latest, err := index.IndexedRange()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), latest)
+
+		<-led.Done()
+		<-diskWAL.Done()
+
+		// the updates of height 1 are recorded when they are replayed
+		diskWAL, err = wal.NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), walDir, 100, pathfinder.PathByteSize, wal.SegmentSize)
+		require.NoError(t, err)
+		defer func() {
+			<-diskWAL.Done()
+		}()
+
+		led, err = complete.NewLedgerWithHistory(diskWAL, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion, index)
+		require.NoError(t, err)
+
+		require.NoError(t, index.IndexHeight(1, states[1]))
+		requireHistory(t, led, states, keys, 0)
+	})
+}
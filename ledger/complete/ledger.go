@@ -13,6 +13,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/encoding"
 	"github.com/onflow/flow-go/ledger/common/hash"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/history"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/ledger/complete/wal"
@@ -30,6 +31,8 @@ const DefaultPathFinderVersion = 1
 // Under the hood, it uses binary Merkle tries to generate inclusion and non-inclusion proofs.
 // Ledger is fork-aware which means any update can be applied at any previous state which forms a tree of tries (forest).
 // The forest is in memory but all changes (e.g. register updates) are captured inside write-ahead-logs for crash recovery reasons.
+// Optionally, the nodes of the tries can be paged out to a disk-backed node store (see mtrie.WithNodeStore), in which case
+// only the nodes cached by the node store are kept in memory.
 // In order to limit the memory usage and maintain the performance storage only keeps a limited number of
 // tries and purge the old ones (LRU-based); in other words, Ledger is not designed to be used
 // for archival usage but make it possible for other software components to reconstruct very old tries using write-ahead logs.
@@ -39,24 +42,44 @@ type Ledger struct {
 	metrics           module.LedgerMetrics
 	logger            zerolog.Logger
 	pathFinderVersion uint8
+	history           *history.Index
 }
 
 // NewLedger creates a new in-memory trie-backed ledger storage with persistence.
+// The forest options are applied to the forest of the ledger, and to the forests used to create checkpoints.
 func NewLedger(
 	wal wal.LedgerWAL,
 	capacity int,
 	metrics module.LedgerMetrics,
 	log zerolog.Logger,
-	pathFinderVer uint8) (*Ledger, error) {
+	pathFinderVer uint8,
+	forestOpts ...mtrie.ForestOption) (*Ledger, error) {
+
+	return NewLedgerWithHistory(wal, capacity, metrics, log, pathFinderVer, nil, forestOpts...)
+}
+
+// NewLedgerWithHistory creates a new ledger like NewLedger, which records its updates in the given register history
+// index, including the updates replayed from the write-ahead log. Registers are read at past heights from the index by
+// GetAtHeight. The history is not recorded if the index is nil.
+func NewLedgerWithHistory(
+	wal wal.LedgerWAL,
+	capacity int,
+	metrics module.LedgerMetrics,
+	log zerolog.Logger,
+	pathFinderVer uint8,
+	index *history.Index,
+	forestOpts ...mtrie.ForestOption) (*Ledger, error) {
 
 	logger := log.With().Str("ledger", "complete").Logger()
 
+	// the logger is set first, so that it can be overridden by the given options
+	forestOpts = append([]mtrie.ForestOption{mtrie.WithLogger(logger)}, forestOpts...)
 	forest, err := mtrie.NewForest(capacity, metrics, func(evictedTrie *trie.MTrie) {
 		err := wal.RecordDelete(evictedTrie.RootHash())
 		if err != nil {
 			logger.Error().Err(err).Msg("failed to save delete record in wal")
 		}
-	})
+	}, forestOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create forest: %w", err)
 	}
@@ -67,13 +90,42 @@ func NewLedger(
 		metrics:           metrics,
 		logger:            logger,
 		pathFinderVersion: pathFinderVer,
+		history:           index,
 	}
 
+	// the nodes of the checkpoints are paged out to the node store of the forest while they are loaded
+	wal.SetNodeStore(forest.NodeStore())
+
 	// pause records to prevent double logging trie removals
 	wal.PauseRecord()
 	defer wal.UnpauseRecord()
 
-	err = wal.ReplayOnForest(forest)
+	if index == nil {
+		err = wal.ReplayOnForest(forest)
+	} else {
+		// the replayed updates are recorded, since the heights of the blocks executed before the node
+		// stopped might not be indexed yet
+		err = wal.Replay(
+			func(tries []*trie.MTrie) error {
+				err := forest.AddTries(tries)
+				if err != nil {
+					return fmt.Errorf("adding rebuilt tries to forest failed: %w", err)
+				}
+				return nil
+			},
+			func(update *ledger.TrieUpdate) error {
+				newRootHash, err := forest.Update(update)
+				if err != nil {
+					return err
+				}
+				return index.StoreUpdate(ledger.State(newRootHash), update)
+			},
+			func(rootHash ledger.RootHash) error {
+				forest.RemoveTrie(rootHash)
+				return nil
+			},
+		)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("cannot restore LedgerWAL: %w", err)
 	}
@@ -163,6 +215,21 @@ func (l *Ledger) Get(query *ledger.Query) (values []ledger.Value, err error) {
 	return values, err
 }
 
+// GetAtHeight reads the values of the given keys at the given height from the register history index,
+// see NewLedgerWithHistory. Keys which were not set at this height have an empty value.
+func (l *Ledger) GetAtHeight(height uint64, keys []ledger.Key) ([]ledger.Value, error) {
+	if l.history == nil {
+		return nil, fmt.Errorf("register history is not enabled")
+	}
+
+	paths, err := pathfinder.KeysToPaths(keys, l.pathFinderVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.history.Get(height, paths)
+}
+
 // Set updates the ledger given an update
 // it returns the state after update and errors (if any)
 func (l *Ledger) Set(update *ledger.Update) (newState ledger.State, trieUpdate *ledger.TrieUpdate, err error) {
@@ -197,6 +264,11 @@ func (l *Ledger) Set(update *ledger.Update) (newState ledger.State, trieUpdate *
 		return ledger.State(hash.DummyHash), nil, fmt.Errorf("error while writing LedgerWAL: %w", walError)
 	}
 
+	if l.history != nil {
+		// recorded in the background, a failure to record the update must not fail the execution
+		l.history.RecordUpdate(ledger.State(newRootHash), trieUpdate)
+	}
+
 	// TODO update to proper value once https://github.com/onflow/flow-go/pull/3720 is merged
 	l.metrics.ForestApproxMemorySize(0)
 
@@ -243,6 +315,24 @@ func (l *Ledger) Prove(query *ledger.Query) (proof ledger.Proof, err error) {
 	return proofToGo, err
 }
 
+// ProveRange provides a proof of all the allocated registers with a path in the range [start, end]
+// at the given state.
+//
+// As paths are hashes of the register keys, the registers of an account are not stored in a range of
+// paths. A range proof rather proves that the range has no other allocated registers than the registers
+// of the proof, e.g. that no register is allocated for any path with a given prefix (see ledger.PrefixRange).
+// It can't prove that a set of registers is all the registers of an account: proving the completeness of
+// an account's storage is not possible with hashed paths, the registers of an account can only be proven
+// per key with Prove.
+func (l *Ledger) ProveRange(state ledger.State, start, end ledger.Path) (ledger.Proof, error) {
+	rangeProof, err := l.forest.RangeProof(ledger.RootHash(state), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("could not get range proof: %w", err)
+	}
+
+	return encoding.EncodeTrieRangeProof(rangeProof), nil
+}
+
 // MemSize return the amount of memory used by ledger
 // TODO implement an approximate MemSize method
 func (l *Ledger) MemSize() (int64, error) {
@@ -263,6 +353,12 @@ func (l *Ledger) Checkpointer() (*wal.Checkpointer, error) {
 	return checkpointer, nil
 }
 
+// AcquireTrie returns the trie with the given root hash, if it is held by the ledger, and a function
+// releasing it, which must be called once the trie is not used anymore (see mtrie.Forest.AcquireTrie).
+func (l *Ledger) AcquireTrie(rootHash ledger.RootHash) (*trie.MTrie, func(), error) {
+	return l.forest.AcquireTrie(rootHash)
+}
+
 // ExportCheckpointAt exports a checkpoint at specific state commitment after applying migrations and returns the new state (after migration) and any errors
 func (l *Ledger) ExportCheckpointAt(
 	state ledger.State,
@@ -280,7 +376,7 @@ func (l *Ledger) ExportCheckpointAt(
 	)
 
 	// get trie
-	t, err := l.forest.GetTrie(ledger.RootHash(state))
+	t, release, err := l.forest.AcquireTrie(ledger.RootHash(state))
 	if err != nil {
 		rh, _ := l.forest.MostRecentTouchedRootHash()
 		l.logger.Info().
@@ -289,6 +385,7 @@ func (l *Ledger) ExportCheckpointAt(
 		return ledger.State(hash.DummyHash),
 			fmt.Errorf("cannot get try at the given state commitment: %w", err)
 	}
+	defer release()
 
 	// clean up tries to release memory
 	err = l.keepOnlyOneTrie(state)
@@ -306,7 +403,10 @@ func (l *Ledger) ExportCheckpointAt(
 	// l.logger.Info().Msg("Trie is valid.")
 
 	// get all payloads
-	payloads := t.AllPayloads()
+	payloads, err := t.AllPayloads()
+	if err != nil {
+		return ledger.State(hash.DummyHash), fmt.Errorf("cannot get payloads of trie: %w", err)
+	}
 	payloadSize := len(payloads)
 
 	// migrate payloads
@@ -414,10 +514,11 @@ func (l *Ledger) MostRecentTouchedState() (ledger.State, error) {
 // DumpTrieAsJSON export trie at specific state as JSONL (each line is JSON encoding of a payload)
 func (l *Ledger) DumpTrieAsJSON(state ledger.State, writer io.Writer) error {
 	fmt.Println(ledger.RootHash(state))
-	trie, err := l.forest.GetTrie(ledger.RootHash(state))
+	trie, release, err := l.forest.AcquireTrie(ledger.RootHash(state))
 	if err != nil {
 		return fmt.Errorf("cannot find the target trie: %w", err)
 	}
+	defer release()
 	return trie.DumpAsJSON(writer)
 }
 
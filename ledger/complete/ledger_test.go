@@ -317,6 +317,67 @@ func TestLedger_Proof(t *testing.T) {
 	})
 }
 
+func TestLedger_RangeProof(t *testing.T) {
+	wal := &fixtures.NoopWAL{}
+	led, err := complete.NewLedger(wal, 100, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	keys := utils.RandomUniqueKeys(50, 2, 1, 10)
+	values := utils.RandomValues(len(keys), 1, 10)
+	u, err := ledger.NewUpdate(led.InitialState(), keys, values)
+	require.NoError(t, err)
+	state, _, err := led.Set(u)
+	require.NoError(t, err)
+
+	paths, err := pathfinder.KeysToPaths(keys, complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	t.Run("registers with a prefix", func(t *testing.T) {
+		start, end := ledger.PrefixRange(paths[0], 2)
+
+		retProof, err := led.ProveRange(state, start, end)
+		require.NoError(t, err)
+
+		rangeProof, err := encoding.DecodeTrieRangeProof(retProof)
+		require.NoError(t, err)
+		assert.True(t, proof.VerifyTrieRangeProof(rangeProof, state))
+		assert.Contains(t, rangeProof.Paths, paths[0])
+
+		for i, path := range paths {
+			if bytes.Compare(path[:], start[:]) < 0 || bytes.Compare(path[:], end[:]) > 0 {
+				assert.NotContains(t, rangeProof.Paths, path)
+				continue
+			}
+			assert.Contains(t, rangeProof.Paths, path)
+			for j, p := range rangeProof.Paths {
+				if p == path {
+					assert.Equal(t, values[i], rangeProof.Payloads[j].Value)
+				}
+			}
+		}
+	})
+
+	t.Run("no registers at the initial state", func(t *testing.T) {
+		start, end := ledger.PrefixRange(paths[0], 0)
+
+		retProof, err := led.ProveRange(led.InitialState(), start, end)
+		require.NoError(t, err)
+
+		rangeProof, err := encoding.DecodeTrieRangeProof(retProof)
+		require.NoError(t, err)
+		assert.True(t, proof.VerifyTrieRangeProof(rangeProof, led.InitialState()))
+		assert.Empty(t, rangeProof.Paths)
+
+		// the proof doesn't prove the absence of registers at the updated state
+		assert.False(t, proof.VerifyTrieRangeProof(rangeProof, state))
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		_, err := led.ProveRange(ledger.State(unittest.StateCommitmentFixture()), paths[0], paths[0])
+		require.Error(t, err)
+	})
+}
+
 func Test_WAL(t *testing.T) {
 	numInsPerStep := 2
 	keyNumberOfParts := 10
@@ -2,6 +2,7 @@ package flattener
 
 import (
 	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 )
@@ -62,6 +63,14 @@ type NodeIterator struct {
 	// No special handling is needed if visitedNodes is nil.
 	// WARNING: visitedNodes is not safe for concurrent use.
 	visitedNodes map[*node.Node]uint64
+	// visitedHashes are the hashes of nodes that were visited, used instead of visitedNodes
+	// for tries paged out to a node store, whose nodes are not identified by their address,
+	// as they can be loaded several times.
+	// WARNING: visitedHashes is not safe for concurrent use.
+	visitedHashes map[hash.Hash]uint64
+	// err is the error which stopped the iteration, if the children of a paged node
+	// could not be loaded from the node store.
+	err error
 }
 
 // NewNodeIterator returns a node NodeIterator, which iterates through all nodes
@@ -99,6 +108,21 @@ func NewNodeIterator(mTrie *trie.MTrie) *NodeIterator {
 // as for each node, the children have been previously encountered.
 // WARNING: visitedNodes is not safe for concurrent use.
 func NewUniqueNodeIterator(mTrie *trie.MTrie, visitedNodes map[*node.Node]uint64) *NodeIterator {
+	return NewUniqueSubtrieNodeIterator(mTrie.RootNode(), visitedNodes)
+}
+
+// NewUniqueNodeHashIterator returns a node NodeIterator, which iterates through all unique nodes
+// whose hashes weren't visited. It provides the same guarantees as NewUniqueNodeIterator, and should be
+// used instead for the iteration of forests whose tries are paged out to a node store.
+// WARNING: visitedHashes is not safe for concurrent use.
+func NewUniqueNodeHashIterator(mTrie *trie.MTrie, visitedHashes map[hash.Hash]uint64) *NodeIterator {
+	return NewUniqueSubtrieNodeHashIterator(mTrie.RootNode(), visitedHashes)
+}
+
+// NewUniqueSubtrieNodeIterator returns a node NodeIterator, which iterates through all unique nodes
+// of the sub-trie with the given root that weren't visited, like NewUniqueNodeIterator.
+// WARNING: visitedNodes is not safe for concurrent use.
+func NewUniqueSubtrieNodeIterator(root *node.Node, visitedNodes map[*node.Node]uint64) *NodeIterator {
 	// For a Trie with height H (measured by number of edges), the longest possible path
 	// contains H+1 vertices.
 	stackSize := ledger.NodeMaxHeight + 1
@@ -106,16 +130,34 @@ func NewUniqueNodeIterator(mTrie *trie.MTrie, visitedNodes map[*node.Node]uint64
 		stack:        make([]*node.Node, 0, stackSize),
 		visitedNodes: visitedNodes,
 	}
-	i.unprocessedRoot = mTrie.RootNode()
+	i.unprocessedRoot = root
 	return i
 }
 
+// NewUniqueSubtrieNodeHashIterator returns a node NodeIterator, which iterates through all unique nodes
+// of the sub-trie with the given root whose hashes weren't visited, like NewUniqueNodeHashIterator.
+// WARNING: visitedHashes is not safe for concurrent use.
+func NewUniqueSubtrieNodeHashIterator(root *node.Node, visitedHashes map[hash.Hash]uint64) *NodeIterator {
+	stackSize := ledger.NodeMaxHeight + 1
+	i := &NodeIterator{
+		stack:         make([]*node.Node, 0, stackSize),
+		visitedHashes: visitedHashes,
+	}
+	i.unprocessedRoot = root
+	return i
+}
+
+// Next advances the iterator to the next node. It returns false when there are no more nodes,
+// or when the iteration failed, in which case Err returns the error.
 func (i *NodeIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
 	if i.unprocessedRoot != nil {
-		// initial call to Next() for a non-empty trie
-		i.dig(i.unprocessedRoot)
+		// initial call to Next() for a non-empty trie, whose root might have been visited already
+		i.err = i.dig(i.unprocessedRoot)
 		i.unprocessedRoot = nil
-		return true
+		return i.err == nil && len(i.stack) > 0
 	}
 
 	// the current head of the stack, `n`, has been recalled
@@ -127,14 +169,27 @@ func (i *NodeIterator) Next() bool {
 		// done so already. As we decent into the left child with priority, the only case where
 		// we still need to dig into the right child is, if n is p's left child.
 		parent := i.peek()
-		if parent.LeftChild() == n {
-			i.dig(parent.RightChild())
+		if isLeftChild(parent, n) {
+			rChild, err := parent.RightChild()
+			if err == nil {
+				err = i.dig(rChild)
+			}
+			if err != nil {
+				i.err = err
+				return false
+			}
 		}
 		return true
 	}
 	return false // as len(i.stack) == 0, i.e. there are no more elements to recall
 }
 
+// Err returns the error which stopped the iteration, or nil if the iteration completed
+// (or is still in progress).
+func (i *NodeIterator) Err() error {
+	return i.err
+}
+
 func (i *NodeIterator) Value() *node.Node {
 	if len(i.stack) == 0 {
 		return nil
@@ -156,27 +211,48 @@ func (i *NodeIterator) peek() *node.Node {
 	return i.stack[len(i.stack)-1]
 }
 
-func (i *NodeIterator) dig(n *node.Node) {
+func (i *NodeIterator) dig(n *node.Node) error {
 	if n == nil {
-		return
+		return nil
 	}
-	if _, found := i.visitedNodes[n]; found {
-		return
+	if i.visited(n) {
+		return nil
 	}
 	for {
 		i.stack = append(i.stack, n)
-		if lChild := n.LeftChild(); lChild != nil {
-			if _, found := i.visitedNodes[lChild]; !found {
-				n = lChild
-				continue
-			}
+		lChild, rChild, err := n.Children()
+		if err != nil {
+			return err
 		}
-		if rChild := n.RightChild(); rChild != nil {
-			if _, found := i.visitedNodes[rChild]; !found {
-				n = rChild
-				continue
-			}
+		if lChild != nil && !i.visited(lChild) {
+			n = lChild
+			continue
+		}
+		if rChild != nil && !i.visited(rChild) {
+			n = rChild
+			continue
 		}
-		return
+		return nil
+	}
+}
+
+func (i *NodeIterator) visited(n *node.Node) bool {
+	if i.visitedHashes != nil {
+		_, found := i.visitedHashes[n.Hash()]
+		return found
+	}
+	_, found := i.visitedNodes[n]
+	return found
+}
+
+// isLeftChild returns whether n is the left child of parent. The children of paged nodes are
+// loaded from the node store, and are therefore identified by hash instead of address.
+func isLeftChild(parent *node.Node, n *node.Node) bool {
+	if parent.IsPaged() {
+		lHash, ok := parent.LeftChildHash()
+		return ok && lHash == n.Hash()
 	}
+	// the children of nodes which are not paged are held in memory, and can't fail to load
+	lChild, _ := parent.LeftChild()
+	return lChild == n
 }
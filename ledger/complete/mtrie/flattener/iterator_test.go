@@ -62,14 +62,14 @@ func TestPopulatedTrie(t *testing.T) {
 
 	require.True(t, itr.Next())
 	p_parent := itr.Value()
-	require.Equal(t, p1_leaf, p_parent.LeftChild())
-	require.Equal(t, p2_leaf, p_parent.RightChild())
+	require.Equal(t, p1_leaf, leftChild(t, p_parent))
+	require.Equal(t, p2_leaf, rightChild(t, p_parent))
 
 	require.True(t, itr.Next())
 	root := itr.Value()
 	require.Equal(t, testTrie.RootNode(), root)
-	require.Equal(t, p_parent, root.LeftChild())
-	require.True(t, nil == root.RightChild())
+	require.Equal(t, p_parent, leftChild(t, root))
+	require.True(t, nil == rightChild(t, root))
 
 	require.False(t, itr.Next())
 	require.True(t, nil == itr.Value())
@@ -118,10 +118,10 @@ func TestUniqueNodeIterator(t *testing.T) {
 		//
 
 		expectedNodes := []*node.Node{
-			updatedTrie.RootNode().LeftChild().LeftChild(),  // n1
-			updatedTrie.RootNode().LeftChild().RightChild(), // n2
-			updatedTrie.RootNode().LeftChild(),              // n3
-			updatedTrie.RootNode(),                          // n4
+			leftChild(t, leftChild(t, updatedTrie.RootNode())),  // n1
+			rightChild(t, leftChild(t, updatedTrie.RootNode())), // n2
+			leftChild(t, updatedTrie.RootNode()),                // n3
+			updatedTrie.RootNode(),                              // n4
 		}
 
 		// visitedNodes is nil
@@ -147,6 +147,11 @@ func TestUniqueNodeIterator(t *testing.T) {
 			i++
 		}
 		require.Equal(t, i, len(expectedNodes))
+
+		// all nodes of the trie were visited
+		itr := flattener.NewUniqueNodeIterator(updatedTrie, visitedNodes)
+		require.False(t, itr.Next())
+		require.True(t, nil == itr.Value())
 	})
 
 	t.Run("forest", func(t *testing.T) {
@@ -235,19 +240,19 @@ func TestUniqueNodeIterator(t *testing.T) {
 
 		expectedNodes := []*node.Node{
 			// unique nodes from trie1
-			trie1.RootNode().LeftChild().LeftChild(),  // n1
-			trie1.RootNode().LeftChild().RightChild(), // n2
-			trie1.RootNode().LeftChild(),              // n3
-			trie1.RootNode(),                          // n4
+			leftChild(t, leftChild(t, trie1.RootNode())),  // n1
+			rightChild(t, leftChild(t, trie1.RootNode())), // n2
+			leftChild(t, trie1.RootNode()),                // n3
+			trie1.RootNode(),                              // n4
 			// unique nodes from trie2
-			trie2.RootNode().RightChild().LeftChild(),  // n5
-			trie2.RootNode().RightChild().RightChild(), // n6
-			trie2.RootNode().RightChild(),              // n7
-			trie2.RootNode(),                           // n8
+			leftChild(t, rightChild(t, trie2.RootNode())),  // n5
+			rightChild(t, rightChild(t, trie2.RootNode())), // n6
+			rightChild(t, trie2.RootNode()),                // n7
+			trie2.RootNode(),                               // n8
 			// unique nodes from trie3
-			trie3.RootNode().LeftChild().LeftChild(), // n9
-			trie3.RootNode().LeftChild(),             // n10
-			trie3.RootNode(),                         // n11
+			leftChild(t, leftChild(t, trie3.RootNode())), // n9
+			leftChild(t, trie3.RootNode()),               // n10
+			trie3.RootNode(),                             // n11
 		}
 
 		// Use visitedNodes to prevent revisiting shared sub-tries.
@@ -266,3 +271,15 @@ func TestUniqueNodeIterator(t *testing.T) {
 		require.Equal(t, i, len(expectedNodes))
 	})
 }
+
+func leftChild(t *testing.T, n *node.Node) *node.Node {
+	lChild, err := n.LeftChild()
+	require.NoError(t, err)
+	return lChild
+}
+
+func rightChild(t *testing.T, n *node.Node) *node.Node {
+	rChild, err := n.RightChild()
+	require.NoError(t, err)
+	return rChild
+}
@@ -3,11 +3,14 @@ package mtrie
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/nodestore"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/module"
 )
@@ -23,6 +26,12 @@ import (
 //
 // TODO: Storage Eviction Policy for Forest
 //       For the execution node: we only evict on sealing a result.
+//
+// When the Forest is created with a node store (see WithNodeStore), the nodes of the added
+// tries are paged out to disk, and only the recently used nodes are kept in memory. Nodes which
+// can not be loaded from the node store are returned as a *node.LoadError. The nodes of the tries
+// removed from the forest are released from the node store once no reader holds the trie anymore
+// (see AcquireTrie).
 type Forest struct {
 	// tries stores all MTries in the forest. It is NOT a CACHE in the conventional sense:
 	// there is no mechanism to load a trie from disk in case of a cache miss. Missing a
@@ -31,6 +40,41 @@ type Forest struct {
 	forestCapacity int
 	onTreeEvicted  func(tree *trie.MTrie)
 	metrics        module.LedgerMetrics
+	nodeStore      *nodestore.Store
+	log            zerolog.Logger
+
+	// mu protects readers and releases
+	mu sync.Mutex
+	// readers counts the readers which acquired the trie with a given root hash
+	readers map[ledger.RootHash]int
+	// releases counts the releases of the nodes of the evicted tries with a given root hash
+	// which are deferred until their readers are done
+	releases map[ledger.RootHash]int
+}
+
+// ForestOption is an option of the forest.
+type ForestOption func(*Forest)
+
+// WithNodeStore pages the nodes of the tries added to the forest out to the given node store, so that
+// only the nodes cached by the node store are kept in memory. The nodes of the tries are loaded from the
+// node store on demand.
+func WithNodeStore(store *nodestore.Store) ForestOption {
+	return func(f *Forest) {
+		f.nodeStore = store
+	}
+}
+
+// WithLogger sets the logger of the forest, which logs the nodes of evicted tries that could not be
+// released from the node store.
+func WithLogger(log zerolog.Logger) ForestOption {
+	return func(f *Forest) {
+		f.log = log
+	}
+}
+
+// NodeStore returns the node store the nodes of the tries are paged out to, or nil if the nodes are kept in memory.
+func (f *Forest) NodeStore() *nodestore.Store {
+	return f.nodeStore
 }
 
 // NewForest returns a new instance of memory forest.
@@ -40,17 +84,29 @@ type Forest struct {
 // THIS IS A ROUGH HEURISTIC as it might evict tries that are still needed.
 // Make sure you chose a sufficiently large forestCapacity, such that, when reaching the capacity, the
 // Least Recently Used trie will never be needed again.
-func NewForest(forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie)) (*Forest, error) {
+func NewForest(forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie), opts ...ForestOption) (*Forest, error) {
+	forest := &Forest{
+		forestCapacity: forestCapacity,
+		onTreeEvicted:  onTreeEvicted,
+		metrics:        metrics,
+		log:            zerolog.Nop(),
+		readers:        make(map[ledger.RootHash]int),
+		releases:       make(map[ledger.RootHash]int),
+	}
+	for _, opt := range opts {
+		opt(forest)
+	}
+
 	// init LRU cache as a SHORTCUT for a usage-related storage eviction policy
 	var cache *lru.Cache
 	var err error
-	if onTreeEvicted != nil {
+	if onTreeEvicted != nil || forest.nodeStore != nil {
 		cache, err = lru.NewWithEvict(forestCapacity, func(key interface{}, value interface{}) {
 			trie, ok := value.(*trie.MTrie)
 			if !ok {
 				panic(fmt.Sprintf("cache contains item of type %T", value))
 			}
-			onTreeEvicted(trie)
+			forest.evict(trie)
 		})
 	} else {
 		cache, err = lru.New(forestCapacity)
@@ -58,12 +114,7 @@ func NewForest(forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted f
 	if err != nil {
 		return nil, fmt.Errorf("cannot create forest cache: %w", err)
 	}
-
-	forest := &Forest{tries: cache,
-		forestCapacity: forestCapacity,
-		onTreeEvicted:  onTreeEvicted,
-		metrics:        metrics,
-	}
+	forest.tries = cache
 
 	// add trie with no allocated registers
 	emptyTrie := trie.NewEmptyMTrie()
@@ -74,19 +125,93 @@ func NewForest(forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted f
 	return forest, nil
 }
 
+// evict is called when a trie is removed from the forest, and releases its nodes from the node store.
+// If the trie is held by readers, the release is deferred until the last of them is done.
+func (f *Forest) evict(tree *trie.MTrie) {
+	if f.onTreeEvicted != nil {
+		f.onTreeEvicted(tree)
+	}
+	if f.nodeStore == nil || tree.RootNode() == nil {
+		return
+	}
+
+	rootHash := tree.RootHash()
+	f.mu.Lock()
+	if f.readers[rootHash] > 0 {
+		f.releases[rootHash]++
+		f.mu.Unlock()
+		return
+	}
+	f.mu.Unlock()
+
+	f.releaseNodes(rootHash, 1)
+}
+
+// releaseNodes releases the nodes of the trie with the given root hash from the node store the given
+// number of times. The nodes which can't be released are kept in the node store.
+func (f *Forest) releaseNodes(rootHash ledger.RootHash, count int) {
+	for i := 0; i < count; i++ {
+		err := f.nodeStore.Release(hash.Hash(rootHash))
+		if err != nil {
+			f.log.Error().Err(err).
+				Hex("root_hash", rootHash[:]).
+				Msg("could not release nodes of evicted trie from node store")
+			return
+		}
+	}
+}
+
+// AcquireTrie returns the trie with the given root hash, and a function releasing it, which must be called
+// once the trie is not used anymore. The nodes of an acquired trie are kept in the node store until it is
+// released, even if the trie is evicted from the forest in the meantime.
+func (f *Forest) AcquireTrie(rootHash ledger.RootHash) (*trie.MTrie, func(), error) {
+	// the trie is acquired before it is looked up, so that it can't be released after it was found:
+	// the forest lock is never held while the cache is accessed, as the cache evicts tries under its own lock
+	f.mu.Lock()
+	f.readers[rootHash]++
+	f.mu.Unlock()
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			f.mu.Lock()
+			f.readers[rootHash]--
+			if f.readers[rootHash] > 0 {
+				f.mu.Unlock()
+				return
+			}
+			delete(f.readers, rootHash)
+			count := f.releases[rootHash]
+			delete(f.releases, rootHash)
+			f.mu.Unlock()
+
+			if count > 0 {
+				f.releaseNodes(rootHash, count)
+			}
+		})
+	}
+
+	t, err := f.GetTrie(rootHash)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return t, release, nil
+}
+
 // ValueSizes returns value sizes for a slice of paths and error (if any)
 // TODO: can be optimized further if we don't care about changing the order of the input r.Paths
 func (f *Forest) ValueSizes(r *ledger.TrieRead) ([]int, error) {
-
 	if len(r.Paths) == 0 {
 		return []int{}, nil
 	}
 
 	// lookup the trie by rootHash
-	trie, err := f.GetTrie(r.RootHash)
+	trie, release, err := f.AcquireTrie(r.RootHash)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	// deduplicate paths:
 	// Generally, we expect the VM to deduplicate reads and writes. Hence, the following is a pre-caution.
@@ -104,7 +229,10 @@ func (f *Forest) ValueSizes(r *ledger.TrieRead) ([]int, error) {
 		pathOrgIndex[path] = append(indices, i)
 	}
 
-	sizes := trie.UnsafeValueSizes(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	sizes, err := trie.UnsafeValueSizes(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	if err != nil {
+		return nil, err
+	}
 
 	// reconstruct value sizes in the same key order that called the method
 	orderedValueSizes := make([]int, len(r.Paths))
@@ -126,16 +254,16 @@ func (f *Forest) ValueSizes(r *ledger.TrieRead) ([]int, error) {
 // Read reads values for an slice of paths and returns values and error (if any)
 // TODO: can be optimized further if we don't care about changing the order of the input r.Paths
 func (f *Forest) Read(r *ledger.TrieRead) ([]*ledger.Payload, error) {
-
 	if len(r.Paths) == 0 {
 		return []*ledger.Payload{}, nil
 	}
 
 	// lookup the trie by rootHash
-	trie, err := f.GetTrie(r.RootHash)
+	trie, release, err := f.AcquireTrie(r.RootHash)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	// deduplicate keys:
 	// Generally, we expect the VM to deduplicate reads and writes. Hence, the following is a pre-caution.
@@ -153,7 +281,10 @@ func (f *Forest) Read(r *ledger.TrieRead) ([]*ledger.Payload, error) {
 		pathOrgIndex[path] = append(indices, i)
 	}
 
-	payloads := trie.UnsafeRead(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	payloads, err := trie.UnsafeRead(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	if err != nil {
+		return nil, err
+	}
 
 	// reconstruct the payloads in the same key order that called the method
 	orderedPayloads := make([]*ledger.Payload, len(r.Paths))
@@ -178,10 +309,12 @@ func (f *Forest) Read(r *ledger.TrieRead) ([]*ledger.Payload, error) {
 func (f *Forest) Update(u *ledger.TrieUpdate) (ledger.RootHash, error) {
 	emptyHash := ledger.RootHash(hash.DummyHash)
 
-	parentTrie, err := f.GetTrie(u.RootHash)
+	// the parent trie is held until the updated trie, which shares its nodes, is added to the forest
+	parentTrie, release, err := f.AcquireTrie(u.RootHash)
 	if err != nil {
 		return emptyHash, err
 	}
+	defer release()
 
 	if len(u.Paths) == 0 { // no key no change
 		return u.RootHash, nil
@@ -234,13 +367,23 @@ func (f *Forest) Update(u *ledger.TrieUpdate) (ledger.RootHash, error) {
 	return newTrie.RootHash(), nil
 }
 
+// RangeProof returns a proof of all the allocated registers with a path in the range [start, end]
+// in the trie with the given root hash.
+func (f *Forest) RangeProof(rootHash ledger.RootHash, start, end ledger.Path) (*ledger.TrieRangeProof, error) {
+	stateTrie, release, err := f.AcquireTrie(rootHash)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return stateTrie.RangeProof(start, end)
+}
+
 // Proofs returns a batch proof for the given paths.
 //
 // Proves are generally _not_ provided in the register order of the query.
 // In the current implementation, input paths in the TrieRead `r` are sorted in an ascendent order,
 // The output proofs are provided following the order of the sorted paths.
 func (f *Forest) Proofs(r *ledger.TrieRead) (*ledger.TrieBatchProof, error) {
-
 	// no path, empty batchproof
 	if len(r.Paths) == 0 {
 		return ledger.NewTrieBatchProof(), nil
@@ -262,10 +405,11 @@ func (f *Forest) Proofs(r *ledger.TrieRead) (*ledger.TrieBatchProof, error) {
 		}
 	}
 
-	stateTrie, err := f.GetTrie(r.RootHash)
+	stateTrie, release, err := f.AcquireTrie(r.RootHash)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	// if we have to insert empty values
 	if len(notFoundPaths) > 0 {
@@ -286,12 +430,12 @@ func (f *Forest) Proofs(r *ledger.TrieRead) (*ledger.TrieBatchProof, error) {
 		stateTrie = newTrie
 	}
 
-	bp := stateTrie.UnsafeProofs(r.Paths)
-	return bp, nil
+	return stateTrie.UnsafeProofs(r.Paths)
 }
 
 // GetTrie returns trie at specific rootHash
-// warning, use this function for read-only operation
+// warning, use this function for read-only operation. The nodes of the returned trie might be
+// released from the node store when it is evicted, use AcquireTrie to hold the trie while it is read.
 func (f *Forest) GetTrie(rootHash ledger.RootHash) (*trie.MTrie, error) {
 	// if in memory
 	if ent, found := f.tries.Get(rootHash); found {
@@ -352,6 +496,22 @@ func (f *Forest) AddTrie(newTrie *trie.MTrie) error {
 		}
 		return fmt.Errorf("forest already contains a tree with same root hash but other properties")
 	}
+
+	if f.nodeStore == nil && newTrie.RootNode().IsPaged() {
+		return fmt.Errorf("cannot add trie paged out to a node store to a forest without node store")
+	}
+
+	if f.nodeStore != nil {
+		root, err := f.nodeStore.Page(newTrie.RootNode())
+		if err != nil {
+			return fmt.Errorf("could not page trie out to node store: %w", err)
+		}
+		newTrie, err = trie.NewMTrie(root, newTrie.AllocatedRegCount(), newTrie.AllocatedRegSize())
+		if err != nil {
+			return fmt.Errorf("could not create paged trie: %w", err)
+		}
+	}
+
 	f.tries.Add(rootHash, newTrie)
 	f.metrics.ForestNumberOfTrees(uint64(f.tries.Len()))
 
@@ -365,6 +525,12 @@ func (f *Forest) RemoveTrie(rootHash ledger.RootHash) {
 	f.metrics.ForestNumberOfTrees(uint64(f.tries.Len()))
 }
 
+// Purge removes all the tries from the forest, calling the eviction callback for each of them.
+func (f *Forest) Purge() {
+	f.tries.Purge()
+	f.metrics.ForestNumberOfTrees(uint64(f.tries.Len()))
+}
+
 // GetEmptyRootHash returns the rootHash of empty Trie
 func (f *Forest) GetEmptyRootHash() ledger.RootHash {
 	return trie.EmptyTrieRootHash()
@@ -36,6 +36,47 @@ type Node struct {
 	path      ledger.Path     // the storage path (dummy value for interim nodes)
 	payload   *ledger.Payload // the payload this node is storing (leaf nodes only)
 	hashValue hash.Hash       // hash value of node (cached)
+	paged     *pagedChildren  // children of a node paged out to a node store (paged nodes only)
+}
+
+// Loader loads nodes which are paged out to a node store.
+type Loader interface {
+	// Load returns the node with the given hash.
+	Load(hash hash.Hash) (*Node, error)
+}
+
+// LoadError is returned by LeftChild and RightChild when a child of a paged node can not be
+// loaded from the node store.
+type LoadError struct {
+	Hash hash.Hash
+	Err  error
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("could not load trie node %x: %v", e.Hash[:], e.Err)
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// load loads the child of a paged node with the given hash.
+func (p *pagedChildren) load(h hash.Hash) (*Node, error) {
+	n, err := p.loader.Load(h)
+	if err != nil {
+		return nil, &LoadError{Hash: h, Err: err}
+	}
+	return n, nil
+}
+
+// pagedChildren references the children of a paged node by hash, the children are loaded
+// from the node store when they are accessed.
+type pagedChildren struct {
+	loader   Loader
+	lHash    hash.Hash
+	rHash    hash.Hash
+	hasLeft  bool
+	hasRight bool
 }
 
 // NewNode creates a new Node.
@@ -59,6 +100,37 @@ func NewNode(height int,
 	return n
 }
 
+// NewPagedNode creates a Node which is stored in a node store. Instead of holding its children,
+// the node references them by hash, and they are loaded through the given loader when accessed.
+// A nil child hash represents an empty sub-trie.
+// UNCHECKED requirement: combination of values must conform to
+// a valid node type (see documentation of `Node` for details)
+func NewPagedNode(height int,
+	lHash,
+	rHash *hash.Hash,
+	path ledger.Path,
+	payload *ledger.Payload,
+	hashValue hash.Hash,
+	loader Loader,
+) *Node {
+	paged := &pagedChildren{loader: loader}
+	if lHash != nil {
+		paged.lHash = *lHash
+		paged.hasLeft = true
+	}
+	if rHash != nil {
+		paged.rHash = *rHash
+		paged.hasRight = true
+	}
+	return &Node{
+		height:    height,
+		path:      path,
+		payload:   payload,
+		hashValue: hashValue,
+		paged:     paged,
+	}
+}
+
 // NewLeaf creates a compact leaf Node.
 // UNCHECKED requirement: height must be non-negative
 // UNCHECKED requirement: payload is non nil
@@ -144,7 +216,7 @@ func (n *Node) IsDefaultNode() bool {
 // computeHash returns the hashValue of the node
 func (n *Node) computeHash() hash.Hash {
 	// check for leaf node
-	if n.lChild == nil && n.rChild == nil {
+	if n.IsLeaf() {
 		// if payload is non-nil, compute the hash based on the payload content
 		if n.payload != nil {
 			return ledger.ComputeCompactValue(hash.Hash(n.path), n.payload.Value, n.height)
@@ -154,36 +226,41 @@ func (n *Node) computeHash() hash.Hash {
 	}
 
 	// this is an interim node at least one of lChild or rChild is not nil.
-	var h1, h2 hash.Hash
-	if n.lChild != nil {
-		h1 = n.lChild.Hash()
-	} else {
+	h1, ok := n.LeftChildHash()
+	if !ok {
 		h1 = ledger.GetDefaultHashForHeight(n.height - 1)
 	}
 
-	if n.rChild != nil {
-		h2 = n.rChild.Hash()
-	} else {
+	h2, ok := n.RightChildHash()
+	if !ok {
 		h2 = ledger.GetDefaultHashForHeight(n.height - 1)
 	}
 	return hash.HashInterNode(h1, h2)
 }
 
 // VerifyCachedHash verifies the hash of a node is valid
-func verifyCachedHashRecursive(n *Node) bool {
+func verifyCachedHashRecursive(n *Node) (bool, error) {
 	if n == nil {
-		return true
+		return true, nil
+	}
+	lChild, rChild, err := n.Children()
+	if err != nil {
+		return false, err
 	}
-	if !verifyCachedHashRecursive(n.lChild) || !verifyCachedHashRecursive(n.rChild) {
-		return false
+	for _, child := range []*Node{lChild, rChild} {
+		valid, err := verifyCachedHashRecursive(child)
+		if err != nil || !valid {
+			return false, err
+		}
 	}
 
 	computedHash := n.computeHash()
-	return n.hashValue == computedHash
+	return n.hashValue == computedHash, nil
 }
 
-// VerifyCachedHash verifies the hash of a node is valid
-func (n *Node) VerifyCachedHash() bool {
+// VerifyCachedHash verifies the hash of a node is valid. It returns an error if a child of a paged
+// node can not be loaded.
+func (n *Node) VerifyCachedHash() (bool, error) {
 	return verifyCachedHashRecursive(n)
 }
 
@@ -216,30 +293,97 @@ func (n *Node) Payload() *ledger.Payload {
 }
 
 // LeftChild returns the the Node's left child.
-// Only INTERIM nodes have children.
+// Only INTERIM nodes have children. The children of paged nodes are loaded from the node store,
+// a *LoadError is returned if the child can not be loaded. Nodes which are not paged never return an error.
 // Do NOT MODIFY returned Node!
-func (n *Node) LeftChild() *Node { return n.lChild }
+func (n *Node) LeftChild() (*Node, error) {
+	if n.paged != nil && n.paged.hasLeft {
+		return n.paged.load(n.paged.lHash)
+	}
+	return n.lChild, nil
+}
 
 // RightChild returns the the Node's right child.
-// Only INTERIM nodes have children.
+// Only INTERIM nodes have children. The children of paged nodes are loaded from the node store,
+// a *LoadError is returned if the child can not be loaded. Nodes which are not paged never return an error.
 // Do NOT MODIFY returned Node!
-func (n *Node) RightChild() *Node { return n.rChild }
+func (n *Node) RightChild() (*Node, error) {
+	if n.paged != nil && n.paged.hasRight {
+		return n.paged.load(n.paged.rHash)
+	}
+	return n.rChild, nil
+}
+
+// Children returns the Node's left and right children (see LeftChild and RightChild).
+// Do NOT MODIFY returned Nodes!
+func (n *Node) Children() (*Node, *Node, error) {
+	lChild, err := n.LeftChild()
+	if err != nil {
+		return nil, nil, err
+	}
+	rChild, err := n.RightChild()
+	if err != nil {
+		return nil, nil, err
+	}
+	return lChild, rChild, nil
+}
+
+// LeftChildHash returns the hash of the Node's left child, without loading the child
+// of a paged node, and whether the Node has a left child.
+func (n *Node) LeftChildHash() (hash.Hash, bool) {
+	if n.paged != nil {
+		return n.paged.lHash, n.paged.hasLeft
+	}
+	if n.lChild == nil {
+		return hash.DummyHash, false
+	}
+	return n.lChild.hashValue, true
+}
+
+// RightChildHash returns the hash of the Node's right child, without loading the child
+// of a paged node, and whether the Node has a right child.
+func (n *Node) RightChildHash() (hash.Hash, bool) {
+	if n.paged != nil {
+		return n.paged.rHash, n.paged.hasRight
+	}
+	if n.rChild == nil {
+		return hash.DummyHash, false
+	}
+	return n.rChild.hashValue, true
+}
+
+// IsPaged returns true if and only if the Node is stored in a node store, in which case
+// its children are stored as well.
+func (n *Node) IsPaged() bool {
+	return n != nil && n.paged != nil
+}
 
 // IsLeaf returns true if and only if Node is a LEAF.
 func (n *Node) IsLeaf() bool {
 	// Per definition, a node is a leaf if and only it has no children
-	return n == nil || (n.lChild == nil && n.rChild == nil)
+	if n == nil {
+		return true
+	}
+	if n.paged != nil {
+		return !n.paged.hasLeft && !n.paged.hasRight
+	}
+	return n.lChild == nil && n.rChild == nil
 }
 
 // FmtStr provides formatted string representation of the Node and sub tree
+// The children of paged nodes which can not be loaded are represented by the load error.
 func (n *Node) FmtStr(prefix string, subpath string) string {
 	right := ""
-	if n.rChild != nil {
-		right = fmt.Sprintf("\n%v", n.rChild.FmtStr(prefix+"\t", subpath+"1"))
+	if rChild, err := n.RightChild(); err != nil {
+		right = fmt.Sprintf("\n%v%v", prefix+"\t", err)
+	} else if rChild != nil {
+		right = fmt.Sprintf("\n%v", rChild.FmtStr(prefix+"\t", subpath+"1"))
 	}
 	left := ""
-	if n.lChild != nil {
-		left = fmt.Sprintf("\n%v", n.lChild.FmtStr(prefix+"\t", subpath+"0"))
+	if lChild, err := n.LeftChild(); err != nil {
+		left = fmt.Sprintf("\n%v%v", prefix+"\t", err)
+	} else if lChild != nil {
+		left = fmt.Sprintf("\n%v", lChild.FmtStr(prefix+"\t", subpath+"0"))
 	}
 	payloadSize := 0
 	if n.payload != nil {
@@ -251,20 +395,26 @@ func (n *Node) FmtStr(prefix string, subpath string) string {
 }
 
 // AllPayloads returns the payload of this node and all payloads of the subtrie
-func (n *Node) AllPayloads() []ledger.Payload {
+func (n *Node) AllPayloads() ([]ledger.Payload, error) {
 	return n.appendSubtreePayloads([]ledger.Payload{})
 }
 
 // appendSubtreePayloads appends the payloads of the subtree with this node as root
 // to the provided Payload slice. Follows same pattern as Go's native append method.
-func (n *Node) appendSubtreePayloads(result []ledger.Payload) []ledger.Payload {
+func (n *Node) appendSubtreePayloads(result []ledger.Payload) ([]ledger.Payload, error) {
 	if n == nil {
-		return result
+		return result, nil
 	}
 	if n.IsLeaf() {
-		return append(result, *n.Payload())
+		return append(result, *n.Payload()), nil
+	}
+	lChild, rChild, err := n.Children()
+	if err != nil {
+		return nil, err
+	}
+	result, err = lChild.appendSubtreePayloads(result)
+	if err != nil {
+		return nil, err
 	}
-	result = n.lChild.appendSubtreePayloads(result)
-	result = n.rChild.appendSubtreePayloads(result)
-	return result
+	return rChild.appendSubtreePayloads(result)
 }
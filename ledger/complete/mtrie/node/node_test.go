@@ -19,7 +19,7 @@ func Test_ProperLeaf(t *testing.T) {
 	n := node.NewLeaf(path, payload, 0)
 	expectedRootHashHex := "0ee164bc69981088186b5ceeb666e90e8e11bb15a1427aa56f47a484aedf73b4"
 	require.Equal(t, expectedRootHashHex, hashToString(n.Hash()))
-	require.True(t, n.VerifyCachedHash())
+	requireValidCachedHash(t, n)
 }
 
 // Test_CompactifiedLeaf verifies that the hash value of a compactified leaf (at height > 0) is computed correctly.
@@ -100,7 +100,9 @@ func Test_AllPayloads(t *testing.T) {
 	n3 := node.NewLeaf(path, payload, 1)
 	n4 := node.NewInterimNode(1, n1, n2)
 	n5 := node.NewInterimNode(2, n4, n3)
-	require.Equal(t, 3, len(n5.AllPayloads()))
+	payloads, err := n5.AllPayloads()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(payloads))
 }
 
 func Test_VerifyCachedHash(t *testing.T) {
@@ -111,7 +113,7 @@ func Test_VerifyCachedHash(t *testing.T) {
 	n3 := node.NewLeaf(path, payload, 1)
 	n4 := node.NewInterimNode(1, n1, n2)
 	n5 := node.NewInterimNode(2, n4, n3)
-	require.True(t, n5.VerifyCachedHash())
+	requireValidCachedHash(t, n5)
 }
 
 // Test_Compactify_EmptySubtrie tests constructing an interim node
@@ -210,9 +212,8 @@ func Test_Compactify_EmptyChild(t *testing.T) {
 		// Constructing a trie with pruning/compactification should result
 		// in n4 being replaced with nil, while keeping the root hash invariant.
 		nn5 := node.NewInterimCompactifiedNode(6, n3, n4)
-		require.Equal(t, n3, nn5.LeftChild())
-		require.Nil(t, nn5.RightChild())
-		require.True(t, nn5.VerifyCachedHash())
+		requireChildren(t, nn5, n3, nil)
+		requireValidCachedHash(t, nn5)
 		require.Equal(t, n5.Hash(), nn5.Hash())
 	})
 
@@ -232,9 +233,8 @@ func Test_Compactify_EmptyChild(t *testing.T) {
 		// Constructing a trie with pruning/compactification should result
 		// in n4 being replaced with nil, while keeping the root hash invariant.
 		nn5 := node.NewInterimCompactifiedNode(6, n3, n4)
-		require.Nil(t, nn5.LeftChild())
-		require.Equal(t, n4, nn5.RightChild())
-		require.True(t, nn5.VerifyCachedHash())
+		requireChildren(t, nn5, nil, n4)
+		requireValidCachedHash(t, nn5)
 		require.Equal(t, n5.Hash(), nn5.Hash())
 	})
 
@@ -264,15 +264,13 @@ func Test_Compactify_BothChildrenPopulated(t *testing.T) {
 	// Constructing a trie with pruning/compactification should result
 	// reproduce exactly the same trie as no pruning/compactification is possible
 	nn3 := node.NewInterimCompactifiedNode(5, n1, n2)
-	require.Equal(t, n1, nn3.LeftChild())
-	require.Equal(t, n2, nn3.RightChild())
-	require.True(t, nn3.VerifyCachedHash())
+	requireChildren(t, nn3, n1, n2)
+	requireValidCachedHash(t, nn3)
 	require.Equal(t, n3.Hash(), nn3.Hash())
 
 	nn5 := node.NewInterimCompactifiedNode(6, nn3, n4)
-	require.Equal(t, nn3, nn5.LeftChild())
-	require.Equal(t, n4, nn5.RightChild())
-	require.True(t, nn5.VerifyCachedHash())
+	requireChildren(t, nn5, nn3, n4)
+	requireValidCachedHash(t, nn5)
 	require.Equal(t, n5.Hash(), nn5.Hash())
 }
 
@@ -289,9 +287,23 @@ func hashToString(hash hash.Hash) string {
 // * re-computing the hash from the children yields the pre-computed value
 // * node reports itself as a leaf
 func requireIsLeafWithHash(t *testing.T, node *node.Node, expectedHash hash.Hash) {
-	require.Nil(t, node.LeftChild())
-	require.Nil(t, node.RightChild())
+	requireChildren(t, node, nil, nil)
 	require.Equal(t, expectedHash, node.Hash())
-	require.True(t, node.VerifyCachedHash())
+	requireValidCachedHash(t, node)
 	require.True(t, node.IsLeaf())
 }
+
+// requireChildren verifies that the children of `n` are the expected nodes.
+func requireChildren(t *testing.T, n *node.Node, expectedLeft *node.Node, expectedRight *node.Node) {
+	lChild, rChild, err := n.Children()
+	require.NoError(t, err)
+	require.Equal(t, expectedLeft, lChild)
+	require.Equal(t, expectedRight, rChild)
+}
+
+// requireValidCachedHash verifies that re-computing the hash of `n` from its children yields the pre-computed value.
+func requireValidCachedHash(t *testing.T, n *node.Node) {
+	valid, err := n.VerifyCachedHash()
+	require.NoError(t, err)
+	require.True(t, valid)
+}
@@ -0,0 +1,172 @@
+package nodestore
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+const (
+	leafNodeType byte = iota
+	interimNodeType
+)
+
+const (
+	hasLeftChild byte = 1 << iota
+	hasRightChild
+)
+
+const (
+	encNodeTypeSize = 1
+	encHeightSize   = 2
+	encHashSize     = hash.HashLen
+	encPathSize     = ledger.PathLen
+	encChildrenSize = 1
+)
+
+const payloadEncodingVersion = 1
+
+// encodeNode encodes a node in the following format:
+// - node type (1 byte)
+// - height (2 bytes)
+// - hash (32 bytes)
+// For leaf nodes:
+// - path (32 bytes)
+// - payload (n bytes)
+// For interim nodes:
+// - children flags (1 byte)
+// - left child hash (32 bytes, if it has a left child)
+// - right child hash (32 bytes, if it has a right child)
+func encodeNode(n *node.Node) []byte {
+	if n.IsLeaf() {
+		encPayloadSize := encoding.EncodedPayloadLengthWithoutPrefix(n.Payload(), payloadEncodingVersion)
+		buf := make([]byte, encNodeTypeSize+encHeightSize+encHashSize+encPathSize, encNodeTypeSize+encHeightSize+encHashSize+encPathSize+encPayloadSize)
+
+		pos := encodeHeader(buf, leafNodeType, n)
+		path := n.Path()
+		copy(buf[pos:], path[:])
+
+		return encoding.EncodeAndAppendPayloadWithoutPrefix(buf, n.Payload(), payloadEncodingVersion)
+	}
+
+	buf := make([]byte, encNodeTypeSize+encHeightSize+encHashSize+encChildrenSize+2*encHashSize)
+	pos := encodeHeader(buf, interimNodeType, n)
+
+	flagsPos := pos
+	pos += encChildrenSize
+
+	if lHash, ok := n.LeftChildHash(); ok {
+		buf[flagsPos] |= hasLeftChild
+		copy(buf[pos:], lHash[:])
+		pos += encHashSize
+	}
+	if rHash, ok := n.RightChildHash(); ok {
+		buf[flagsPos] |= hasRightChild
+		copy(buf[pos:], rHash[:])
+		pos += encHashSize
+	}
+
+	return buf[:pos]
+}
+
+// encodeHeader encodes the node type, height and hash of a node, and returns the encoded size.
+func encodeHeader(buf []byte, nodeType byte, n *node.Node) int {
+	pos := 0
+
+	buf[pos] = nodeType
+	pos += encNodeTypeSize
+
+	binary.BigEndian.PutUint16(buf[pos:], uint16(n.Height()))
+	pos += encHeightSize
+
+	h := n.Hash()
+	copy(buf[pos:], h[:])
+	pos += encHashSize
+
+	return pos
+}
+
+// decodeNode decodes a node encoded by encodeNode, as a paged node whose children are loaded by
+// the given loader. The encoded data is copied.
+func decodeNode(data []byte, loader node.Loader) (*node.Node, error) {
+	const headerSize = encNodeTypeSize + encHeightSize + encHashSize
+	if len(data) < headerSize {
+		return nil, fmt.Errorf("encoded node is too short: %d bytes", len(data))
+	}
+
+	pos := 0
+
+	nodeType := data[pos]
+	pos += encNodeTypeSize
+
+	height := binary.BigEndian.Uint16(data[pos:])
+	pos += encHeightSize
+
+	nodeHash, err := hash.ToHash(data[pos : pos+encHashSize])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hash of node: %w", err)
+	}
+	pos += encHashSize
+
+	switch nodeType {
+	case leafNodeType:
+		if len(data) < pos+encPathSize {
+			return nil, fmt.Errorf("encoded leaf node is too short: %d bytes", len(data))
+		}
+
+		path, err := ledger.ToPath(data[pos : pos+encPathSize])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode path of node: %w", err)
+		}
+		pos += encPathSize
+
+		payload, err := encoding.DecodePayloadWithoutPrefix(data[pos:], false, payloadEncodingVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode payload of node: %w", err)
+		}
+
+		return node.NewPagedNode(int(height), nil, nil, path, payload, nodeHash, loader), nil
+
+	case interimNodeType:
+		if len(data) < pos+encChildrenSize {
+			return nil, fmt.Errorf("encoded interim node is too short: %d bytes", len(data))
+		}
+
+		flags := data[pos]
+		pos += encChildrenSize
+
+		var lHash, rHash *hash.Hash
+		if flags&hasLeftChild != 0 {
+			lHash, pos, err = decodeChildHash(data, pos)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode left child of node: %w", err)
+			}
+		}
+		if flags&hasRightChild != 0 {
+			rHash, _, err = decodeChildHash(data, pos)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode right child of node: %w", err)
+			}
+		}
+
+		return node.NewPagedNode(int(height), lHash, rHash, ledger.DummyPath, nil, nodeHash, loader), nil
+
+	default:
+		return nil, fmt.Errorf("failed to decode node type %d", nodeType)
+	}
+}
+
+func decodeChildHash(data []byte, pos int) (*hash.Hash, int, error) {
+	if len(data) < pos+encHashSize {
+		return nil, pos, fmt.Errorf("encoded node is too short: %d bytes", len(data))
+	}
+	h, err := hash.ToHash(data[pos : pos+encHashSize])
+	if err != nil {
+		return nil, pos, err
+	}
+	return &h, pos + encHashSize, nil
+}
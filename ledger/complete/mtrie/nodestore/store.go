@@ -0,0 +1,441 @@
+// Package nodestore implements a disk-backed store of trie nodes, which allows the forest to keep the
+// nodes of its tries out of memory.
+package nodestore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/hashicorp/golang-lru/simplelru"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/module"
+)
+
+// nodeOverhead is the approximate memory size of a cached node, without its payload.
+const nodeOverhead = 256
+
+// key prefixes of the stored nodes and of their reference counts
+const (
+	codeNode     byte = 1
+	codeRefCount byte = 2
+)
+
+// Store is a disk-backed store of trie nodes, keyed by node hash. Nodes are paged out to the store
+// once their trie is added to the forest, and loaded on demand when their parent is traversed.
+// Loaded nodes are cached in memory by a LRU cache, whose approximate memory size is bounded by the
+// memory budget of the store.
+//
+// Nodes are content-addressed, nodes shared by several tries are therefore only stored once. Stored
+// nodes are reference counted: a node is referenced by each stored parent, and by each trie of a forest
+// it is the root of. Once the last reference to a node is released, for example when its trie is
+// evicted from the forest, the node is removed and its children are released in turn. The store is
+// reset when it is opened, since the forest is restored from the checkpoint and write-ahead log.
+type Store struct {
+	db           *badger.DB
+	metrics      module.LedgerMetrics
+	log          zerolog.Logger
+	memoryBudget uint64
+
+	mu         sync.Mutex
+	cache      *simplelru.LRU
+	cachedSize uint64 // approximate memory size of the cached nodes
+
+	// writeMu serializes the updates of the reference counts
+	writeMu sync.Mutex
+}
+
+// New opens the node store in the given directory, removing the nodes stored previously. The memory
+// size of the nodes cached by the store is kept approximately below the given memory budget.
+func New(dir string, memoryBudget uint64, metrics module.LedgerMetrics, log zerolog.Logger) (*Store, error) {
+	if memoryBudget < nodeOverhead {
+		return nil, fmt.Errorf("memory budget must be at least %d bytes", nodeOverhead)
+	}
+
+	db, err := badger.Open(badger.DefaultOptions(dir).WithLogger(nil))
+	if err != nil {
+		return nil, fmt.Errorf("could not open node store: %w", err)
+	}
+
+	err = db.DropAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not reset node store: %w", err)
+	}
+
+	s := &Store{
+		db:           db,
+		metrics:      metrics,
+		log:          log.With().Str("component", "mtrie_node_store").Logger(),
+		memoryBudget: memoryBudget,
+	}
+
+	// the cache is bounded by the memory budget, the number of cached nodes is only bounded
+	// by the number of nodes fitting in the budget
+	s.cache, err = simplelru.NewLRU(int(memoryBudget/nodeOverhead), func(_ interface{}, value interface{}) {
+		s.cachedSize -= nodeSize(value.(*node.Node))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create node cache: %w", err)
+	}
+
+	return s, nil
+}
+
+// Load returns the node with the given hash, from the cache or from disk.
+func (s *Store) Load(h hash.Hash) (*node.Node, error) {
+	s.mu.Lock()
+	cached, ok := s.cache.Get(h)
+	s.mu.Unlock()
+	if ok {
+		s.metrics.NodeStoreCacheHit()
+		return cached.(*node.Node), nil
+	}
+
+	s.metrics.NodeStoreCacheMiss()
+
+	var n *node.Node
+	err := s.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(nodeKey(h))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			n, err = decodeNode(val, s)
+			return err
+		})
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, fmt.Errorf("trie node %x is not stored", h[:])
+	}
+	if err != nil {
+		s.log.Error().Err(err).Hex("hash", h[:]).Msg("could not load trie node")
+		return nil, fmt.Errorf("could not load trie node %x: %w", h[:], err)
+	}
+
+	s.add(n)
+
+	return n, nil
+}
+
+// Page adds a reference to the given root node, stores the nodes of its sub-trie which are not stored
+// yet, and returns the root as a paged node, whose descendants are loaded from the store when they are
+// accessed. The reference must be released once the sub-trie is not used anymore, see Release.
+func (s *Store) Page(root *node.Node) (*node.Node, error) {
+	if root == nil {
+		return nil, nil
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	refs := s.newRefCounter()
+	defer refs.cancel()
+
+	paged, err := s.page(root, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = refs.flush()
+	if err != nil {
+		return nil, err
+	}
+
+	return paged, nil
+}
+
+// PageNodes adds a reference to each of the given nodes like Page, in a single write to disk.
+func (s *Store) PageNodes(nodes []*node.Node) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	refs := s.newRefCounter()
+	defer refs.cancel()
+
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		_, err := s.page(n, refs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return refs.flush()
+}
+
+// page adds a reference to the given node. If the node is not stored yet, it references its children,
+// descendants first, and writes the node.
+func (s *Store) page(n *node.Node, refs *refCounter) (*node.Node, error) {
+	h := n.Hash()
+	count, err := refs.get(h)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > 0 {
+		err = refs.set(h, count+1)
+		if err != nil {
+			return nil, err
+		}
+		if n.IsPaged() {
+			return n, nil
+		}
+		return s.pagedNode(n), nil
+	}
+
+	if n.IsPaged() {
+		return nil, fmt.Errorf("paged trie node %x is not stored", h[:])
+	}
+
+	// the node is not paged, its children are therefore held in memory
+	l, r, err := n.Children()
+	if err != nil {
+		return nil, err
+	}
+	if l != nil {
+		_, err = s.page(l, refs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r != nil {
+		_, err = s.page(r, refs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = refs.store(n)
+	if err != nil {
+		return nil, err
+	}
+
+	// recently written nodes are likely to be read again soon, they are therefore cached
+	paged := s.pagedNode(n)
+	s.add(paged)
+
+	return paged, nil
+}
+
+// pagedNode returns the paged node of the given node, whose children are stored.
+func (s *Store) pagedNode(n *node.Node) *node.Node {
+	var lHash, rHash *hash.Hash
+	if l, ok := n.LeftChildHash(); ok {
+		lHash = &l
+	}
+	if r, ok := n.RightChildHash(); ok {
+		rHash = &r
+	}
+
+	path := ledger.DummyPath
+	if p := n.Path(); p != nil {
+		path = *p
+	}
+
+	return node.NewPagedNode(n.Height(), lHash, rHash, path, n.Payload(), n.Hash(), s)
+}
+
+// Release releases a reference to each of the nodes with the given hashes. Nodes which are not
+// referenced anymore are removed, and their children are released in turn.
+func (s *Store) Release(hashes ...hash.Hash) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	refs := s.newRefCounter()
+	defer refs.cancel()
+
+	for _, h := range hashes {
+		err := s.release(h, refs)
+		if err != nil {
+			s.log.Error().Err(err).Hex("hash", h[:]).Msg("could not release trie node")
+			return err
+		}
+	}
+
+	return refs.flush()
+}
+
+// release releases a reference to the node with the given hash, and removes it if it is not referenced anymore.
+func (s *Store) release(h hash.Hash, refs *refCounter) error {
+	count, err := refs.get(h)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("trie node %x is not referenced", h[:])
+	}
+	if count > 1 {
+		return refs.set(h, count-1)
+	}
+
+	n, err := s.Load(h)
+	if err != nil {
+		return err
+	}
+
+	err = refs.remove(h)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.cache.Remove(h)
+	s.metrics.NodeStoreCacheSize(s.cachedSize)
+	s.mu.Unlock()
+
+	if l, ok := n.LeftChildHash(); ok {
+		err = s.release(l, refs)
+		if err != nil {
+			return err
+		}
+	}
+	if r, ok := n.RightChildHash(); ok {
+		err = s.release(r, refs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// add adds a paged node to the cache, and evicts the least recently used nodes exceeding the memory budget.
+func (s *Store) add(n *node.Node) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache.Contains(n.Hash()) {
+		return
+	}
+
+	s.cache.Add(n.Hash(), n)
+	s.cachedSize += nodeSize(n)
+	for s.cachedSize > s.memoryBudget && s.cache.Len() > 1 {
+		s.cache.RemoveOldest()
+	}
+
+	s.metrics.NodeStoreCacheSize(s.cachedSize)
+}
+
+// Close closes the node store.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// nodeSize returns the approximate memory size of a node.
+func nodeSize(n *node.Node) uint64 {
+	size := uint64(nodeOverhead)
+	if payload := n.Payload(); payload != nil {
+		size += uint64(payload.Size())
+	}
+	return size
+}
+
+// refCounter updates the reference counts of stored nodes in a single write batch. The counts updated
+// by the batch are kept in memory until it is flushed, since the batch can not be read.
+type refCounter struct {
+	db      *badger.DB
+	batch   *badger.WriteBatch
+	pending map[hash.Hash]uint64
+}
+
+func (s *Store) newRefCounter() *refCounter {
+	return &refCounter{
+		db:      s.db,
+		batch:   s.db.NewWriteBatch(),
+		pending: make(map[hash.Hash]uint64),
+	}
+}
+
+// get returns the reference count of the node with the given hash, zero if it is not stored.
+func (c *refCounter) get(h hash.Hash) (uint64, error) {
+	if count, ok := c.pending[h]; ok {
+		return count, nil
+	}
+
+	var count uint64
+	err := c.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(refCountKey(h))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			if len(val) != 8 {
+				return fmt.Errorf("invalid reference count length %d", len(val))
+			}
+			count = binary.BigEndian.Uint64(val)
+			return nil
+		})
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not read reference count of trie node %x: %w", h[:], err)
+	}
+
+	return count, nil
+}
+
+func (c *refCounter) set(h hash.Hash, count uint64) error {
+	c.pending[h] = count
+	val := make([]byte, 8)
+	binary.BigEndian.PutUint64(val, count)
+	err := c.batch.Set(refCountKey(h), val)
+	if err != nil {
+		return fmt.Errorf("could not write reference count of trie node %x: %w", h[:], err)
+	}
+	return nil
+}
+
+// store writes the given node, referenced once.
+func (c *refCounter) store(n *node.Node) error {
+	h := n.Hash()
+	err := c.batch.Set(nodeKey(h), encodeNode(n))
+	if err != nil {
+		return fmt.Errorf("could not store trie node %x: %w", h[:], err)
+	}
+	return c.set(h, 1)
+}
+
+// remove removes the node with the given hash and its reference count.
+func (c *refCounter) remove(h hash.Hash) error {
+	c.pending[h] = 0
+	err := c.batch.Delete(nodeKey(h))
+	if err != nil {
+		return fmt.Errorf("could not remove trie node %x: %w", h[:], err)
+	}
+	err = c.batch.Delete(refCountKey(h))
+	if err != nil {
+		return fmt.Errorf("could not remove reference count of trie node %x: %w", h[:], err)
+	}
+	return nil
+}
+
+func (c *refCounter) flush() error {
+	err := c.batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not store trie nodes: %w", err)
+	}
+	return nil
+}
+
+func (c *refCounter) cancel() {
+	c.batch.Cancel()
+}
+
+func nodeKey(h hash.Hash) []byte {
+	return append([]byte{codeNode}, h[:]...)
+}
+
+func refCountKey(h hash.Hash) []byte {
+	return append([]byte{codeRefCount}, h[:]...)
+}
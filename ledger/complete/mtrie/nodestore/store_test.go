@@ -0,0 +1,451 @@
+package nodestore_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/nodestore"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const memoryBudget = 16 * 1024
+
+// storeMetrics records the node store metrics reported to it.
+type storeMetrics struct {
+	metrics.NoopCollector
+	mu            sync.Mutex
+	hits          int
+	misses        int
+	cachedSize    uint64
+	maxCachedSize uint64
+}
+
+func (m *storeMetrics) NodeStoreCacheHit() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hits++
+}
+
+func (m *storeMetrics) NodeStoreCacheMiss() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.misses++
+}
+
+func (m *storeMetrics) NodeStoreCacheSize(bytes uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cachedSize = bytes
+	if bytes > m.maxCachedSize {
+		m.maxCachedSize = bytes
+	}
+}
+
+// runWithForests runs the given function with an in-memory forest and a forest backed by a node store.
+func runWithForests(t *testing.T, collector *storeMetrics, f func(inMemory *mtrie.Forest, paged *mtrie.Forest)) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		store, err := nodestore.New(dir, memoryBudget, collector, zerolog.Nop())
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, store.Close())
+		}()
+
+		inMemory, err := mtrie.NewForest(10, &metrics.NoopCollector{}, nil)
+		require.NoError(t, err)
+
+		paged, err := mtrie.NewForest(10, collector, nil, mtrie.WithNodeStore(store))
+		require.NoError(t, err)
+
+		f(inMemory, paged)
+	})
+}
+
+// applyRandomUpdates applies the same random updates to both forests, and returns the resulting
+// root hashes and the updated paths, without repetition.
+func applyRandomUpdates(t *testing.T, inMemory *mtrie.Forest, paged *mtrie.Forest, steps int) ([]ledger.RootHash, []ledger.Path) {
+	rootHash := inMemory.GetEmptyRootHash()
+	require.Equal(t, rootHash, paged.GetEmptyRootHash())
+
+	var rootHashes []ledger.RootHash
+	var allPaths []ledger.Path
+	updatedPaths := make(map[ledger.Path]struct{})
+
+	for i := 0; i < steps; i++ {
+		paths := utils.RandomPaths(50)
+		payloads := utils.RandomPayloads(len(paths), 1, 100)
+
+		// overwrite and remove some of the previously updated registers
+		for j := 0; j < 10 && i > 0; j++ {
+			paths = append(paths, allPaths[rand.Intn(len(allPaths))])
+			payload := utils.RandomPayload(1, 100)
+			if j%2 == 0 {
+				payload = ledger.EmptyPayload()
+			}
+			payloads = append(payloads, payload)
+		}
+
+		update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads}
+
+		expected, err := inMemory.Update(update)
+		require.NoError(t, err)
+
+		actual, err := paged.Update(update)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		rootHash = expected
+		rootHashes = append(rootHashes, rootHash)
+		for _, path := range paths {
+			if _, ok := updatedPaths[path]; !ok {
+				updatedPaths[path] = struct{}{}
+				allPaths = append(allPaths, path)
+			}
+		}
+	}
+
+	return rootHashes, allPaths
+}
+
+// TestStore_ForestMatchesInMemoryForest tests that reads, value sizes and proofs of a forest backed by a
+// node store are the same as the ones of an in-memory forest.
+func TestStore_ForestMatchesInMemoryForest(t *testing.T) {
+	collector := &storeMetrics{}
+	runWithForests(t, collector, func(inMemory *mtrie.Forest, paged *mtrie.Forest) {
+		rootHashes, allPaths := applyRandomUpdates(t, inMemory, paged, 10)
+
+		for _, rootHash := range rootHashes {
+			read := &ledger.TrieRead{RootHash: rootHash, Paths: allPaths}
+
+			expectedPayloads, err := inMemory.Read(read)
+			require.NoError(t, err)
+			actualPayloads, err := paged.Read(read)
+			require.NoError(t, err)
+			require.Equal(t, len(expectedPayloads), len(actualPayloads))
+			for i := range expectedPayloads {
+				require.True(t, expectedPayloads[i].Equals(actualPayloads[i]))
+			}
+
+			expectedSizes, err := inMemory.ValueSizes(read)
+			require.NoError(t, err)
+			actualSizes, err := paged.ValueSizes(read)
+			require.NoError(t, err)
+			require.Equal(t, expectedSizes, actualSizes)
+
+			expectedProofs, err := inMemory.Proofs(read)
+			require.NoError(t, err)
+			actualProofs, err := paged.Proofs(read)
+			require.NoError(t, err)
+			require.Equal(t, encoding.EncodeTrieBatchProof(expectedProofs), encoding.EncodeTrieBatchProof(actualProofs))
+		}
+	})
+}
+
+// TestStore_MemoryBudget tests that the cached nodes are kept within the memory budget, and that
+// evicted nodes are loaded from disk.
+func TestStore_MemoryBudget(t *testing.T) {
+	collector := &storeMetrics{}
+	runWithForests(t, collector, func(inMemory *mtrie.Forest, paged *mtrie.Forest) {
+		rootHashes, allPaths := applyRandomUpdates(t, inMemory, paged, 5)
+
+		_, err := paged.Read(&ledger.TrieRead{RootHash: rootHashes[0], Paths: allPaths})
+		require.NoError(t, err)
+
+		require.LessOrEqual(t, collector.maxCachedSize, uint64(memoryBudget))
+		require.Greater(t, collector.misses, 0)
+		require.Greater(t, collector.hits, 0)
+	})
+}
+
+// TestStore_Checkpoint tests that a checkpoint of tries backed by a node store can be restored.
+func TestStore_Checkpoint(t *testing.T) {
+	collector := &storeMetrics{}
+	runWithForests(t, collector, func(inMemory *mtrie.Forest, paged *mtrie.Forest) {
+		_, allPaths := applyRandomUpdates(t, inMemory, paged, 5)
+
+		tries, err := paged.GetTries()
+		require.NoError(t, err)
+
+		unittest.RunWithTempDir(t, func(dir string) {
+			checkpointPath := filepath.Join(dir, "checkpoint")
+			file, err := os.Create(checkpointPath)
+			require.NoError(t, err)
+
+			err = wal.StoreCheckpoint(file, tries...)
+			require.NoError(t, err)
+			require.NoError(t, file.Close())
+
+			logger := zerolog.Nop()
+			restoredTries, err := wal.LoadCheckpoint(checkpointPath, &logger)
+			require.NoError(t, err)
+			require.Equal(t, len(tries), len(restoredTries))
+
+			restored, err := mtrie.NewForest(10, &metrics.NoopCollector{}, nil)
+			require.NoError(t, err)
+			require.NoError(t, restored.AddTries(restoredTries))
+
+			for i, restoredTrie := range restoredTries {
+				require.Equal(t, tries[i].RootHash(), restoredTrie.RootHash())
+				require.Equal(t, tries[i].AllocatedRegCount(), restoredTrie.AllocatedRegCount())
+				require.Equal(t, tries[i].AllocatedRegSize(), restoredTrie.AllocatedRegSize())
+
+				read := &ledger.TrieRead{RootHash: restoredTrie.RootHash(), Paths: allPaths}
+				expected, err := inMemory.Read(read)
+				require.NoError(t, err)
+				actual, err := restored.Read(read)
+				require.NoError(t, err)
+				require.Equal(t, len(expected), len(actual))
+				for j := range expected {
+					require.True(t, expected[j].Equals(actual[j]))
+				}
+			}
+		})
+	})
+}
+
+// requireSameReads requires the forests to read the same payloads from the tries with the given root hashes.
+func requireSameReads(t *testing.T, expected *mtrie.Forest, actual *mtrie.Forest, rootHashes []ledger.RootHash, paths []ledger.Path) {
+	for _, rootHash := range rootHashes {
+		read := &ledger.TrieRead{RootHash: rootHash, Paths: paths}
+		expectedPayloads, err := expected.Read(read)
+		require.NoError(t, err)
+		actualPayloads, err := actual.Read(read)
+		require.NoError(t, err)
+		require.Equal(t, len(expectedPayloads), len(actualPayloads))
+		for i := range expectedPayloads {
+			require.True(t, expectedPayloads[i].Equals(actualPayloads[i]))
+		}
+	}
+}
+
+// TestStore_LoadError tests that nodes which can not be loaded are returned as errors.
+func TestStore_LoadError(t *testing.T) {
+	collector := &storeMetrics{}
+	runWithForests(t, collector, func(inMemory *mtrie.Forest, paged *mtrie.Forest) {
+		rootHashes, allPaths := applyRandomUpdates(t, inMemory, paged, 1)
+
+		_, err := paged.NodeStore().Load(hash.DummyHash)
+		require.Error(t, err)
+
+		// releasing the root of the trie held by the forest removes its nodes
+		stateTrie, err := paged.GetTrie(rootHashes[0])
+		require.NoError(t, err)
+		require.NoError(t, paged.NodeStore().Release(stateTrie.RootNode().Hash()))
+
+		_, err = paged.Read(&ledger.TrieRead{RootHash: rootHashes[0], Paths: allPaths})
+		var loadErr *node.LoadError
+		require.True(t, errors.As(err, &loadErr))
+
+		// the iteration over the nodes of the trie stops at the first node which can not be loaded
+		itr := flattener.NewNodeIterator(stateTrie)
+		for itr.Next() {
+		}
+		require.True(t, errors.As(itr.Err(), &loadErr))
+	})
+}
+
+// TestStore_AcquiredTriesAreKept tests that the nodes of an evicted trie are kept in the node store
+// until the readers which acquired the trie release it.
+func TestStore_AcquiredTriesAreKept(t *testing.T) {
+	collector := &storeMetrics{}
+	runWithForests(t, collector, func(inMemory *mtrie.Forest, paged *mtrie.Forest) {
+		rootHashes, allPaths := applyRandomUpdates(t, inMemory, paged, 1)
+		rootHash := rootHashes[0]
+
+		stateTrie, release1, err := paged.AcquireTrie(rootHash)
+		require.NoError(t, err)
+		_, release2, err := paged.AcquireTrie(rootHash)
+		require.NoError(t, err)
+
+		paged.RemoveTrie(rootHash)
+		_, _, err = paged.AcquireTrie(rootHash)
+		require.Error(t, err)
+
+		expected, err := inMemory.Read(&ledger.TrieRead{RootHash: rootHash, Paths: allPaths})
+		require.NoError(t, err)
+
+		release1()
+		// releasing twice has no effect
+		release1()
+
+		actual, err := stateTrie.UnsafeRead(allPaths)
+		require.NoError(t, err)
+		require.Len(t, actual, len(expected))
+
+		// the nodes are released once the last reader is done
+		release2()
+		_, err = paged.NodeStore().Load(hash.Hash(rootHash))
+		require.Error(t, err)
+	})
+}
+
+// TestStore_ReleaseErrorsAreLogged tests that the nodes of evicted tries which can not be released
+// are logged by the forest.
+func TestStore_ReleaseErrorsAreLogged(t *testing.T) {
+	collector := &storeMetrics{}
+	runWithForests(t, collector, func(inMemory *mtrie.Forest, paged *mtrie.Forest) {
+		var logs bytes.Buffer
+		forest, err := mtrie.NewForest(10, collector, nil,
+			mtrie.WithNodeStore(paged.NodeStore()),
+			mtrie.WithLogger(zerolog.New(&logs)))
+		require.NoError(t, err)
+
+		rootHashes, _ := applyRandomUpdates(t, inMemory, forest, 1)
+
+		// the nodes of the trie are removed before the trie is evicted
+		require.NoError(t, forest.NodeStore().Release(hash.Hash(rootHashes[0])))
+		forest.RemoveTrie(rootHashes[0])
+
+		require.Contains(t, logs.String(), "could not release nodes of evicted trie from node store")
+	})
+}
+
+// TestStore_EvictedTriesAreCollected tests that the nodes of the tries evicted from the forest are removed
+// from the node store, while the nodes shared with the remaining tries are kept.
+func TestStore_EvictedTriesAreCollected(t *testing.T) {
+	collector := &storeMetrics{}
+	runWithForests(t, collector, func(inMemory *mtrie.Forest, paged *mtrie.Forest) {
+		// the forests hold 10 tries, including the empty trie
+		rootHashes, allPaths := applyRandomUpdates(t, inMemory, paged, 15)
+
+		evicted := rootHashes[:5]
+		kept := rootHashes[5:]
+		for _, rootHash := range evicted {
+			_, err := paged.GetTrie(rootHash)
+			require.Error(t, err)
+			_, err = paged.NodeStore().Load(hash.Hash(rootHash))
+			require.Error(t, err)
+		}
+
+		requireSameReads(t, inMemory, paged, kept, allPaths)
+
+		paged.Purge()
+		for _, rootHash := range kept {
+			_, err := paged.NodeStore().Load(hash.Hash(rootHash))
+			require.Error(t, err)
+		}
+		require.Equal(t, uint64(0), collector.cachedSize)
+	})
+}
+
+// TestStore_ReplayCheckpoints tests that full and delta checkpoints are paged out to the node store
+// while they are loaded, and that the nodes of the checkpointing forest are released.
+func TestStore_ReplayCheckpoints(t *testing.T) {
+	const segmentSize = 32 * 1024
+
+	collector := &storeMetrics{}
+	unittest.RunWithTempDir(t, func(walDir string) {
+		runWithForests(t, collector, func(inMemory *mtrie.Forest, paged *mtrie.Forest) {
+			store := paged.NodeStore()
+
+			// records the same random updates to the write-ahead log as to the forests
+			recordUpdates := func(w *wal.DiskWAL, restored *mtrie.Forest) []ledger.Path {
+				var paths []ledger.Path
+				for i := 0; i < 5; i++ {
+					rootHash, err := inMemory.MostRecentTouchedRootHash()
+					require.NoError(t, err)
+
+					update := &ledger.TrieUpdate{RootHash: rootHash, Paths: utils.RandomPaths(50)}
+					update.Payloads = utils.RandomPayloads(len(update.Paths), 1, 100)
+					require.NoError(t, w.RecordUpdate(update))
+
+					expected, err := inMemory.Update(update)
+					require.NoError(t, err)
+					actual, err := restored.Update(update)
+					require.NoError(t, err)
+					require.Equal(t, expected, actual)
+
+					paths = append(paths, update.Paths...)
+				}
+				return paths
+			}
+
+			w, err := wal.NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), walDir, 10, ledger.PathLen, segmentSize)
+			require.NoError(t, err)
+			unpaged, err := mtrie.NewForest(10, &metrics.NoopCollector{}, nil)
+			require.NoError(t, err)
+			allPaths := recordUpdates(w, unpaged)
+
+			checkpointer, err := w.NewCheckpointer()
+			require.NoError(t, err)
+			checkpointer.SetMaxDeltaCheckpoints(1)
+			_, last, err := w.Segments()
+			require.NoError(t, err)
+			require.NoError(t, checkpointer.CreateCheckpoint(last))
+			<-w.Done()
+
+			// the full checkpoint is loaded into the node store
+			w, err = wal.NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), walDir, 10, ledger.PathLen, segmentSize)
+			require.NoError(t, err)
+			w.SetNodeStore(store)
+			require.NoError(t, w.ReplayOnForest(paged))
+
+			tries, err := paged.GetTries()
+			require.NoError(t, err)
+			rootHashes := make([]ledger.RootHash, 0, len(tries))
+			for _, tr := range tries {
+				require.True(t, tr.IsEmpty() || tr.RootNode().IsPaged())
+				rootHashes = append(rootHashes, tr.RootHash())
+			}
+			requireSameReads(t, inMemory, paged, rootHashes, allPaths)
+
+			// a delta checkpoint is created on a forest backed by the same node store
+			allPaths = append(allPaths, recordUpdates(w, paged)...)
+			checkpointer, err = w.NewCheckpointer()
+			require.NoError(t, err)
+			checkpointer.SetMaxDeltaCheckpoints(1)
+			_, last, err = w.Segments()
+			require.NoError(t, err)
+			require.NoError(t, checkpointer.CreateCheckpoint(last))
+			base, err := checkpointer.CheckpointBase(last)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, base, 0)
+			<-w.Done()
+
+			tries, err = paged.GetTries()
+			require.NoError(t, err)
+			rootHashes = rootHashes[:0]
+			for _, tr := range tries {
+				rootHashes = append(rootHashes, tr.RootHash())
+			}
+			requireSameReads(t, inMemory, paged, rootHashes, allPaths)
+
+			// the delta checkpoint is loaded into a new forest backed by the same node store
+			restored, err := mtrie.NewForest(10, collector, nil, mtrie.WithNodeStore(store))
+			require.NoError(t, err)
+			w, err = wal.NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), walDir, 10, ledger.PathLen, segmentSize)
+			require.NoError(t, err)
+			w.SetNodeStore(store)
+			require.NoError(t, w.ReplayOnForest(restored))
+			<-w.Done()
+
+			requireSameReads(t, inMemory, restored, rootHashes, allPaths)
+			require.LessOrEqual(t, collector.maxCachedSize, uint64(memoryBudget))
+
+			// once both forests are purged, no node is left in the store
+			paged.Purge()
+			restored.Purge()
+			for _, rootHash := range rootHashes {
+				_, err := store.Load(hash.Hash(rootHash))
+				require.Error(t, err)
+			}
+			require.Equal(t, uint64(0), collector.cachedSize)
+		})
+	})
+}
@@ -0,0 +1,147 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+// RangeProof provides a proof of all the allocated registers with a path in the range [start, end].
+//
+// The trie is walked down along the start and the end path. The sub-tries between the two paths are
+// within the range, and all their allocated registers are included in the proof. The sub-tries branching
+// off the two paths outside of the range are only included by their hash value.
+func (mt *MTrie) RangeProof(start, end ledger.Path) (*ledger.TrieRangeProof, error) {
+	if bytes.Compare(start[:], end[:]) > 0 {
+		return nil, fmt.Errorf("invalid range: start path %v is after end path %v", start, end)
+	}
+
+	p := &rangeProver{proof: ledger.NewTrieRangeProof(start, end)}
+	err := p.prove(mt.root, ledger.NodeMaxHeight, ledger.Path{}, true, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.proof, nil
+}
+
+// rangeProver builds a range proof.
+type rangeProver struct {
+	proof    *ledger.TrieRangeProof
+	siblings int // number of sub-tries next to the range added to the proof
+}
+
+// prove walks the sub-trie of the given height whose paths start with the given prefix. The node `n` is either
+// the root of the sub-trie, a compactified leaf above the sub-trie, or nil if the sub-trie is empty.
+// onStart (onEnd) is true if the prefix is a prefix of the start (end) path of the range, only sub-tries which
+// are not outside of the range are walked.
+func (p *rangeProver) prove(n *node.Node, height int, prefix ledger.Path, onStart, onEnd bool) error {
+	depth := ledger.NodeMaxHeight - height
+	n = subtrie(n, prefix, depth)
+
+	// the sub-trie is within the range
+	if height == 0 || (!onStart && !onEnd) {
+		return p.appendRegisters(n)
+	}
+
+	lChild, rChild, err := children(n)
+	if err != nil {
+		return err
+	}
+	lPrefix, rPrefix := prefix, prefix
+	bitutils.SetBit(rPrefix[:], depth)
+
+	startBit := bitutils.ReadBit(p.proof.StartPath[:], depth)
+	endBit := bitutils.ReadBit(p.proof.EndPath[:], depth)
+
+	// the left sub-trie is before the range if the start path branches right
+	if onStart && startBit == 1 {
+		p.appendSibling(subtrieHash(subtrie(lChild, lPrefix, depth+1), height-1), height-1)
+	} else {
+		err = p.prove(lChild, height-1, lPrefix, onStart, onEnd && endBit == 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the right sub-trie is after the range if the end path branches left
+	if onEnd && endBit == 0 {
+		p.appendSibling(subtrieHash(subtrie(rChild, rPrefix, depth+1), height-1), height-1)
+		return nil
+	}
+	return p.prove(rChild, height-1, rPrefix, onStart && startBit == 1, onEnd)
+}
+
+// appendRegisters adds all the allocated registers of the given sub-trie to the proof.
+func (p *rangeProver) appendRegisters(n *node.Node) error {
+	if n == nil {
+		return nil
+	}
+	if n.IsLeaf() {
+		// registers with an empty payload are unallocated
+		if n.Payload().IsEmpty() {
+			return nil
+		}
+		p.proof.Paths = append(p.proof.Paths, *n.Path())
+		p.proof.Payloads = append(p.proof.Payloads, n.Payload())
+		return nil
+	}
+	lChild, rChild, err := n.Children()
+	if err != nil {
+		return err
+	}
+	err = p.appendRegisters(lChild)
+	if err != nil {
+		return err
+	}
+	return p.appendRegisters(rChild)
+}
+
+// appendSibling adds the hash value of a sub-trie next to the range to the proof, if it is non-default.
+func (p *rangeProver) appendSibling(h hash.Hash, height int) {
+	index := p.siblings
+	p.siblings++
+	if index>>3 >= len(p.proof.Flags) {
+		p.proof.Flags = append(p.proof.Flags, 0)
+	}
+
+	if h != ledger.GetDefaultHashForHeight(height) {
+		bitutils.SetBit(p.proof.Flags, index)
+		p.proof.Interims = append(p.proof.Interims, h)
+	}
+}
+
+// subtrie returns the root of the sub-trie whose paths start with the first `depth` bits of the given prefix,
+// given its root node or a compactified leaf above it. It returns nil if the sub-trie has no allocated register.
+func subtrie(n *node.Node, prefix ledger.Path, depth int) *node.Node {
+	if n == nil || !n.IsLeaf() {
+		return n
+	}
+	if n.Payload().IsEmpty() || !bitutils.HasPrefix(n.Path()[:], prefix[:], depth) {
+		return nil
+	}
+	return n
+}
+
+// children returns the roots of the child sub-tries of the given sub-trie. A compactified leaf is the root
+// of a sub-trie of its children.
+func children(n *node.Node) (*node.Node, *node.Node, error) {
+	if n == nil || n.IsLeaf() {
+		return n, n, nil
+	}
+	return n.Children()
+}
+
+// subtrieHash returns the hash value of the sub-trie of the given height, given its root node as returned by subtrie.
+func subtrieHash(n *node.Node, height int) hash.Hash {
+	if n == nil {
+		return ledger.GetDefaultHashForHeight(height)
+	}
+	if n.IsLeaf() {
+		return ledger.ComputeCompactValue(hash.Hash(*n.Path()), n.Payload().Value, height)
+	}
+	return n.Hash()
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/bitutils"
 	"github.com/onflow/flow-go/ledger/common/hash"
 	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -539,7 +540,7 @@ func Test_Pruning(t *testing.T) {
 		require.Equal(t, uint16(3), maxDepthTouched)
 		require.Equal(t, expectedRegCount, trie1withpruning.AllocatedRegCount())
 		require.Equal(t, expectedRegSize, trie1withpruning.AllocatedRegSize())
-		require.True(t, trie1withpruning.RootNode().VerifyCachedHash())
+		requireValidCachedHash(t, trie1withpruning.RootNode())
 
 		// after pruning
 		//                    n7
@@ -571,7 +572,7 @@ func Test_Pruning(t *testing.T) {
 		require.Equal(t, uint16(2), maxDepthTouched)
 		require.Equal(t, expectedRegCount, trie2withpruning.AllocatedRegCount())
 		require.Equal(t, expectedRegSize, trie2withpruning.AllocatedRegSize())
-		require.True(t, trie2withpruning.RootNode().VerifyCachedHash())
+		requireValidCachedHash(t, trie2withpruning.RootNode())
 
 		require.Equal(t, trie2.RootHash(), trie2withpruning.RootHash())
 
@@ -599,7 +600,7 @@ func Test_Pruning(t *testing.T) {
 		//                 /payload1)
 
 		require.Equal(t, trie22.RootHash(), trie22withpruning.RootHash())
-		require.True(t, trie22withpruning.RootNode().VerifyCachedHash())
+		requireValidCachedHash(t, trie22withpruning.RootNode())
 
 	})
 
@@ -621,7 +622,7 @@ func Test_Pruning(t *testing.T) {
 		// after pruning
 		//       n7  (path1/payload1)
 		require.Equal(t, trie3.RootHash(), trie3withpruning.RootHash())
-		require.True(t, trie3withpruning.RootNode().VerifyCachedHash())
+		requireValidCachedHash(t, trie3withpruning.RootNode())
 	})
 
 	t.Run("smoke testing trie pruning", func(t *testing.T) {
@@ -701,13 +702,17 @@ func Test_Pruning(t *testing.T) {
 				queryPaths = append(queryPaths, path)
 			}
 
-			payloads := activeTrie.UnsafeRead(queryPaths)
+			payloads, err := activeTrie.UnsafeRead(queryPaths)
+
+			require.NoError(t, err)
 			for i, pp := range payloads {
 				expectedPayload := allPaths[queryPaths[i]]
 				require.True(t, pp.Equals(&expectedPayload))
 			}
 
-			payloads = activeTrieWithPruning.UnsafeRead(queryPaths)
+			payloads, err = activeTrieWithPruning.UnsafeRead(queryPaths)
+
+			require.NoError(t, err)
 			for i, pp := range payloads {
 				expectedPayload := allPaths[queryPaths[i]]
 				require.True(t, pp.Equals(&expectedPayload))
@@ -730,7 +735,8 @@ func TestValueSizes(t *testing.T) {
 	t.Run("empty trie", func(t *testing.T) {
 		path := utils.PathByUint16LeftPadded(0)
 		pathsToGetValueSize := []ledger.Path{path}
-		sizes := emptyTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err := emptyTrie.UnsafeValueSizes(pathsToGetValueSize)
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		require.Equal(t, 0, sizes[0])
 	})
@@ -752,7 +758,9 @@ func TestValueSizes(t *testing.T) {
 
 		pathsToGetValueSize := []ledger.Path{path1, path2}
 
-		sizes := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		require.Equal(t, payload1.Value.Size(), sizes[0])
 		require.Equal(t, 0, sizes[1])
@@ -794,7 +802,8 @@ func TestValueSizes(t *testing.T) {
 		}
 
 		// Test value sizes for a mix of existent and non-existent paths.
-		sizes := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		for i, p := range pathsToGetValueSize {
 			switch p {
@@ -810,13 +819,15 @@ func TestValueSizes(t *testing.T) {
 
 		// Test value size for a single existent path
 		pathsToGetValueSize = []ledger.Path{path1}
-		sizes = newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err = newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		require.Equal(t, payload1.Value.Size(), sizes[0])
 
 		// Test value size for a single non-existent path
 		pathsToGetValueSize = []ledger.Path{utils.PathByUint16(3 << 12)}
-		sizes = newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err = newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		require.Equal(t, 0, sizes[0])
 	})
@@ -845,7 +856,9 @@ func TestValueSizesWithDuplicatePaths(t *testing.T) {
 		path1, path2, path3,
 	}
 
-	sizes := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+	sizes, err := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+
+	require.NoError(t, err)
 	require.Equal(t, len(pathsToGetValueSize), len(sizes))
 	for i, p := range pathsToGetValueSize {
 		switch p {
@@ -1094,3 +1107,10 @@ func TestTrieAllocatedRegCountRegSizeWithMixedPruneFlag(t *testing.T) {
 	require.Equal(t, expectedAllocatedRegCount, updatedTrie.AllocatedRegCount())
 	require.Equal(t, expectedAllocatedRegSize, updatedTrie.AllocatedRegSize())
 }
+
+// requireValidCachedHash verifies that re-computing the hash of `n` from its children yields the pre-computed value.
+func requireValidCachedHash(t *testing.T, n *node.Node) {
+	valid, err := n.VerifyCachedHash()
+	require.NoError(t, err)
+	require.True(t, valid)
+}
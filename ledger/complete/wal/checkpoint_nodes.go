@@ -0,0 +1,187 @@
+package wal
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/nodestore"
+)
+
+// pageBatchSize is the number of decoded nodes paged out to the node store at once.
+const pageBatchSize = 10_000
+
+// checkpointNodes holds the nodes of a checkpoint being decoded, by node index.
+// Index 0 is a special case with nil node.
+//
+// When a node store is given, the decoded nodes are paged out to the store in batches, and only their
+// hashes are kept in memory, so that the memory used to load a checkpoint is bounded by the memory
+// budget of the store and the table of node hashes. Each paged out node is referenced once by the
+// table, the references must be released once the tries of the checkpoint are referenced, see release.
+type checkpointNodes struct {
+	store *nodestore.Store
+
+	mu      sync.Mutex
+	nodes   []*node.Node // decoded nodes, which are not paged out yet if a node store is given
+	hashes  []hash.Hash  // hashes of the nodes, if a node store is given
+	unpaged []uint64     // indexes of the decoded nodes which are not paged out yet
+}
+
+func newCheckpointNodes(store *nodestore.Store) *checkpointNodes {
+	x := &checkpointNodes{
+		store: store,
+		nodes: make([]*node.Node, 1),
+	}
+	if store != nil {
+		x.hashes = make([]hash.Hash, 1)
+	}
+	return x
+}
+
+// paged returns true if the nodes are paged out to a node store.
+func (x *checkpointNodes) paged() bool {
+	return x.store != nil
+}
+
+// len returns the number of nodes, including the nil node at index 0.
+func (x *checkpointNodes) len() uint64 {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return uint64(len(x.nodes))
+}
+
+// grow adds the given number of nodes to be decoded.
+func (x *checkpointNodes) grow(count uint64) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.nodes = append(x.nodes, make([]*node.Node, count)...)
+	if x.paged() {
+		x.hashes = append(x.hashes, make([]hash.Hash, count)...)
+	}
+}
+
+// get returns the node with the given index, loaded from the node store if it is paged out.
+func (x *checkpointNodes) get(index uint64) (*node.Node, error) {
+	if index == 0 {
+		return nil, nil
+	}
+
+	x.mu.Lock()
+	n := x.nodes[index]
+	var h hash.Hash
+	if x.paged() {
+		h = x.hashes[index]
+	}
+	x.mu.Unlock()
+	if n != nil || !x.paged() {
+		return n, nil
+	}
+
+	return x.store.Load(h)
+}
+
+// hash returns the hash of the node with the given index.
+func (x *checkpointNodes) hash(index uint64) hash.Hash {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.paged() {
+		return x.hashes[index]
+	}
+	return x.nodes[index].Hash()
+}
+
+// set sets the decoded node with the given index.
+func (x *checkpointNodes) set(index uint64, n *node.Node) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.nodes[index] = n
+	if !x.paged() {
+		return nil
+	}
+
+	x.hashes[index] = n.Hash()
+	x.unpaged = append(x.unpaged, index)
+	if len(x.unpaged) < pageBatchSize {
+		return nil
+	}
+	return x.pageLocked()
+}
+
+// page pages the decoded nodes out to the node store.
+func (x *checkpointNodes) page() error {
+	if !x.paged() {
+		return nil
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.pageLocked()
+}
+
+func (x *checkpointNodes) pageLocked() error {
+	if len(x.unpaged) == 0 {
+		return nil
+	}
+
+	nodes := make([]*node.Node, len(x.unpaged))
+	for i, index := range x.unpaged {
+		nodes[i] = x.nodes[index]
+	}
+
+	err := x.store.PageNodes(nodes)
+	if err != nil {
+		return fmt.Errorf("cannot page checkpoint nodes out to node store: %w", err)
+	}
+
+	for _, index := range x.unpaged {
+		x.nodes[index] = nil
+	}
+	x.unpaged = x.unpaged[:0]
+
+	return nil
+}
+
+// release releases the references to the nodes paged out to the node store. Nodes which are not referenced
+// by a trie added to a forest backed by the node store are then removed.
+func (x *checkpointNodes) release() error {
+	if !x.paged() {
+		return nil
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	// nodes which are not paged out yet are not referenced
+	for _, index := range x.unpaged {
+		x.hashes[index] = hash.DummyHash
+	}
+	x.unpaged = nil
+
+	var batch []hash.Hash
+	for i := 1; i < len(x.hashes); i++ {
+		if x.hashes[i] == hash.DummyHash {
+			continue
+		}
+		batch = append(batch, x.hashes[i])
+		if len(batch) == pageBatchSize {
+			err := x.store.Release(batch...)
+			if err != nil {
+				return fmt.Errorf("cannot release checkpoint nodes from node store: %w", err)
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		err := x.store.Release(batch...)
+		if err != nil {
+			return fmt.Errorf("cannot release checkpoint nodes from node store: %w", err)
+		}
+	}
+
+	x.nodes = make([]*node.Node, 1)
+	x.hashes = make([]hash.Hash, 1)
+
+	return nil
+}
@@ -0,0 +1,646 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	utilsio "github.com/onflow/flow-go/utils/io"
+)
+
+// checkpointPartitionBits is the number of leading path bits by which the nodes of partitioned
+// checkpoints are partitioned.
+const checkpointPartitionBits = 4
+
+// checkpointPartitionCount is the number of partitions of partitioned checkpoints.
+const checkpointPartitionCount = 1 << checkpointPartitionBits
+
+const (
+	encPartitionCountSize = 2
+	encPartitionIndexSize = 2
+	partitionHeaderSize   = headerSize + encPartitionIndexSize
+	partitionFooterSize   = encNodeCountSize
+	encPartitionEntrySize = encNodeCountSize + crc32SumSize
+)
+
+// partitionFilename returns the name of the file of the given partition of a partitioned checkpoint.
+// Partition files are not listed as checkpoints, as their name doesn't end with a checkpoint number.
+func partitionFilename(filename string, partition int) string {
+	return fmt.Sprintf("%s.part%03d", filename, partition)
+}
+
+// partitionInfo is the entry of a partition in the main file of a partitioned checkpoint.
+type partitionInfo struct {
+	nodeCount uint64
+	crc32     uint32
+}
+
+// StorePartitionedCheckpoint writes the given tries to a partitioned checkpoint (version 7) with the given
+// file name in the given directory. The nodes of the sub-tries rooted at depth checkpointPartitionBits are
+// partitioned by the leading bits of their path, and the partitions are written concurrently by the given
+// number of workers to separate files, each appended with its own CRC32 checksum.
+//
+// The main checkpoint file is written last, and consists of:
+//   * a header with the node count and CRC32 checksum of each partition.
+//   * the nodes above the partitioned sub-tries, which reference the nodes of the partitions by index.
+//   * a list of encoded tries, each referencing their respective root node by index.
+// Nodes are indexed as if the partitions and the top nodes were stored in a single checkpoint file:
+// the nodes of a partition are indexed after the nodes of the previous partitions, and the top nodes
+// are indexed after all partitioned nodes. Within a partition file, nodes are referenced by their index
+// in the partition, so that partitions can be decoded independently.
+func StorePartitionedCheckpoint(dir, filename string, logger *zerolog.Logger, workers int, tries ...*trie.MTrie) (err error) {
+	if utilsio.FileExists(filepath.Join(dir, filename)) {
+		return fmt.Errorf("checkpoint file %s already exists", filepath.Join(dir, filename))
+	}
+
+	// Partition files left over by an interrupted checkpointing are removed, as the main file
+	// doesn't exist.
+	err = removePartitionFiles(dir, filename)
+	if err != nil {
+		return fmt.Errorf("cannot remove partitions of incomplete checkpoint: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			removeErr := removePartitionFiles(dir, filename)
+			if removeErr != nil {
+				logger.Warn().Err(removeErr).Msgf("failed to remove partitions of checkpoint %s", filename)
+			}
+		}
+	}()
+
+	// subtrieRoots contains the roots of the partitioned sub-tries of each trie.
+	subtrieRoots := make([][checkpointPartitionCount]*node.Node, len(tries))
+	for i, t := range tries {
+		err = collectSubtrieRoots(t.RootNode(), 0, 0, &subtrieRoots[i])
+		if err != nil {
+			return fmt.Errorf("cannot partition trie %d: %w", i, err)
+		}
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	partitions := make([]partitionInfo, checkpointPartitionCount)
+	partitionNodes := make([]*checkpointNodeIndex, checkpointPartitionCount)
+
+	jobs := make(chan int, checkpointPartitionCount)
+	for p := 0; p < checkpointPartitionCount; p++ {
+		jobs <- p
+	}
+	close(jobs)
+
+	var g errgroup.Group
+	for w := 0; w < workers; w++ {
+		g.Go(func() error {
+			for p := range jobs {
+				roots := make([]*node.Node, len(tries))
+				for i := range tries {
+					roots[i] = subtrieRoots[i][p]
+				}
+
+				partitionNodes[p] = newCheckpointNodeIndex(tries, false)
+				info, err := storePartition(dir, partitionFilename(filename, p), logger, p, roots, partitionNodes[p])
+				if err != nil {
+					return fmt.Errorf("cannot store partition %d: %w", p, err)
+				}
+				partitions[p] = info
+			}
+			return nil
+		})
+	}
+	err = g.Wait()
+	if err != nil {
+		return err
+	}
+
+	writer, err := CreateCheckpointWriterForFile(dir, filename, logger)
+	if err != nil {
+		return fmt.Errorf("cannot generate checkpoint writer: %w", err)
+	}
+	defer func() {
+		closeErr := writer.Close()
+		// Return close error if there isn't any prior error to return.
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	return storePartitionedCheckpointTop(writer, tries, partitions, partitionNodes)
+}
+
+// collectSubtrieRoots collects the roots of the sub-tries at depth checkpointPartitionBits, by the
+// leading bits of their path. Leaves above this depth are not partitioned.
+func collectSubtrieRoots(n *node.Node, depth int, prefix int, roots *[checkpointPartitionCount]*node.Node) error {
+	if n == nil {
+		return nil
+	}
+	if depth == checkpointPartitionBits {
+		roots[prefix] = n
+		return nil
+	}
+	if n.IsLeaf() {
+		return nil
+	}
+	lChild, rChild, err := n.Children()
+	if err != nil {
+		return err
+	}
+	err = collectSubtrieRoots(lChild, depth+1, prefix<<1, roots)
+	if err != nil {
+		return err
+	}
+	return collectSubtrieRoots(rChild, depth+1, prefix<<1|1, roots)
+}
+
+// storePartition writes the unique nodes of the sub-tries with the given roots to a partition file,
+// and returns the node count and CRC32 checksum of the partition. The nodes are indexed in the given
+// index, starting from 1.
+func storePartition(
+	dir string,
+	filename string,
+	logger *zerolog.Logger,
+	partition int,
+	roots []*node.Node,
+	allNodes *checkpointNodeIndex,
+) (info partitionInfo, err error) {
+	writer, err := CreateCheckpointWriterForFile(dir, filename, logger)
+	if err != nil {
+		return partitionInfo{}, fmt.Errorf("cannot generate partition writer: %w", err)
+	}
+	defer func() {
+		closeErr := writer.Close()
+		// Return close error if there isn't any prior error to return.
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	crc32Writer := NewCRC32Writer(writer)
+
+	scratch := make([]byte, 1024*4)
+
+	// Write header: magic (2 bytes) + version (2 bytes) + partition index (2 bytes)
+	header := scratch[:partitionHeaderSize]
+	binary.BigEndian.PutUint16(header, MagicBytes)
+	binary.BigEndian.PutUint16(header[encMagicSize:], VersionV7)
+	binary.BigEndian.PutUint16(header[headerSize:], uint16(partition))
+
+	_, err = crc32Writer.Write(header)
+	if err != nil {
+		return partitionInfo{}, fmt.Errorf("cannot write partition header: %w", err)
+	}
+
+	nodeCounter := uint64(1) // start from 1, as 0 marks nil node
+	for _, root := range roots {
+		nodeCounter, err = storeUniqueNodes(crc32Writer, root, allNodes, nodeCounter, scratch)
+		if err != nil {
+			return partitionInfo{}, err
+		}
+	}
+
+	// Write footer with nodes count
+	footer := scratch[:partitionFooterSize]
+	binary.BigEndian.PutUint64(footer, nodeCounter-1) // -1 to account for 0 node meaning nil
+
+	_, err = crc32Writer.Write(footer)
+	if err != nil {
+		return partitionInfo{}, fmt.Errorf("cannot write partition footer: %w", err)
+	}
+
+	// Write CRC32 sum
+	crc32Sum := crc32Writer.Crc32()
+	crc32buf := scratch[:crc32SumSize]
+	binary.BigEndian.PutUint32(crc32buf, crc32Sum)
+
+	_, err = writer.Write(crc32buf)
+	if err != nil {
+		return partitionInfo{}, fmt.Errorf("cannot write CRC32: %w", err)
+	}
+
+	return partitionInfo{nodeCount: nodeCounter - 1, crc32: crc32Sum}, nil
+}
+
+// storePartitionedCheckpointTop writes the main file of a partitioned checkpoint, given the written
+// partitions and the index of their nodes.
+func storePartitionedCheckpointTop(
+	writer io.Writer,
+	tries []*trie.MTrie,
+	partitions []partitionInfo,
+	partitionNodes []*checkpointNodeIndex,
+) error {
+
+	crc32Writer := NewCRC32Writer(writer)
+
+	scratch := make([]byte, 1024*4)
+
+	// Write header: magic (2 bytes) + version (2 bytes) + partition count (2 bytes)
+	// + node count (8 bytes) and CRC32 sum (4 bytes) of each partition
+	header := make([]byte, headerSize+encPartitionCountSize+len(partitions)*encPartitionEntrySize)
+	binary.BigEndian.PutUint16(header, MagicBytes)
+	binary.BigEndian.PutUint16(header[encMagicSize:], VersionV7)
+	binary.BigEndian.PutUint16(header[headerSize:], uint16(len(partitions)))
+
+	// partitionOffsets are the global indexes preceding the nodes of each partition.
+	partitionOffsets := make([]uint64, len(partitions))
+	nodeCounter := uint64(1) // start from 1, as 0 marks nil node
+
+	pos := headerSize + encPartitionCountSize
+	for p, info := range partitions {
+		binary.BigEndian.PutUint64(header[pos:], info.nodeCount)
+		binary.BigEndian.PutUint32(header[pos+encNodeCountSize:], info.crc32)
+		pos += encPartitionEntrySize
+
+		partitionOffsets[p] = nodeCounter - 1
+		nodeCounter += info.nodeCount
+	}
+
+	_, err := crc32Writer.Write(header)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint header: %w", err)
+	}
+
+	// Serialize the unique top nodes, descendants first
+	partitionedNodeCount := nodeCounter - 1
+	topNodes := newCheckpointNodeIndex(tries, false)
+
+	var storeTopNode func(n *node.Node, depth int, prefix int) (uint64, error)
+	storeTopNode = func(n *node.Node, depth int, prefix int) (uint64, error) {
+		if n == nil {
+			return 0, nil
+		}
+
+		if depth == checkpointPartitionBits {
+			index, found := partitionNodes[prefix].index(n)
+			if !found {
+				hash := n.Hash()
+				return 0, fmt.Errorf("internal error: missing node with hash %x in partition %d", hash[:], prefix)
+			}
+			return partitionOffsets[prefix] + index, nil
+		}
+
+		if index, found := topNodes.index(n); found {
+			return index, nil
+		}
+
+		var lchildIndex, rchildIndex uint64
+		if !n.IsLeaf() {
+			lChild, rChild, err := n.Children()
+			if err != nil {
+				return 0, err
+			}
+			lchildIndex, err = storeTopNode(lChild, depth+1, prefix<<1)
+			if err != nil {
+				return 0, err
+			}
+			rchildIndex, err = storeTopNode(rChild, depth+1, prefix<<1|1)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		index := nodeCounter
+		topNodes.add(n, index)
+		nodeCounter++
+
+		encNode := flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch)
+		_, err := crc32Writer.Write(encNode)
+		if err != nil {
+			return 0, fmt.Errorf("cannot serialize node: %w", err)
+		}
+
+		return index, nil
+	}
+
+	rootIndexes := make([]uint64, len(tries))
+	for i, t := range tries {
+		rootIndexes[i], err = storeTopNode(t.RootNode(), 0, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Serialize trie root nodes
+	for i, t := range tries {
+		encTrie := flattener.EncodeTrie(t, rootIndexes[i], scratch)
+		_, err = crc32Writer.Write(encTrie)
+		if err != nil {
+			return fmt.Errorf("cannot serialize trie: %w", err)
+		}
+	}
+
+	// Write footer with top nodes count and tries count
+	footer := scratch[:encNodeCountSize+encTrieCountSize]
+	binary.BigEndian.PutUint64(footer, nodeCounter-1-partitionedNodeCount) // -1 to account for 0 node meaning nil
+	binary.BigEndian.PutUint16(footer[encNodeCountSize:], uint16(len(tries)))
+
+	_, err = crc32Writer.Write(footer)
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint footer: %w", err)
+	}
+
+	// Write CRC32 sum
+	crc32buf := scratch[:crc32SumSize]
+	binary.BigEndian.PutUint32(crc32buf, crc32Writer.Crc32())
+
+	_, err = writer.Write(crc32buf)
+	if err != nil {
+		return fmt.Errorf("cannot write CRC32: %w", err)
+	}
+
+	return nil
+}
+
+// removePartitionFiles removes the partition files of the partitioned checkpoint with the given
+// file name, ignoring missing partitions.
+func removePartitionFiles(dir, filename string) error {
+	for p := 0; p < checkpointPartitionCount; p++ {
+		err := os.Remove(filepath.Join(dir, partitionFilename(filename, p)))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// readCheckpointV7 decodes the main file of a partitioned checkpoint (version 7), reads its partitions
+// concurrently into the given nodes, which must be empty, and returns the list of tries.
+// Checkpoint file header (magic and version) are verified by the caller.
+func readCheckpointV7(f *os.File, logger *zerolog.Logger, nodes *checkpointNodes) ([]*trie.MTrie, error) {
+
+	scratch := make([]byte, 1024*4)
+
+	// Read footer to get top node count and trie count
+	const footerOffset = encNodeCountSize + encTrieCountSize + crc32SumSize
+	const footerSize = encNodeCountSize + encTrieCountSize // footer doesn't include crc32 sum
+
+	_, err := f.Seek(-footerOffset, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("cannot seek to footer: %w", err)
+	}
+
+	footer := scratch[:footerSize]
+
+	_, err = io.ReadFull(f, footer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	topNodesCount := binary.BigEndian.Uint64(footer)
+	triesCount := binary.BigEndian.Uint16(footer[encNodeCountSize:])
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("cannot seek to start of file: %w", err)
+	}
+
+	var bufReader io.Reader = bufio.NewReaderSize(f, defaultBufioReadSize)
+	crcReader := NewCRC32Reader(bufReader)
+	var reader io.Reader = crcReader
+
+	// Read header: magic (2 bytes) + version (2 bytes) + partition count (2 bytes)
+	// Magic and version are verified by the caller.
+	_, err = io.ReadFull(reader, scratch[:headerSize+encPartitionCountSize])
+	if err != nil {
+		return nil, fmt.Errorf("cannot read header: %w", err)
+	}
+	partitionCount := int(binary.BigEndian.Uint16(scratch[headerSize:]))
+	if partitionCount != checkpointPartitionCount {
+		return nil, fmt.Errorf("unsupported partition count %d, expected %d", partitionCount, checkpointPartitionCount)
+	}
+
+	partitions := make([]partitionInfo, partitionCount)
+	partitionOffsets := make([]uint64, partitionCount)
+	nodesCount := uint64(0)
+	for p := range partitions {
+		entry := scratch[:encPartitionEntrySize]
+		_, err = io.ReadFull(reader, entry)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read header of partition %d: %w", p, err)
+		}
+		partitions[p] = partitionInfo{
+			nodeCount: binary.BigEndian.Uint64(entry),
+			crc32:     binary.BigEndian.Uint32(entry[encNodeCountSize:]),
+		}
+		partitionOffsets[p] = nodesCount
+		nodesCount += partitions[p].nodeCount
+	}
+	partitionedNodeCount := nodesCount
+	nodesCount += topNodesCount
+
+	// nodes's element at index 0 is a special, meaning nil.
+	if nodes.len() != 1 {
+		return nil, fmt.Errorf("partitioned checkpoint can not be decoded after other nodes")
+	}
+	nodes.grow(nodesCount)
+
+	// Each partition is decoded into its own range of nodes, concurrently.
+	jobs := make(chan int, partitionCount)
+	for p := 0; p < partitionCount; p++ {
+		jobs <- p
+	}
+	close(jobs)
+
+	workers := runtime.NumCPU()
+	if workers > partitionCount {
+		workers = partitionCount
+	}
+
+	dir := filepath.Dir(f.Name())
+	var g errgroup.Group
+	for w := 0; w < workers; w++ {
+		g.Go(func() error {
+			for p := range jobs {
+				filename := filepath.Join(dir, partitionFilename(filepath.Base(f.Name()), p))
+				err := readPartition(filename, logger, p, partitions[p], nodes, partitionOffsets[p])
+				if err != nil {
+					return fmt.Errorf("cannot read partition %d: %w", p, err)
+				}
+			}
+			return nil
+		})
+	}
+	err = g.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := partitionedNodeCount + 1; i <= nodesCount; i++ {
+		n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= uint64(i) {
+				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+			}
+			return nodes.get(nodeIndex)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read node %d: %w", i, err)
+		}
+		err = nodes.set(i, n)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = nodes.page()
+	if err != nil {
+		return nil, err
+	}
+
+	tries := make([]*trie.MTrie, triesCount)
+	for i := uint16(0); i < triesCount; i++ {
+		trie, err := flattener.ReadTrie(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex > nodesCount {
+				return nil, fmt.Errorf("sequence of stored nodes doesn't contain node")
+			}
+			return nodes.get(nodeIndex)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read trie %d: %w", i, err)
+		}
+		tries[i] = trie
+	}
+
+	// Read footer again for crc32 computation
+	// No action is needed.
+	_, err = io.ReadFull(reader, footer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	// Read CRC32
+	crc32buf := scratch[:crc32SumSize]
+	_, err = io.ReadFull(bufReader, crc32buf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CRC32: %w", err)
+	}
+
+	readCrc32 := binary.BigEndian.Uint32(crc32buf)
+
+	calculatedCrc32 := crcReader.Crc32()
+
+	if calculatedCrc32 != readCrc32 {
+		return nil, fmt.Errorf("checkpoint checksum failed! File contains %x but calculated crc32 is %x", readCrc32, calculatedCrc32)
+	}
+
+	return tries, nil
+}
+
+// readPartition decodes the partition file with the given name into the given nodes, at the given
+// offset of the partition plus its partition index. The node count and CRC32 checksum of the partition are verified against the given
+// partition info from the main checkpoint file.
+func readPartition(
+	filename string,
+	logger *zerolog.Logger,
+	partition int,
+	info partitionInfo,
+	nodes *checkpointNodes,
+	offset uint64,
+) error {
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("cannot open partition file %s: %w", filename, err)
+	}
+	defer func() {
+		evictErr := evictFileFromLinuxPageCache(f, false, logger)
+		if evictErr != nil {
+			logger.Warn().Msgf("failed to evict file %s from Linux page cache: %s", filename, evictErr)
+		}
+
+		_ = f.Close()
+	}()
+
+	scratch := make([]byte, 1024*4)
+
+	var bufReader io.Reader = bufio.NewReaderSize(f, defaultBufioReadSize)
+	crcReader := NewCRC32Reader(bufReader)
+	var reader io.Reader = crcReader
+
+	// Read header: magic (2 bytes) + version (2 bytes) + partition index (2 bytes)
+	header := scratch[:partitionHeaderSize]
+	_, err = io.ReadFull(reader, header)
+	if err != nil {
+		return fmt.Errorf("cannot read header: %w", err)
+	}
+
+	magicBytes := binary.BigEndian.Uint16(header)
+	version := binary.BigEndian.Uint16(header[encMagicSize:])
+	partitionIndex := int(binary.BigEndian.Uint16(header[headerSize:]))
+
+	if magicBytes != MagicBytes {
+		return fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
+	}
+	if version != VersionV7 {
+		return fmt.Errorf("unsupported partition file version %x", version)
+	}
+	if partitionIndex != partition {
+		return fmt.Errorf("partition file contains partition %d, expected %d", partitionIndex, partition)
+	}
+
+	for localIndex := uint64(1); localIndex <= info.nodeCount; localIndex++ {
+		n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= localIndex {
+				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+			}
+			if nodeIndex == 0 {
+				return nil, nil
+			}
+			return nodes.get(offset + nodeIndex)
+		})
+		if err != nil {
+			return fmt.Errorf("cannot read node %d: %w", localIndex, err)
+		}
+		err = nodes.set(offset+localIndex, n)
+		if err != nil {
+			return err
+		}
+	}
+
+	footer := scratch[:partitionFooterSize]
+	_, err = io.ReadFull(reader, footer)
+	if err != nil {
+		return fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	nodeCount := binary.BigEndian.Uint64(footer)
+	if nodeCount != info.nodeCount {
+		return fmt.Errorf("partition contains %d nodes, but %d nodes are expected", nodeCount, info.nodeCount)
+	}
+
+	// Read CRC32
+	crc32buf := scratch[:crc32SumSize]
+	_, err = io.ReadFull(bufReader, crc32buf)
+	if err != nil {
+		return fmt.Errorf("cannot read CRC32: %w", err)
+	}
+
+	readCrc32 := binary.BigEndian.Uint32(crc32buf)
+
+	calculatedCrc32 := crcReader.Crc32()
+
+	if calculatedCrc32 != readCrc32 {
+		return fmt.Errorf("partition checksum failed! File contains %x but calculated crc32 is %x", readCrc32, calculatedCrc32)
+	}
+	if readCrc32 != info.crc32 {
+		return fmt.Errorf("partition checksum %x does not match checksum %x of checkpoint", readCrc32, info.crc32)
+	}
+
+	// The partition must not contain more nodes than its node count.
+	_, err = io.ReadFull(bufReader, scratch[:1])
+	if !errors.Is(err, io.EOF) {
+		return fmt.Errorf("partition file contains more data than expected")
+	}
+
+	return nil
+}
@@ -16,9 +16,11 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/nodestore"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/module/metrics"
@@ -45,13 +47,27 @@ const VersionV4 uint16 = 0x04
 // See EncodeNode() and EncodeTrie() for more details.
 const VersionV5 uint16 = 0x05
 
+// Version 6 is a delta checkpoint, which only contains the nodes created since its base checkpoint,
+// and references the other nodes by their index in the base checkpoint:
+// - add base checkpoint number and base node count to the header.
+// - node indexes of the delta checkpoint start after the nodes of the base checkpoint.
+// - node count in the footer only counts the nodes of the delta checkpoint.
+// Nodes and tries are encoded like in version 5, the base checkpoint is either a version 5
+// checkpoint, or a version 6 checkpoint itself.
+const VersionV6 uint16 = 0x06
+
+// Version 7 is a partitioned checkpoint, whose nodes are stored in separate partition files which
+// are written and read concurrently, see StorePartitionedCheckpoint for details.
+const VersionV7 uint16 = 0x07
+
 const (
-	encMagicSize     = 2
-	encVersionSize   = 2
-	headerSize       = encMagicSize + encVersionSize
-	encNodeCountSize = 8
-	encTrieCountSize = 2
-	crc32SumSize     = 4
+	encMagicSize          = 2
+	encVersionSize        = 2
+	headerSize            = encMagicSize + encVersionSize
+	encNodeCountSize      = 8
+	encTrieCountSize      = 2
+	encBaseCheckpointSize = 8
+	crc32SumSize          = 4
 )
 
 // defaultBufioReadSize replaces the default bufio buffer size of 4096 bytes.
@@ -65,10 +81,28 @@ const defaultBufioReadSize = 1024 * 32
 const defaultBufioWriteSize = 1024 * 32
 
 type Checkpointer struct {
-	dir            string
-	wal            *DiskWAL
-	keyByteSize    int
-	forestCapacity int
+	dir                 string
+	wal                 *DiskWAL
+	keyByteSize         int
+	forestCapacity      int
+	nodeStore           *nodestore.Store
+	maxDeltaCheckpoints uint
+	partitionWorkers    uint
+	// base is the latest checkpoint created or loaded as the base of a delta checkpoint, which is kept
+	// between checkpoints so that the next delta checkpoint doesn't load its base again.
+	base *checkpointBase
+}
+
+// checkpointBase is the checkpoint a delta checkpoint is based on.
+type checkpointBase struct {
+	number int
+	// index indexes the nodes of the base checkpoint and the checkpoints it is based on.
+	index *checkpointNodeIndex
+	// nodeCount is the number of nodes of the base checkpoint and the checkpoints it is based on.
+	nodeCount uint64
+	// deltas is the number of delta checkpoints in a row ending with the base checkpoint.
+	deltas uint
+	tries  []*trie.MTrie
 }
 
 func NewCheckpointer(wal *DiskWAL, keyByteSize int, forestCapacity int) *Checkpointer {
@@ -77,9 +111,31 @@ func NewCheckpointer(wal *DiskWAL, keyByteSize int, forestCapacity int) *Checkpo
 		wal:            wal,
 		keyByteSize:    keyByteSize,
 		forestCapacity: forestCapacity,
+		nodeStore:      wal.nodeStore,
 	}
 }
 
+// SetNodeStore sets the node store the nodes of the loaded checkpoints are paged out to, which also backs the
+// forest the write-ahead log is replayed on to create checkpoints (see mtrie.WithNodeStore).
+func (c *Checkpointer) SetNodeStore(store *nodestore.Store) {
+	c.nodeStore = store
+}
+
+// SetMaxDeltaCheckpoints sets the maximum number of delta checkpoints created in a row. A delta checkpoint
+// only contains the nodes created since the previous checkpoint, after the given number of delta checkpoints
+// a full checkpoint is created again, which rebases the following delta checkpoints. Zero disables delta
+// checkpoints. The tries and the node index of the last created checkpoint are kept until the next delta
+// checkpoint, which is therefore created without loading its base again.
+func (c *Checkpointer) SetMaxDeltaCheckpoints(n uint) {
+	c.maxDeltaCheckpoints = n
+}
+
+// SetPartitionWorkers sets the number of workers writing the partitions of the full checkpoints created by
+// CreateCheckpoint concurrently (see VersionV7). Zero disables partitioned checkpoints.
+func (c *Checkpointer) SetPartitionWorkers(n uint) {
+	c.partitionWorkers = n
+}
+
 // listCheckpoints returns all the numbers (unsorted) of the checkpoint files, and the number of the last checkpoint.
 func (c *Checkpointer) listCheckpoints() ([]int, int, error) {
 
@@ -91,13 +147,8 @@ func (c *Checkpointer) listCheckpoints() ([]int, int, error) {
 	}
 	last := -1
 	for _, fn := range files {
-		fname := fn.Name()
-		if !strings.HasPrefix(fname, checkpointFilenamePrefix) {
-			continue
-		}
-		justNumber := fname[len(checkpointFilenamePrefix):]
-		k, err := strconv.Atoi(justNumber)
-		if err != nil {
+		k, ok := checkpointNumber(fn.Name())
+		if !ok {
 			continue
 		}
 
@@ -112,6 +163,20 @@ func (c *Checkpointer) listCheckpoints() ([]int, int, error) {
 	return list, last, nil
 }
 
+// checkpointNumber returns the number of the checkpoint with the given file name, and whether
+// the file name is the name of a checkpoint file.
+func checkpointNumber(fname string) (int, bool) {
+	if !strings.HasPrefix(fname, checkpointFilenamePrefix) {
+		return 0, false
+	}
+	justNumber := fname[len(checkpointFilenamePrefix):]
+	k, err := strconv.Atoi(justNumber)
+	if err != nil {
+		return 0, false
+	}
+	return k, true
+}
+
 // Checkpoints returns all the checkpoint numbers in asc order
 func (c *Checkpointer) Checkpoints() ([]int, error) {
 	list, _, err := c.listCheckpoints()
@@ -168,7 +233,33 @@ func (c *Checkpointer) NotCheckpointedSegments() (from, to int, err error) {
 }
 
 // Checkpoint creates new checkpoint stopping at given segment
-func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, error)) (err error) {
+func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, error)) error {
+	return c.checkpoint(to, func(base *checkpointBase, tries []*trie.MTrie) (*checkpointNodeIndex, uint64, error) {
+		// the checkpoint isn't necessarily written to the checkpoint directory, it can't be the base of a delta checkpoint
+		_, _, err := storeCheckpointToWriter(targetWriter, base, tries)
+		return nil, 0, err
+	})
+}
+
+// CreateCheckpoint creates new checkpoint stopping at given segment in the checkpoint directory.
+// Full checkpoints are partitioned if partition workers are set, see SetPartitionWorkers.
+// If delta checkpoints are enabled, the created checkpoint is kept as the base of the next delta checkpoint.
+func (c *Checkpointer) CreateCheckpoint(to int) error {
+	return c.checkpoint(to, func(base *checkpointBase, tries []*trie.MTrie) (*checkpointNodeIndex, uint64, error) {
+		if base != nil || c.partitionWorkers == 0 {
+			return storeCheckpointToWriter(func() (io.WriteCloser, error) {
+				return c.CheckpointWriter(to)
+			}, base, tries)
+		}
+		return nil, 0, StorePartitionedCheckpoint(c.dir, NumberToFilename(to), &c.wal.log, int(c.partitionWorkers), tries...)
+	})
+}
+
+// checkpoint replays the write-ahead log to the given segment, and stores the resulting tries with the given
+// function, along with the base checkpoint if a delta checkpoint is created. The store function returns the
+// index of the stored nodes and their count, including the nodes of the base checkpoint, if the stored
+// checkpoint can be the base of the next delta checkpoint.
+func (c *Checkpointer) checkpoint(to int, store func(base *checkpointBase, tries []*trie.MTrie) (*checkpointNodeIndex, uint64, error)) error {
 
 	_, notCheckpointedTo, err := c.NotCheckpointedSegments()
 	if err != nil {
@@ -188,23 +279,47 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 		return fmt.Errorf("no segments to checkpoint to %d, latests not checkpointed segment: %d", to, notCheckpointedTo)
 	}
 
-	forest, err := mtrie.NewForest(c.forestCapacity, &metrics.NoopCollector{}, nil)
+	var forestOpts []mtrie.ForestOption
+	if c.nodeStore != nil {
+		forestOpts = append(forestOpts, mtrie.WithNodeStore(c.nodeStore))
+	}
+	forest, err := mtrie.NewForest(c.forestCapacity, &metrics.NoopCollector{}, nil, forestOpts...)
 	if err != nil {
 		return fmt.Errorf("cannot create Forest: %w", err)
 	}
+	// release the nodes of the tries from the node store once the checkpoint is created
+	defer forest.Purge()
+
+	base, err := c.deltaCheckpointBase(to)
+	if err != nil {
+		return fmt.Errorf("cannot get base of delta checkpoint: %w", err)
+	}
+
+	updateFn := func(update *ledger.TrieUpdate) error {
+		_, err := forest.Update(update)
+		return err
+	}
+	deleteFn := func(rootHash ledger.RootHash) error {
+		return nil
+	}
+
+	if base != nil {
+		c.wal.log.Info().Msgf("creating delta checkpoint %d based on checkpoint %d", to, base.number)
+
+		err = forest.AddTries(base.tries)
+		if err != nil {
+			return fmt.Errorf("cannot add tries of base checkpoint: %w", err)
+		}
 
-	c.wal.log.Info().Msgf("creating checkpoint %d", to)
+		err = c.wal.replay(base.number+1, to, nil, updateFn, deleteFn, false)
+	} else {
+		c.wal.log.Info().Msgf("creating checkpoint %d", to)
 
-	err = c.wal.replay(0, to,
-		func(tries []*trie.MTrie) error {
-			return forest.AddTries(tries)
-		},
-		func(update *ledger.TrieUpdate) error {
-			_, err := forest.Update(update)
-			return err
-		}, func(rootHash ledger.RootHash) error {
-			return nil
-		}, true)
+		err = c.wal.replay(0, to,
+			func(tries []*trie.MTrie) error {
+				return forest.AddTries(tries)
+			}, updateFn, deleteFn, true)
+	}
 
 	if err != nil {
 		return fmt.Errorf("cannot replay WAL: %w", err)
@@ -217,9 +332,68 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 
 	c.wal.log.Info().Msgf("serializing checkpoint %d", to)
 
+	deltas := uint(0)
+	if base != nil {
+		deltas = base.deltas + 1
+	}
+
+	// the index of the base checkpoint is extended by the stored nodes, it can't be used again
+	index, nodeCount, err := store(base, tries)
+	if err != nil || index == nil || deltas >= c.maxDeltaCheckpoints {
+		// the next checkpoint is a full checkpoint, or its base is loaded again
+		c.setBase(nil)
+		return err
+	}
+
+	c.setBase(&checkpointBase{
+		number:    to,
+		index:     index,
+		nodeCount: nodeCount,
+		deltas:    deltas,
+		tries:     tries,
+	})
+
+	c.wal.log.Info().Msgf("created checkpoint %d with %d tries", to, len(tries))
+
+	return nil
+}
+
+// setBase replaces the base of the next delta checkpoint. The tries of the base are referenced in the node store,
+// if any, until the base is replaced.
+func (c *Checkpointer) setBase(base *checkpointBase) {
+	if c.nodeStore != nil && base != nil {
+		for _, t := range base.tries {
+			if t.RootNode() != nil {
+				_, err := c.nodeStore.Page(t.RootNode())
+				if err != nil {
+					c.wal.log.Warn().Err(err).Int("checkpoint", base.number).
+						Msg("cannot reference tries of base checkpoint, it will be loaded again")
+					base = nil
+					break
+				}
+			}
+		}
+	}
+
+	if c.nodeStore != nil && c.base != nil {
+		for _, t := range c.base.tries {
+			if t.RootNode() != nil {
+				// release errors are logged by the node store
+				_ = c.nodeStore.Release(t.RootNode().Hash())
+			}
+		}
+	}
+
+	c.base = base
+}
+
+// storeCheckpointToWriter writes the given tries to the checkpoint writer generated by the given function, as a
+// delta checkpoint if a base checkpoint is given.
+// It returns the index of the written nodes and their count, including the nodes of the base checkpoint.
+func storeCheckpointToWriter(targetWriter func() (io.WriteCloser, error), base *checkpointBase, tries []*trie.MTrie) (_ *checkpointNodeIndex, _ uint64, err error) {
 	writer, err := targetWriter()
 	if err != nil {
-		return fmt.Errorf("cannot generate writer: %w", err)
+		return nil, 0, fmt.Errorf("cannot generate writer: %w", err)
 	}
 	defer func() {
 		closeErr := writer.Close()
@@ -229,11 +403,115 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 		}
 	}()
 
-	err = StoreCheckpoint(writer, tries...)
+	return storeCheckpoint(writer, base, tries)
+}
 
-	c.wal.log.Info().Msgf("created checkpoint %d with %d tries", to, len(tries))
+// deltaCheckpointBase returns the base of a delta checkpoint stopping at the given segment, or nil if a
+// full checkpoint is created instead: when delta checkpoints are disabled, when there is no previous
+// checkpoint, or when the previous checkpoint ends the maximum number of delta checkpoints in a row.
+func (c *Checkpointer) deltaCheckpointBase(to int) (*checkpointBase, error) {
+	if c.maxDeltaCheckpoints == 0 {
+		return nil, nil
+	}
 
-	return err
+	latestCheckpoint, err := c.LatestCheckpoint()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get latest checkpoint: %w", err)
+	}
+	if latestCheckpoint < 0 || latestCheckpoint >= to {
+		return nil, nil
+	}
+
+	// count the delta checkpoints in a row ending with the latest checkpoint
+	deltas := uint(0)
+	for checkpoint := latestCheckpoint; ; deltas++ {
+		checkpoint, err = c.CheckpointBase(checkpoint)
+		if err != nil {
+			// the chain of the latest checkpoint is broken, a full checkpoint is created instead
+			c.wal.log.Warn().Err(err).Int("checkpoint", latestCheckpoint).
+				Msg("cannot read base checkpoints of latest checkpoint, creating a full checkpoint")
+			return nil, nil
+		}
+		if checkpoint < 0 {
+			break
+		}
+	}
+	if deltas >= c.maxDeltaCheckpoints {
+		return nil, nil
+	}
+
+	// the latest checkpoint is kept if it was created by this checkpointer
+	if c.base != nil && c.base.number == latestCheckpoint {
+		return c.base, nil
+	}
+
+	base, err := c.loadCheckpointBase(latestCheckpoint, deltas)
+	if err != nil {
+		// the latest checkpoint can't be used as a base, a full checkpoint is created instead
+		c.wal.log.Warn().Err(err).Int("checkpoint", latestCheckpoint).
+			Msg("cannot load latest checkpoint as base of delta checkpoint, creating a full checkpoint")
+		return nil, nil
+	}
+
+	return base, nil
+}
+
+// loadCheckpointBase loads the given checkpoint, ending the given number of delta checkpoints in a row, as
+// the base of the next delta checkpoint.
+func (c *Checkpointer) loadCheckpointBase(checkpoint int, deltas uint) (*checkpointBase, error) {
+	nodes, tries, err := loadCheckpointNodes(path.Join(c.dir, NumberToFilename(checkpoint)), &c.wal.log, c.nodeStore)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// the nodes of the base are referenced by its tries
+		releaseErr := nodes.release()
+		if releaseErr != nil {
+			c.wal.log.Warn().Err(releaseErr).Int("checkpoint", checkpoint).Msg("cannot release nodes of base checkpoint")
+		}
+	}()
+
+	index := newCheckpointNodeIndex(tries, nodes.paged())
+	err = index.addBase(nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setBase(&checkpointBase{
+		number:    checkpoint,
+		index:     index,
+		nodeCount: nodes.len() - 1, // -1 to account for 0 node meaning nil
+		deltas:    deltas,
+		tries:     tries,
+	})
+
+	return c.base, nil
+}
+
+// CheckpointBase returns the number of the checkpoint the given delta checkpoint is based on,
+// or -1 if the given checkpoint is a full checkpoint.
+func (c *Checkpointer) CheckpointBase(checkpoint int) (int, error) {
+	filepath := path.Join(c.dir, NumberToFilename(checkpoint))
+	file, err := os.Open(filepath)
+	if err != nil {
+		return -1, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	version, err := readCheckpointHeader(file)
+	if err != nil {
+		return -1, err
+	}
+	if version != VersionV6 {
+		return -1, nil
+	}
+
+	baseNumber, _, err := readDeltaCheckpointHeader(file)
+	if err != nil {
+		return -1, err
+	}
+
+	return int(baseNumber), nil
 }
 
 func NumberToFilenamePart(n int) string {
@@ -285,9 +563,18 @@ func CreateCheckpointWriterForFile(dir, filename string, logger *zerolog.Logger)
 // When rebuilding the trie from the sequence of nodes, build the trie on the fly,
 // as for each node, the children have been previously encountered.
 // TODO: evaluate alternatives to CRC32 since checkpoint file is many GB in size.
-// TODO: add concurrency if the performance gains are enough to offset complexity.
+// See StorePartitionedCheckpoint to write checkpoints concurrently.
 func StoreCheckpoint(writer io.Writer, tries ...*trie.MTrie) error {
+	_, _, err := storeCheckpoint(writer, nil, tries)
+	return err
+}
 
+// storeCheckpoint writes the given tries to a checkpoint file. If a base checkpoint is given, a delta
+// checkpoint is written (see VersionV6): only the nodes which are not in the base checkpoint are written,
+// and the nodes of the base checkpoint are referenced by their index in the base checkpoint.
+// It returns the index of the written nodes and their count, including the nodes of the base checkpoint, whose
+// index is extended.
+func storeCheckpoint(writer io.Writer, base *checkpointBase, tries []*trie.MTrie) (*checkpointNodeIndex, uint64, error) {
 	crc32Writer := NewCRC32Writer(writer)
 
 	// Scratch buffer is used as temporary buffer that node can encode into.
@@ -298,56 +585,45 @@ func StoreCheckpoint(writer io.Writer, tries ...*trie.MTrie) error {
 	scratch := make([]byte, 1024*4)
 
 	// Write header: magic (2 bytes) + version (2 bytes)
+	// Delta checkpoints also include: base checkpoint number (8 bytes) + base node count (8 bytes)
 	header := scratch[:headerSize]
 	binary.BigEndian.PutUint16(header, MagicBytes)
 	binary.BigEndian.PutUint16(header[encMagicSize:], VersionV5)
 
+	baseNodeCount := uint64(0)
+	if base != nil {
+		baseNodeCount = base.nodeCount
+
+		header = scratch[:headerSize+encBaseCheckpointSize+encNodeCountSize]
+		binary.BigEndian.PutUint16(header[encMagicSize:], VersionV6)
+		binary.BigEndian.PutUint64(header[headerSize:], uint64(base.number))
+		binary.BigEndian.PutUint64(header[headerSize+encBaseCheckpointSize:], baseNodeCount)
+	}
+
 	_, err := crc32Writer.Write(header)
 	if err != nil {
-		return fmt.Errorf("cannot write checkpoint header: %w", err)
+		return nil, 0, fmt.Errorf("cannot write checkpoint header: %w", err)
 	}
 
 	// allNodes contains all unique nodes of given tries and their index
 	// (ordered by node traversal sequence).
 	// Index 0 is a special case with nil node.
-	allNodes := make(map[*node.Node]uint64)
-	allNodes[nil] = 0
+	// The nodes of the base checkpoint are indexed as visited nodes, which are not serialized again.
+	var allNodes *checkpointNodeIndex
+	if base != nil {
+		allNodes = base.index
+	} else {
+		allNodes = newCheckpointNodeIndex(tries, false)
+	}
 
 	// Serialize all unique nodes
-	nodeCounter := uint64(1) // start from 1, as 0 marks nil node
+	nodeCounter := baseNodeCount + 1 // start from 1, as 0 marks nil node
 	for _, t := range tries {
 
 		// Traverse all unique nodes for trie t.
-		for itr := flattener.NewUniqueNodeIterator(t, allNodes); itr.Next(); {
-			n := itr.Value()
-
-			allNodes[n] = nodeCounter
-			nodeCounter++
-
-			var lchildIndex, rchildIndex uint64
-
-			if lchild := n.LeftChild(); lchild != nil {
-				var found bool
-				lchildIndex, found = allNodes[lchild]
-				if !found {
-					hash := lchild.Hash()
-					return fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(hash[:]))
-				}
-			}
-			if rchild := n.RightChild(); rchild != nil {
-				var found bool
-				rchildIndex, found = allNodes[rchild]
-				if !found {
-					hash := rchild.Hash()
-					return fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(hash[:]))
-				}
-			}
-
-			encNode := flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch)
-			_, err = crc32Writer.Write(encNode)
-			if err != nil {
-				return fmt.Errorf("cannot serialize node: %w", err)
-			}
+		nodeCounter, err = storeUniqueNodes(crc32Writer, t.RootNode(), allNodes, nodeCounter, scratch)
+		if err != nil {
+			return nil, 0, err
 		}
 	}
 
@@ -356,27 +632,27 @@ func StoreCheckpoint(writer io.Writer, tries ...*trie.MTrie) error {
 		rootNode := t.RootNode()
 
 		// Get root node index
-		rootIndex, found := allNodes[rootNode]
+		rootIndex, found := allNodes.index(rootNode)
 		if !found {
 			rootHash := t.RootHash()
-			return fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(rootHash[:]))
+			return nil, 0, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(rootHash[:]))
 		}
 
 		encTrie := flattener.EncodeTrie(t, rootIndex, scratch)
 		_, err = crc32Writer.Write(encTrie)
 		if err != nil {
-			return fmt.Errorf("cannot serialize trie: %w", err)
+			return nil, 0, fmt.Errorf("cannot serialize trie: %w", err)
 		}
 	}
 
 	// Write footer with nodes count and tries count
 	footer := scratch[:encNodeCountSize+encTrieCountSize]
-	binary.BigEndian.PutUint64(footer, uint64(len(allNodes)-1)) // -1 to account for 0 node meaning nil
+	binary.BigEndian.PutUint64(footer, nodeCounter-1-baseNodeCount) // -1 to account for 0 node meaning nil
 	binary.BigEndian.PutUint16(footer[encNodeCountSize:], uint16(len(tries)))
 
 	_, err = crc32Writer.Write(footer)
 	if err != nil {
-		return fmt.Errorf("cannot write checkpoint footer: %w", err)
+		return nil, 0, fmt.Errorf("cannot write checkpoint footer: %w", err)
 	}
 
 	// Write CRC32 sum
@@ -385,12 +661,127 @@ func StoreCheckpoint(writer io.Writer, tries ...*trie.MTrie) error {
 
 	_, err = writer.Write(crc32buf)
 	if err != nil {
-		return fmt.Errorf("cannot write CRC32: %w", err)
+		return nil, 0, fmt.Errorf("cannot write CRC32: %w", err)
+	}
+
+	return allNodes, nodeCounter - 1, nil
+}
+
+// checkpointNodeIndex indexes the serialized nodes of a checkpoint. The nodes of tries paged out
+// to a node store can be loaded several times, they are therefore indexed by hash instead.
+type checkpointNodeIndex struct {
+	paged  bool
+	nodes  map[*node.Node]uint64
+	hashes map[hash.Hash]uint64
+}
+
+// newCheckpointNodeIndex returns an empty index for the nodes of the given tries, indexed by hash
+// if the tries or the base checkpoint are paged out to a node store.
+func newCheckpointNodeIndex(tries []*trie.MTrie, paged bool) *checkpointNodeIndex {
+	if paged {
+		return &checkpointNodeIndex{paged: true, hashes: make(map[hash.Hash]uint64)}
+	}
+	for _, t := range tries {
+		if t.RootNode().IsPaged() {
+			return &checkpointNodeIndex{paged: true, hashes: make(map[hash.Hash]uint64)}
+		}
+	}
+	return &checkpointNodeIndex{nodes: make(map[*node.Node]uint64)}
+}
+
+// index returns the index of the given node, 0 for nil, and whether it was found.
+func (x *checkpointNodeIndex) index(n *node.Node) (uint64, bool) {
+	if n == nil {
+		return 0, true
+	}
+	if x.paged {
+		index, found := x.hashes[n.Hash()]
+		return index, found
 	}
+	index, found := x.nodes[n]
+	return index, found
+}
 
+func (x *checkpointNodeIndex) add(n *node.Node, index uint64) {
+	if x.paged {
+		x.hashes[n.Hash()] = index
+	} else {
+		x.nodes[n] = index
+	}
+}
+
+// addBase indexes the nodes of a base checkpoint.
+func (x *checkpointNodeIndex) addBase(nodes *checkpointNodes) error {
+	count := nodes.len()
+	for i := uint64(1); i < count; i++ {
+		if x.paged {
+			x.hashes[nodes.hash(i)] = i
+			continue
+		}
+		n, err := nodes.get(i)
+		if err != nil {
+			return fmt.Errorf("cannot get node %d of base checkpoint: %w", i, err)
+		}
+		x.nodes[n] = i
+	}
 	return nil
 }
 
+// iterator returns an iterator over the nodes of the sub-trie with the given root which are not indexed yet.
+func (x *checkpointNodeIndex) iterator(root *node.Node) *flattener.NodeIterator {
+	if x.paged {
+		return flattener.NewUniqueSubtrieNodeHashIterator(root, x.hashes)
+	}
+	return flattener.NewUniqueSubtrieNodeIterator(root, x.nodes)
+}
+
+// storeUniqueNodes serializes the nodes of the sub-trie with the given root which are not indexed yet,
+// indexes them starting at the given node counter, and returns the next node counter.
+func storeUniqueNodes(writer io.Writer, root *node.Node, allNodes *checkpointNodeIndex, nodeCounter uint64, scratch []byte) (uint64, error) {
+	itr := allNodes.iterator(root)
+	for itr.Next() {
+		n := itr.Value()
+
+		allNodes.add(n, nodeCounter)
+		nodeCounter++
+
+		var lchildIndex, rchildIndex uint64
+
+		// the children of the node were loaded by the iterator, and are cached by the node store
+		lchild, rchild, err := n.Children()
+		if err != nil {
+			return 0, err
+		}
+
+		if lchild != nil {
+			var found bool
+			lchildIndex, found = allNodes.index(lchild)
+			if !found {
+				hash := lchild.Hash()
+				return 0, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(hash[:]))
+			}
+		}
+		if rchild != nil {
+			var found bool
+			rchildIndex, found = allNodes.index(rchild)
+			if !found {
+				hash := rchild.Hash()
+				return 0, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(hash[:]))
+			}
+		}
+
+		encNode := flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch)
+		_, err = writer.Write(encNode)
+		if err != nil {
+			return 0, fmt.Errorf("cannot serialize node: %w", err)
+		}
+	}
+	if err := itr.Err(); err != nil {
+		return 0, fmt.Errorf("cannot iterate trie nodes: %w", err)
+	}
+	return nodeCounter, nil
+}
+
 func (c *Checkpointer) LoadCheckpoint(checkpoint int) ([]*trie.MTrie, error) {
 	filepath := path.Join(c.dir, NumberToFilename(checkpoint))
 	return LoadCheckpoint(filepath, &c.wal.log)
@@ -411,8 +802,13 @@ func (c *Checkpointer) HasRootCheckpoint() (bool, error) {
 	}
 }
 
+// RemoveCheckpoint removes the given checkpoint, including the partitions of partitioned checkpoints.
 func (c *Checkpointer) RemoveCheckpoint(checkpoint int) error {
-	return os.Remove(path.Join(c.dir, NumberToFilename(checkpoint)))
+	err := os.Remove(path.Join(c.dir, NumberToFilename(checkpoint)))
+	if err != nil {
+		return err
+	}
+	return removePartitionFiles(c.dir, NumberToFilename(checkpoint))
 }
 
 func LoadCheckpoint(filepath string, logger *zerolog.Logger) ([]*trie.MTrie, error) {
@@ -430,16 +826,94 @@ func LoadCheckpoint(filepath string, logger *zerolog.Logger) ([]*trie.MTrie, err
 		_ = file.Close()
 	}()
 
-	return readCheckpoint(file)
+	return readCheckpoint(file, logger)
+}
+
+// loadCheckpoint loads the tries of the given checkpoint file. If the checkpointer has a node store, the nodes
+// of checkpoints which can be the base of a delta checkpoint are paged out to the node store while they are
+// decoded, and the returned nodes reference them. They must be released once the tries are referenced, for
+// example by adding them to a forest backed by the node store.
+func (c *Checkpointer) loadCheckpoint(filepath string) (*checkpointNodes, []*trie.MTrie, error) {
+	if c.nodeStore != nil {
+		file, err := os.Open(filepath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+		}
+		version, err := readCheckpointHeader(file)
+		_ = file.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if version >= VersionV5 {
+			return loadCheckpointNodes(filepath, &c.wal.log, c.nodeStore)
+		}
+	}
+
+	tries, err := LoadCheckpoint(filepath, &c.wal.log)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newCheckpointNodes(nil), tries, nil
+}
+
+// loadCheckpointNodes loads the checkpoint file which is the base of a delta checkpoint, and returns
+// its nodes by index, including the nodes of the checkpoints it is based on, and its tries. The nodes
+// are paged out to the given node store, if any, see checkpointNodes.
+func loadCheckpointNodes(filepath string, logger *zerolog.Logger, store *nodestore.Store) (*checkpointNodes, []*trie.MTrie, error) {
+	nodes := newCheckpointNodes(store)
+	tries, err := readCheckpointNodes(filepath, logger, nodes)
+	if err != nil {
+		releaseErr := nodes.release()
+		if releaseErr != nil {
+			logger.Warn().Err(releaseErr).Msgf("cannot release nodes of checkpoint file %s", filepath)
+		}
+		return nil, nil, err
+	}
+	return nodes, tries, nil
+}
+
+// readCheckpointNodes decodes the checkpoint file which can be the base of a delta checkpoint into the given nodes.
+func readCheckpointNodes(filepath string, logger *zerolog.Logger, nodes *checkpointNodes) ([]*trie.MTrie, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer func() {
+		evictErr := evictFileFromLinuxPageCache(file, false, logger)
+		if evictErr != nil {
+			logger.Warn().Msgf("failed to evict file %s from Linux page cache: %s", filepath, evictErr)
+		}
+
+		_ = file.Close()
+	}()
+
+	version, err := readCheckpointHeader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	switch version {
+	case VersionV5:
+		return readCheckpointV5Nodes(file, headerSize, nodes)
+	case VersionV6:
+		return readCheckpointV6(file, logger, nodes)
+	case VersionV7:
+		return readCheckpointV7(file, logger, nodes)
+	default:
+		return nil, fmt.Errorf("checkpoint file version %x can not be the base of a delta checkpoint", version)
+	}
 }
 
-func readCheckpoint(f *os.File) ([]*trie.MTrie, error) {
+// readCheckpointHeader reads and verifies the magic bytes of a checkpoint file, and returns its version.
+// The offset of the file is reset to the start of the file.
+func readCheckpointHeader(f *os.File) (uint16, error) {
 
 	// Read header: magic (2 bytes) + version (2 bytes)
 	header := make([]byte, headerSize)
 	_, err := io.ReadFull(f, header)
 	if err != nil {
-		return nil, fmt.Errorf("cannot read header: %w", err)
+		return 0, fmt.Errorf("cannot read header: %w", err)
 	}
 
 	// Decode header
@@ -449,11 +923,21 @@ func readCheckpoint(f *os.File) ([]*trie.MTrie, error) {
 	// Reset offset
 	_, err = f.Seek(0, io.SeekStart)
 	if err != nil {
-		return nil, fmt.Errorf("cannot seek to start of file: %w", err)
+		return 0, fmt.Errorf("cannot seek to start of file: %w", err)
 	}
 
 	if magicBytes != MagicBytes {
-		return nil, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
+		return 0, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
+	}
+
+	return version, nil
+}
+
+func readCheckpoint(f *os.File, logger *zerolog.Logger) ([]*trie.MTrie, error) {
+
+	version, err := readCheckpointHeader(f)
+	if err != nil {
+		return nil, err
 	}
 
 	switch version {
@@ -463,6 +947,10 @@ func readCheckpoint(f *os.File) ([]*trie.MTrie, error) {
 		return readCheckpointV4(f)
 	case VersionV5:
 		return readCheckpointV5(f)
+	case VersionV6:
+		return readCheckpointV6(f, logger, newCheckpointNodes(nil))
+	case VersionV7:
+		return readCheckpointV7(f, logger, newCheckpointNodes(nil))
 	default:
 		return nil, fmt.Errorf("unsupported file version %x", version)
 	}
@@ -592,6 +1080,11 @@ func readCheckpointV4(f *os.File) ([]*trie.MTrie, error) {
 	nodesCount := binary.BigEndian.Uint64(footer)
 	triesCount := binary.BigEndian.Uint16(footer[encNodeCountSize:])
 
+	err = validateNodeCount(f, nodesCount)
+	if err != nil {
+		return nil, err
+	}
+
 	// Seek to the start of file
 	_, err = f.Seek(0, io.SeekStart)
 	if err != nil {
@@ -669,9 +1162,81 @@ func readCheckpointV4(f *os.File) ([]*trie.MTrie, error) {
 	return tries, nil
 }
 
+// validateNodeCount checks that the node count read from the footer of the given checkpoint file does not
+// exceed the file size. Every node is encoded in more than one byte, so a larger node count is the sign of
+// a truncated or corrupted file, which must not cause the nodes to be allocated before the checksum is verified.
+func validateNodeCount(f *os.File, nodesCount uint64) error {
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot get checkpoint file size: %w", err)
+	}
+	if nodesCount > uint64(info.Size()) {
+		return fmt.Errorf("node count %d exceeds checkpoint file size %d", nodesCount, info.Size())
+	}
+	return nil
+}
+
 // readCheckpointV5 decodes checkpoint file (version 5) and returns a list of tries.
 // Checkpoint file header (magic and version) are verified by the caller.
 func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
+	return readCheckpointV5Nodes(f, headerSize, newCheckpointNodes(nil))
+}
+
+// readCheckpointV6 decodes delta checkpoint file (version 6), loads the checkpoint it is based on,
+// and decodes the nodes into the given nodes after the nodes of the base checkpoint. It returns the
+// list of tries.
+// Checkpoint file header (magic and version) are verified by the caller.
+func readCheckpointV6(f *os.File, logger *zerolog.Logger, nodes *checkpointNodes) ([]*trie.MTrie, error) {
+
+	baseNumber, baseNodesCount, err := readDeltaCheckpointHeader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// The base checkpoint is stored in the same directory, and must precede the delta checkpoint.
+	dir, filename := filepath.Split(f.Name())
+	if number, ok := checkpointNumber(filename); ok && baseNumber >= uint64(number) {
+		return nil, fmt.Errorf("base checkpoint %d does not precede delta checkpoint %d", baseNumber, number)
+	}
+
+	_, err = readCheckpointNodes(filepath.Join(dir, NumberToFilename(int(baseNumber))), logger, nodes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load base checkpoint %d: %w", baseNumber, err)
+	}
+
+	if nodes.len()-1 != baseNodesCount {
+		return nil, fmt.Errorf("base checkpoint %d contains %d nodes, but %d nodes are expected", baseNumber, nodes.len()-1, baseNodesCount)
+	}
+
+	return readCheckpointV5Nodes(f, headerSize+encBaseCheckpointSize+encNodeCountSize, nodes)
+}
+
+// readDeltaCheckpointHeader reads the header of a delta checkpoint file (version 6), and returns the
+// number of its base checkpoint and the number of nodes of the base checkpoint.
+// The offset of the file is reset to the start of the file.
+func readDeltaCheckpointHeader(f *os.File) (uint64, uint64, error) {
+	header := make([]byte, headerSize+encBaseCheckpointSize+encNodeCountSize)
+	_, err := io.ReadFull(f, header)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot read header: %w", err)
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot seek to start of file: %w", err)
+	}
+
+	baseNumber := binary.BigEndian.Uint64(header[headerSize:])
+	baseNodesCount := binary.BigEndian.Uint64(header[headerSize+encBaseCheckpointSize:])
+
+	return baseNumber, baseNodesCount, nil
+}
+
+// readCheckpointV5Nodes decodes the nodes and tries of a checkpoint file encoded like version 5,
+// whose header has the given size. The decoded nodes are indexed after the given nodes, which are
+// the nodes of the base checkpoint of delta checkpoints, and can reference them. It returns the
+// list of tries.
+func readCheckpointV5Nodes(f *os.File, fileHeaderSize int, nodes *checkpointNodes) ([]*trie.MTrie, error) {
 
 	// Scratch buffer is used as temporary buffer that reader can read into.
 	// Raw data in scratch buffer should be copied or converted into desired
@@ -703,6 +1268,11 @@ func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
 	nodesCount := binary.BigEndian.Uint64(footer)
 	triesCount := binary.BigEndian.Uint16(footer[encNodeCountSize:])
 
+	err = validateNodeCount(f, nodesCount)
+	if err != nil {
+		return nil, err
+	}
+
 	// Seek to the start of file
 	_, err = f.Seek(0, io.SeekStart)
 	if err != nil {
@@ -713,37 +1283,46 @@ func readCheckpointV5(f *os.File) ([]*trie.MTrie, error) {
 	crcReader := NewCRC32Reader(bufReader)
 	var reader io.Reader = crcReader
 
-	// Read header: magic (2 bytes) + version (2 bytes)
+	// Read header: magic (2 bytes) + version (2 bytes), and base checkpoint of delta checkpoints
 	// No action is needed for header because it is verified by the caller.
 
-	_, err = io.ReadFull(reader, scratch[:headerSize])
+	_, err = io.ReadFull(reader, scratch[:fileHeaderSize])
 	if err != nil {
 		return nil, fmt.Errorf("cannot read header: %w", err)
 	}
 
 	// nodes's element at index 0 is a special, meaning nil .
-	nodes := make([]*node.Node, nodesCount+1) //+1 for 0 index meaning nil
+	baseNodesCount := nodes.len()
+	nodes.grow(nodesCount)
 	tries := make([]*trie.MTrie, triesCount)
 
-	for i := uint64(1); i <= nodesCount; i++ {
+	for i := baseNodesCount; i < baseNodesCount+nodesCount; i++ {
 		n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
 			if nodeIndex >= uint64(i) {
 				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
 			}
-			return nodes[nodeIndex], nil
+			return nodes.get(nodeIndex)
 		})
 		if err != nil {
 			return nil, fmt.Errorf("cannot read node %d: %w", i, err)
 		}
-		nodes[i] = n
+		err = nodes.set(i, n)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = nodes.page()
+	if err != nil {
+		return nil, err
 	}
 
 	for i := uint16(0); i < triesCount; i++ {
 		trie, err := flattener.ReadTrie(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
-			if nodeIndex >= uint64(len(nodes)) {
+			if nodeIndex >= baseNodesCount+nodesCount {
 				return nil, fmt.Errorf("sequence of stored nodes doesn't contain node")
 			}
-			return nodes[nodeIndex], nil
+			return nodes.get(nodeIndex)
 		})
 		if err != nil {
 			return nil, fmt.Errorf("cannot read trie %d: %w", i, err)
@@ -532,7 +532,9 @@ func Test_StoringLoadingCheckpoints(t *testing.T) {
 		updatedTrie, _, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, paths, payloads, true)
 		require.NoError(t, err)
 
-		someHash := updatedTrie.RootNode().LeftChild().Hash() // Hash of left child
+		lChild, err := updatedTrie.RootNode().LeftChild()
+		require.NoError(t, err)
+		someHash := lChild.Hash() // Hash of left child
 
 		file, err := ioutil.TempFile(dir, "temp-checkpoint")
 		filepath := file.Name()
@@ -590,3 +592,82 @@ func (wc *writeCloserWithErrors) Write(p []byte) (n int, err error) {
 func (wc *writeCloserWithErrors) Close() error {
 	return wc.closeError
 }
+
+func Test_StoringLoadingPartitionedCheckpoints(t *testing.T) {
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		emptyTrie := trie.NewEmptyMTrie()
+
+		// a trie whose root is a leaf, above the partitioned sub-tries
+		leafTrie, _, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, []ledger.Path{utils.PathByUint8(0)}, []ledger.Payload{*utils.LightPayload8('A', 'a')}, true)
+		require.NoError(t, err)
+
+		tries := []*trie.MTrie{emptyTrie, leafTrie}
+
+		// tries sharing sub-tries with their parent
+		parent := emptyTrie
+		for i := 0; i < 5; i++ {
+			paths := utils.RandomPaths(100)
+			payloads := utils.RandomPayloads(len(paths), 1, 100)
+			values := make([]ledger.Payload, len(payloads))
+			for j, payload := range payloads {
+				values[j] = *payload
+			}
+
+			updatedTrie, _, err := trie.NewTrieWithUpdatedRegisters(parent, paths, values, true)
+			require.NoError(t, err)
+
+			tries = append(tries, updatedTrie)
+			parent = updatedTrie
+		}
+
+		filename := realWAL.NumberToFilename(1)
+		filepath := path.Join(dir, filename)
+
+		logger := zerolog.Nop()
+		err = realWAL.StorePartitionedCheckpoint(dir, filename, &logger, 4, tries...)
+		require.NoError(t, err)
+
+		t.Run("works without data modification", func(t *testing.T) {
+			loadedTries, err := realWAL.LoadCheckpoint(filepath, &logger)
+			require.NoError(t, err)
+			require.Equal(t, len(tries), len(loadedTries))
+			for i := range tries {
+				require.Equal(t, tries[i], loadedTries[i])
+			}
+		})
+
+		t.Run("can't overwrite existing checkpoint", func(t *testing.T) {
+			err := realWAL.StorePartitionedCheckpoint(dir, filename, &logger, 4, tries...)
+			require.Error(t, err)
+		})
+
+		t.Run("detects modified partition", func(t *testing.T) {
+			partitionFile := filepath + ".part005"
+			b, err := ioutil.ReadFile(partitionFile)
+			require.NoError(t, err)
+
+			err = os.WriteFile(partitionFile+".orig", b, 0644)
+			require.NoError(t, err)
+
+			randomlyModifyFile(t, partitionFile)
+
+			loadedTries, err := realWAL.LoadCheckpoint(filepath, &logger)
+			require.Error(t, err)
+			require.Nil(t, loadedTries)
+
+			err = os.Rename(partitionFile+".orig", partitionFile)
+			require.NoError(t, err)
+		})
+
+		t.Run("detects missing partition", func(t *testing.T) {
+			err := os.Remove(filepath + ".part010")
+			require.NoError(t, err)
+
+			loadedTries, err := realWAL.LoadCheckpoint(filepath, &logger)
+			require.Error(t, err)
+			require.Nil(t, loadedTries)
+		})
+	})
+}
@@ -26,7 +26,9 @@ func TestLoadCheckpointV1(t *testing.T) {
 
 	for i, trie := range tries {
 		require.Equal(t, expectedRootHash[i], trie.RootHash())
-		require.True(t, trie.RootNode().VerifyCachedHash())
+		valid, err := trie.RootNode().VerifyCachedHash()
+		require.NoError(t, err)
+		require.True(t, valid)
 	}
 }
 
@@ -46,7 +48,9 @@ func TestLoadCheckpointV3(t *testing.T) {
 
 	for i, trie := range tries {
 		require.Equal(t, expectedRootHash[i], trie.RootHash())
-		require.True(t, trie.RootNode().VerifyCachedHash())
+		valid, err := trie.RootNode().VerifyCachedHash()
+		require.NoError(t, err)
+		require.True(t, valid)
 	}
 }
 
@@ -66,7 +70,9 @@ func TestLoadCheckpointV4(t *testing.T) {
 
 	for i, trie := range tries {
 		require.Equal(t, expectedRootHash[i], trie.RootHash())
-		require.True(t, trie.RootNode().VerifyCachedHash())
+		valid, err := trie.RootNode().VerifyCachedHash()
+		require.NoError(t, err)
+		require.True(t, valid)
 	}
 }
 
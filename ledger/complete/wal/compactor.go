@@ -1,8 +1,9 @@
 package wal
 
 import (
+	"errors"
 	"fmt"
-	"io"
+	"os"
 	"sync"
 	"time"
 
@@ -120,9 +121,7 @@ func (c *Compactor) createCheckpoints() (int, error) {
 
 		checkpointNumber := to - 1
 		c.logger.Info().Msgf("creating checkpoint %d from segment %d to segment %d", checkpointNumber, from, checkpointNumber)
-		err = c.checkpointer.Checkpoint(checkpointNumber, func() (io.WriteCloser, error) {
-			return c.checkpointer.CheckpointWriter(checkpointNumber)
-		})
+		err = c.checkpointer.CreateCheckpoint(checkpointNumber)
 		if err != nil {
 			return -1, fmt.Errorf("error creating checkpoint (%d): %w", checkpointNumber, err)
 		}
@@ -146,7 +145,16 @@ func (c *Compactor) cleanupCheckpoints() error {
 	if len(checkpoints) > int(c.checkpointsToKeep) {
 		checkpointsToRemove := checkpoints[:len(checkpoints)-int(c.checkpointsToKeep)] // if condition guarantees this never fails
 
+		// the checkpoints which kept delta checkpoints are based on are kept as well
+		baseCheckpoints, err := c.baseCheckpoints(checkpoints[len(checkpoints)-int(c.checkpointsToKeep):])
+		if err != nil {
+			return fmt.Errorf("cannot get base checkpoints: %w", err)
+		}
+
 		for _, checkpoint := range checkpointsToRemove {
+			if _, ok := baseCheckpoints[checkpoint]; ok {
+				continue
+			}
 			err := c.checkpointer.RemoveCheckpoint(checkpoint)
 			if err != nil {
 				return fmt.Errorf("cannot remove checkpoint %d: %w", checkpoint, err)
@@ -155,3 +163,31 @@ func (c *Compactor) cleanupCheckpoints() error {
 	}
 	return nil
 }
+
+// baseCheckpoints returns the checkpoints which the given delta checkpoints are based on, directly or
+// through other delta checkpoints.
+func (c *Compactor) baseCheckpoints(checkpoints []int) (map[int]struct{}, error) {
+	bases := make(map[int]struct{})
+	for _, checkpoint := range checkpoints {
+		for {
+			base, err := c.checkpointer.CheckpointBase(checkpoint)
+			if errors.Is(err, os.ErrNotExist) {
+				// the chain of the delta checkpoint is broken, it can't be loaded anyway
+				c.logger.Warn().Err(err).Int("checkpoint", checkpoint).Msg("base checkpoint is missing")
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("cannot get base of checkpoint %d: %w", checkpoint, err)
+			}
+			if base < 0 {
+				break
+			}
+			if _, ok := bases[base]; ok {
+				break
+			}
+			bases[base] = struct{}{}
+			checkpoint = base
+		}
+	}
+	return bases, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/module/metrics"
+	utilsio "github.com/onflow/flow-go/utils/io"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -328,3 +330,304 @@ func Test_Compactor_checkpointInterval(t *testing.T) {
 		})
 	})
 }
+
+func Test_Compactor_deltaCheckpoints(t *testing.T) {
+
+	numInsPerStep := 2
+	pathByteSize := 32
+	minPayloadByteSize := 100
+	maxPayloadByteSize := 2 << 16
+	size := 20
+	metricsCollector := &metrics.NoopCollector{}
+	checkpointDistance := uint(3)
+	maxDeltaCheckpoints := uint(2)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		f, err := mtrie.NewForest(size*10, metricsCollector, nil)
+		require.NoError(t, err)
+
+		var rootHash = f.GetEmptyRootHash()
+
+		//saved data after updates
+		savedData := make(map[ledger.RootHash]map[ledger.Path]*ledger.Payload)
+
+		wal, err := NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), dir, size*10, pathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+		checkpointer.SetMaxDeltaCheckpoints(maxDeltaCheckpoints)
+
+		compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 1, zerolog.Nop()) //keep only latest checkpoint
+
+		t.Run("Compactor creates delta checkpoints", func(t *testing.T) {
+
+			// Generate the tree and create WAL
+			for i := 0; i < size; i++ {
+
+				paths := utils.RandomPaths(numInsPerStep)
+				payloads := utils.RandomPayloads(numInsPerStep, minPayloadByteSize, maxPayloadByteSize)
+
+				update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads}
+
+				err = wal.RecordUpdate(update)
+				require.NoError(t, err)
+
+				rootHash, err = f.Update(update)
+				require.NoError(t, err)
+
+				data := make(map[ledger.Path]*ledger.Payload, len(paths))
+				for j, path := range paths {
+					data[path] = payloads[j]
+				}
+				savedData[rootHash] = data
+
+				// run checkpoint creation after every file
+				_, err = compactor.createCheckpoints()
+				require.NoError(t, err)
+			}
+
+			// full checkpoints are created after at most 2 delta checkpoints
+			expectedBases := map[int]int{3: -1, 7: 3, 11: 7, 15: -1, 19: 15}
+
+			checkpoints, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			require.Equal(t, []int{3, 7, 11, 15, 19}, checkpoints)
+
+			for checkpoint, expectedBase := range expectedBases {
+				base, err := checkpointer.CheckpointBase(checkpoint)
+				require.NoError(t, err)
+				require.Equal(t, expectedBase, base, "base of checkpoint %d", checkpoint)
+			}
+
+			// delta checkpoints only contain the nodes created since their base checkpoint
+			fullInfo, err := os.Stat(path.Join(dir, NumberToFilename(15)))
+			require.NoError(t, err)
+			deltaInfo, err := os.Stat(path.Join(dir, NumberToFilename(19)))
+			require.NoError(t, err)
+			require.Less(t, deltaInfo.Size(), fullInfo.Size())
+		})
+
+		t.Run("delta checkpoints are loaded with their base checkpoints", func(t *testing.T) {
+			for _, checkpoint := range []int{7, 11, 19} {
+				tries, err := checkpointer.LoadCheckpoint(checkpoint)
+				require.NoError(t, err)
+				require.NotEmpty(t, tries)
+
+				f2, err := mtrie.NewForest(size*10, metricsCollector, nil)
+				require.NoError(t, err)
+				require.NoError(t, f2.AddTries(tries))
+
+				for _, tr := range tries {
+					data, ok := savedData[tr.RootHash()]
+					if !ok {
+						// empty trie
+						require.Equal(t, f.GetEmptyRootHash(), tr.RootHash())
+						continue
+					}
+
+					paths := make([]ledger.Path, 0, len(data))
+					for path := range data {
+						paths = append(paths, path)
+					}
+
+					payloads, err := f2.Read(&ledger.TrieRead{RootHash: tr.RootHash(), Paths: paths})
+					require.NoError(t, err)
+					for i, path := range paths {
+						require.True(t, data[path].Equals(payloads[i]))
+					}
+				}
+			}
+		})
+
+		t.Run("base checkpoints of kept delta checkpoints are kept", func(t *testing.T) {
+			err = compactor.cleanupCheckpoints()
+			require.NoError(t, err)
+
+			checkpoints, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			require.Equal(t, []int{15, 19}, checkpoints)
+		})
+
+		t.Run("delta checkpoints can't be loaded without their base checkpoint", func(t *testing.T) {
+			err = checkpointer.RemoveCheckpoint(15)
+			require.NoError(t, err)
+
+			_, err = checkpointer.LoadCheckpoint(19)
+			require.Error(t, err)
+		})
+
+		<-wal.Done()
+	})
+}
+
+func Test_Compactor_deltaCheckpointsKeepBase(t *testing.T) {
+
+	numInsPerStep := 2
+	pathByteSize := 32
+	minPayloadByteSize := 100
+	maxPayloadByteSize := 2 << 16
+	size := 8
+	metricsCollector := &metrics.NoopCollector{}
+	checkpointDistance := uint(3)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		f, err := mtrie.NewForest(size*10, metricsCollector, nil)
+		require.NoError(t, err)
+
+		var rootHash = f.GetEmptyRootHash()
+
+		wal, err := NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), dir, size*10, pathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+		checkpointer.SetMaxDeltaCheckpoints(2)
+
+		compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 0, zerolog.Nop())
+
+		corrupted := false
+		for i := 0; i < size; i++ {
+
+			paths := utils.RandomPaths(numInsPerStep)
+			payloads := utils.RandomPayloads(numInsPerStep, minPayloadByteSize, maxPayloadByteSize)
+
+			update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads}
+
+			err = wal.RecordUpdate(update)
+			require.NoError(t, err)
+
+			rootHash, err = f.Update(update)
+			require.NoError(t, err)
+
+			_, err = compactor.createCheckpoints()
+			require.NoError(t, err)
+
+			// the nodes of the first checkpoint are corrupted once it is created, only its header is kept
+			if !corrupted && utilsio.FileExists(path.Join(dir, NumberToFilename(3))) {
+				file, err := os.OpenFile(path.Join(dir, NumberToFilename(3)), os.O_WRONLY, 0)
+				require.NoError(t, err)
+				info, err := file.Stat()
+				require.NoError(t, err)
+				_, err = file.WriteAt(make([]byte, info.Size()-headerSize), headerSize)
+				require.NoError(t, err)
+				require.NoError(t, file.Close())
+				corrupted = true
+			}
+		}
+
+		checkpoints, err := checkpointer.Checkpoints()
+		require.NoError(t, err)
+		require.Equal(t, []int{3, 7}, checkpoints)
+
+		// the delta checkpoint is based on the first checkpoint kept by the checkpointer, which isn't loaded again
+		base, err := checkpointer.CheckpointBase(7)
+		require.NoError(t, err)
+		require.Equal(t, 3, base)
+
+		<-wal.Done()
+	})
+}
+
+func Test_Compactor_partitionedCheckpoints(t *testing.T) {
+
+	numInsPerStep := 2
+	pathByteSize := 32
+	minPayloadByteSize := 100
+	maxPayloadByteSize := 2 << 16
+	size := 12
+	metricsCollector := &metrics.NoopCollector{}
+	checkpointDistance := uint(3)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		f, err := mtrie.NewForest(size*10, metricsCollector, nil)
+		require.NoError(t, err)
+
+		var rootHash = f.GetEmptyRootHash()
+
+		wal, err := NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), dir, size*10, pathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+		checkpointer.SetMaxDeltaCheckpoints(1)
+		checkpointer.SetPartitionWorkers(4)
+
+		compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 1, zerolog.Nop()) //keep only latest checkpoint
+
+		t.Run("Compactor creates partitioned checkpoints", func(t *testing.T) {
+
+			for i := 0; i < size; i++ {
+
+				paths := utils.RandomPaths(numInsPerStep)
+				payloads := utils.RandomPayloads(numInsPerStep, minPayloadByteSize, maxPayloadByteSize)
+
+				update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads}
+
+				err = wal.RecordUpdate(update)
+				require.NoError(t, err)
+
+				rootHash, err = f.Update(update)
+				require.NoError(t, err)
+
+				// run checkpoint creation after every file
+				_, err = compactor.createCheckpoints()
+				require.NoError(t, err)
+			}
+
+			checkpoints, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			require.Equal(t, []int{3, 7, 11}, checkpoints)
+
+			// full checkpoints are partitioned, delta checkpoints are based on them
+			expectedBases := map[int]int{3: -1, 7: 3, 11: -1}
+			for checkpoint, expectedBase := range expectedBases {
+				base, err := checkpointer.CheckpointBase(checkpoint)
+				require.NoError(t, err)
+				require.Equal(t, expectedBase, base, "base of checkpoint %d", checkpoint)
+
+				partitions, err := filepath.Glob(path.Join(dir, NumberToFilename(checkpoint)+".part*"))
+				require.NoError(t, err)
+				if expectedBase == -1 {
+					require.Len(t, partitions, checkpointPartitionCount)
+				} else {
+					require.Empty(t, partitions)
+				}
+			}
+		})
+
+		t.Run("partitioned checkpoints are loaded", func(t *testing.T) {
+			for _, checkpoint := range []int{3, 7, 11} {
+				tries, err := checkpointer.LoadCheckpoint(checkpoint)
+				require.NoError(t, err)
+				require.NotEmpty(t, tries)
+
+				for _, tr := range tries {
+					expected, err := f.GetTrie(tr.RootHash())
+					require.NoError(t, err)
+					require.Equal(t, expected.RootHash(), tr.RootHash())
+					require.Equal(t, expected.AllocatedRegCount(), tr.AllocatedRegCount())
+					require.Equal(t, expected.AllocatedRegSize(), tr.AllocatedRegSize())
+				}
+			}
+		})
+
+		t.Run("partitions are removed with their checkpoint", func(t *testing.T) {
+			err = compactor.cleanupCheckpoints()
+			require.NoError(t, err)
+
+			checkpoints, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			require.Equal(t, []int{11}, checkpoints)
+
+			partitions, err := filepath.Glob(path.Join(dir, NumberToFilename(3)+".part*"))
+			require.NoError(t, err)
+			require.Empty(t, partitions)
+		})
+
+		<-wal.Done()
+	})
+}
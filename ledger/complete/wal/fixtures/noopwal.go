@@ -3,6 +3,7 @@ package fixtures
 import (
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/nodestore"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 )
@@ -25,6 +26,8 @@ func (w *NoopWAL) NewCheckpointer() (*wal.Checkpointer, error) {
 	return nil, nil
 }
 
+func (w *NoopWAL) SetNodeStore(store *nodestore.Store) {}
+
 func (w *NoopWAL) PauseRecord() {}
 
 func (w *NoopWAL) UnpauseRecord() {}
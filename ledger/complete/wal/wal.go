@@ -2,6 +2,7 @@ package wal
 
 import (
 	"fmt"
+	"path"
 	"sort"
 	"time"
 
@@ -11,7 +12,9 @@ import (
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/nodestore"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/utils/io"
 )
@@ -28,6 +31,7 @@ type DiskWAL struct {
 	diskUpdateLimiter *time.Ticker
 	metrics           module.WALMetrics
 	dir               string
+	nodeStore         *nodestore.Store
 }
 
 // TODO use real logger and metrics, but that would require passing them to Trie storage
@@ -48,6 +52,12 @@ func NewDiskWAL(logger zerolog.Logger, reg prometheus.Registerer, metrics module
 	}, nil
 }
 
+// SetNodeStore sets the node store the nodes of the loaded checkpoints are paged out to, which must be
+// the node store backing the forest the write-ahead log is replayed on (see mtrie.WithNodeStore).
+func (w *DiskWAL) SetNodeStore(store *nodestore.Store) {
+	w.nodeStore = store
+}
+
 func (w *DiskWAL) PauseRecord() {
 	w.paused = true
 }
@@ -194,7 +204,7 @@ func (w *DiskWAL) replay(
 
 			w.log.Info().Int("checkpoint", latestCheckpoint).Msg("loading checkpoint")
 
-			forestSequencing, err := checkpointer.LoadCheckpoint(latestCheckpoint)
+			nodes, forestSequencing, err := checkpointer.loadCheckpoint(path.Join(checkpointer.dir, NumberToFilename(latestCheckpoint)))
 			if err != nil {
 				w.log.Warn().Int("checkpoint", latestCheckpoint).Err(err).
 					Msg("checkpoint loading failed")
@@ -209,6 +219,11 @@ func (w *DiskWAL) replay(
 			if err != nil {
 				return fmt.Errorf("error while handling checkpoint: %w", err)
 			}
+			// the nodes paged out to the node store are referenced by the tries added to the forest
+			err = nodes.release()
+			if err != nil {
+				return fmt.Errorf("cannot release nodes of checkpoint: %w", err)
+			}
 			loadedCheckpoint = latestCheckpoint
 			break
 		}
@@ -228,7 +243,7 @@ func (w *DiskWAL) replay(
 			return fmt.Errorf("cannot check root checkpoint existence: %w", err)
 		}
 		if hasRootCheckpoint {
-			flattenedForest, err := checkpointer.LoadRootCheckpoint()
+			nodes, flattenedForest, err := checkpointer.loadCheckpoint(path.Join(checkpointer.dir, bootstrap.FilenameWALRootCheckpoint))
 			if err != nil {
 				return fmt.Errorf("cannot load root checkpoint: %w", err)
 			}
@@ -236,6 +251,10 @@ func (w *DiskWAL) replay(
 			if err != nil {
 				return fmt.Errorf("error while handling root checkpoint: %w", err)
 			}
+			err = nodes.release()
+			if err != nil {
+				return fmt.Errorf("cannot release nodes of root checkpoint: %w", err)
+			}
 		}
 	}
 
@@ -335,6 +354,7 @@ type LedgerWAL interface {
 	module.ReadyDoneAware
 
 	NewCheckpointer() (*Checkpointer, error)
+	SetNodeStore(store *nodestore.Store)
 	PauseRecord()
 	UnpauseRecord()
 	RecordUpdate(update *ledger.TrieUpdate) error
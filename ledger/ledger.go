@@ -32,6 +32,11 @@ type Ledger interface {
 
 	// Prove returns proofs for the given keys at specific state
 	Prove(query *Query) (proof Proof, err error)
+
+	// ProveRange returns a proof of all the allocated registers with a path in the range [start, end] at specific state.
+	// As paths are hashes of the keys, it can prove the absence of registers in a path range, but not that a set of
+	// registers is all the registers of an account.
+	ProveRange(state State, start, end Path) (proof Proof, err error)
 }
 
 // Query holds all data needed for a ledger read or ledger proof
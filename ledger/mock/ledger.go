@@ -92,6 +92,29 @@ func (_m *Ledger) Prove(query *ledger.Query) (ledger.Proof, error) {
 	return r0, r1
 }
 
+// ProveRange provides a mock function with given fields: state, start, end
+func (_m *Ledger) ProveRange(state ledger.State, start ledger.Path, end ledger.Path) (ledger.Proof, error) {
+	ret := _m.Called(state, start, end)
+
+	var r0 ledger.Proof
+	if rf, ok := ret.Get(0).(func(ledger.State, ledger.Path, ledger.Path) ledger.Proof); ok {
+		r0 = rf(state, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ledger.Proof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ledger.State, ledger.Path, ledger.Path) error); ok {
+		r1 = rf(state, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Ready provides a mock function with given fields:
 func (_m *Ledger) Ready() <-chan struct{} {
 	ret := _m.Called()
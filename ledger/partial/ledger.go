@@ -126,3 +126,9 @@ func (l *Ledger) Set(update *ledger.Update) (newState ledger.State, trieUpdate *
 func (l *Ledger) Prove(query *ledger.Query) (proof ledger.Proof, err error) {
 	return nil, err
 }
+
+// ProveRange is not supported by the partial ledger, which only holds the registers of its proofs and
+// can't prove that a path range holds no other registers.
+func (l *Ledger) ProveRange(state ledger.State, start, end ledger.Path) (proof ledger.Proof, err error) {
+	return nil, fmt.Errorf("range proofs are not supported by the partial ledger")
+}
@@ -422,3 +422,91 @@ func (bp *TrieBatchProof) Equals(o *TrieBatchProof) bool {
 	}
 	return true
 }
+
+// TrieRangeProof proves all the allocated registers with a path in the range [StartPath, EndPath]
+// (both inclusive). A range proof without registers proves that no register is allocated in the range.
+//
+// The proof includes the paths and payloads of the allocated registers in the range, in ascending order of
+// their paths, and the hash values of the sub-tries next to the range, which are needed to walk from the
+// registers up to the root of the trie. These sub-tries are ordered from left to right, the hash value of a
+// sub-trie is only included if it is non-default.
+type TrieRangeProof struct {
+	StartPath Path        // first path of the range
+	EndPath   Path        // last path of the range
+	Paths     []Path      // paths of the allocated registers in the range
+	Payloads  []*Payload  // payloads of the allocated registers in the range
+	Interims  []hash.Hash // the non-default hash values of the sub-tries next to the range
+	Flags     []byte      // the flags of the sub-tries next to the range (set if a sub-trie has a non-default hash value)
+}
+
+// NewTrieRangeProof creates a new instance of a trie range proof without registers
+func NewTrieRangeProof(start, end Path) *TrieRangeProof {
+	return &TrieRangeProof{
+		StartPath: start,
+		EndPath:   end,
+		Paths:     make([]Path, 0),
+		Payloads:  make([]*Payload, 0),
+		Interims:  make([]hash.Hash, 0),
+		Flags:     make([]byte, 0),
+	}
+}
+
+// PrefixRange returns the range of the paths starting with the first prefixBitLen bits of the given prefix.
+// A range proof of this range proves all the allocated registers whose path starts with the prefix.
+func PrefixRange(prefix Path, prefixBitLen int) (start Path, end Path) {
+	for i := 0; i < NodeMaxHeight; i++ {
+		if i < prefixBitLen {
+			if bitutils.ReadBit(prefix[:], i) == 1 {
+				bitutils.SetBit(start[:], i)
+				bitutils.SetBit(end[:], i)
+			}
+			continue
+		}
+		bitutils.SetBit(end[:], i)
+	}
+	return start, end
+}
+
+func (p *TrieRangeProof) String() string {
+	proofStr := fmt.Sprintf("range: [%v, %v] registers: %d\n", p.StartPath, p.EndPath, len(p.Paths))
+	for i, path := range p.Paths {
+		proofStr += fmt.Sprintf("\t path: %v payload: %v\n", path, p.Payloads[i])
+	}
+	proofStr += "\t interims:\n"
+	for i, inter := range p.Interims {
+		proofStr += fmt.Sprintf("\t\t %d: [%x]\n", i, inter)
+	}
+	return proofStr
+}
+
+// Equals compares this range proof to another range proof
+func (p *TrieRangeProof) Equals(o *TrieRangeProof) bool {
+	if o == nil {
+		return false
+	}
+	if !p.StartPath.Equals(o.StartPath) || !p.EndPath.Equals(o.EndPath) {
+		return false
+	}
+	if len(p.Paths) != len(o.Paths) || len(p.Payloads) != len(o.Payloads) {
+		return false
+	}
+	for i, path := range p.Paths {
+		if !path.Equals(o.Paths[i]) {
+			return false
+		}
+	}
+	for i, payload := range p.Payloads {
+		if !payload.Equals(o.Payloads[i]) {
+			return false
+		}
+	}
+	if len(p.Interims) != len(o.Interims) {
+		return false
+	}
+	for i, inter := range p.Interims {
+		if inter != o.Interims[i] {
+			return false
+		}
+	}
+	return bytes.Equal(p.Flags, o.Flags)
+}
@@ -13,6 +13,10 @@ type TransactionResult struct {
 	ErrorMessage string
 	// Computation used
 	ComputationUsed uint64
+	// Bytes read from storage, metered like computation
+	StorageBytesRead uint64
+	// Bytes written to storage, metered like computation
+	StorageBytesWritten uint64
 }
 
 // String returns the string representation of this error.
@@ -73,14 +73,16 @@ func (fcv *ChunkVerifier) SystemChunkVerify(vc *verification.VerifiableChunkData
 		return nil, nil, fmt.Errorf("wrong method invoked for verifying non-system chunk")
 	}
 
-	// transaction body of system chunk
-	txBody, err := blueprints.SystemChunkTransaction(fcv.vmCtx.Chain)
+	// transaction bodies of system chunk, the system chunk transaction and the system tasks scheduled at the block
+	txBodies, err := blueprints.SystemChunkTransactions(fcv.vmCtx.Chain, vc.Header.Height, fcv.systemChunkCtx.SystemTasks)
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not get system chunk transaction: %w", err)
+		return nil, nil, fmt.Errorf("could not get system chunk transactions: %w", err)
 	}
 
-	tx := fvm.Transaction(txBody, vc.TransactionOffset+uint32(0))
-	transactions := []*fvm.TransactionProcedure{tx}
+	transactions := make([]*fvm.TransactionProcedure, 0, len(txBodies))
+	for i, txBody := range txBodies {
+		transactions = append(transactions, fvm.Transaction(txBody, vc.TransactionOffset+uint32(i)))
+	}
 
 	systemChunkContext := fvm.NewContextFromParent(fcv.systemChunkCtx,
 		fvm.WithBlockHeader(vc.Header),
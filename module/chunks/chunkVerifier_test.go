@@ -2,6 +2,7 @@ package chunks_test
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 
 	executionState "github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/blueprints"
 	fvmErrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
@@ -186,6 +188,28 @@ func (s *ChunkVerifierTestSuite) TestServiceEventsAreChecked() {
 	assert.Nil(s.T(), chFault)
 }
 
+// TestSystemTasksAreVerified ensures that the system tasks scheduled at the block of a system chunk
+// are executed after the system chunk transaction.
+func (s *ChunkVerifierTestSuite) TestSystemTasksAreVerified() {
+	tasks, err := blueprints.NewSystemTasks(
+		blueprints.SystemTask{Name: "every_block", Interval: 1, GasLimit: 10, Script: []byte("task")},
+		blueprints.SystemTask{Name: "never", StartHeight: math.MaxUint64, GasLimit: 10, Script: []byte("never")},
+	)
+	require.NoError(s.T(), err)
+
+	vm := &vmSystemTasksMock{}
+	vmCtx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(testChain.Chain()), fvm.WithSystemTasks(tasks))
+	verifier := chunks.NewChunkVerifier(vm, vmCtx, zerolog.Nop())
+
+	vch := GetBaselineVerifiableChunk(s.T(), "doesn't matter", true)
+	_, chFault, err := verifier.SystemChunkVerify(vch)
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), chFault)
+
+	require.Len(s.T(), vm.scripts, 2)
+	assert.Equal(s.T(), "task", vm.scripts[1])
+}
+
 // TestVerifyWrongChunkType evaluates that following invocations return an error:
 // - verifying a system chunk with Verify method.
 // - verifying a non-system chunk with SystemChunkVerify method.
@@ -420,6 +444,30 @@ func (vm *vmSystemOkMock) Run(ctx fvm.Context, proc fvm.Procedure, led state.Vie
 	return nil
 }
 
+// vmSystemTasksMock emits the expected service event for the system chunk transaction, and records the
+// scripts of the executed transactions.
+type vmSystemTasksMock struct {
+	scripts []string
+}
+
+func (vm *vmSystemTasksMock) Run(ctx fvm.Context, proc fvm.Procedure, led state.View, programs *programs.Programs) error {
+	tx, ok := proc.(*fvm.TransactionProcedure)
+	if !ok {
+		return fmt.Errorf("invokable is not a transaction")
+	}
+
+	if len(vm.scripts) == 0 {
+		tx.ServiceEvents = []flow.Event{epochSetupEvent}
+	}
+	vm.scripts = append(vm.scripts, string(tx.Transaction.Script))
+
+	_, _ = led.Get("00", "", "")
+	_, _ = led.Get("05", "", "")
+	_ = led.Set("05", "", "", []byte{'B'})
+
+	return nil
+}
+
 type vmSystemBadMock struct{}
 
 func (vm *vmSystemBadMock) Run(ctx fvm.Context, proc fvm.Procedure, led state.View, programs *programs.Programs) error {
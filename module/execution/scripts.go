@@ -0,0 +1,122 @@
+// Package execution provides local execution of scripts on nodes which do not execute blocks,
+// against the execution state indexed from execution data.
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultScriptExecutionTimeLimit is the default maximum duration of a script execution.
+const DefaultScriptExecutionTimeLimit = 10 * time.Second
+
+// ErrScriptFailed is returned when a script was executed but failed, e.g. because of a runtime
+// error in the script. It is not returned for errors of the executor itself.
+var ErrScriptFailed = errors.New("script execution failed")
+
+// Scripts executes scripts against the register values stored in a register index.
+type Scripts struct {
+	log       zerolog.Logger
+	vm        *fvm.VirtualMachine
+	vmCtx     fvm.Context
+	headers   storage.Headers
+	registers storage.RegisterIndex
+	timeLimit time.Duration
+}
+
+// NewScripts creates a new script executor. The given context is used as the parent context of
+// all script executions, and must be configured for the chain of the node.
+func NewScripts(
+	log zerolog.Logger,
+	vm *fvm.VirtualMachine,
+	vmCtx fvm.Context,
+	headers storage.Headers,
+	registers storage.RegisterIndex,
+	timeLimit time.Duration,
+) *Scripts {
+	return &Scripts{
+		log:       log.With().Str("component", "script_executor").Logger(),
+		vm:        vm,
+		vmCtx:     vmCtx,
+		headers:   headers,
+		registers: registers,
+		timeLimit: timeLimit,
+	}
+}
+
+// ExecuteAtBlockHeight executes the script against the execution state at the given height and
+// returns the JSON-CDC encoded result.
+//
+// Expected errors:
+//   - storage.ErrHeightNotIndexed if the execution state at the height is not indexed
+//   - ErrScriptFailed if the script failed
+func (s *Scripts) ExecuteAtBlockHeight(
+	ctx context.Context,
+	script []byte,
+	arguments [][]byte,
+	height uint64,
+) ([]byte, error) {
+	if height < s.registers.FirstHeight() || height > s.registers.LatestHeight() {
+		return nil, fmt.Errorf("could not execute script at height %d: %w", height, storage.ErrHeightNotIndexed)
+	}
+
+	header, err := s.headers.ByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get header at height %d: %w", height, err)
+	}
+
+	view := registerView(s.registers, height)
+
+	requestCtx, cancel := context.WithTimeout(ctx, s.timeLimit)
+	defer cancel()
+
+	scriptProc := fvm.NewScriptWithContextAndArgs(script, requestCtx, arguments...)
+	blockCtx := fvm.NewContextFromParent(s.vmCtx, fvm.WithBlockHeader(header))
+
+	err = func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				s.log.Error().
+					Hex("script_hex", script).
+					Interface("recovered", r).
+					Msg("script execution caused runtime panic")
+
+				err = fmt.Errorf("cadence runtime error: %s", r)
+			}
+		}()
+
+		return s.vm.Run(blockCtx, scriptProc, view, programs.NewEmptyPrograms())
+	}()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute script (internal error): %w", err)
+	}
+
+	if scriptProc.Err != nil {
+		return nil, fmt.Errorf("%w at block (%s): %s", ErrScriptFailed, header.ID(), scriptProc.Err.Error())
+	}
+
+	encodedValue, err := jsoncdc.Encode(scriptProc.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode runtime value: %w", err)
+	}
+
+	return encodedValue, nil
+}
+
+// registerView returns a view reading the register values at the given height from the index.
+func registerView(registers storage.RegisterIndex, height uint64) *delta.View {
+	return delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+		return registers.Get(flow.NewRegisterID(owner, controller, key), height)
+	})
+}
@@ -0,0 +1,77 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func newTestScripts(t *testing.T, first, latest uint64) (*Scripts, *storagemock.Headers, *storagemock.RegisterIndex) {
+	headers := new(storagemock.Headers)
+	registers := new(storagemock.RegisterIndex)
+	registers.On("FirstHeight").Return(first)
+	registers.On("LatestHeight").Return(latest)
+
+	vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+	vmCtx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(flow.Testnet.Chain()))
+
+	return NewScripts(zerolog.Nop(), vm, vmCtx, headers, registers, DefaultScriptExecutionTimeLimit), headers, registers
+}
+
+func TestExecuteAtBlockHeight(t *testing.T) {
+	t.Run("executes script against indexed registers", func(t *testing.T) {
+		scripts, headers, registers := newTestScripts(t, 10, 20)
+
+		header := unittest.BlockHeaderFixture()
+		header.Height = 15
+		headers.On("ByHeight", uint64(15)).Return(&header, nil)
+		registers.On("Get", mock.Anything, uint64(15)).Return(nil, nil)
+
+		script := []byte("pub fun main(): Int { return 42 }")
+		result, err := scripts.ExecuteAtBlockHeight(context.Background(), script, nil, 15)
+		require.NoError(t, err)
+
+		value, err := jsoncdc.Decode(nil, result)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(42), value)
+	})
+
+	t.Run("returns ErrScriptFailed for failing scripts", func(t *testing.T) {
+		scripts, headers, registers := newTestScripts(t, 10, 20)
+
+		header := unittest.BlockHeaderFixture()
+		header.Height = 15
+		headers.On("ByHeight", uint64(15)).Return(&header, nil)
+		registers.On("Get", mock.Anything, uint64(15)).Return(nil, nil)
+
+		script := []byte("pub fun main(): Int { panic(\"failed\") }")
+		_, err := scripts.ExecuteAtBlockHeight(context.Background(), script, nil, 15)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrScriptFailed))
+	})
+
+	t.Run("returns ErrHeightNotIndexed outside of indexed range", func(t *testing.T) {
+		scripts, _, _ := newTestScripts(t, 10, 20)
+
+		script := []byte("pub fun main(): Int { return 42 }")
+
+		_, err := scripts.ExecuteAtBlockHeight(context.Background(), script, nil, 9)
+		assert.True(t, errors.Is(err, storage.ErrHeightNotIndexed))
+
+		_, err = scripts.ExecuteAtBlockHeight(context.Background(), script, nil, 21)
+		assert.True(t, errors.Is(err, storage.ErrHeightNotIndexed))
+	})
+}
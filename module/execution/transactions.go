@@ -0,0 +1,150 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/blueprints"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// Transactions simulates transactions against the register values stored in a register index,
+// without committing their changes.
+type Transactions struct {
+	log       zerolog.Logger
+	vm        *fvm.VirtualMachine
+	vmCtx     fvm.Context
+	headers   storage.Headers
+	registers storage.RegisterIndex
+}
+
+// NewTransactions creates a new transaction simulator. The given context is used as the parent
+// context of all simulations, and must be configured for the chain of the node.
+func NewTransactions(
+	log zerolog.Logger,
+	vm *fvm.VirtualMachine,
+	vmCtx fvm.Context,
+	headers storage.Headers,
+	registers storage.RegisterIndex,
+) *Transactions {
+	return &Transactions{
+		log:       log.With().Str("component", "transaction_simulator").Logger(),
+		vm:        vm,
+		vmCtx:     vmCtx,
+		headers:   headers,
+		registers: registers,
+	}
+}
+
+// Simulate executes the transaction against the execution state at the latest indexed height,
+// and returns its result and the fees it would be charged. If skipSignatureVerification is set,
+// the signatures of the transaction are not verified, so that it can be simulated before it is
+// signed.
+//
+// Failures of the transaction are reported in the result, and are not returned as errors.
+func (t *Transactions) Simulate(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	skipSignatureVerification bool,
+) (*access.SimulatedTransactionResult, error) {
+	height := t.registers.LatestHeight()
+
+	header, err := t.headers.ByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get header at height %d: %w", height, err)
+	}
+
+	options := []fvm.Option{fvm.WithBlockHeader(header)}
+	if skipSignatureVerification {
+		options = append(options, fvm.WithTransactionProcessors(
+			fvm.NewTransactionAccountFrozenChecker(),
+			fvm.NewTransactionSequenceNumberChecker(),
+			fvm.NewTransactionAccountFrozenEnabler(),
+			fvm.NewTransactionInvoker(t.log),
+		))
+	}
+	blockCtx := fvm.NewContextFromParent(t.vmCtx, options...)
+
+	txProc := fvm.Transaction(tx, 0)
+	err = func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.log.Error().
+					Hex("tx_id", logging.Entity(tx)).
+					Interface("recovered", r).
+					Msg("transaction simulation caused runtime panic")
+
+				err = fmt.Errorf("cadence runtime error: %s", r)
+			}
+		}()
+
+		return t.vm.Run(blockCtx, txProc, registerView(t.registers, height), programs.NewEmptyPrograms())
+	}()
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction (internal error): %w", err)
+	}
+
+	result := &access.SimulatedTransactionResult{
+		BlockID:                header.ID(),
+		BlockHeight:            height,
+		Events:                 txProc.Events,
+		ComputationUsed:        txProc.ComputationUsed,
+		MemoryUsed:             txProc.MemoryUsed,
+		ComputationIntensities: txProc.ComputationIntensities,
+		MemoryIntensities:      txProc.MemoryIntensities,
+	}
+	if txProc.Err != nil {
+		result.ErrorCode = txProc.Err.Code()
+		result.ErrorMessage = txProc.Err.Error()
+	}
+
+	if blockCtx.TransactionFeesEnabled {
+		// the execution effort is capped at the computation limit, the same way as when fees are
+		// deducted
+		executionEffort := txProc.ComputationUsed
+		if limit := txProc.ComputationLimit(blockCtx); executionEffort > limit {
+			executionEffort = limit
+		}
+
+		result.Fees, err = t.computeFees(ctx, blockCtx, height, executionEffort)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute transaction fees: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// computeFees computes the fees of a transaction with the given execution effort, with the fee
+// parameters at the given height.
+func (t *Transactions) computeFees(ctx context.Context, blockCtx fvm.Context, height uint64, executionEffort uint64) (uint64, error) {
+	script, arguments := blueprints.ComputeFeesScript(
+		fvm.FlowFeesAddress(blockCtx.Chain),
+		cadence.UFix64(fvm.InclusionEffort),
+		cadence.UFix64(executionEffort),
+	)
+
+	scriptProc := fvm.NewScriptWithContextAndArgs(script, ctx, arguments...)
+	err := t.vm.Run(blockCtx, scriptProc, registerView(t.registers, height), programs.NewEmptyPrograms())
+	if err != nil {
+		return 0, err
+	}
+	if scriptProc.Err != nil {
+		return 0, scriptProc.Err
+	}
+
+	fees, ok := scriptProc.Value.(cadence.UFix64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected fees value type %T", scriptProc.Value)
+	}
+
+	return uint64(fees), nil
+}
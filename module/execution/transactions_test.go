@@ -0,0 +1,92 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/fvm/utils"
+	"github.com/onflow/flow-go/model/flow"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSimulate(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+	vmCtx := fvm.NewContext(zerolog.Nop(),
+		fvm.WithChain(chain),
+		fvm.WithTransactionFeesEnabled(true),
+	)
+
+	// bootstrap the execution state, which is then served by the register index
+	view := utils.NewSimpleView()
+	err := vm.Run(vmCtx, fvm.Bootstrap(
+		unittest.ServiceAccountPublicKey,
+		fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply),
+		fvm.WithTransactionFee(fvm.DefaultTransactionFees),
+	), view, programs.NewEmptyPrograms())
+	require.NoError(t, err)
+
+	const height = uint64(10)
+	header := unittest.BlockHeaderFixture()
+	header.Height = height
+
+	headers := new(storagemock.Headers)
+	headers.On("ByHeight", height).Return(&header, nil)
+
+	registers := new(storagemock.RegisterIndex)
+	registers.On("LatestHeight").Return(height)
+	registers.On("Get", mock.Anything, height).Return(
+		func(id flow.RegisterID, _ uint64) flow.RegisterValue {
+			value, err := view.Get(id.Owner, id.Controller, id.Key)
+			require.NoError(t, err)
+			return value
+		},
+		nil,
+	)
+
+	simulator := NewTransactions(zerolog.Nop(), vm, vmCtx, headers, registers)
+
+	tx := flow.NewTransactionBody().
+		SetScript([]byte(`
+			transaction {
+				prepare(signer: AuthAccount) {
+					var a = 0
+					while a < 10 {
+						a = a + 1
+					}
+				}
+			}
+		`)).
+		SetProposalKey(chain.ServiceAddress(), 0, 0).
+		AddAuthorizer(chain.ServiceAddress()).
+		SetPayer(chain.ServiceAddress())
+
+	t.Run("unsigned transaction with signature verification skipped", func(t *testing.T) {
+		result, err := simulator.Simulate(context.Background(), tx, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, header.ID(), result.BlockID)
+		assert.Equal(t, height, result.BlockHeight)
+		assert.Empty(t, result.ErrorMessage)
+		assert.Greater(t, result.ComputationUsed, uint64(0))
+		assert.Equal(t, uint(10), result.ComputationIntensities[common.ComputationKindLoop])
+		assert.Greater(t, result.Fees, uint64(0))
+	})
+
+	t.Run("unsigned transaction with signature verification", func(t *testing.T) {
+		result, err := simulator.Simulate(context.Background(), tx, false)
+		require.NoError(t, err)
+
+		assert.NotZero(t, result.ErrorCode)
+		assert.NotEmpty(t, result.ErrorMessage)
+	})
+}
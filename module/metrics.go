@@ -314,6 +314,15 @@ type LedgerMetrics interface {
 
 	// ReadDurationPerItem records read time for single value (total duration / number of read values)
 	ReadDurationPerItem(duration time.Duration)
+
+	// NodeStoreCacheHit increases a counter of trie nodes loaded from the node store cache
+	NodeStoreCacheHit()
+
+	// NodeStoreCacheMiss increases a counter of trie nodes loaded from the node store on disk
+	NodeStoreCacheMiss()
+
+	// NodeStoreCacheSize records the approximate memory size of the trie nodes cached by the node store (in bytes)
+	NodeStoreCacheSize(bytes uint64)
 }
 
 type WALMetrics interface {
@@ -383,6 +392,10 @@ type ExecutionMetrics interface {
 	// ExecutionTransactionExecuted reports the total time and computation spent on executing a single transaction
 	ExecutionTransactionExecuted(dur time.Duration, compUsed uint64, eventCounts int, failed bool)
 
+	// ExecutionTransactionRegisterInteractions reports the number and size of the registers read and written
+	// by a single transaction, and the number of accounts whose registers it accessed
+	ExecutionTransactionRegisterInteractions(registersRead, bytesRead, registersWritten, bytesWritten uint64, accounts int)
+
 	// ExecutionScriptExecuted reports the time spent on executing an script
 	ExecutionScriptExecuted(dur time.Duration, compUsed uint64)
 
@@ -400,6 +413,38 @@ type ExecutionMetrics interface {
 	ExecutionBlockDataUploadFinished(dur time.Duration)
 }
 
+type ExecutionPrunerMetrics interface {
+	// ExecutionPrunerTargetHeight reports the height up to which the execution storage is going to be pruned
+	ExecutionPrunerTargetHeight(height uint64)
+
+	// ExecutionPrunerBatchPruned reports a batch of heights whose execution data was pruned, the highest pruned
+	// height after the batch, and the time spent on pruning the batch
+	ExecutionPrunerBatchPruned(prunedHeight uint64, heights int, dur time.Duration)
+}
+
+type ExecutionConflictMetrics interface {
+	// ExecutionResultConflicts reports the number of blocks for which results of other execution nodes conflict
+	// with the result computed by the node, and how many of these blocks were sealed with a conflicting result
+	ExecutionResultConflicts(blocks int, sealedBlocks int)
+
+	// ExecutionHalted reports whether block execution is halted because of conflicting results
+	ExecutionHalted(halted bool)
+}
+
+type ExecutionProgramsCacheMetrics interface {
+	// ExecutionProgramsCacheHit reports the first use of a contract program since the node started, by the
+	// execution of a block, for a program which was loaded by the warm-up of the persistent programs cache
+	ExecutionProgramsCacheHit()
+
+	// ExecutionProgramsCacheMiss reports the first use of a contract program since the node started, by the
+	// execution of a block, for a program which was not loaded by the warm-up of the persistent programs cache
+	ExecutionProgramsCacheMiss()
+
+	// ExecutionProgramsCacheWarmedUp reports the number of contract programs loaded from the persistent programs
+	// cache when the node started, and the duration of loading them
+	ExecutionProgramsCacheWarmedUp(programs int, dur time.Duration)
+}
+
 type TransactionMetrics interface {
 	// TransactionReceived starts tracking of transaction execution/finalization/sealing
 	TransactionReceived(txID flow.Identifier, when time.Time)
@@ -419,6 +464,19 @@ type TransactionMetrics interface {
 	TransactionSubmissionFailed()
 }
 
+type AccessQuotaMetrics interface {
+	// QuotaRequestAllowed tracks a request of the given client to the given API method which was within the quota
+	// of the client. Clients are identified by the name of their API key, or as anonymous.
+	QuotaRequestAllowed(client string, method string)
+
+	// QuotaRequestRejected tracks a request of the given client to the given API method which was rejected, because
+	// the quota of the client was exhausted.
+	QuotaRequestRejected(client string, method string)
+
+	// QuotaKeysLoaded reports the number of API keys loaded from the key store.
+	QuotaKeysLoaded(count int)
+}
+
 type PingMetrics interface {
 	// NodeReachable tracks the round trip time in milliseconds taken to ping a node
 	// The nodeInfo provides additional information about the node such as the name of the node operator
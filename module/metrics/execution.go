@@ -38,6 +38,9 @@ type ExecutionCollector struct {
 	readValuesSize                   prometheus.Gauge
 	readDuration                     prometheus.Histogram
 	readDurationPerValue             prometheus.Histogram
+	nodeStoreCacheHits               prometheus.Counter
+	nodeStoreCacheMisses             prometheus.Counter
+	nodeStoreCacheSize               prometheus.Gauge
 	blockComputationUsed             prometheus.Histogram
 	blockExecutionTime               prometheus.Histogram
 	blockTransactionCounts           prometheus.Histogram
@@ -53,6 +56,11 @@ type ExecutionCollector struct {
 	transactionExecutionTime         prometheus.Histogram
 	transactionComputationUsed       prometheus.Histogram
 	transactionEmittedEvents         prometheus.Histogram
+	transactionRegistersRead         prometheus.Histogram
+	transactionRegisterBytesRead     prometheus.Histogram
+	transactionRegistersWritten      prometheus.Histogram
+	transactionRegisterBytesWritten  prometheus.Histogram
+	transactionAccountsAccessed      prometheus.Histogram
 	scriptExecutionTime              prometheus.Histogram
 	scriptComputationUsed            prometheus.Histogram
 	numberOfAccounts                 prometheus.Gauge
@@ -188,6 +196,27 @@ func NewExecutionCollector(tracer module.Tracer) *ExecutionCollector {
 		Buckets:   []float64{0.05, 0.2, 0.5, 1, 2, 5},
 	})
 
+	nodeStoreCacheHits := promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemMTrie,
+		Name:      "node_store_cache_hits_total",
+		Help:      "the number of trie nodes loaded from the node store cache",
+	})
+
+	nodeStoreCacheMisses := promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemMTrie,
+		Name:      "node_store_cache_misses_total",
+		Help:      "the number of trie nodes loaded from the node store on disk",
+	})
+
+	nodeStoreCacheSize := promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemMTrie,
+		Name:      "node_store_cache_size_bytes",
+		Help:      "an approximate size of the trie nodes cached in memory by the node store in bytes",
+	})
+
 	blockExecutionTime := promauto.NewHistogram(prometheus.HistogramOpts{
 		Namespace: namespaceExecution,
 		Subsystem: subsystemRuntime,
@@ -357,6 +386,9 @@ func NewExecutionCollector(tracer module.Tracer) *ExecutionCollector {
 		readValuesSize:              readValuesSize,
 		readDuration:                readDuration,
 		readDurationPerValue:        readDurationPerValue,
+		nodeStoreCacheHits:          nodeStoreCacheHits,
+		nodeStoreCacheMisses:        nodeStoreCacheMisses,
+		nodeStoreCacheSize:          nodeStoreCacheSize,
 		blockExecutionTime:          blockExecutionTime,
 		blockComputationUsed:        blockComputationUsed,
 		blockTransactionCounts:      blockTransactionCounts,
@@ -378,6 +410,46 @@ func NewExecutionCollector(tracer module.Tracer) *ExecutionCollector {
 		blockDataUploadsInProgress:  blockDataUploadsInProgress,
 		blockDataUploadsDuration:    blockDataUploadsDuration,
 
+		transactionRegistersRead: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+			Name:      "transaction_registers_read",
+			Help:      "the number of registers read by a transaction",
+		}),
+
+		transactionRegisterBytesRead: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 10),
+			Name:      "transaction_register_bytes_read",
+			Help:      "the size of the registers read by a transaction in bytes",
+		}),
+
+		transactionRegistersWritten: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+			Name:      "transaction_registers_written",
+			Help:      "the number of registers written by a transaction",
+		}),
+
+		transactionRegisterBytesWritten: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 10),
+			Name:      "transaction_register_bytes_written",
+			Help:      "the size of the registers written by a transaction in bytes",
+		}),
+
+		transactionAccountsAccessed: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Buckets:   []float64{1, 2, 4, 8, 16, 32, 64},
+			Name:      "transaction_accounts_accessed",
+			Help:      "the number of accounts whose registers were read or written by a transaction",
+		}),
+
 		stateReadsPerBlock: promauto.NewHistogram(prometheus.HistogramOpts{
 			Namespace: namespaceExecution,
 			Subsystem: subsystemRuntime,
@@ -505,6 +577,16 @@ func (ec *ExecutionCollector) ExecutionTransactionExecuted(dur time.Duration, co
 	}
 }
 
+// ExecutionTransactionRegisterInteractions reports the number and size of the registers read and written
+// by a single transaction, and the number of accounts whose registers it accessed
+func (ec *ExecutionCollector) ExecutionTransactionRegisterInteractions(registersRead, bytesRead, registersWritten, bytesWritten uint64, accounts int) {
+	ec.transactionRegistersRead.Observe(float64(registersRead))
+	ec.transactionRegisterBytesRead.Observe(float64(bytesRead))
+	ec.transactionRegistersWritten.Observe(float64(registersWritten))
+	ec.transactionRegisterBytesWritten.Observe(float64(bytesWritten))
+	ec.transactionAccountsAccessed.Observe(float64(accounts))
+}
+
 // ScriptExecuted reports the time spent executing a single script
 func (ec *ExecutionCollector) ExecutionScriptExecuted(dur time.Duration, compUsed uint64) {
 	ec.totalExecutedScriptsCounter.Inc()
@@ -617,6 +699,21 @@ func (ec *ExecutionCollector) ReadDurationPerItem(duration time.Duration) {
 	ec.readDurationPerValue.Observe(duration.Seconds())
 }
 
+// NodeStoreCacheHit increases a counter of trie nodes loaded from the node store cache
+func (ec *ExecutionCollector) NodeStoreCacheHit() {
+	ec.nodeStoreCacheHits.Inc()
+}
+
+// NodeStoreCacheMiss increases a counter of trie nodes loaded from the node store on disk
+func (ec *ExecutionCollector) NodeStoreCacheMiss() {
+	ec.nodeStoreCacheMisses.Inc()
+}
+
+// NodeStoreCacheSize records the approximate memory size of the trie nodes cached by the node store
+func (ec *ExecutionCollector) NodeStoreCacheSize(bytes uint64) {
+	ec.nodeStoreCacheSize.Set(float64(bytes))
+}
+
 func (ec *ExecutionCollector) ExecutionCollectionRequestSent() {
 	ec.collectionRequestSent.Inc()
 }
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type ExecutionConflictCollector struct {
+	conflictingBlocks       prometheus.Gauge
+	sealedConflictingBlocks prometheus.Gauge
+	halted                  prometheus.Gauge
+}
+
+func NewExecutionConflictCollector() *ExecutionConflictCollector {
+	return &ExecutionConflictCollector{
+		conflictingBlocks: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "conflicting_blocks",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemConflicts,
+			Help:      "the number of blocks for which results of other execution nodes conflict with the own result",
+		}),
+		sealedConflictingBlocks: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "sealed_conflicting_blocks",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemConflicts,
+			Help:      "the number of blocks which were sealed with a result conflicting with the own result",
+		}),
+		halted: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "execution_halted",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemConflicts,
+			Help:      "whether block execution is halted because of conflicting results (1) or not (0)",
+		}),
+	}
+}
+
+func (cc *ExecutionConflictCollector) ExecutionResultConflicts(blocks int, sealedBlocks int) {
+	cc.conflictingBlocks.Set(float64(blocks))
+	cc.sealedConflictingBlocks.Set(float64(sealedBlocks))
+}
+
+func (cc *ExecutionConflictCollector) ExecutionHalted(halted bool) {
+	if halted {
+		cc.halted.Set(1)
+	} else {
+		cc.halted.Set(0)
+	}
+}
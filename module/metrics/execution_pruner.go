@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type ExecutionPrunerCollector struct {
+	targetHeight  prometheus.Gauge
+	prunedHeight  prometheus.Gauge
+	heightsPruned prometheus.Counter
+	batchDuration prometheus.Histogram
+}
+
+func NewExecutionPrunerCollector() *ExecutionPrunerCollector {
+	return &ExecutionPrunerCollector{
+		targetHeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "target_height",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemPruner,
+			Help:      "the height up to which the execution storage is going to be pruned",
+		}),
+		prunedHeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "pruned_height",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemPruner,
+			Help:      "the highest height whose execution data was pruned",
+		}),
+		heightsPruned: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "heights_pruned_total",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemPruner,
+			Help:      "the number of heights whose execution data was pruned",
+		}),
+		batchDuration: promauto.NewHistogram(prometheus.HistogramOpts{
+			Name:      "batch_duration_seconds",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemPruner,
+			Help:      "the time spent on pruning a batch of heights",
+			Buckets:   []float64{.1, .5, 1, 2.5, 5, 10, 30, 60},
+		}),
+	}
+}
+
+func (pc *ExecutionPrunerCollector) ExecutionPrunerTargetHeight(height uint64) {
+	pc.targetHeight.Set(float64(height))
+}
+
+func (pc *ExecutionPrunerCollector) ExecutionPrunerBatchPruned(prunedHeight uint64, heights int, dur time.Duration) {
+	pc.prunedHeight.Set(float64(prunedHeight))
+	pc.heightsPruned.Add(float64(heights))
+	pc.batchDuration.Observe(dur.Seconds())
+}
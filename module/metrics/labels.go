@@ -13,6 +13,9 @@ const (
 	LabelNodeInfo    = "nodeinfo"
 	LabelNodeVersion = "nodeversion"
 	LabelPriority    = "priority"
+	LabelClient      = "client"
+	LabelMethod      = "method"
+	LabelResult      = "result"
 )
 
 const (
@@ -32,6 +32,7 @@ const (
 const (
 	subsystemTransactionTiming     = "transaction_timing"
 	subsystemTransactionSubmission = "transaction_submission"
+	subsystemQuota                 = "quota"
 )
 
 // Collection subsystem
@@ -54,6 +55,9 @@ const (
 	subsystemRuntime           = "runtime"
 	subsystemProvider          = "provider"
 	subsystemBlockDataUploader = "block_data_uploader"
+	subsystemConflicts         = "conflicts"
+	subsystemProgramsCache     = "programs_cache"
+	subsystemPruner            = "pruner"
 )
 
 // Verification Subsystems
@@ -136,6 +136,9 @@ func (nc *NoopCollector) ReadValuesNumber(number uint64)
 func (nc *NoopCollector) ReadValuesSize(byte uint64)                                            {}
 func (nc *NoopCollector) ReadDuration(duration time.Duration)                                   {}
 func (nc *NoopCollector) ReadDurationPerItem(duration time.Duration)                            {}
+func (nc *NoopCollector) NodeStoreCacheHit()                                                    {}
+func (nc *NoopCollector) NodeStoreCacheMiss()                                                   {}
+func (nc *NoopCollector) NodeStoreCacheSize(bytes uint64)                                       {}
 func (nc *NoopCollector) ExecutionCollectionRequestSent()                                       {}
 func (nc *NoopCollector) ExecutionCollectionRequestRetried()                                    {}
 func (nc *NoopCollector) RuntimeTransactionParsed(dur time.Duration)                            {}
@@ -147,19 +150,32 @@ func (nc *NoopCollector) TransactionFinalized(txID flow.Identifier, when time.Ti
 func (nc *NoopCollector) TransactionExecuted(txID flow.Identifier, when time.Time)              {}
 func (nc *NoopCollector) TransactionExpired(txID flow.Identifier)                               {}
 func (nc *NoopCollector) TransactionSubmissionFailed()                                          {}
+func (nc *NoopCollector) QuotaRequestAllowed(client string, method string)                      {}
+func (nc *NoopCollector) QuotaRequestRejected(client string, method string)                     {}
+func (nc *NoopCollector) QuotaKeysLoaded(count int)                                             {}
 func (nc *NoopCollector) ChunkDataPackRequested()                                               {}
 func (nc *NoopCollector) ExecutionSync(syncing bool)                                            {}
 func (nc *NoopCollector) DiskSize(uint64)                                                       {}
 func (nc *NoopCollector) ExecutionBlockDataUploadStarted()                                      {}
 func (nc *NoopCollector) ExecutionBlockDataUploadFinished(dur time.Duration)                    {}
-func (nc *NoopCollector) ExecutionDataAddStarted()                                              {}
-func (nc *NoopCollector) ExecutionDataAddFinished(time.Duration, bool, uint64)                  {}
-func (nc *NoopCollector) ExecutionDataGetStarted()                                              {}
-func (nc *NoopCollector) ExecutionDataGetFinished(time.Duration, bool, uint64)                  {}
-func (nc *NoopCollector) BucketAvailableSlots(uint64, uint64)                                   {}
-func (nc *NoopCollector) OnKeyPutSuccess()                                                      {}
-func (nc *NoopCollector) OnEntityEjectionDueToFullCapacity()                                    {}
-func (nc *NoopCollector) OnEntityEjectionDueToEmergency()                                       {}
-func (nc *NoopCollector) OnKeyPutFailure()                                                      {}
-func (nc *NoopCollector) OnKeyGetSuccess()                                                      {}
-func (nc *NoopCollector) OnKeyGetFailure()                                                      {}
+func (nc *NoopCollector) ExecutionPrunerTargetHeight(height uint64)                             {}
+func (nc *NoopCollector) ExecutionResultConflicts(blocks int, sealedBlocks int)                 {}
+func (nc *NoopCollector) ExecutionHalted(halted bool)                                           {}
+func (nc *NoopCollector) ExecutionProgramsCacheHit()                                            {}
+func (nc *NoopCollector) ExecutionProgramsCacheMiss()                                           {}
+func (nc *NoopCollector) ExecutionProgramsCacheWarmedUp(programs int, dur time.Duration)        {}
+func (nc *NoopCollector) ExecutionTransactionRegisterInteractions(registersRead, bytesRead, registersWritten, bytesWritten uint64, accounts int) {
+}
+func (nc *NoopCollector) ExecutionPrunerBatchPruned(prunedHeight uint64, heights int, dur time.Duration) {
+}
+func (nc *NoopCollector) ExecutionDataAddStarted()                             {}
+func (nc *NoopCollector) ExecutionDataAddFinished(time.Duration, bool, uint64) {}
+func (nc *NoopCollector) ExecutionDataGetStarted()                             {}
+func (nc *NoopCollector) ExecutionDataGetFinished(time.Duration, bool, uint64) {}
+func (nc *NoopCollector) BucketAvailableSlots(uint64, uint64)                  {}
+func (nc *NoopCollector) OnKeyPutSuccess()                                     {}
+func (nc *NoopCollector) OnEntityEjectionDueToFullCapacity()                   {}
+func (nc *NoopCollector) OnEntityEjectionDueToEmergency()                      {}
+func (nc *NoopCollector) OnKeyPutFailure()                                     {}
+func (nc *NoopCollector) OnKeyGetSuccess()                                     {}
+func (nc *NoopCollector) OnKeyGetFailure()                                     {}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type ExecutionProgramsCacheCollector struct {
+	hits           prometheus.Counter
+	misses         prometheus.Counter
+	warmedUp       prometheus.Gauge
+	warmUpDuration prometheus.Gauge
+}
+
+func NewExecutionProgramsCacheCollector() *ExecutionProgramsCacheCollector {
+	return &ExecutionProgramsCacheCollector{
+		hits: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "hits_total",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemProgramsCache,
+			Help:      "the number of contract programs used after the node started which were loaded by the warm-up of the persistent programs cache",
+		}),
+		misses: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "misses_total",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemProgramsCache,
+			Help:      "the number of contract programs used after the node started which were not loaded by the warm-up of the persistent programs cache",
+		}),
+		warmedUp: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "warmed_up_programs",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemProgramsCache,
+			Help:      "the number of contract programs loaded from the persistent programs cache when the node started",
+		}),
+		warmUpDuration: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "warm_up_duration_seconds",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemProgramsCache,
+			Help:      "the duration of loading the contract programs of the persistent programs cache when the node started",
+		}),
+	}
+}
+
+func (pc *ExecutionProgramsCacheCollector) ExecutionProgramsCacheHit() {
+	pc.hits.Inc()
+}
+
+func (pc *ExecutionProgramsCacheCollector) ExecutionProgramsCacheMiss() {
+	pc.misses.Inc()
+}
+
+func (pc *ExecutionProgramsCacheCollector) ExecutionProgramsCacheWarmedUp(programs int, dur time.Duration) {
+	pc.warmedUp.Set(float64(programs))
+	pc.warmUpDuration.Set(dur.Seconds())
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	quotaResultAllowed  = "allowed"
+	quotaResultRejected = "rejected"
+)
+
+type AccessQuotaCollector struct {
+	requests   *prometheus.CounterVec
+	keysLoaded prometheus.Gauge
+}
+
+func NewAccessQuotaCollector() *AccessQuotaCollector {
+	return &AccessQuotaCollector{
+		requests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "requests_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemQuota,
+			Help:      "the number of API requests checked against the quota of their client, by result",
+		}, []string{LabelClient, LabelMethod, LabelResult}),
+		keysLoaded: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "keys_loaded",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemQuota,
+			Help:      "the number of API keys loaded from the key store",
+		}),
+	}
+}
+
+func (qc *AccessQuotaCollector) QuotaRequestAllowed(client string, method string) {
+	qc.requests.With(prometheus.Labels{
+		LabelClient: client,
+		LabelMethod: method,
+		LabelResult: quotaResultAllowed,
+	}).Inc()
+}
+
+func (qc *AccessQuotaCollector) QuotaRequestRejected(client string, method string) {
+	qc.requests.With(prometheus.Labels{
+		LabelClient: client,
+		LabelMethod: method,
+		LabelResult: quotaResultRejected,
+	}).Inc()
+}
+
+func (qc *AccessQuotaCollector) QuotaKeysLoaded(count int) {
+	qc.keysLoaded.Set(float64(count))
+}
@@ -0,0 +1,34 @@
+// Code generated by mockery v2.12.1. DO NOT EDIT.
+
+package mock
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// ExecutionConflictMetrics is an autogenerated mock type for the ExecutionConflictMetrics type
+type ExecutionConflictMetrics struct {
+	mock.Mock
+}
+
+// ExecutionHalted provides a mock function with given fields: halted
+func (_m *ExecutionConflictMetrics) ExecutionHalted(halted bool) {
+	_m.Called(halted)
+}
+
+// ExecutionResultConflicts provides a mock function with given fields: blocks, sealedBlocks
+func (_m *ExecutionConflictMetrics) ExecutionResultConflicts(blocks int, sealedBlocks int) {
+	_m.Called(blocks, sealedBlocks)
+}
+
+// NewExecutionConflictMetrics creates a new instance of ExecutionConflictMetrics. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
+func NewExecutionConflictMetrics(t testing.TB) *ExecutionConflictMetrics {
+	mock := &ExecutionConflictMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
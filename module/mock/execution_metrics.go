@@ -86,6 +86,11 @@ func (_m *ExecutionMetrics) ExecutionTransactionExecuted(dur time.Duration, comp
 	_m.Called(dur, compUsed, eventCounts, failed)
 }
 
+// ExecutionTransactionRegisterInteractions provides a mock function with given fields: registersRead, bytesRead, registersWritten, bytesWritten, accounts
+func (_m *ExecutionMetrics) ExecutionTransactionRegisterInteractions(registersRead uint64, bytesRead uint64, registersWritten uint64, bytesWritten uint64, accounts int) {
+	_m.Called(registersRead, bytesRead, registersWritten, bytesWritten, accounts)
+}
+
 // FinishBlockReceivedToExecuted provides a mock function with given fields: blockID
 func (_m *ExecutionMetrics) FinishBlockReceivedToExecuted(blockID flow.Identifier) {
 	_m.Called(blockID)
@@ -126,6 +131,21 @@ func (_m *ExecutionMetrics) LatestTrieRegSizeDiff(size int64) {
 	_m.Called(size)
 }
 
+// NodeStoreCacheHit provides a mock function with given fields:
+func (_m *ExecutionMetrics) NodeStoreCacheHit() {
+	_m.Called()
+}
+
+// NodeStoreCacheMiss provides a mock function with given fields:
+func (_m *ExecutionMetrics) NodeStoreCacheMiss() {
+	_m.Called()
+}
+
+// NodeStoreCacheSize provides a mock function with given fields: bytes
+func (_m *ExecutionMetrics) NodeStoreCacheSize(bytes uint64) {
+	_m.Called(bytes)
+}
+
 // ProofSize provides a mock function with given fields: bytes
 func (_m *ExecutionMetrics) ProofSize(bytes uint32) {
 	_m.Called(bytes)
@@ -0,0 +1,41 @@
+// Code generated by mockery v2.12.1. DO NOT EDIT.
+
+package mock
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+
+	time "time"
+)
+
+// ExecutionProgramsCacheMetrics is an autogenerated mock type for the ExecutionProgramsCacheMetrics type
+type ExecutionProgramsCacheMetrics struct {
+	mock.Mock
+}
+
+// ExecutionProgramsCacheHit provides a mock function with given fields:
+func (_m *ExecutionProgramsCacheMetrics) ExecutionProgramsCacheHit() {
+	_m.Called()
+}
+
+// ExecutionProgramsCacheMiss provides a mock function with given fields:
+func (_m *ExecutionProgramsCacheMetrics) ExecutionProgramsCacheMiss() {
+	_m.Called()
+}
+
+// ExecutionProgramsCacheWarmedUp provides a mock function with given fields: programs, dur
+func (_m *ExecutionProgramsCacheMetrics) ExecutionProgramsCacheWarmedUp(programs int, dur time.Duration) {
+	_m.Called(programs, dur)
+}
+
+// NewExecutionProgramsCacheMetrics creates a new instance of ExecutionProgramsCacheMetrics. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
+func NewExecutionProgramsCacheMetrics(t testing.TB) *ExecutionProgramsCacheMetrics {
+	mock := &ExecutionProgramsCacheMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
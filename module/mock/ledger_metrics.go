@@ -50,6 +50,21 @@ func (_m *LedgerMetrics) LatestTrieRegSizeDiff(size int64) {
 	_m.Called(size)
 }
 
+// NodeStoreCacheHit provides a mock function with given fields:
+func (_m *LedgerMetrics) NodeStoreCacheHit() {
+	_m.Called()
+}
+
+// NodeStoreCacheMiss provides a mock function with given fields:
+func (_m *LedgerMetrics) NodeStoreCacheMiss() {
+	_m.Called()
+}
+
+// NodeStoreCacheSize provides a mock function with given fields: bytes
+func (_m *LedgerMetrics) NodeStoreCacheSize(bytes uint64) {
+	_m.Called(bytes)
+}
+
 // ProofSize provides a mock function with given fields: bytes
 func (_m *LedgerMetrics) ProofSize(bytes uint32) {
 	_m.Called(bytes)
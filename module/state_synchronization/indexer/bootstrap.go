@@ -0,0 +1,49 @@
+package indexer
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+// BootstrapRegisterIndex initializes the register index stored in the given database with the
+// complete execution state contained in the root checkpoint, at the root block height.
+func BootstrapRegisterIndex(
+	log zerolog.Logger,
+	db *badger.DB,
+	checkpointFile string,
+	rootHeight uint64,
+) (*bstorage.RegisterIndex, error) {
+	log.Info().Str("checkpoint", checkpointFile).Msg("loading root checkpoint")
+
+	tries, err := wal.LoadCheckpoint(checkpointFile, &log)
+	if err != nil {
+		return nil, fmt.Errorf("could not load checkpoint: %w", err)
+	}
+	if len(tries) != 1 {
+		return nil, fmt.Errorf("root checkpoint must contain exactly one trie, got %d", len(tries))
+	}
+
+	payloads, err := tries[0].AllPayloads()
+	if err != nil {
+		return nil, fmt.Errorf("could not get payloads of root checkpoint: %w", err)
+	}
+	entries := make(flow.RegisterEntries, 0, len(payloads))
+	for _, payload := range payloads {
+		registerID, err := state.KeyToRegisterID(payload.Key)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert payload key: %w", err)
+		}
+		entries = append(entries, flow.RegisterEntry{Key: registerID, Value: flow.RegisterValue(payload.Value)})
+	}
+
+	log.Info().Int("registers", len(entries)).Uint64("height", rootHeight).Msg("bootstrapping register index")
+
+	return bstorage.BootstrapRegisterIndex(db, rootHeight, entries)
+}
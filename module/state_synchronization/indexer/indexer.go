@@ -0,0 +1,231 @@
+// Package indexer indexes the execution data of sealed blocks on nodes which do not execute
+// blocks themselves, so that the execution state can be queried locally.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultFetchTimeout is the default timeout for downloading the execution data of a block.
+const DefaultFetchTimeout = 30 * time.Second
+
+// Indexer downloads the execution data of every sealed block, in height order, and indexes the
+// register updates it contains into the register index. If an event index is provided, the events
+// of the block are indexed as well.
+//
+// The result of a sealed block is only known once the access ingestion engine has processed the
+// block containing the seal, and the execution data may not be available yet on the network.
+// In both cases, indexing stops and is retried once the next block is finalized.
+type Indexer struct {
+	*component.ComponentManager
+	log          zerolog.Logger
+	state        protocol.State
+	headers      storage.Headers
+	results      storage.ExecutionResults
+	eds          state_synchronization.ExecutionDataService
+	registers    storage.RegisterIndex
+	events       storage.EventIndex
+	notifier     engine.Notifier
+	fetchTimeout time.Duration
+}
+
+// New creates a new indexer, which indexes sealed blocks following the latest height of the
+// given register index. The event index is optional.
+func New(
+	log zerolog.Logger,
+	state protocol.State,
+	headers storage.Headers,
+	results storage.ExecutionResults,
+	eds state_synchronization.ExecutionDataService,
+	registers storage.RegisterIndex,
+	events storage.EventIndex,
+	fetchTimeout time.Duration,
+) *Indexer {
+	i := &Indexer{
+		log:          log.With().Str("component", "execution_data_indexer").Logger(),
+		state:        state,
+		headers:      headers,
+		results:      results,
+		eds:          eds,
+		registers:    registers,
+		events:       events,
+		notifier:     engine.NewNotifier(),
+		fetchTimeout: fetchTimeout,
+	}
+
+	i.ComponentManager = component.NewComponentManagerBuilder().
+		AddWorker(i.processSealedBlocks).
+		Build()
+
+	return i
+}
+
+// OnFinalizedBlock notifies the indexer that a new block was finalized, which may have sealed
+// new blocks.
+func (i *Indexer) OnFinalizedBlock(*model.Block) {
+	i.notifier.Notify()
+}
+
+// processSealedBlocks indexes new sealed blocks every time a block is finalized.
+func (i *Indexer) processSealedBlocks(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	// catch up with the blocks sealed while the node was down
+	i.notifier.Notify()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-i.notifier.Channel():
+			err := i.indexSealedBlocks(ctx)
+			if err != nil {
+				ctx.Throw(err)
+			}
+		}
+	}
+}
+
+// indexSealedBlocks indexes all sealed blocks above the latest indexed height. No error is
+// returned if the execution data of a block can not be retrieved yet.
+func (i *Indexer) indexSealedBlocks(ctx context.Context) error {
+	sealed, err := i.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get latest sealed header: %w", err)
+	}
+
+	for height := i.registers.LatestHeight() + 1; height <= sealed.Height; height++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		err := i.indexHeight(ctx, height)
+		if errors.Is(err, errExecutionDataNotAvailable) {
+			i.log.Debug().Err(err).Uint64("height", height).Msg("execution data not available yet")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not index block at height %d: %w", height, err)
+		}
+	}
+
+	return nil
+}
+
+var errExecutionDataNotAvailable = errors.New("execution data not available")
+
+// indexHeight downloads and indexes the execution data of the sealed block at the given height.
+func (i *Indexer) indexHeight(ctx context.Context, height uint64) error {
+	header, err := i.headers.ByHeight(height)
+	if err != nil {
+		return fmt.Errorf("could not get header: %w", err)
+	}
+	blockID := header.ID()
+
+	result, err := i.results.ByBlockID(blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("sealed result of block %v not indexed: %w", blockID, errExecutionDataNotAvailable)
+	}
+	if err != nil {
+		return fmt.Errorf("could not get sealed result: %w", err)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, i.fetchTimeout)
+	defer cancel()
+
+	executionData, err := i.eds.Get(fetchCtx, result.ExecutionDataID)
+	if err != nil {
+		// the execution data may not have been propagated yet, or may be unavailable from the
+		// connected peers, in which case the download is retried later
+		return fmt.Errorf("could not download execution data %v: %v: %w", result.ExecutionDataID, err, errExecutionDataNotAvailable)
+	}
+
+	// events are indexed first, so that a height is never skipped by the event index if the
+	// node stops before the registers of the height are stored
+	err = i.indexEvents(height, blockID, executionData)
+	if err != nil {
+		return fmt.Errorf("could not index events: %w", err)
+	}
+
+	entries, err := RegisterEntries(executionData)
+	if err != nil {
+		return fmt.Errorf("could not get register entries from execution data: %w", err)
+	}
+
+	err = i.registers.Store(entries, height)
+	if err != nil {
+		return fmt.Errorf("could not store registers: %w", err)
+	}
+
+	i.log.Debug().
+		Uint64("height", height).
+		Hex("block_id", blockID[:]).
+		Int("registers", len(entries)).
+		Msg("indexed execution data")
+
+	return nil
+}
+
+// indexEvents indexes the events of the block at the given height, unless the block was already
+// indexed.
+func (i *Indexer) indexEvents(height uint64, blockID flow.Identifier, executionData *state_synchronization.ExecutionData) error {
+	if i.events == nil {
+		return nil
+	}
+
+	_, latestHeight, err := i.events.IndexedRange()
+	if err == nil && height <= latestHeight {
+		return nil
+	}
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not get indexed range: %w", err)
+	}
+
+	return i.events.Store(height, blockID, executionData.Events)
+}
+
+// RegisterEntries returns the final value of every register updated in the execution data.
+// Trie updates are applied in order, so later updates of a register override earlier ones.
+func RegisterEntries(executionData *state_synchronization.ExecutionData) (flow.RegisterEntries, error) {
+	positions := make(map[flow.RegisterID]int)
+	var entries flow.RegisterEntries
+
+	for _, update := range executionData.TrieUpdates {
+		if update == nil {
+			continue
+		}
+
+		for _, payload := range update.Payloads {
+			registerID, err := state.KeyToRegisterID(payload.Key)
+			if err != nil {
+				return nil, err
+			}
+
+			entry := flow.RegisterEntry{Key: registerID, Value: flow.RegisterValue(payload.Value)}
+			if pos, ok := positions[registerID]; ok {
+				entries[pos] = entry
+				continue
+			}
+
+			positions[registerID] = len(entries)
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
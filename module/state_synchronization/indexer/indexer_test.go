@@ -0,0 +1,122 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	synchronizationmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func trieUpdateFixture(entries ...flow.RegisterEntry) *ledger.TrieUpdate {
+	update := &ledger.TrieUpdate{}
+	for _, entry := range entries {
+		key := state.RegisterIDToKey(entry.Key)
+		update.Paths = append(update.Paths, ledger.Path{})
+		update.Payloads = append(update.Payloads, ledger.NewPayload(key, ledger.Value(entry.Value)))
+	}
+	return update
+}
+
+func TestRegisterEntries(t *testing.T) {
+	reg1 := flow.NewRegisterID("owner", "controller", "1")
+	reg2 := flow.NewRegisterID("owner", "controller", "2")
+	reg3 := flow.NewRegisterID("owner", "", "3")
+
+	executionData := &state_synchronization.ExecutionData{
+		TrieUpdates: []*ledger.TrieUpdate{
+			trieUpdateFixture(
+				flow.RegisterEntry{Key: reg1, Value: []byte("a")},
+				flow.RegisterEntry{Key: reg2, Value: []byte("b")},
+			),
+			nil,
+			trieUpdateFixture(
+				flow.RegisterEntry{Key: reg1, Value: []byte("c")},
+				flow.RegisterEntry{Key: reg3, Value: []byte("d")},
+			),
+		},
+	}
+
+	entries, err := RegisterEntries(executionData)
+	require.NoError(t, err)
+	assert.Equal(t, flow.RegisterEntries{
+		{Key: reg1, Value: []byte("c")},
+		{Key: reg2, Value: []byte("b")},
+		{Key: reg3, Value: []byte("d")},
+	}, entries)
+}
+
+func TestIndexSealedBlocks(t *testing.T) {
+	const latestIndexed = uint64(10)
+	const sealedHeight = uint64(13)
+
+	sealed := unittest.BlockHeaderFixture()
+	sealed.Height = sealedHeight
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Head").Return(&sealed, nil)
+	protoState := new(protocol.State)
+	protoState.On("Sealed").Return(snapshot)
+
+	headers := new(storagemock.Headers)
+	results := new(storagemock.ExecutionResults)
+	eds := new(synchronizationmock.ExecutionDataService)
+	registers := new(storagemock.RegisterIndex)
+	registers.On("LatestHeight").Return(latestIndexed)
+	events := new(storagemock.EventIndex)
+	events.On("IndexedRange").Return(uint64(1), latestIndexed, nil)
+
+	register := flow.NewRegisterID("owner", "controller", "key")
+
+	// the first block can be indexed, but the execution data of the second block can not be
+	// downloaded yet, so the third block must not be indexed
+	for height := latestIndexed + 1; height <= sealedHeight; height++ {
+		header := unittest.BlockHeaderFixture()
+		header.Height = height
+		headers.On("ByHeight", height).Return(&header, nil)
+
+		switch height {
+		case latestIndexed + 1:
+			result := unittest.ExecutionResultFixture()
+			results.On("ByBlockID", header.ID()).Return(result, nil)
+			blockEvents := []flow.EventsList{{unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture(), 0)}}
+			events.On("Store", height, header.ID(), blockEvents).Return(nil).Once()
+			eds.On("Get", mock.Anything, result.ExecutionDataID).Return(&state_synchronization.ExecutionData{
+				BlockID: header.ID(),
+				Events:  blockEvents,
+				TrieUpdates: []*ledger.TrieUpdate{
+					trieUpdateFixture(flow.RegisterEntry{Key: register, Value: []byte{1}}),
+				},
+			}, nil)
+		case latestIndexed + 2:
+			result := unittest.ExecutionResultFixture()
+			results.On("ByBlockID", header.ID()).Return(result, nil)
+			eds.On("Get", mock.Anything, result.ExecutionDataID).Return(nil, fmt.Errorf("blob not found"))
+		default:
+			results.On("ByBlockID", header.ID()).Return(nil, storage.ErrNotFound)
+		}
+	}
+
+	registers.On("Store", flow.RegisterEntries{{Key: register, Value: []byte{1}}}, latestIndexed+1).Return(nil).Once()
+
+	idx := New(zerolog.Nop(), protoState, headers, results, eds, registers, events, DefaultFetchTimeout)
+
+	err := idx.indexSealedBlocks(context.Background())
+	require.NoError(t, err)
+
+	registers.AssertExpectations(t)
+	events.AssertExpectations(t)
+	headers.AssertNotCalled(t, "ByHeight", sealedHeight)
+}
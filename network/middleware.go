@@ -58,6 +58,9 @@ type Middleware interface {
 	// NewPingService creates a new PingService for the given ping protocol ID.
 	NewPingService(pingProtocol protocol.ID, provider PingInfoProvider) PingService
 
+	// NewStreamProtocol creates a new StreamProtocol for the given protocol ID, handling inbound streams with the given handler.
+	NewStreamProtocol(protocolID protocol.ID, handler StreamHandler) StreamProtocol
+
 	IsConnected(nodeID flow.Identifier) (bool, error)
 }
 
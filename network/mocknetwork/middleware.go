@@ -100,6 +100,22 @@ func (_m *Middleware) NewPingService(pingProtocol protocol.ID, provider network.
 	return r0
 }
 
+// NewStreamProtocol provides a mock function with given fields: protocolID, handler
+func (_m *Middleware) NewStreamProtocol(protocolID protocol.ID, handler network.StreamHandler) network.StreamProtocol {
+	ret := _m.Called(protocolID, handler)
+
+	var r0 network.StreamProtocol
+	if rf, ok := ret.Get(0).(func(protocol.ID, network.StreamHandler) network.StreamProtocol); ok {
+		r0 = rf(protocolID, handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(network.StreamProtocol)
+		}
+	}
+
+	return r0
+}
+
 // Publish provides a mock function with given fields: msg, channel
 func (_m *Middleware) Publish(msg *message.Message, channel network.Channel) error {
 	ret := _m.Called(msg, channel)
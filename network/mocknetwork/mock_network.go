@@ -114,6 +114,21 @@ func (mr *MockNetworkMockRecorder) RegisterPingService(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPingService", reflect.TypeOf((*MockNetwork)(nil).RegisterPingService), arg0, arg1)
 }
 
+// RegisterStreamProtocol mocks base method
+func (m *MockNetwork) RegisterStreamProtocol(arg0 protocol.ID, arg1 network.StreamHandler) (network.StreamProtocol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterStreamProtocol", arg0, arg1)
+	ret0, _ := ret[0].(network.StreamProtocol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterStreamProtocol indicates an expected call of RegisterStreamProtocol
+func (mr *MockNetworkMockRecorder) RegisterStreamProtocol(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterStreamProtocol", reflect.TypeOf((*MockNetwork)(nil).RegisterStreamProtocol), arg0, arg1)
+}
+
 // Start mocks base method
 func (m *MockNetwork) Start(arg0 irrecoverable.SignalerContext) {
 	m.ctrl.T.Helper()
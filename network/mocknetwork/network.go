@@ -127,6 +127,29 @@ func (_m *Network) RegisterPingService(pingProtocolID protocol.ID, pingInfoProvi
 	return r0, r1
 }
 
+// RegisterStreamProtocol provides a mock function with given fields: protocolID, handler
+func (_m *Network) RegisterStreamProtocol(protocolID protocol.ID, handler network.StreamHandler) (network.StreamProtocol, error) {
+	ret := _m.Called(protocolID, handler)
+
+	var r0 network.StreamProtocol
+	if rf, ok := ret.Get(0).(func(protocol.ID, network.StreamHandler) network.StreamProtocol); ok {
+		r0 = rf(protocolID, handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(network.StreamProtocol)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(protocol.ID, network.StreamHandler) error); ok {
+		r1 = rf(protocolID, handler)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields: _a0
 func (_m *Network) Start(_a0 irrecoverable.SignalerContext) {
 	_m.Called(_a0)
@@ -0,0 +1,53 @@
+// Code generated by mockery v2.12.1. DO NOT EDIT.
+
+package mocknetwork
+
+import (
+	context "context"
+
+	flow "github.com/onflow/flow-go/model/flow"
+
+	mock "github.com/stretchr/testify/mock"
+
+	network "github.com/libp2p/go-libp2p-core/network"
+
+	testing "testing"
+)
+
+// StreamProtocol is an autogenerated mock type for the StreamProtocol type
+type StreamProtocol struct {
+	mock.Mock
+}
+
+// OpenStream provides a mock function with given fields: ctx, nodeID
+func (_m *StreamProtocol) OpenStream(ctx context.Context, nodeID flow.Identifier) (network.Stream, error) {
+	ret := _m.Called(ctx, nodeID)
+
+	var r0 network.Stream
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) network.Stream); ok {
+		r0 = rf(ctx, nodeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(network.Stream)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(ctx, nodeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStreamProtocol creates a new instance of StreamProtocol. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
+func NewStreamProtocol(t testing.TB) *StreamProtocol {
+	mock := &StreamProtocol{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -27,6 +27,11 @@ type Network interface {
 
 	// RegisterPingService registers a ping protocol handler for the given protocol ID
 	RegisterPingService(pingProtocolID protocol.ID, pingInfoProvider PingInfoProvider) (PingService, error)
+
+	// RegisterStreamProtocol registers the given handler for inbound streams of the given protocol ID. If the
+	// handler is nil, inbound streams are not accepted. The returned StreamProtocol can be used to open streams
+	// of the protocol to other nodes.
+	RegisterStreamProtocol(protocolID protocol.ID, handler StreamHandler) (StreamProtocol, error)
 }
 
 // Adapter is a wrapper around the Network implementation. It only exposes message dissemination functionalities.
@@ -185,6 +185,10 @@ func (m *Middleware) NewPingService(pingProtocol protocol.ID, provider network.P
 	return NewPingService(m.libP2PNode.Host(), pingProtocol, m.log, provider)
 }
 
+func (m *Middleware) NewStreamProtocol(protocolID protocol.ID, handler network.StreamHandler) network.StreamProtocol {
+	return NewStreamProtocol(m.libP2PNode.Host(), protocolID, m.idTranslator, m.log, handler)
+}
+
 func (m *Middleware) topologyPeers() (peer.IDSlice, error) {
 	identities, err := m.ov.Topology()
 	if err != nil {
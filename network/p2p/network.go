@@ -283,6 +283,15 @@ func (n *Network) RegisterPingService(pingProtocol protocol.ID, provider network
 	}
 }
 
+func (n *Network) RegisterStreamProtocol(protocolID protocol.ID, handler network.StreamHandler) (network.StreamProtocol, error) {
+	select {
+	case <-n.ComponentManager.ShutdownSignal():
+		return nil, ErrNetworkShutdown
+	default:
+		return n.mw.NewStreamProtocol(protocolID, handler), nil
+	}
+}
+
 // RegisterBlobService registers a BlobService on the given channel.
 // The returned BlobService can be used to request blobs from the network.
 func (n *Network) RegisterBlobService(channel network.Channel, ds datastore.Batching, opts ...network.BlobServiceOption) (network.BlobService, error) {
@@ -0,0 +1,78 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	fnetwork "github.com/onflow/flow-go/network"
+)
+
+var _ fnetwork.StreamProtocol = (*StreamProtocol)(nil)
+
+// StreamProtocol opens and handles streams of a libp2p protocol between Flow nodes.
+type StreamProtocol struct {
+	host         host.Host
+	protocolID   protocol.ID
+	idTranslator IDTranslator
+	logger       zerolog.Logger
+}
+
+// NewStreamProtocol creates a StreamProtocol for the given protocol ID, and registers the given handler
+// for inbound streams of the protocol. Inbound streams of peers which are not Flow nodes are reset.
+// If the handler is nil, the node only opens streams of the protocol and does not accept any.
+func NewStreamProtocol(
+	h host.Host,
+	protocolID protocol.ID,
+	idTranslator IDTranslator,
+	logger zerolog.Logger,
+	handler fnetwork.StreamHandler,
+) *StreamProtocol {
+	sp := &StreamProtocol{
+		host:         h,
+		protocolID:   protocolID,
+		idTranslator: idTranslator,
+		logger:       logger.With().Str("protocol", string(protocolID)).Logger(),
+	}
+
+	if handler == nil {
+		return sp
+	}
+
+	h.SetStreamHandler(protocolID, func(s network.Stream) {
+		originID, err := sp.idTranslator.GetFlowID(s.Conn().RemotePeer())
+		if err != nil {
+			log := streamLogger(sp.logger, s)
+			log.Warn().Err(err).Msg("rejecting stream of unknown peer")
+			err = s.Reset()
+			if err != nil {
+				log.Error().Err(err).Msg("failed to reset stream")
+			}
+			return
+		}
+
+		handler(originID, s)
+	})
+
+	return sp
+}
+
+// OpenStream opens a stream of the protocol to the node with the given ID.
+func (sp *StreamProtocol) OpenStream(ctx context.Context, nodeID flow.Identifier) (network.Stream, error) {
+	peerID, err := sp.idTranslator.GetPeerID(nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get peer ID of node %v: %w", nodeID, err)
+	}
+
+	s, err := sp.host.NewStream(ctx, peerID, sp.protocolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stream to node %v: %w", nodeID, err)
+	}
+
+	return s, nil
+}
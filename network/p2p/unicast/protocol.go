@@ -26,6 +26,9 @@ const (
 	// FlowLibP2PPingProtocolPrefix is the Flow Ping protocol prefix
 	FlowLibP2PPingProtocolPrefix = FlowLibP2PProtocolCommonPrefix + "/ping/"
 
+	// FlowLibP2PCheckpointProtocolPrefix is the prefix of the protocol execution nodes use to fetch checkpoints from each other
+	FlowLibP2PCheckpointProtocolPrefix = FlowLibP2PProtocolCommonPrefix + "/checkpoint/"
+
 	// FlowLibP2PProtocolGzipCompressedOneToOne represents the protocol id for compressed streams under gzip compressor.
 	FlowLibP2PProtocolGzipCompressedOneToOne = FlowLibP2POneToOneProtocolIDPrefix + "/gzip/"
 )
@@ -52,6 +55,10 @@ func PingProtocolId(sporkId flow.Identifier) protocol.ID {
 	return protocol.ID(FlowLibP2PPingProtocolPrefix + sporkId.String())
 }
 
+func CheckpointProtocolId(sporkId flow.Identifier) protocol.ID {
+	return protocol.ID(FlowLibP2PCheckpointProtocolPrefix + sporkId.String())
+}
+
 type ProtocolName string
 type ProtocolFactory func(zerolog.Logger, flow.Identifier, libp2pnet.StreamHandler) Protocol
 
@@ -76,3 +76,7 @@ func (r *RelayNetwork) RegisterBlobService(channel network.Channel, store datast
 func (r *RelayNetwork) RegisterPingService(pid protocol.ID, provider network.PingInfoProvider) (network.PingService, error) {
 	return r.originNet.RegisterPingService(pid, provider)
 }
+
+func (r *RelayNetwork) RegisterStreamProtocol(pid protocol.ID, handler network.StreamHandler) (network.StreamProtocol, error) {
+	return r.originNet.RegisterStreamProtocol(pid, handler)
+}
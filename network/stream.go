@@ -0,0 +1,20 @@
+package network
+
+import (
+	"context"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// StreamHandler handles an inbound stream of a stream protocol, which was opened by the node with the given ID.
+// The handler is responsible for closing or resetting the stream.
+type StreamHandler func(originID flow.Identifier, stream libp2pnetwork.Stream)
+
+// StreamProtocol is a libp2p protocol which exchanges data over raw streams between two nodes, rather than
+// messages on channels.
+type StreamProtocol interface {
+	// OpenStream opens a stream of the protocol to the node with the given ID.
+	OpenStream(ctx context.Context, nodeID flow.Identifier) (libp2pnetwork.Stream, error)
+}
@@ -0,0 +1,252 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// EventIndex implements storage.EventIndex. The events of each block are stored with Events, and
+// every event is indexed under its type and under the account which deployed the contract emitting
+// it, followed by the height of the block, so that the events of a height range are found with a
+// single range scan. Block IDs are resolved with the index of finalized blocks by height.
+type EventIndex struct {
+	db     *badger.DB
+	events *Events
+
+	mu           sync.RWMutex
+	indexed      bool
+	firstHeight  uint64
+	latestHeight uint64
+}
+
+var _ storage.EventIndex = (*EventIndex)(nil)
+
+// NewEventIndex returns the event index stored in the given database, which also stores the
+// events of the indexed blocks.
+func NewEventIndex(db *badger.DB, events *Events) (*EventIndex, error) {
+	index := &EventIndex{
+		db:     db,
+		events: events,
+	}
+
+	err := db.View(operation.RetrieveEventIndexFirstHeight(&index.firstHeight))
+	if errors.Is(err, storage.ErrNotFound) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve first indexed height: %w", err)
+	}
+
+	err = db.View(operation.RetrieveEventIndexLatestHeight(&index.latestHeight))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve latest indexed height: %w", err)
+	}
+	index.indexed = true
+
+	return index, nil
+}
+
+func (e *EventIndex) Store(height uint64, blockID flow.Identifier, events []flow.EventsList) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	firstHeight, latestHeight := height, height
+	if e.indexed {
+		firstHeight, latestHeight = e.firstHeight, e.latestHeight
+		switch {
+		case height == e.latestHeight+1:
+			latestHeight = height
+		case height+1 == e.firstHeight:
+			firstHeight = height
+		default:
+			return fmt.Errorf("must index consecutive heights, indexed range is [%d, %d], got %d", e.firstHeight, e.latestHeight, height)
+		}
+	}
+
+	batch := NewBatch(e.db)
+	err := e.events.BatchStore(blockID, events, batch)
+	if err != nil {
+		return fmt.Errorf("could not add events to batch: %w", err)
+	}
+
+	writeBatch := batch.GetWriter()
+	for _, list := range events {
+		for _, event := range list {
+			err := operation.BatchIndexEventByType(height, event)(writeBatch)
+			if err != nil {
+				return fmt.Errorf("could not index event by type: %w", err)
+			}
+
+			address, _, ok := storage.EventContract(event.Type)
+			if !ok {
+				continue
+			}
+			err = operation.BatchIndexEventByAddress(height, address, event)(writeBatch)
+			if err != nil {
+				return fmt.Errorf("could not index event by address: %w", err)
+			}
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush events at height %d: %w", height, err)
+	}
+
+	// the indexed range is only updated once all events of the height are persisted, so that an
+	// interrupted write can be retried
+	batch = NewBatch(e.db)
+	err = operation.BatchUpdateEventIndexFirstHeight(firstHeight)(batch.GetWriter())
+	if err != nil {
+		return fmt.Errorf("could not add first indexed height to batch: %w", err)
+	}
+	err = operation.BatchUpdateEventIndexLatestHeight(latestHeight)(batch.GetWriter())
+	if err != nil {
+		return fmt.Errorf("could not add latest indexed height to batch: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush indexed range: %w", err)
+	}
+
+	e.indexed = true
+	e.firstHeight = firstHeight
+	e.latestHeight = latestHeight
+
+	return nil
+}
+
+func (e *EventIndex) ByHeightRange(startHeight uint64, endHeight uint64, query storage.EventQuery) ([]flow.BlockEvents, error) {
+	firstHeight, latestHeight, err := e.IndexedRange()
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("no events indexed: %w", storage.ErrHeightNotIndexed)
+	}
+	if startHeight > endHeight || startHeight < firstHeight || endHeight > latestHeight {
+		return nil, fmt.Errorf("height range [%d, %d] is not within the indexed range [%d, %d]: %w",
+			startHeight, endHeight, firstHeight, latestHeight, storage.ErrHeightNotIndexed)
+	}
+
+	tx := e.db.NewTransaction(false)
+	defer tx.Discard()
+
+	// use the most selective index for the query, events are matched against the complete query
+	// once retrieved
+	var refs []operation.EventRef
+	switch {
+	case query.Type != "":
+		err = operation.LookupEventsByType(query.Type, startHeight, endHeight, &refs)(tx)
+	case query.Address != flow.EmptyAddress:
+		err = operation.LookupEventsByAddress(query.Address, startHeight, endHeight, &refs)(tx)
+	case query.Contract != "":
+		parts := strings.Split(query.Contract, ".")
+		if len(parts) != 3 || parts[0] != "A" {
+			return nil, fmt.Errorf("invalid contract %s, expected format A.<address>.<name>", query.Contract)
+		}
+		err = operation.LookupEventsByAddress(flow.HexToAddress(parts[1]), startHeight, endHeight, &refs)(tx)
+	default:
+		return e.scanHeightRange(tx, startHeight, endHeight, query)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not look up indexed events: %w", err)
+	}
+
+	var results []flow.BlockEvents
+	for _, ref := range refs {
+		if len(results) == 0 || results[len(results)-1].BlockHeight != ref.Height {
+			var blockID flow.Identifier
+			err := operation.LookupBlockHeight(ref.Height, &blockID)(tx)
+			if err != nil {
+				return nil, fmt.Errorf("could not look up block at height %d: %w", ref.Height, err)
+			}
+			results = append(results, flow.BlockEvents{BlockID: blockID, BlockHeight: ref.Height})
+		}
+		result := &results[len(results)-1]
+
+		if query.TransactionID != flow.ZeroID && ref.TransactionID != query.TransactionID {
+			continue
+		}
+
+		var event flow.Event
+		err := operation.RetrieveEvent(result.BlockID, ref, &event)(tx)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve event of block %v: %w", result.BlockID, err)
+		}
+		if query.Match(event) {
+			result.Events = append(result.Events, event)
+		}
+	}
+
+	return withoutEmptyBlocks(results), nil
+}
+
+// scanHeightRange returns the events matching queries which can not use the indexes, by looking up
+// the events of every block of the height range.
+func (e *EventIndex) scanHeightRange(tx *badger.Txn, startHeight uint64, endHeight uint64, query storage.EventQuery) ([]flow.BlockEvents, error) {
+	var results []flow.BlockEvents
+	for height := startHeight; height <= endHeight; height++ {
+		var blockID flow.Identifier
+		err := operation.LookupBlockHeight(height, &blockID)(tx)
+		if err != nil {
+			return nil, fmt.Errorf("could not look up block at height %d: %w", height, err)
+		}
+
+		var events []flow.Event
+		if query.TransactionID != flow.ZeroID {
+			err = operation.RetrieveEvents(blockID, query.TransactionID, &events)(tx)
+		} else {
+			err = operation.LookupEventsByBlockID(blockID, &events)(tx)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve events of block %v: %w", blockID, err)
+		}
+
+		// events are stored by transaction ID, restore their execution order
+		sort.Slice(events, func(i, j int) bool {
+			if events[i].TransactionIndex != events[j].TransactionIndex {
+				return events[i].TransactionIndex < events[j].TransactionIndex
+			}
+			return events[i].EventIndex < events[j].EventIndex
+		})
+
+		result := flow.BlockEvents{BlockID: blockID, BlockHeight: height}
+		for _, event := range events {
+			if query.Match(event) {
+				result.Events = append(result.Events, event)
+			}
+		}
+		results = append(results, result)
+	}
+
+	return withoutEmptyBlocks(results), nil
+}
+
+func (e *EventIndex) IndexedRange() (uint64, uint64, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.indexed {
+		return 0, 0, storage.ErrNotFound
+	}
+	return e.firstHeight, e.latestHeight, nil
+}
+
+// withoutEmptyBlocks removes the blocks without events from the results.
+func withoutEmptyBlocks(results []flow.BlockEvents) []flow.BlockEvents {
+	filtered := make([]flow.BlockEvents, 0, len(results))
+	for _, result := range results {
+		if len(result.Events) > 0 {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,182 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestEventIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		events := bstorage.NewEvents(metrics.NewNoopCollector(), db)
+		index, err := bstorage.NewEventIndex(db, events)
+		require.NoError(t, err)
+
+		_, _, err = index.IndexedRange()
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		const fooType = flow.EventType("A.0000000000000001.Foo.Created")
+		const barType = flow.EventType("A.0000000000000002.Bar.Created")
+		const barTypeLonger = flow.EventType("A.0000000000000002.Bar.CreatedTwice")
+		const coreType = flow.EventType("flow.AccountCreated")
+
+		txA := unittest.IdentifierFixture()
+		txB := unittest.IdentifierFixture()
+
+		blockEvents := map[uint64][]flow.EventsList{
+			10: {{
+				unittest.EventFixture(fooType, 0, 0, txA, 0),
+				unittest.EventFixture(coreType, 0, 1, txA, 0),
+			}},
+			11: {},
+			12: {{
+				unittest.EventFixture(barType, 0, 0, txB, 0),
+				unittest.EventFixture(barTypeLonger, 0, 1, txB, 0),
+			}, {
+				unittest.EventFixture(fooType, 1, 0, txA, 0),
+			}},
+		}
+
+		blockIDs := make(map[uint64]flow.Identifier)
+		for _, height := range []uint64{11, 12, 10} {
+			blockIDs[height] = unittest.IdentifierFixture()
+			err := db.Update(operation.IndexBlockHeight(height, blockIDs[height]))
+			require.NoError(t, err)
+
+			// heights are indexed upwards and backfilled downwards
+			err = index.Store(height, blockIDs[height], blockEvents[height])
+			require.NoError(t, err)
+		}
+
+		// heights must be consecutive
+		err = index.Store(14, unittest.IdentifierFixture(), nil)
+		require.Error(t, err)
+
+		first, latest, err := index.IndexedRange()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), first)
+		assert.Equal(t, uint64(12), latest)
+
+		// the events are also stored with the events of the block
+		stored, err := events.ByBlockID(blockIDs[12])
+		require.NoError(t, err)
+		assert.Len(t, stored, 3)
+
+		expected := func(height uint64, events ...flow.Event) flow.BlockEvents {
+			return flow.BlockEvents{BlockID: blockIDs[height], BlockHeight: height, Events: events}
+		}
+
+		tests := []struct {
+			name     string
+			query    storage.EventQuery
+			start    uint64
+			end      uint64
+			expected []flow.BlockEvents
+		}{
+			{
+				name:  "by type",
+				query: storage.EventQuery{Type: fooType},
+				start: 10, end: 12,
+				expected: []flow.BlockEvents{
+					expected(10, blockEvents[10][0][0]),
+					expected(12, blockEvents[12][1][0]),
+				},
+			},
+			{
+				name:     "by type within range",
+				query:    storage.EventQuery{Type: fooType},
+				start:    11,
+				end:      12,
+				expected: []flow.BlockEvents{expected(12, blockEvents[12][1][0])},
+			},
+			{
+				name:     "by type does not match longer types",
+				query:    storage.EventQuery{Type: barType},
+				start:    10,
+				end:      12,
+				expected: []flow.BlockEvents{expected(12, blockEvents[12][0][0])},
+			},
+			{
+				name:     "by address",
+				query:    storage.EventQuery{Address: flow.HexToAddress("02")},
+				start:    10,
+				end:      12,
+				expected: []flow.BlockEvents{expected(12, blockEvents[12][0]...)},
+			},
+			{
+				name:     "by contract",
+				query:    storage.EventQuery{Contract: "A.0000000000000001.Foo"},
+				start:    10,
+				end:      10,
+				expected: []flow.BlockEvents{expected(10, blockEvents[10][0][0])},
+			},
+			{
+				name:  "by transaction",
+				query: storage.EventQuery{TransactionID: txA},
+				start: 10, end: 12,
+				expected: []flow.BlockEvents{
+					expected(10, blockEvents[10][0]...),
+					expected(12, blockEvents[12][1][0]),
+				},
+			},
+			{
+				name:     "by type and transaction",
+				query:    storage.EventQuery{Type: coreType, TransactionID: txA},
+				start:    10,
+				end:      12,
+				expected: []flow.BlockEvents{expected(10, blockEvents[10][0][1])},
+			},
+			{
+				name:     "no match",
+				query:    storage.EventQuery{Type: coreType, TransactionID: txB},
+				start:    10,
+				end:      12,
+				expected: []flow.BlockEvents{},
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				actual, err := index.ByHeightRange(test.start, test.end, test.query)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			})
+		}
+
+		_, err = index.ByHeightRange(10, 13, storage.EventQuery{Type: fooType})
+		require.True(t, errors.Is(err, storage.ErrHeightNotIndexed))
+
+		// the indexed range is restored from the database
+		index, err = bstorage.NewEventIndex(db, events)
+		require.NoError(t, err)
+		first, latest, err = index.IndexedRange()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), first)
+		assert.Equal(t, uint64(12), latest)
+	})
+}
+
+func TestEventQueryMatch(t *testing.T) {
+	event := unittest.EventFixture("A.0000000000000001.Foo.Created", 0, 0, unittest.IdentifierFixture(), 0)
+	core := unittest.EventFixture("flow.AccountCreated", 0, 0, event.TransactionID, 0)
+
+	assert.True(t, storage.EventQuery{}.Match(event))
+	assert.True(t, storage.EventQuery{Type: event.Type, TransactionID: event.TransactionID}.Match(event))
+	assert.True(t, storage.EventQuery{Address: flow.HexToAddress("01"), Contract: "A.0000000000000001.Foo"}.Match(event))
+	assert.False(t, storage.EventQuery{Contract: "A.0000000000000001.Bar"}.Match(event))
+	assert.False(t, storage.EventQuery{Address: flow.HexToAddress("02")}.Match(event))
+	assert.False(t, storage.EventQuery{TransactionID: unittest.IdentifierFixture()}.Match(event))
+
+	// core events are not emitted by contracts
+	assert.True(t, storage.EventQuery{TransactionID: event.TransactionID}.Match(core))
+	assert.False(t, storage.EventQuery{Address: flow.HexToAddress("01")}.Match(core))
+}
@@ -124,9 +124,14 @@ func (e *Events) ByBlockIDEventType(blockID flow.Identifier, eventType flow.Even
 	return matched, nil
 }
 
-// RemoveByBlockID removes events by block ID
+// RemoveByBlockID removes events by block ID, and evicts them from the cache.
 func (e *Events) RemoveByBlockID(blockID flow.Identifier) error {
-	return e.db.Update(operation.RemoveEventsByBlockID(blockID))
+	err := e.db.Update(operation.RemoveEventsByBlockID(blockID))
+	if err != nil {
+		return err
+	}
+	e.cache.Remove(blockID)
+	return nil
 }
 
 type ServiceEvents struct {
@@ -179,7 +184,12 @@ func (e *ServiceEvents) ByBlockID(blockID flow.Identifier) ([]flow.Event, error)
 	return val.([]flow.Event), nil
 }
 
-// RemoveByBlockID removes service events by block ID
+// RemoveByBlockID removes service events by block ID, and evicts them from the cache.
 func (e *ServiceEvents) RemoveByBlockID(blockID flow.Identifier) error {
-	return e.db.Update(operation.RemoveServiceEventsByBlockID(blockID))
+	err := e.db.Update(operation.RemoveServiceEventsByBlockID(blockID))
+	if err != nil {
+		return err
+	}
+	e.cache.Remove(blockID)
+	return nil
 }
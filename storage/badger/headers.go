@@ -193,7 +193,12 @@ func (h *Headers) BatchIndexByChunkID(headerID, chunkID flow.Identifier, batch s
 }
 
 func (h *Headers) RemoveChunkBlockIndexByChunkID(chunkID flow.Identifier) error {
-	return h.db.Update(operation.RemoveBlockIDByChunkID(chunkID))
+	err := h.db.Update(operation.RemoveBlockIDByChunkID(chunkID))
+	if err != nil {
+		return err
+	}
+	h.chunkIDCache.Remove(chunkID)
+	return nil
 }
 
 // RollbackExecutedBlock update the executed block header to the given header.
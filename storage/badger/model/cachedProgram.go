@@ -0,0 +1,16 @@
+package badgermodel
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// CachedProgram is an in-storage record of a contract program which was loaded by an execution node,
+// so that the program can be loaded again after a restart.
+type CachedProgram struct {
+	Address flow.Address
+	Name    string
+	// CodeHash is the hash of the contract code the program was loaded from, ZeroID if it is not known yet
+	CodeHash flow.Identifier
+	// Height is the height of the last executed block which loaded the program
+	Height uint64
+}
@@ -0,0 +1,24 @@
+package badgermodel
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ExecutionResultConflict is an in-storage record of the execution results of other execution nodes for a block
+// which differ from the execution result an execution node computed for the block.
+type ExecutionResultConflict struct {
+	BlockID     flow.Identifier
+	Height      uint64
+	OwnResultID flow.Identifier
+	// ConflictingResultIDs are the IDs of the results of other execution nodes which differ from the own result
+	ConflictingResultIDs []flow.Identifier
+	// SealedResultID is the ID of the sealed result if it differs from the own result, ZeroID otherwise
+	SealedResultID flow.Identifier
+	// Resumed is whether an operator resumed execution after the sealed conflicting result halted it
+	Resumed bool
+}
+
+// Sealed returns whether the sealed result of the block conflicts with the own result.
+func (c *ExecutionResultConflict) Sealed() bool {
+	return c.SealedResultID != flow.ZeroID
+}
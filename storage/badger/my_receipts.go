@@ -146,5 +146,10 @@ func (m *MyExecutionReceipts) MyReceipt(blockID flow.Identifier) (*flow.Executio
 }
 
 func (m *MyExecutionReceipts) RemoveIndexByBlockID(blockID flow.Identifier) error {
-	return m.db.Update(operation.SkipNonExist(operation.RemoveOwnExecutionReceipt(blockID)))
+	err := m.db.Update(operation.SkipNonExist(operation.RemoveOwnExecutionReceipt(blockID)))
+	if err != nil {
+		return err
+	}
+	m.cache.Remove(blockID)
+	return nil
 }
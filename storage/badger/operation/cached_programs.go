@@ -0,0 +1,45 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+)
+
+// InsertCachedProgram inserts a cached contract program, keyed by contract location.
+func InsertCachedProgram(program *badgermodel.CachedProgram) func(*badger.Txn) error {
+	return insert(makePrefix(codeCachedProgram, program.Address, program.Name), program)
+}
+
+// UpdateCachedProgram updates a cached contract program, keyed by contract location.
+func UpdateCachedProgram(program *badgermodel.CachedProgram) func(*badger.Txn) error {
+	return update(makePrefix(codeCachedProgram, program.Address, program.Name), program)
+}
+
+// RemoveCachedProgram removes the cached program of a contract.
+func RemoveCachedProgram(address flow.Address, name string) func(*badger.Txn) error {
+	return remove(makePrefix(codeCachedProgram, address, name))
+}
+
+// LookupCachedPrograms retrieves all cached contract programs.
+func LookupCachedPrograms(programs *[]*badgermodel.CachedProgram) func(*badger.Txn) error {
+
+	iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+		check := func(_ []byte) bool {
+			return true
+		}
+		var val badgermodel.CachedProgram
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			program := val
+			*programs = append(*programs, &program)
+			return nil
+		}
+		return check, create, handle
+	}
+
+	return traverse(makePrefix(codeCachedProgram), iterationFunc)
+}
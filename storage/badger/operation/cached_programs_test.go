@@ -0,0 +1,52 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestCachedPrograms(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		address := flow.HexToAddress("01")
+		first := &badgermodel.CachedProgram{
+			Address: address,
+			Name:    "A",
+			Height:  10,
+		}
+		second := &badgermodel.CachedProgram{
+			Address:  address,
+			Name:     "AB",
+			CodeHash: unittest.IdentifierFixture(),
+			Height:   11,
+		}
+		require.NoError(t, db.Update(InsertCachedProgram(first)))
+		require.NoError(t, db.Update(InsertCachedProgram(second)))
+
+		err := db.Update(InsertCachedProgram(first))
+		require.ErrorIs(t, err, storage.ErrAlreadyExists)
+
+		first.CodeHash = unittest.IdentifierFixture()
+		first.Height = 12
+		require.NoError(t, db.Update(UpdateCachedProgram(first)))
+
+		var all []*badgermodel.CachedProgram
+		require.NoError(t, db.View(LookupCachedPrograms(&all)))
+		require.ElementsMatch(t, []*badgermodel.CachedProgram{first, second}, all)
+
+		// removing a contract program does not remove programs of contracts with a longer name
+		require.NoError(t, db.Update(RemoveCachedProgram(address, "A")))
+		all = nil
+		require.NoError(t, db.View(LookupCachedPrograms(&all)))
+		require.Equal(t, []*badgermodel.CachedProgram{second}, all)
+
+		err = db.Update(RemoveCachedProgram(address, "A"))
+		require.ErrorIs(t, err, storage.ErrNotFound)
+	})
+}
@@ -413,6 +413,43 @@ func traverse(prefix []byte, iteration iterationFunc) func(*badger.Txn) error {
 	}
 }
 
+// findHighestAtOrBelow searches for the highest key with the given prefix and a height at or
+// below the target height, and decodes the value associated with the key into the given entity.
+// The height must be the last part of the key, following the prefix.
+// If no key is found, the function returns storage.ErrNotFound.
+func findHighestAtOrBelow(prefix []byte, height uint64, entity interface{}) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		if len(prefix) == 0 {
+			return fmt.Errorf("prefix must not be empty")
+		}
+
+		options := badger.DefaultIteratorOptions
+		options.Prefix = prefix
+		options.Reverse = true // seek to the first key that is less than or equal to the seek key
+
+		it := tx.NewIterator(options)
+		defer it.Close()
+
+		seekKey := make([]byte, 0, len(prefix)+8)
+		seekKey = append(seekKey, prefix...)
+		seekKey = append(seekKey, b(height)...)
+
+		it.Seek(seekKey)
+		if !it.Valid() {
+			return storage.ErrNotFound
+		}
+
+		err := it.Item().Value(func(val []byte) error {
+			return msgpack.Unmarshal(val, entity)
+		})
+		if err != nil {
+			return fmt.Errorf("could not decode entity: %w", err)
+		}
+
+		return nil
+	}
+}
+
 // Fail returns a DB operation function that always fails with the given error.
 func Fail(err error) func(*badger.Txn) error {
 	return func(_ *badger.Txn) error {
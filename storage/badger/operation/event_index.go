@@ -0,0 +1,104 @@
+package operation
+
+import (
+	"encoding/binary"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EventRef locates an indexed event, which is stored with the events of its block.
+type EventRef struct {
+	Height           uint64
+	TransactionID    flow.Identifier
+	TransactionIndex uint32
+	EventIndex       uint32
+}
+
+// eventRefSuffixLength is the length of the height, transaction index and event index which end
+// the keys of the event indexes.
+const eventRefSuffixLength = 8 + 4 + 4
+
+// eventTypeKey returns the key part of an event type. Types are terminated with a zero byte, so
+// that the keys of a type never share a prefix with the keys of a longer type.
+func eventTypeKey(eventType flow.EventType) string {
+	return string(eventType) + "\x00"
+}
+
+// BatchIndexEventByType indexes an event of the block at the given height by its type.
+func BatchIndexEventByType(height uint64, event flow.Event) func(*badger.WriteBatch) error {
+	key := makePrefix(codeEventTypeIndex, eventTypeKey(event.Type), height, event.TransactionIndex, event.EventIndex)
+	return batchWrite(key, event.TransactionID)
+}
+
+// BatchIndexEventByAddress indexes an event of the block at the given height by the account which
+// deployed the contract emitting it.
+func BatchIndexEventByAddress(height uint64, address flow.Address, event flow.Event) func(*badger.WriteBatch) error {
+	key := makePrefix(codeEventAddressIndex, address, height, event.TransactionIndex, event.EventIndex)
+	return batchWrite(key, event.TransactionID)
+}
+
+// LookupEventsByType retrieves the references of the events of the given type emitted within the
+// height range (inclusive), ordered by height, transaction index and event index.
+func LookupEventsByType(eventType flow.EventType, startHeight uint64, endHeight uint64, refs *[]EventRef) func(*badger.Txn) error {
+	start := makePrefix(codeEventTypeIndex, eventTypeKey(eventType), startHeight)
+	end := makePrefix(codeEventTypeIndex, eventTypeKey(eventType), endHeight)
+	return iterate(start, end, eventRefIterationFunc(refs))
+}
+
+// LookupEventsByAddress retrieves the references of the events emitted by the contracts of the
+// given account within the height range (inclusive), ordered by height, transaction index and
+// event index.
+func LookupEventsByAddress(address flow.Address, startHeight uint64, endHeight uint64, refs *[]EventRef) func(*badger.Txn) error {
+	start := makePrefix(codeEventAddressIndex, address, startHeight)
+	end := makePrefix(codeEventAddressIndex, address, endHeight)
+	return iterate(start, end, eventRefIterationFunc(refs))
+}
+
+// RetrieveEvent retrieves an event of the given block stored with the events of the block.
+func RetrieveEvent(blockID flow.Identifier, ref EventRef, event *flow.Event) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEvent, blockID, ref.TransactionID, ref.TransactionIndex, ref.EventIndex), event)
+}
+
+func BatchUpdateEventIndexFirstHeight(height uint64) func(*badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEventIndexFirstHeight), height)
+}
+
+func RetrieveEventIndexFirstHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEventIndexFirstHeight), height)
+}
+
+func BatchUpdateEventIndexLatestHeight(height uint64) func(*badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEventIndexLatestHeight), height)
+}
+
+func RetrieveEventIndexLatestHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEventIndexLatestHeight), height)
+}
+
+// eventRefIterationFunc returns an iteration function which decodes the event references of the
+// event indexes, the position of the event being encoded at the end of the keys.
+func eventRefIterationFunc(refs *[]EventRef) func() (checkFunc, createFunc, handleFunc) {
+	return func() (checkFunc, createFunc, handleFunc) {
+		var ref EventRef
+		check := func(key []byte) bool {
+			if len(key) < 1+eventRefSuffixLength {
+				return false
+			}
+			suffix := key[len(key)-eventRefSuffixLength:]
+			ref.Height = binary.BigEndian.Uint64(suffix[:8])
+			ref.TransactionIndex = binary.BigEndian.Uint32(suffix[8:12])
+			ref.EventIndex = binary.BigEndian.Uint32(suffix[12:])
+			return true
+		}
+		create := func() interface{} {
+			return &ref.TransactionID
+		}
+		handle := func() error {
+			*refs = append(*refs, ref)
+			return nil
+		}
+		return check, create, handle
+	}
+}
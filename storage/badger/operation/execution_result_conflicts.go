@@ -0,0 +1,45 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+)
+
+// InsertExecutionResultConflict inserts the conflicting execution results of a block, keyed by block ID.
+func InsertExecutionResultConflict(conflict *badgermodel.ExecutionResultConflict) func(*badger.Txn) error {
+	return insert(makePrefix(codeExecutionResultConflict, conflict.BlockID), conflict)
+}
+
+// UpdateExecutionResultConflict updates the conflicting execution results of a block, keyed by block ID.
+func UpdateExecutionResultConflict(conflict *badgermodel.ExecutionResultConflict) func(*badger.Txn) error {
+	return update(makePrefix(codeExecutionResultConflict, conflict.BlockID), conflict)
+}
+
+// RetrieveExecutionResultConflict retrieves the conflicting execution results of a block by block ID.
+func RetrieveExecutionResultConflict(blockID flow.Identifier, conflict *badgermodel.ExecutionResultConflict) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeExecutionResultConflict, blockID), conflict)
+}
+
+// LookupExecutionResultConflicts retrieves the conflicting execution results of all blocks.
+func LookupExecutionResultConflicts(conflicts *[]*badgermodel.ExecutionResultConflict) func(*badger.Txn) error {
+
+	iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+		check := func(_ []byte) bool {
+			return true
+		}
+		var val badgermodel.ExecutionResultConflict
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			conflict := val
+			*conflicts = append(*conflicts, &conflict)
+			return nil
+		}
+		return check, create, handle
+	}
+
+	return traverse(makePrefix(codeExecutionResultConflict), iterationFunc)
+}
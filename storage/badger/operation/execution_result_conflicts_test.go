@@ -0,0 +1,48 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestExecutionResultConflicts(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		first := &badgermodel.ExecutionResultConflict{
+			BlockID:              unittest.IdentifierFixture(),
+			Height:               10,
+			OwnResultID:          unittest.IdentifierFixture(),
+			ConflictingResultIDs: unittest.IdentifierListFixture(1),
+		}
+		second := &badgermodel.ExecutionResultConflict{
+			BlockID:              unittest.IdentifierFixture(),
+			Height:               11,
+			OwnResultID:          unittest.IdentifierFixture(),
+			ConflictingResultIDs: unittest.IdentifierListFixture(2),
+		}
+		require.NoError(t, db.Update(InsertExecutionResultConflict(first)))
+		require.NoError(t, db.Update(InsertExecutionResultConflict(second)))
+
+		// conflicts are updated as more results are known
+		first.SealedResultID = first.ConflictingResultIDs[0]
+		require.NoError(t, db.Update(UpdateExecutionResultConflict(first)))
+
+		var actual badgermodel.ExecutionResultConflict
+		require.NoError(t, db.View(RetrieveExecutionResultConflict(first.BlockID, &actual)))
+		require.Equal(t, first, &actual)
+		require.True(t, actual.Sealed())
+
+		var all []*badgermodel.ExecutionResultConflict
+		require.NoError(t, db.View(LookupExecutionResultConflicts(&all)))
+		require.ElementsMatch(t, []*badgermodel.ExecutionResultConflict{first, second}, all)
+
+		err := db.View(RetrieveExecutionResultConflict(flow.ZeroID, &actual))
+		require.ErrorIs(t, err, storage.ErrNotFound)
+	})
+}
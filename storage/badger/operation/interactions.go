@@ -14,3 +14,7 @@ func InsertExecutionStateInteractions(blockID flow.Identifier, interactions []*d
 func RetrieveExecutionStateInteractions(blockID flow.Identifier, interactions *[]*delta.Snapshot) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeExecutionStateInteractions, blockID), interactions)
 }
+
+func RemoveExecutionStateInteractions(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeExecutionStateInteractions, blockID))
+}
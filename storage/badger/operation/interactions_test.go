@@ -11,6 +11,7 @@ import (
 
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -53,5 +54,11 @@ func TestStateInteractionsInsertCheckRetrieve(t *testing.T) {
 		assert.Equal(t, interactions, readInteractions)
 
 		assert.Equal(t, d1.Delta(), d1.Interactions().Delta)
+
+		err = db.Update(RemoveExecutionStateInteractions(blockID))
+		require.NoError(t, err)
+
+		err = db.View(RetrieveExecutionStateInteractions(blockID, &readInteractions))
+		require.ErrorIs(t, err, storage.ErrNotFound)
 	})
 }
@@ -31,6 +31,10 @@ const (
 	codeExecutedBlock           = 23 // latest executed block with max height
 	codeRootHeight              = 24 // the height of the first loaded block
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
+	codeRegisterFirstHeight     = 26 // the height of the first block indexed in the register index
+	codeRegisterLatestHeight    = 27 // the height of the latest block indexed in the register index
+	codeEventIndexFirstHeight   = 28 // the height of the first block indexed in the event index
+	codeEventIndexLatestHeight  = 29 // the height of the latest block indexed in the event index
 
 	// codes for single entity storage
 	// 31 was used for identities before epochs
@@ -76,6 +80,13 @@ const (
 	codeJobQueue             = 71
 	codeJobQueuePointer      = 72
 
+	// codes for indexing events by height
+	codeEventTypeIndex    = 80 // index mapping event type and height to events
+	codeEventAddressIndex = 81 // index mapping emitting account and height to events
+
+	// codes for caches of execution nodes persisted across restarts
+	codeCachedProgram = 90 // contract programs loaded by execution nodes, keyed by contract location
+
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
 	codeCommit                       = 101
@@ -85,12 +96,14 @@ const (
 	codeFinalizedCluster             = 105
 	codeServiceEvent                 = 106
 	codeTransactionResultIndex       = 107
+	codeRegister                     = 108
 	codeIndexCollection              = 200
 	codeIndexExecutionResultByBlock  = 202
 	codeIndexCollectionByTransaction = 203
 	codeIndexResultApprovalByChunk   = 204
 
 	// internal failure information that should be preserved across restarts
+	codeExecutionResultConflict         = 253 // results of other execution nodes conflicting with own results, by block
 	codeExecutionFork                   = 254
 	codeEpochEmergencyFallbackTriggered = 255
 )
@@ -122,6 +135,8 @@ func b(v interface{}) []byte {
 		return []byte{byte(i)}
 	case flow.Identifier:
 		return i[:]
+	case flow.Address:
+		return i[:]
 	case flow.ChainID:
 		return []byte(i)
 	default:
@@ -0,0 +1,40 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// registerKey returns the fixed size key part of a register, which guarantees that the keys of
+// different registers never share a prefix.
+func registerKey(registerID flow.RegisterID) flow.Identifier {
+	return flow.MakeID(registerID)
+}
+
+// BatchInsertRegister inserts the value of a register set at the given height into a batch.
+func BatchInsertRegister(height uint64, registerID flow.RegisterID, value flow.RegisterValue) func(*badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeRegister, registerKey(registerID), height), value)
+}
+
+// LookupRegister retrieves the value of a register at the given height, which is the value set by
+// the latest update at or below the height.
+func LookupRegister(height uint64, registerID flow.RegisterID, value *flow.RegisterValue) func(*badger.Txn) error {
+	return findHighestAtOrBelow(makePrefix(codeRegister, registerKey(registerID)), height, value)
+}
+
+func InsertRegisterFirstHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeRegisterFirstHeight), height)
+}
+
+func RetrieveRegisterFirstHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeRegisterFirstHeight), height)
+}
+
+func BatchUpdateRegisterLatestHeight(height uint64) func(*badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeRegisterLatestHeight), height)
+}
+
+func RetrieveRegisterLatestHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeRegisterLatestHeight), height)
+}
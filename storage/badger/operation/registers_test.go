@@ -0,0 +1,51 @@
+package operation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestRegisterInsertLookup(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		registerID := flow.NewRegisterID("owner", "", "key")
+		// shares a prefix with the first register when the parts are concatenated
+		otherID := flow.NewRegisterID("owner", "", "key2")
+
+		insert := func(height uint64, id flow.RegisterID, value string) {
+			batch := db.NewWriteBatch()
+			require.NoError(t, BatchInsertRegister(height, id, []byte(value))(batch))
+			require.NoError(t, batch.Flush())
+		}
+		insert(10, registerID, "a")
+		insert(20, registerID, "b")
+		insert(15, otherID, "c")
+
+		lookup := func(height uint64, id flow.RegisterID) (flow.RegisterValue, error) {
+			var value flow.RegisterValue
+			err := db.View(LookupRegister(height, id, &value))
+			return value, err
+		}
+
+		// no update at or below the height
+		_, err := lookup(9, registerID)
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		for height, expected := range map[uint64]string{10: "a", 15: "a", 19: "a", 20: "b", 100: "b"} {
+			value, err := lookup(height, registerID)
+			require.NoError(t, err)
+			assert.Equal(t, []byte(expected), value, "height %d", height)
+		}
+
+		value, err := lookup(20, otherID)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("c"), value)
+	})
+}
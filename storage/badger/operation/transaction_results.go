@@ -82,6 +82,12 @@ func RemoveTransactionResultsByBlockID(blockID flow.Identifier) func(*badger.Txn
 			return fmt.Errorf("could not remove transaction results for block %v: %w", blockID, err)
 		}
 
+		indexPrefix := makePrefix(codeTransactionResultIndex, blockID)
+		err = removeByPrefix(indexPrefix)(txn)
+		if err != nil {
+			return fmt.Errorf("could not remove transaction result index for block %v: %w", blockID, err)
+		}
+
 		return nil
 	}
 }
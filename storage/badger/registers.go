@@ -0,0 +1,149 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// RegisterIndex implements storage.RegisterIndex. Every update of a register is stored under a
+// key made of the register and the height of the update, so that the value at a given height is
+// found by seeking to the highest key at or below that height.
+type RegisterIndex struct {
+	db           *badger.DB
+	firstHeight  uint64
+	latestHeight *atomic.Uint64
+}
+
+var _ storage.RegisterIndex = (*RegisterIndex)(nil)
+
+// NewRegisterIndex returns the register index stored in the given database, which must have been
+// bootstrapped with BootstrapRegisterIndex.
+func NewRegisterIndex(db *badger.DB) (*RegisterIndex, error) {
+	var firstHeight uint64
+	err := db.View(operation.RetrieveRegisterFirstHeight(&firstHeight))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve first indexed height: %w", err)
+	}
+
+	var latestHeight uint64
+	err = db.View(operation.RetrieveRegisterLatestHeight(&latestHeight))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve latest indexed height: %w", err)
+	}
+
+	return &RegisterIndex{
+		db:           db,
+		firstHeight:  firstHeight,
+		latestHeight: atomic.NewUint64(latestHeight),
+	}, nil
+}
+
+// BootstrapRegisterIndex initializes the register index with the complete state at the given
+// height. The entries are written in batches, so that the complete state of large chains can be
+// bootstrapped.
+func BootstrapRegisterIndex(db *badger.DB, height uint64, entries flow.RegisterEntries) (*RegisterIndex, error) {
+	err := db.View(operation.RetrieveRegisterFirstHeight(new(uint64)))
+	if err == nil {
+		return nil, fmt.Errorf("register index already bootstrapped: %w", storage.ErrAlreadyExists)
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("could not check whether register index is bootstrapped: %w", err)
+	}
+
+	err = storeRegisters(db, entries, height)
+	if err != nil {
+		return nil, fmt.Errorf("could not store registers at height %d: %w", height, err)
+	}
+
+	// the first height is only set once all registers are stored, so that an interrupted
+	// bootstrapping can be retried
+	err = operation.RetryOnConflict(db.Update, operation.InsertRegisterFirstHeight(height))
+	if err != nil {
+		return nil, fmt.Errorf("could not insert first indexed height: %w", err)
+	}
+
+	return NewRegisterIndex(db)
+}
+
+func (r *RegisterIndex) Get(ID flow.RegisterID, height uint64) (flow.RegisterValue, error) {
+	if height < r.firstHeight || height > r.latestHeight.Load() {
+		return nil, fmt.Errorf("could not get register at height %d: %w", height, storage.ErrHeightNotIndexed)
+	}
+
+	var value flow.RegisterValue
+	err := r.db.View(operation.LookupRegister(height, ID, &value))
+	if errors.Is(err, storage.ErrNotFound) {
+		// the register was never set
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve register %s: %w", ID.String(), err)
+	}
+
+	return value, nil
+}
+
+func (r *RegisterIndex) Store(entries flow.RegisterEntries, height uint64) error {
+	latestHeight := r.latestHeight.Load()
+	if height != latestHeight+1 {
+		return fmt.Errorf("must index consecutive heights, latest indexed height is %d, got %d", latestHeight, height)
+	}
+
+	err := storeRegisters(r.db, entries, height)
+	if err != nil {
+		return fmt.Errorf("could not store registers at height %d: %w", height, err)
+	}
+
+	r.latestHeight.Store(height)
+
+	return nil
+}
+
+func (r *RegisterIndex) FirstHeight() uint64 {
+	return r.firstHeight
+}
+
+func (r *RegisterIndex) LatestHeight() uint64 {
+	return r.latestHeight.Load()
+}
+
+// storeRegisters stores the register entries at the given height and then updates the latest
+// indexed height. Storing the same entries more than once is idempotent, so that an interrupted
+// write can be retried.
+func storeRegisters(db *badger.DB, entries flow.RegisterEntries, height uint64) error {
+	batch := NewBatch(db)
+
+	writeBatch := batch.GetWriter()
+	for _, entry := range entries {
+		err := operation.BatchInsertRegister(height, entry.Key, entry.Value)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("could not add register %s to batch: %w", entry.Key.String(), err)
+		}
+	}
+
+	err := batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush registers: %w", err)
+	}
+
+	// the latest height is only updated once all registers of the height are persisted
+	batch = NewBatch(db)
+	err = operation.BatchUpdateRegisterLatestHeight(height)(batch.GetWriter())
+	if err != nil {
+		return fmt.Errorf("could not add latest indexed height to batch: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush latest indexed height: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestRegisterIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		registerA := flow.NewRegisterID("a", "", "key")
+		registerB := flow.NewRegisterID("b", "", "key")
+		registerC := flow.NewRegisterID("c", "", "key")
+
+		_, err := bstorage.NewRegisterIndex(db)
+		require.Error(t, err, "register index must be bootstrapped")
+
+		index, err := bstorage.BootstrapRegisterIndex(db, 10, flow.RegisterEntries{
+			{Key: registerA, Value: []byte("a10")},
+			{Key: registerB, Value: []byte("b10")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), index.FirstHeight())
+		assert.Equal(t, uint64(10), index.LatestHeight())
+
+		_, err = bstorage.BootstrapRegisterIndex(db, 10, nil)
+		require.True(t, errors.Is(err, storage.ErrAlreadyExists))
+
+		err = index.Store(flow.RegisterEntries{{Key: registerA, Value: []byte("a11")}}, 11)
+		require.NoError(t, err)
+		err = index.Store(flow.RegisterEntries{{Key: registerC, Value: []byte("c12")}}, 12)
+		require.NoError(t, err)
+
+		// heights must be indexed in order
+		err = index.Store(flow.RegisterEntries{{Key: registerC, Value: []byte("c14")}}, 14)
+		require.Error(t, err)
+
+		tests := []struct {
+			id       flow.RegisterID
+			height   uint64
+			expected flow.RegisterValue
+		}{
+			{registerA, 10, []byte("a10")},
+			{registerA, 11, []byte("a11")},
+			{registerA, 12, []byte("a11")},
+			{registerB, 12, []byte("b10")},
+			{registerC, 11, nil},
+			{registerC, 12, []byte("c12")},
+		}
+		for _, test := range tests {
+			value, err := index.Get(test.id, test.height)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, value, "register %s at height %d", test.id.String(), test.height)
+		}
+
+		for _, height := range []uint64{9, 13} {
+			_, err = index.Get(registerA, height)
+			assert.True(t, errors.Is(err, storage.ErrHeightNotIndexed))
+		}
+
+		// the indexed range is restored from the database
+		index, err = bstorage.NewRegisterIndex(db)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), index.FirstHeight())
+		assert.Equal(t, uint64(12), index.LatestHeight())
+	})
+}
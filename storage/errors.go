@@ -14,4 +14,7 @@ var (
 
 	ErrAlreadyExists = errors.New("key already exists")
 	ErrDataMismatch  = errors.New("data for key is different")
+
+	// ErrHeightNotIndexed is returned when data at a block height outside of the indexed range is requested
+	ErrHeightNotIndexed = errors.New("height not indexed")
 )
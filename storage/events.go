@@ -1,6 +1,11 @@
 package storage
 
-import "github.com/onflow/flow-go/model/flow"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go/model/flow"
+)
 
 // Events represents persistent storage for events.
 type Events interface {
@@ -28,3 +33,76 @@ type ServiceEvents interface {
 	// ByBlockID returns the events for the given block ID
 	ByBlockID(blockID flow.Identifier) ([]flow.Event, error)
 }
+
+// EventIndex indexes the events of sealed blocks by height, event type and emitting account, so
+// that events can be looked up over wide height ranges without knowing the IDs of the blocks.
+// The events themselves are stored in Events.
+type EventIndex interface {
+	// Store stores the events of the block at the given height and indexes them. The height must
+	// directly follow the latest indexed height, or directly precede the first indexed height
+	// when backfilling, unless the index is empty.
+	Store(height uint64, blockID flow.Identifier, events []flow.EventsList) error
+
+	// ByHeightRange returns the events matching the query for all blocks within the height range
+	// (inclusive). Only blocks with matching events are returned, ordered by height, and their
+	// events are ordered by transaction index and event index.
+	// Returns storage.ErrHeightNotIndexed if the range is not fully indexed.
+	ByHeightRange(startHeight uint64, endHeight uint64, query EventQuery) ([]flow.BlockEvents, error)
+
+	// IndexedRange returns the first and the latest indexed heights.
+	// Returns storage.ErrNotFound if no block was indexed yet.
+	IndexedRange() (uint64, uint64, error)
+}
+
+// EventQuery selects events from the EventIndex. An event matches the query if it matches all
+// the fields which are set.
+type EventQuery struct {
+	// Type is the type of the event.
+	Type flow.EventType
+	// Address is the address of the account which deployed the contract emitting the event.
+	Address flow.Address
+	// Contract is the contract emitting the event, formatted as A.<address>.<name>.
+	Contract string
+	// TransactionID is the ID of the transaction emitting the event.
+	TransactionID flow.Identifier
+}
+
+// Match returns true if the event matches the query.
+func (q EventQuery) Match(event flow.Event) bool {
+	if q.Type != "" && event.Type != q.Type {
+		return false
+	}
+
+	if q.TransactionID != flow.ZeroID && event.TransactionID != q.TransactionID {
+		return false
+	}
+
+	if q.Address == flow.EmptyAddress && q.Contract == "" {
+		return true
+	}
+
+	address, contract, ok := EventContract(event.Type)
+	if !ok {
+		// core events are not emitted by a contract
+		return false
+	}
+
+	if q.Address != flow.EmptyAddress && address != q.Address {
+		return false
+	}
+
+	return q.Contract == "" || contract == q.Contract
+}
+
+// EventContract returns the address of the account and the identifier of the contract which
+// emitted events of the given type. Returns false for core events, which are not emitted by a
+// contract.
+func EventContract(eventType flow.EventType) (flow.Address, string, bool) {
+	parts := strings.Split(string(eventType), ".")
+	if len(parts) != 4 || parts[0] != "A" || parts[2] == "" {
+		return flow.EmptyAddress, "", false
+	}
+
+	address := flow.HexToAddress(parts[1])
+	return address, fmt.Sprintf("A.%s.%s", address, parts[2]), true
+}
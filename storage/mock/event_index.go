@@ -0,0 +1,92 @@
+// Code generated by mockery v2.12.1. DO NOT EDIT.
+
+package mock
+
+import (
+	flow "github.com/onflow/flow-go/model/flow"
+	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/onflow/flow-go/storage"
+
+	testing "testing"
+)
+
+// EventIndex is an autogenerated mock type for the EventIndex type
+type EventIndex struct {
+	mock.Mock
+}
+
+// ByHeightRange provides a mock function with given fields: startHeight, endHeight, query
+func (_m *EventIndex) ByHeightRange(startHeight uint64, endHeight uint64, query storage.EventQuery) ([]flow.BlockEvents, error) {
+	ret := _m.Called(startHeight, endHeight, query)
+
+	var r0 []flow.BlockEvents
+	if rf, ok := ret.Get(0).(func(uint64, uint64, storage.EventQuery) []flow.BlockEvents); ok {
+		r0 = rf(startHeight, endHeight, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.BlockEvents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, uint64, storage.EventQuery) error); ok {
+		r1 = rf(startHeight, endHeight, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IndexedRange provides a mock function with given fields:
+func (_m *EventIndex) IndexedRange() (uint64, uint64, error) {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func() uint64); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Store provides a mock function with given fields: height, blockID, events
+func (_m *EventIndex) Store(height uint64, blockID flow.Identifier, events []flow.EventsList) error {
+	ret := _m.Called(height, blockID, events)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, flow.Identifier, []flow.EventsList) error); ok {
+		r0 = rf(height, blockID, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEventIndex creates a new instance of EventIndex. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEventIndex(t testing.TB) *EventIndex {
+	mock := &EventIndex{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// RegisterIndex stores the values of registers indexed by the height of the block which updated
+// them, so that the value of any register can be read at any height of the indexed range.
+type RegisterIndex interface {
+	// Get returns the value of the register at the given height, which is the value set by the
+	// latest update at or below that height. Registers which were never set have an empty value.
+	// Returns storage.ErrHeightNotIndexed if the height is outside of the indexed range.
+	Get(ID flow.RegisterID, height uint64) (flow.RegisterValue, error)
+
+	// Store stores the register entries updated by the block at the given height, which must
+	// directly follow the latest indexed height.
+	Store(entries flow.RegisterEntries, height uint64) error
+
+	// FirstHeight returns the first indexed height, at which the complete state was indexed.
+	FirstHeight() uint64
+
+	// LatestHeight returns the latest indexed height.
+	LatestHeight() uint64
+}